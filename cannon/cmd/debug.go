@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"net"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/debugger"
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+var (
+	DebugInputFlag = &cli.PathFlag{
+		Name:      "input",
+		Usage:     "path of input state, in JSON or binary format and optionally gzip compressed.",
+		TakesFile: true,
+		Value:     "state.json",
+		Required:  true,
+	}
+	DebugMetaFlag = &cli.PathFlag{
+		Name:     "meta",
+		Usage:    "path to metadata file for symbol lookup, used for breakpoints on symbols.",
+		Value:    "meta.json",
+		Required: false,
+	}
+	DebugGDBFlag = &cli.StringFlag{
+		Name:     "gdb",
+		Usage:    "address to listen on for a GDB remote connection, e.g. localhost:1234. The interactive debugger is used if empty.",
+		Required: false,
+	}
+	DebugTypeFlag = newVMTypeFlag()
+)
+
+func Debug(ctx *cli.Context) error {
+	state, err := loadState(ctx.Path(DebugInputFlag.Name), ctx.String(DebugTypeFlag.Name))
+	if err != nil {
+		return err
+	}
+
+	l := Logger(os.Stderr, log.LvlInfo)
+	outLog := &mipsevm.LoggingWriter{Name: "program std-out", Log: l}
+	errLog := &mipsevm.LoggingWriter{Name: "program std-err", Log: l}
+
+	// split CLI args after first '--'
+	args := ctx.Args().Slice()
+	for i, arg := range args {
+		if arg == "--" {
+			args = args[i+1:]
+			break
+		}
+	}
+	if len(args) == 0 {
+		args = []string{""}
+	}
+
+	po, err := NewProcessPreimageOracle(args[0], args[1:])
+	if err != nil {
+		return fmt.Errorf("failed to create pre-image oracle process: %w", err)
+	}
+	if err := po.Start(); err != nil {
+		return fmt.Errorf("failed to start pre-image oracle server: %w", err)
+	}
+	defer func() {
+		if err := po.Close(); err != nil {
+			l.Error("failed to close pre-image server", "err", err)
+		}
+	}()
+
+	meta := &mipsevm.Metadata{}
+	if metaPath := ctx.Path(DebugMetaFlag.Name); metaPath != "" {
+		m, err := loadJSON[mipsevm.Metadata](metaPath)
+		if err != nil {
+			return fmt.Errorf("failed to load metadata: %w", err)
+		}
+		meta = m
+	}
+
+	vm, err := newVM(state, debugger.NewLoggingOracle(l, po), outLog, errLog)
+	if err != nil {
+		return err
+	}
+	d := debugger.NewDebugger(state, vm, meta)
+
+	addr := ctx.String(DebugGDBFlag.Name)
+	if addr == "" {
+		return debugger.RunREPL(ctx.Context, d, os.Stdin, os.Stdout)
+	}
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to listen for GDB connection: %w", err)
+	}
+	defer listener.Close()
+	l.Info("Waiting for GDB connection", "addr", listener.Addr())
+	go func() {
+		// Unblock Accept if the command is interrupted before GDB connects
+		<-ctx.Context.Done()
+		_ = listener.Close()
+	}()
+	conn, err := listener.Accept()
+	if err != nil {
+		if ctx.Context.Err() != nil {
+			return ctx.Context.Err()
+		}
+		return fmt.Errorf("failed to accept GDB connection: %w", err)
+	}
+	defer conn.Close()
+	l.Info("GDB connected", "remote", conn.RemoteAddr())
+	return debugger.NewGDBServer(l, d, conn).Serve(ctx.Context)
+}
+
+var DebugCommand = &cli.Command{
+	Name:        "debug",
+	Usage:       "Debug a VM state interactively.",
+	Description: "Debug a VM state with an interactive debugger, or serve the GDB remote protocol so gdb-multiarch can attach. Pre-image requests are logged. Arguments after -- start the pre-image server.",
+	Action:      Debug,
+	Flags: []cli.Flag{
+		DebugInputFlag,
+		DebugMetaFlag,
+		DebugGDBFlag,
+		DebugTypeFlag,
+	},
+}
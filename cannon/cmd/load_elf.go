@@ -7,6 +7,7 @@ import (
 	"github.com/urfave/cli/v2"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
 )
 
 var (
@@ -24,7 +25,7 @@ var (
 	}
 	LoadELFOutFlag = &cli.PathFlag{
 		Name:     "out",
-		Usage:    "Output path to write JSON state to. State is dumped to stdout if set to -. Not written if empty.",
+		Usage:    "Output path to write state to. Written in binary format if the path ends with .bin or .bin.gz, otherwise as JSON. State is dumped to stdout if set to -. Not written if empty.",
 		Value:    "state.json",
 		Required: false,
 	}
@@ -34,6 +35,7 @@ var (
 		Value:    "meta.json",
 		Required: false,
 	}
+	LoadELFTypeFlag = newVMTypeFlag()
 )
 
 func LoadELF(ctx *cli.Context) error {
@@ -45,6 +47,10 @@ func LoadELF(ctx *cli.Context) error {
 	if elfProgram.Machine != elf.EM_MIPS {
 		return fmt.Errorf("ELF is not big-endian MIPS R3000, but got %q", elfProgram.Machine.String())
 	}
+	vmType := ctx.String(LoadELFTypeFlag.Name)
+	if vmType != vmTypeSingleThreaded && vmType != vmTypeMultiThreaded {
+		return fmt.Errorf("unrecognized VM type: %q", vmType)
+	}
 	state, err := mipsevm.LoadELF(elfProgram)
 	if err != nil {
 		return fmt.Errorf("failed to load ELF data into VM state: %w", err)
@@ -54,7 +60,11 @@ func LoadELF(ctx *cli.Context) error {
 		case "stack":
 			err = mipsevm.PatchStack(state)
 		case "go":
-			err = mipsevm.PatchGo(elfProgram, state)
+			if vmType == vmTypeMultiThreaded {
+				err = mipsevm.PatchGoMT(elfProgram, state)
+			} else {
+				err = mipsevm.PatchGo(elfProgram, state)
+			}
 		default:
 			return fmt.Errorf("unrecognized form of patching: %q", typ)
 		}
@@ -69,7 +79,10 @@ func LoadELF(ctx *cli.Context) error {
 	if err := writeJSON[*mipsevm.Metadata](ctx.Path(LoadELFMetaFlag.Name), meta); err != nil {
 		return fmt.Errorf("failed to output metadata: %w", err)
 	}
-	return writeJSON[*mipsevm.State](ctx.Path(LoadELFOutFlag.Name), state)
+	if vmType == vmTypeMultiThreaded {
+		return serialize.WriteState(ctx.Path(LoadELFOutFlag.Name), mipsevm.NewMTState(state))
+	}
+	return serialize.WriteState(ctx.Path(LoadELFOutFlag.Name), state)
 }
 
 var LoadELFCommand = &cli.Command{
@@ -82,5 +95,6 @@ var LoadELFCommand = &cli.Command{
 		LoadELFPatchFlag,
 		LoadELFOutFlag,
 		LoadELFMetaFlag,
+		LoadELFTypeFlag,
 	},
 }
@@ -15,20 +15,22 @@ import (
 	"github.com/pkg/profile"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/profiler"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 )
 
 var (
 	RunInputFlag = &cli.PathFlag{
 		Name:      "input",
-		Usage:     "path of input JSON state. Stdin if left empty.",
+		Usage:     "path of input state, in JSON or binary format and optionally gzip compressed.",
 		TakesFile: true,
 		Value:     "state.json",
 		Required:  true,
 	}
 	RunOutputFlag = &cli.PathFlag{
 		Name:      "output",
-		Usage:     "path of output JSON state. Not written if empty, use - to write to Stdout.",
+		Usage:     "path of output state. Written in binary format if the path ends with .bin or .bin.gz, otherwise as JSON. Compressed if the path ends with .gz. Not written if empty, use - to write JSON to Stdout.",
 		TakesFile: true,
 		Value:     "out.json",
 		Required:  false,
@@ -54,7 +56,7 @@ var (
 	}
 	RunSnapshotFmtFlag = &cli.StringFlag{
 		Name:     "snapshot-fmt",
-		Usage:    "format for snapshot output file names.",
+		Usage:    "format for snapshot output file names. Written in binary format if the name ends with .bin or .bin.gz, otherwise as JSON.",
 		Value:    "state-%d.json",
 		Required: false,
 	}
@@ -80,6 +82,19 @@ var (
 		Name:  "pprof.cpu",
 		Usage: "enable pprof cpu profiling",
 	}
+	RunPProfMIPS = &cli.PathFlag{
+		Name:      "pprof.mips",
+		Usage:     "path to write a pprof profile of the instructions executed by each function of the MIPS program. Uses the metadata file for symbols. Disabled if empty.",
+		TakesFile: true,
+		Required:  false,
+	}
+	RunPProfMIPSRate = &cli.Uint64Flag{
+		Name:     "pprof.mips-rate",
+		Usage:    "number of steps between samples of the MIPS program profile",
+		Value:    100,
+		Required: false,
+	}
+	RunTypeFlag = newVMTypeFlag()
 )
 
 type Proof struct {
@@ -227,7 +242,7 @@ func Run(ctx *cli.Context) error {
 		defer profile.Start(profile.NoShutdownHook, profile.ProfilePath("."), profile.CPUProfile).Stop()
 	}
 
-	state, err := loadJSON[mipsevm.State](ctx.Path(RunInputFlag.Name))
+	state, err := loadState(ctx.Path(RunInputFlag.Name), ctx.String(RunTypeFlag.Name))
 	if err != nil {
 		return err
 	}
@@ -278,7 +293,10 @@ func Run(ctx *cli.Context) error {
 		}
 	}
 
-	us := mipsevm.NewInstrumentedState(state, po, outLog, errLog)
+	us, err := newVM(state, po, outLog, errLog)
+	if err != nil {
+		return err
+	}
 	proofFmt := ctx.String(RunProofFmtFlag.Name)
 	snapshotFmt := ctx.String(RunSnapshotFmtFlag.Name)
 
@@ -287,35 +305,51 @@ func Run(ctx *cli.Context) error {
 		stepFn = Guard(po.cmd.ProcessState, stepFn)
 	}
 
+	var prof *profiler.Profiler
+	if profPath := ctx.Path(RunPProfMIPS.Name); profPath != "" {
+		prof = profiler.NewProfiler(meta, ctx.Uint64(RunPProfMIPSRate.Name))
+		defer func() {
+			if err := prof.WriteProfile(profPath); err != nil {
+				l.Error("failed to write MIPS profile", "err", err)
+			} else {
+				l.Info("wrote MIPS profile", "path", profPath)
+			}
+		}()
+	}
+
 	start := time.Now()
-	startStep := state.Step
+	startStep := state.GetStep()
 
 	// avoid symbol lookups every instruction by preparing a matcher func
 	sleepCheck := meta.SymbolMatcher("runtime.notesleep")
+	if _, ok := state.(*mipsevm.MTState); ok {
+		// threads of the multi-threaded VM sleep until another thread wakes them
+		sleepCheck = func(addr uint32) bool { return false }
+	}
 
-	for !state.Exited {
-		if state.Step%100 == 0 { // don't do the ctx err check (includes lock) too often
+	for !state.GetExited() {
+		if state.GetStep()%100 == 0 { // don't do the ctx err check (includes lock) too often
 			if err := ctx.Context.Err(); err != nil {
 				return err
 			}
 		}
 
-		step := state.Step
+		step := state.GetStep()
 
 		if infoAt(state) {
 			delta := time.Since(start)
 			l.Info("processing",
 				"step", step,
-				"pc", mipsevm.HexU32(state.PC),
-				"insn", mipsevm.HexU32(state.Memory.GetMemory(state.PC)),
+				"pc", mipsevm.HexU32(state.GetPC()),
+				"insn", mipsevm.HexU32(state.GetMemory().GetMemory(state.GetPC())),
 				"ips", float64(step-startStep)/(float64(delta)/float64(time.Second)),
-				"pages", state.Memory.PageCount(),
-				"mem", state.Memory.Usage(),
-				"name", meta.LookupSymbol(state.PC),
+				"pages", state.GetMemory().PageCount(),
+				"mem", state.GetMemory().Usage(),
+				"name", meta.LookupSymbol(state.GetPC()),
 			)
 		}
 
-		if sleepCheck(state.PC) { // don't loop forever when we get stuck because of an unexpected bad program
+		if sleepCheck(state.GetPC()) { // don't loop forever when we get stuck because of an unexpected bad program
 			return fmt.Errorf("got stuck in Go sleep at step %d", step)
 		}
 
@@ -324,11 +358,15 @@ func Run(ctx *cli.Context) error {
 		}
 
 		if snapshotAt(state) {
-			if err := writeJSON(fmt.Sprintf(snapshotFmt, step), state); err != nil {
+			if err := serialize.WriteState(fmt.Sprintf(snapshotFmt, step), state); err != nil {
 				return fmt.Errorf("failed to write state snapshot: %w", err)
 			}
 		}
 
+		if prof != nil {
+			prof.Observe(state)
+		}
+
 		if proofAt(state) {
 			preStateHash, err := state.EncodeWitness().StateHash()
 			if err != nil {
@@ -336,7 +374,7 @@ func Run(ctx *cli.Context) error {
 			}
 			witness, err := stepFn(true)
 			if err != nil {
-				return fmt.Errorf("failed at proof-gen step %d (PC: %08x): %w", step, state.PC, err)
+				return fmt.Errorf("failed at proof-gen step %d (PC: %08x): %w", step, state.GetPC(), err)
 			}
 			postStateHash, err := state.EncodeWitness().StateHash()
 			if err != nil {
@@ -366,12 +404,12 @@ func Run(ctx *cli.Context) error {
 		} else {
 			_, err = stepFn(false)
 			if err != nil {
-				return fmt.Errorf("failed at step %d (PC: %08x): %w", step, state.PC, err)
+				return fmt.Errorf("failed at step %d (PC: %08x): %w", step, state.GetPC(), err)
 			}
 		}
 	}
 
-	if err := writeJSON(ctx.Path(RunOutputFlag.Name), state); err != nil {
+	if err := serialize.WriteState(ctx.Path(RunOutputFlag.Name), state); err != nil {
 		return fmt.Errorf("failed to write state output: %w", err)
 	}
 	return nil
@@ -393,5 +431,8 @@ var RunCommand = &cli.Command{
 		RunMetaFlag,
 		RunInfoAtFlag,
 		RunPProfCPU,
+		RunPProfMIPS,
+		RunPProfMIPSRate,
+		RunTypeFlag,
 	},
 }
@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
+)
+
+const (
+	vmTypeSingleThreaded = "singlethreaded"
+	vmTypeMultiThreaded  = "multithreaded"
+)
+
+func newVMTypeFlag() *cli.StringFlag {
+	return &cli.StringFlag{
+		Name:     "type",
+		Usage:    fmt.Sprintf("VM type of the state: %q or %q", vmTypeSingleThreaded, vmTypeMultiThreaded),
+		Value:    vmTypeSingleThreaded,
+		Required: false,
+	}
+}
+
+// loadState loads a state of the given VM type, in either the JSON or the binary format.
+func loadState(path string, vmType string) (mipsevm.FPVMState, error) {
+	switch vmType {
+	case vmTypeSingleThreaded:
+		return serialize.LoadState[mipsevm.State](path)
+	case vmTypeMultiThreaded:
+		return serialize.LoadState[mipsevm.MTState](path)
+	default:
+		return nil, fmt.Errorf("unrecognized VM type: %q", vmType)
+	}
+}
+
+// newVM instruments a state loaded by loadState.
+func newVM(state mipsevm.FPVMState, po mipsevm.PreimageOracle, stdOut, stdErr io.Writer) (mipsevm.FPVM, error) {
+	switch state := state.(type) {
+	case *mipsevm.State:
+		return mipsevm.NewInstrumentedState(state, po, stdOut, stdErr), nil
+	case *mipsevm.MTState:
+		return mipsevm.NewMTInstrumentedState(state, po, stdOut, stdErr), nil
+	default:
+		return nil, fmt.Errorf("unsupported state type: %T", state)
+	}
+}
@@ -4,14 +4,13 @@ import (
 	"fmt"
 	"os"
 
-	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
 	"github.com/urfave/cli/v2"
 )
 
 var (
 	WitnessInputFlag = &cli.PathFlag{
 		Name:      "input",
-		Usage:     "path of input JSON state.",
+		Usage:     "path of input state, in JSON or binary format and optionally gzip compressed.",
 		TakesFile: true,
 		Required:  true,
 	}
@@ -20,12 +19,13 @@ var (
 		Usage:     "path to write binary witness.",
 		TakesFile: true,
 	}
+	WitnessTypeFlag = newVMTypeFlag()
 )
 
 func Witness(ctx *cli.Context) error {
 	input := ctx.Path(WitnessInputFlag.Name)
 	output := ctx.Path(WitnessOutputFlag.Name)
-	state, err := loadJSON[mipsevm.State](input)
+	state, err := loadState(input, ctx.String(WitnessTypeFlag.Name))
 	if err != nil {
 		return fmt.Errorf("invalid input state (%v): %w", input, err)
 	}
@@ -51,5 +51,6 @@ var WitnessCommand = &cli.Command{
 	Flags: []cli.Flag{
 		WitnessInputFlag,
 		WitnessOutputFlag,
+		WitnessTypeFlag,
 	},
 }
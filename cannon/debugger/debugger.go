@@ -0,0 +1,193 @@
+package debugger
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// StopReason is the reason execution stopped.
+type StopReason int
+
+const (
+	StopStep StopReason = iota
+	StopBreakpoint
+	StopSymbol
+	StopExited
+	StopInterrupted
+)
+
+func (r StopReason) String() string {
+	switch r {
+	case StopStep:
+		return "step"
+	case StopBreakpoint:
+		return "breakpoint"
+	case StopSymbol:
+		return "symbol"
+	case StopExited:
+		return "exited"
+	case StopInterrupted:
+		return "interrupted"
+	default:
+		return fmt.Sprintf("unknown(%d)", int(r))
+	}
+}
+
+// Registers is the CPU state of the thread executing the next instruction.
+type Registers struct {
+	GPR    [32]uint32
+	LO     uint32
+	HI     uint32
+	PC     uint32
+	NextPC uint32
+}
+
+// Debugger controls the execution of a VM one step at a time, stopping at breakpoints.
+type Debugger struct {
+	state mipsevm.FPVMState
+	vm    mipsevm.FPVM
+	meta  *mipsevm.Metadata
+
+	breakpoints map[uint32]bool
+}
+
+func NewDebugger(state mipsevm.FPVMState, vm mipsevm.FPVM, meta *mipsevm.Metadata) *Debugger {
+	if meta == nil {
+		meta = &mipsevm.Metadata{}
+	}
+	return &Debugger{
+		state:       state,
+		vm:          vm,
+		meta:        meta,
+		breakpoints: make(map[uint32]bool),
+	}
+}
+
+func (d *Debugger) State() mipsevm.FPVMState {
+	return d.state
+}
+
+// Symbol returns the name of the symbol containing the address.
+func (d *Debugger) Symbol(addr uint32) string {
+	return d.meta.LookupSymbol(addr)
+}
+
+// ResolveAddress parses an address in hex, with or without a 0x prefix, or looks up the start address of a symbol.
+func (d *Debugger) ResolveAddress(target string) (uint32, error) {
+	if addr, ok := d.meta.LookupSymbolAddress(target); ok {
+		return addr, nil
+	}
+	var addr uint32
+	if _, err := fmt.Sscanf(target, "0x%x", &addr); err == nil {
+		return addr, nil
+	}
+	if _, err := fmt.Sscanf(target, "%x", &addr); err == nil {
+		return addr, nil
+	}
+	return 0, fmt.Errorf("unknown address or symbol: %q", target)
+}
+
+func (d *Debugger) AddBreakpoint(addr uint32) {
+	d.breakpoints[addr] = true
+}
+
+func (d *Debugger) RemoveBreakpoint(addr uint32) bool {
+	if !d.breakpoints[addr] {
+		return false
+	}
+	delete(d.breakpoints, addr)
+	return true
+}
+
+// Breakpoints returns the addresses of all breakpoints.
+func (d *Debugger) Breakpoints() []uint32 {
+	out := make([]uint32, 0, len(d.breakpoints))
+	for addr := range d.breakpoints {
+		out = append(out, addr)
+	}
+	return out
+}
+
+// Step executes up to n steps, stopping early if the VM exits.
+func (d *Debugger) Step(n uint64) (StopReason, error) {
+	for i := uint64(0); i < n; i++ {
+		if d.state.GetExited() {
+			return StopExited, nil
+		}
+		if _, err := d.vm.Step(false); err != nil {
+			return StopStep, fmt.Errorf("failed at step %d (PC: %08x): %w", d.state.GetStep(), d.state.GetPC(), err)
+		}
+	}
+	if d.state.GetExited() {
+		return StopExited, nil
+	}
+	return StopStep, nil
+}
+
+// Continue executes until the PC reaches a breakpoint, the VM exits or the context is done.
+// At least one step is executed, so continuing from a breakpoint doesn't stop at it immediately.
+func (d *Debugger) Continue(ctx context.Context) (StopReason, error) {
+	return d.continueUntil(ctx, func(uint32) bool { return false })
+}
+
+// ContinueToSymbol executes until the PC is within the named symbol, or execution stops for any reason Continue stops.
+func (d *Debugger) ContinueToSymbol(ctx context.Context, name string) (StopReason, error) {
+	if _, ok := d.meta.LookupSymbolAddress(name); !ok {
+		return StopStep, fmt.Errorf("unknown symbol: %q", name)
+	}
+	return d.continueUntil(ctx, d.meta.SymbolMatcher(name))
+}
+
+func (d *Debugger) continueUntil(ctx context.Context, inSymbol func(addr uint32) bool) (StopReason, error) {
+	for i := 0; ; i++ {
+		if i%1000 == 0 && ctx.Err() != nil {
+			return StopInterrupted, nil
+		}
+		if reason, err := d.Step(1); err != nil || reason == StopExited {
+			return reason, err
+		}
+		pc := d.state.GetPC()
+		if d.breakpoints[pc] {
+			return StopBreakpoint, nil
+		}
+		if inSymbol(pc) {
+			return StopSymbol, nil
+		}
+	}
+}
+
+// Registers returns the registers of the thread executing the next instruction.
+func (d *Debugger) Registers() Registers {
+	switch state := d.state.(type) {
+	case *mipsevm.State:
+		return Registers{GPR: state.Registers, LO: state.LO, HI: state.HI, PC: state.PC, NextPC: state.NextPC}
+	case *mipsevm.MTState:
+		thread := state.CurrentThread()
+		if thread == nil {
+			return Registers{}
+		}
+		return Registers{GPR: thread.Registers, LO: thread.LO, HI: thread.HI, PC: thread.PC, NextPC: thread.NextPC}
+	default:
+		panic(fmt.Errorf("unsupported state type: %T", d.state))
+	}
+}
+
+// ExitCode returns the exit code of the program, which is only set once the VM has exited.
+func (d *Debugger) ExitCode() uint8 {
+	switch state := d.state.(type) {
+	case *mipsevm.State:
+		return state.ExitCode
+	case *mipsevm.MTState:
+		return state.ExitCode
+	default:
+		panic(fmt.Errorf("unsupported state type: %T", d.state))
+	}
+}
+
+// ReadMemory reads length bytes of memory starting at addr. Unallocated memory reads as zero.
+func (d *Debugger) ReadMemory(addr uint32, length uint32) ([]byte, error) {
+	return io.ReadAll(d.state.GetMemory().ReadMemoryRange(addr, length))
+}
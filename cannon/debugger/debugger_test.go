@@ -0,0 +1,135 @@
+package debugger
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+const (
+	loopAddr = 0x1000
+	exitAddr = 0x2000
+)
+
+// newTestDebugger creates a debugger for a program with a "loop" function that increments t0 forever,
+// and an "exit" function that exits with code 3.
+func newTestDebugger(t *testing.T, pc uint32) *Debugger {
+	state := &mipsevm.State{Memory: mipsevm.NewMemory(), PC: pc, NextPC: pc + 4}
+	state.Memory.SetMemory(loopAddr, 0x25080001)   // addiu t0, t0, 1
+	state.Memory.SetMemory(loopAddr+4, 0x08000400) // j 0x1000
+	state.Memory.SetMemory(loopAddr+8, 0)          // nop
+	state.Memory.SetMemory(exitAddr, 0x24040003)   // addiu a0, zero, 3
+	state.Memory.SetMemory(exitAddr+4, 0x24021096) // addiu v0, zero, 4246 (exit_group)
+	state.Memory.SetMemory(exitAddr+8, 0x0000000c) // syscall
+	meta := &mipsevm.Metadata{Symbols: []mipsevm.Symbol{
+		{Name: "loop", Start: loopAddr, Size: 12},
+		{Name: "exit", Start: exitAddr, Size: 12},
+	}}
+	vm := mipsevm.NewInstrumentedState(state, nil, io.Discard, io.Discard)
+	return NewDebugger(state, vm, meta)
+}
+
+func TestStep(t *testing.T) {
+	d := newTestDebugger(t, loopAddr)
+	reason, err := d.Step(4)
+	require.NoError(t, err)
+	require.Equal(t, StopStep, reason)
+	require.Equal(t, uint64(4), d.State().GetStep())
+	require.Equal(t, uint32(loopAddr+4), d.State().GetPC())
+	require.Equal(t, uint32(2), d.Registers().GPR[8])
+}
+
+func TestStepStopsAtExit(t *testing.T) {
+	d := newTestDebugger(t, exitAddr)
+	reason, err := d.Step(10)
+	require.NoError(t, err)
+	require.Equal(t, StopExited, reason)
+	require.Equal(t, uint64(3), d.State().GetStep())
+	require.Equal(t, uint8(3), d.ExitCode())
+}
+
+func TestContinue(t *testing.T) {
+	t.Run("Breakpoint", func(t *testing.T) {
+		d := newTestDebugger(t, loopAddr)
+		d.AddBreakpoint(loopAddr)
+		reason, err := d.Continue(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, StopBreakpoint, reason)
+		require.Equal(t, uint32(loopAddr), d.State().GetPC())
+		require.Equal(t, uint64(3), d.State().GetStep())
+
+		// Continuing again from the breakpoint stops at the next time it is reached
+		reason, err = d.Continue(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, StopBreakpoint, reason)
+		require.Equal(t, uint64(6), d.State().GetStep())
+	})
+
+	t.Run("RemovedBreakpoint", func(t *testing.T) {
+		d := newTestDebugger(t, loopAddr)
+		d.AddBreakpoint(loopAddr + 8)
+		require.Equal(t, []uint32{loopAddr + 8}, d.Breakpoints())
+		require.True(t, d.RemoveBreakpoint(loopAddr+8))
+		require.False(t, d.RemoveBreakpoint(loopAddr+8))
+		require.Empty(t, d.Breakpoints())
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		reason, err := d.Continue(ctx)
+		require.NoError(t, err)
+		require.Equal(t, StopInterrupted, reason)
+	})
+
+	t.Run("Exit", func(t *testing.T) {
+		d := newTestDebugger(t, exitAddr)
+		reason, err := d.Continue(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, StopExited, reason)
+		require.True(t, d.State().GetExited())
+	})
+}
+
+func TestContinueToSymbol(t *testing.T) {
+	d := newTestDebugger(t, loopAddr)
+	// Jump from the loop to the exit function
+	d.State().GetMemory().SetMemory(loopAddr+4, 0x08000800) // j 0x2000
+	reason, err := d.ContinueToSymbol(context.Background(), "exit")
+	require.NoError(t, err)
+	require.Equal(t, StopSymbol, reason)
+	require.Equal(t, uint32(exitAddr), d.State().GetPC())
+
+	_, err = d.ContinueToSymbol(context.Background(), "unknown")
+	require.ErrorContains(t, err, "unknown symbol")
+}
+
+func TestResolveAddress(t *testing.T) {
+	d := newTestDebugger(t, loopAddr)
+	for _, target := range []string{"exit", "0x2000", "2000"} {
+		addr, err := d.ResolveAddress(target)
+		require.NoError(t, err, target)
+		require.Equal(t, uint32(exitAddr), addr, target)
+	}
+	_, err := d.ResolveAddress("unknown")
+	require.ErrorContains(t, err, "unknown address or symbol")
+}
+
+func TestReadMemory(t *testing.T) {
+	d := newTestDebugger(t, loopAddr)
+	data, err := d.ReadMemory(loopAddr, 6)
+	require.NoError(t, err)
+	require.Equal(t, []byte{0x25, 0x08, 0x00, 0x01, 0x08, 0x00}, data)
+}
+
+func TestRegistersMultiThreaded(t *testing.T) {
+	state := mipsevm.NewMTState(&mipsevm.State{Memory: mipsevm.NewMemory(), PC: 0x1000, NextPC: 0x1004})
+	state.CurrentThread().Registers[29] = 0x7fff0000
+	d := NewDebugger(state, mipsevm.NewMTInstrumentedState(state, nil, io.Discard, io.Discard), nil)
+	regs := d.Registers()
+	require.Equal(t, uint32(0x1000), regs.PC)
+	require.Equal(t, uint32(0x1004), regs.NextPC)
+	require.Equal(t, uint32(0x7fff0000), regs.GPR[29])
+}
@@ -0,0 +1,298 @@
+package debugger
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	gdbInterrupt = 0x03
+
+	sigInt  = 2
+	sigTrap = 5
+
+	// gdbRegisterCount is the number of registers in the MIPS32 'g' packet:
+	// 32 general purpose registers, followed by sr, lo, hi, bad, cause and pc.
+	gdbRegisterCount = 38
+)
+
+// GDBServer serves the GDB remote serial protocol for a debugger over a single connection,
+// so a debugger such as gdb-multiarch can attach with "target remote".
+// Breakpoints are supported but the registers and memory can't be modified, as that would change the execution being proven.
+type GDBServer struct {
+	log  log.Logger
+	d    *Debugger
+	conn io.ReadWriter
+
+	packets    chan string
+	interrupts chan struct{}
+	readErr    chan error
+	done       chan struct{}
+}
+
+func NewGDBServer(logger log.Logger, d *Debugger, conn io.ReadWriter) *GDBServer {
+	return &GDBServer{
+		log:        logger,
+		d:          d,
+		conn:       conn,
+		packets:    make(chan string),
+		interrupts: make(chan struct{}, 1),
+		readErr:    make(chan error, 1),
+		done:       make(chan struct{}),
+	}
+}
+
+// Serve handles packets until the client detaches or kills the program, the connection is closed or the context is done.
+func (s *GDBServer) Serve(ctx context.Context) error {
+	defer close(s.done)
+	go s.readPackets()
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-s.readErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case packet := <-s.packets:
+			s.log.Debug("Received GDB packet", "packet", packet)
+			reply, done, err := s.handle(ctx, packet)
+			if err != nil {
+				return err
+			}
+			if done {
+				if reply != "" {
+					return s.send(reply)
+				}
+				return nil
+			}
+			if err := s.send(reply); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// readPackets parses packets from the connection, acknowledging each one.
+// Interrupts are delivered separately so they can stop a continue that is in progress.
+func (s *GDBServer) readPackets() {
+	r := bufio.NewReader(s.conn)
+	for {
+		b, err := r.ReadByte()
+		if err != nil {
+			s.readErr <- err
+			return
+		}
+		switch b {
+		case gdbInterrupt:
+			select {
+			case s.interrupts <- struct{}{}:
+			default:
+			}
+			continue
+		case '$':
+		default:
+			// acknowledgements of our replies, and anything else outside a packet, are ignored
+			continue
+		}
+		data, err := r.ReadString('#')
+		if err != nil {
+			s.readErr <- err
+			return
+		}
+		data = data[:len(data)-1]
+		var checksum [2]byte
+		if _, err := io.ReadFull(r, checksum[:]); err != nil {
+			s.readErr <- err
+			return
+		}
+		expected, err := strconv.ParseUint(string(checksum[:]), 16, 8)
+		if err != nil || uint8(expected) != packetChecksum(data) {
+			if _, err := s.conn.Write([]byte{'-'}); err != nil {
+				s.readErr <- err
+				return
+			}
+			continue
+		}
+		if _, err := s.conn.Write([]byte{'+'}); err != nil {
+			s.readErr <- err
+			return
+		}
+		select {
+		case s.packets <- data:
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *GDBServer) send(data string) error {
+	_, err := fmt.Fprintf(s.conn, "$%s#%02x", data, packetChecksum(data))
+	return err
+}
+
+func packetChecksum(data string) uint8 {
+	var sum uint8
+	for i := 0; i < len(data); i++ {
+		sum += data[i]
+	}
+	return sum
+}
+
+// handle returns the reply to a packet, and true if the session is finished.
+// Unsupported packets get an empty reply, as required by the protocol.
+func (s *GDBServer) handle(ctx context.Context, packet string) (string, bool, error) {
+	if packet == "" {
+		return "", false, nil
+	}
+	switch {
+	case packet == "?":
+		return s.stopReply(StopStep), false, nil
+	case packet == "g":
+		return s.readRegisters(), false, nil
+	case packet[0] == 'p':
+		n, err := strconv.ParseUint(packet[1:], 16, 32)
+		if err != nil {
+			return "E01", false, nil
+		}
+		return s.readRegister(int(n)), false, nil
+	case packet[0] == 'm':
+		addr, length, ok := parseAddrLength(packet[1:])
+		if !ok {
+			return "E01", false, nil
+		}
+		data, err := s.d.ReadMemory(addr, length)
+		if err != nil {
+			return "E01", false, nil
+		}
+		return hex.EncodeToString(data), false, nil
+	case packet[0] == 'G' || packet[0] == 'P' || packet[0] == 'M' || packet[0] == 'X':
+		// writes are not supported
+		return "E01", false, nil
+	case packet[0] == 'c':
+		reason, err := s.continueExecution(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		return s.stopReply(reason), false, nil
+	case packet[0] == 's':
+		reason, err := s.d.Step(1)
+		if err != nil {
+			return "", false, err
+		}
+		return s.stopReply(reason), false, nil
+	case strings.HasPrefix(packet, "Z0,") || strings.HasPrefix(packet, "Z1,"):
+		addr, _, ok := parseAddrLength(packet[3:])
+		if !ok {
+			return "E01", false, nil
+		}
+		s.d.AddBreakpoint(addr)
+		return "OK", false, nil
+	case strings.HasPrefix(packet, "z0,") || strings.HasPrefix(packet, "z1,"):
+		addr, _, ok := parseAddrLength(packet[3:])
+		if !ok {
+			return "E01", false, nil
+		}
+		s.d.RemoveBreakpoint(addr)
+		return "OK", false, nil
+	case strings.HasPrefix(packet, "qSupported"):
+		return "PacketSize=4000;swbreak+;hwbreak+", false, nil
+	case packet == "qAttached":
+		return "1", false, nil
+	case packet == "qC":
+		return "QC1", false, nil
+	case packet == "qfThreadInfo":
+		return "m1", false, nil
+	case packet == "qsThreadInfo":
+		return "l", false, nil
+	case packet[0] == 'H' || packet[0] == 'T':
+		return "OK", false, nil
+	case packet == "D" || strings.HasPrefix(packet, "D;"):
+		return "OK", true, nil
+	case packet == "k":
+		return "", true, nil
+	default:
+		return "", false, nil
+	}
+}
+
+// continueExecution continues until execution stops or the client sends an interrupt.
+// The interrupt may already be pending, as the client can send it before the continue starts.
+func (s *GDBServer) continueExecution(ctx context.Context) (StopReason, error) {
+	runCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-s.interrupts:
+			cancel()
+		case <-runCtx.Done():
+		}
+	}()
+	return s.d.Continue(runCtx)
+}
+
+func (s *GDBServer) stopReply(reason StopReason) string {
+	if s.d.State().GetExited() {
+		return fmt.Sprintf("W%02x", s.d.ExitCode())
+	}
+	if reason == StopInterrupted {
+		return fmt.Sprintf("S%02x", sigInt)
+	}
+	return fmt.Sprintf("S%02x", sigTrap)
+}
+
+func (s *GDBServer) readRegisters() string {
+	var out strings.Builder
+	for i := 0; i < gdbRegisterCount; i++ {
+		out.WriteString(s.readRegister(i))
+	}
+	return out.String()
+}
+
+// readRegister encodes a register in target byte order, which is big-endian.
+func (s *GDBServer) readRegister(n int) string {
+	regs := s.d.Registers()
+	var v uint32
+	switch {
+	case n < 32:
+		v = regs.GPR[n]
+	case n == 33:
+		v = regs.LO
+	case n == 34:
+		v = regs.HI
+	case n == 37:
+		v = regs.PC
+	case n < gdbRegisterCount:
+		// sr, bad and cause are not emulated
+		v = 0
+	default:
+		return "xxxxxxxx"
+	}
+	return fmt.Sprintf("%08x", v)
+}
+
+// parseAddrLength parses the "addr,length" arguments of a packet, both in hex.
+func parseAddrLength(args string) (uint32, uint32, bool) {
+	addrStr, lengthStr, ok := strings.Cut(args, ",")
+	if !ok {
+		return 0, 0, false
+	}
+	addr, err := strconv.ParseUint(addrStr, 16, 32)
+	if err != nil {
+		return 0, 0, false
+	}
+	length, err := strconv.ParseUint(lengthStr, 16, 32)
+	if err != nil {
+		return 0, 0, false
+	}
+	return uint32(addr), uint32(length), true
+}
@@ -0,0 +1,161 @@
+package debugger
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+type gdbClient struct {
+	t    *testing.T
+	conn net.Conn
+	r    *bufio.Reader
+}
+
+func startGDBServer(t *testing.T, d *Debugger) (*gdbClient, chan error) {
+	client, server := net.Pipe()
+	t.Cleanup(func() {
+		_ = client.Close()
+		_ = server.Close()
+	})
+	result := make(chan error, 1)
+	go func() {
+		result <- NewGDBServer(testlog.Logger(t, log.LvlInfo), d, server).Serve(context.Background())
+	}()
+	return &gdbClient{t: t, conn: client, r: bufio.NewReader(client)}, result
+}
+
+func (c *gdbClient) send(data string) {
+	_, err := fmt.Fprintf(c.conn, "$%s#%02x", data, packetChecksum(data))
+	require.NoError(c.t, err)
+}
+
+func (c *gdbClient) readAck() byte {
+	b, err := c.r.ReadByte()
+	require.NoError(c.t, err)
+	return b
+}
+
+func (c *gdbClient) readReply() string {
+	b, err := c.r.ReadByte()
+	require.NoError(c.t, err)
+	require.Equal(c.t, byte('$'), b)
+	data, err := c.r.ReadString('#')
+	require.NoError(c.t, err)
+	data = data[:len(data)-1]
+	var checksum [2]byte
+	_, err = io.ReadFull(c.r, checksum[:])
+	require.NoError(c.t, err)
+	require.Equal(c.t, fmt.Sprintf("%02x", packetChecksum(data)), string(checksum[:]))
+	_, err = c.conn.Write([]byte{'+'})
+	require.NoError(c.t, err)
+	return data
+}
+
+func (c *gdbClient) request(data string) string {
+	c.send(data)
+	require.Equal(c.t, byte('+'), c.readAck())
+	return c.readReply()
+}
+
+func TestGDBServer(t *testing.T) {
+	t.Run("Queries", func(t *testing.T) {
+		c, _ := startGDBServer(t, newTestDebugger(t, loopAddr))
+		require.Equal(t, "PacketSize=4000;swbreak+;hwbreak+", c.request("qSupported:multiprocess+;swbreak+"))
+		require.Equal(t, "1", c.request("qAttached"))
+		require.Equal(t, "QC1", c.request("qC"))
+		require.Equal(t, "m1", c.request("qfThreadInfo"))
+		require.Equal(t, "l", c.request("qsThreadInfo"))
+		require.Equal(t, "OK", c.request("Hg0"))
+		require.Equal(t, "S05", c.request("?"))
+		require.Equal(t, "", c.request("vMustReplyEmpty"))
+	})
+
+	t.Run("BadChecksum", func(t *testing.T) {
+		c, _ := startGDBServer(t, newTestDebugger(t, loopAddr))
+		_, err := c.conn.Write([]byte("$?#00"))
+		require.NoError(t, err)
+		require.Equal(t, byte('-'), c.readAck())
+		require.Equal(t, "S05", c.request("?"))
+	})
+
+	t.Run("Registers", func(t *testing.T) {
+		c, _ := startGDBServer(t, newTestDebugger(t, loopAddr))
+		require.Equal(t, "S05", c.request("s"))
+		regs := c.request("g")
+		require.Len(t, regs, gdbRegisterCount*8)
+		require.Equal(t, "00000001", regs[8*8:9*8])
+		require.Equal(t, "00001004", regs[37*8:])
+		require.Equal(t, "00000001", c.request("p8"))
+		require.Equal(t, "00001004", c.request("p25"))
+		require.Equal(t, "xxxxxxxx", c.request("p40"))
+		require.Equal(t, "E01", c.request("P8=00000002"))
+	})
+
+	t.Run("Memory", func(t *testing.T) {
+		c, _ := startGDBServer(t, newTestDebugger(t, loopAddr))
+		require.Equal(t, "2508000108000400", c.request("m1000,8"))
+		require.Equal(t, "E01", c.request("mzz"))
+		require.Equal(t, "E01", c.request("M1000,4:00000000"))
+	})
+
+	t.Run("Breakpoints", func(t *testing.T) {
+		d := newTestDebugger(t, loopAddr)
+		c, _ := startGDBServer(t, d)
+		require.Equal(t, "OK", c.request("Z0,1008,4"))
+		require.Equal(t, "S05", c.request("c"))
+		require.Equal(t, uint32(loopAddr+8), d.State().GetPC())
+		require.Equal(t, "OK", c.request("z0,1008,4"))
+		require.Empty(t, d.Breakpoints())
+	})
+
+	t.Run("Interrupt", func(t *testing.T) {
+		d := newTestDebugger(t, loopAddr)
+		c, _ := startGDBServer(t, d)
+		c.send("c")
+		require.Equal(t, byte('+'), c.readAck())
+		_, err := c.conn.Write([]byte{gdbInterrupt})
+		require.NoError(t, err)
+		require.Equal(t, "S02", c.readReply())
+		require.NotZero(t, d.State().GetStep())
+	})
+
+	t.Run("Exit", func(t *testing.T) {
+		c, _ := startGDBServer(t, newTestDebugger(t, exitAddr))
+		require.Equal(t, "W03", c.request("c"))
+		require.Equal(t, "W03", c.request("?"))
+	})
+
+	t.Run("Detach", func(t *testing.T) {
+		c, result := startGDBServer(t, newTestDebugger(t, loopAddr))
+		require.Equal(t, "OK", c.request("D"))
+		require.NoError(t, <-result)
+	})
+
+	t.Run("Kill", func(t *testing.T) {
+		c, result := startGDBServer(t, newTestDebugger(t, loopAddr))
+		c.send("k")
+		require.Equal(t, byte('+'), c.readAck())
+		require.NoError(t, <-result)
+	})
+
+	t.Run("Disconnect", func(t *testing.T) {
+		c, result := startGDBServer(t, newTestDebugger(t, loopAddr))
+		require.NoError(t, c.conn.Close())
+		require.NoError(t, <-result)
+	})
+}
+
+func TestPacketChecksum(t *testing.T) {
+	require.Equal(t, uint8(0x3f), packetChecksum("?"))
+	require.Equal(t, uint8(0), packetChecksum(""))
+	require.Equal(t, uint8(0x9a), packetChecksum("OK"))
+}
@@ -0,0 +1,31 @@
+package debugger
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// LoggingOracle logs the hints and pre-image requests the program makes to the pre-image oracle.
+type LoggingOracle struct {
+	log    log.Logger
+	oracle mipsevm.PreimageOracle
+}
+
+func NewLoggingOracle(logger log.Logger, oracle mipsevm.PreimageOracle) *LoggingOracle {
+	return &LoggingOracle{log: logger, oracle: oracle}
+}
+
+func (o *LoggingOracle) Hint(v []byte) {
+	o.log.Info("Pre-image hint", "hint", string(v))
+	o.oracle.Hint(v)
+}
+
+func (o *LoggingOracle) GetPreimage(k [32]byte) []byte {
+	data := o.oracle.GetPreimage(k)
+	o.log.Info("Pre-image request", "key", common.Hash(k), "size", len(data))
+	return data
+}
+
+var _ mipsevm.PreimageOracle = (*LoggingOracle)(nil)
@@ -0,0 +1,210 @@
+package debugger
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const replHelp = `Commands:
+  step [n], s [n]          execute n steps (default 1)
+  continue, c              execute until a breakpoint or the program exits
+  until <symbol>           execute until the PC is within the symbol
+  break <addr|symbol>, b   add a breakpoint
+  delete <addr|symbol>     remove a breakpoint
+  breakpoints              list breakpoints
+  regs                     print the registers of the current thread
+  mem <addr|symbol> [len]  print len bytes of memory (default 64)
+  info                     print the current step and location
+  help                     print this help
+  quit, q                  exit the debugger`
+
+var errQuit = errors.New("quit")
+
+// RunREPL reads debugger commands from in and writes the results to out, until the input ends or quit is entered.
+func RunREPL(ctx context.Context, d *Debugger, in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	printLocation(d, out)
+	for {
+		_, _ = fmt.Fprint(out, "(cannon) ")
+		if !scanner.Scan() {
+			_, _ = fmt.Fprintln(out)
+			return scanner.Err()
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		err := runCommand(ctx, d, out, fields[0], fields[1:])
+		if errors.Is(err, errQuit) {
+			return nil
+		}
+		var cmdErr *commandError
+		if errors.As(err, &cmdErr) {
+			_, _ = fmt.Fprintf(out, "error: %v\n", cmdErr.err)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// commandError is an invalid command, which is reported without ending the REPL.
+type commandError struct {
+	err error
+}
+
+func (e *commandError) Error() string {
+	return e.err.Error()
+}
+
+func invalidCommand(format string, args ...any) error {
+	return &commandError{fmt.Errorf(format, args...)}
+}
+
+func runCommand(ctx context.Context, d *Debugger, out io.Writer, cmd string, args []string) error {
+	switch cmd {
+	case "help", "h":
+		_, _ = fmt.Fprintln(out, replHelp)
+	case "quit", "q", "exit":
+		return errQuit
+	case "step", "s":
+		n := uint64(1)
+		if len(args) > 0 {
+			parsed, err := strconv.ParseUint(args[0], 0, 64)
+			if err != nil {
+				return invalidCommand("invalid step count %q: %w", args[0], err)
+			}
+			n = parsed
+		}
+		reason, err := d.Step(n)
+		if err != nil {
+			return err
+		}
+		printStop(d, out, reason)
+	case "continue", "c":
+		reason, err := d.Continue(ctx)
+		if err != nil {
+			return err
+		}
+		printStop(d, out, reason)
+	case "until":
+		if len(args) != 1 {
+			return invalidCommand("usage: until <symbol>")
+		}
+		reason, err := d.ContinueToSymbol(ctx, args[0])
+		if err != nil {
+			return invalidCommand("%w", err)
+		}
+		printStop(d, out, reason)
+	case "break", "b":
+		if len(args) != 1 {
+			return invalidCommand("usage: break <addr|symbol>")
+		}
+		addr, err := d.ResolveAddress(args[0])
+		if err != nil {
+			return invalidCommand("%w", err)
+		}
+		d.AddBreakpoint(addr)
+		_, _ = fmt.Fprintf(out, "breakpoint at 0x%08x in %s\n", addr, d.Symbol(addr))
+	case "delete":
+		if len(args) != 1 {
+			return invalidCommand("usage: delete <addr|symbol>")
+		}
+		addr, err := d.ResolveAddress(args[0])
+		if err != nil {
+			return invalidCommand("%w", err)
+		}
+		if !d.RemoveBreakpoint(addr) {
+			return invalidCommand("no breakpoint at 0x%08x", addr)
+		}
+		_, _ = fmt.Fprintf(out, "deleted breakpoint at 0x%08x\n", addr)
+	case "breakpoints":
+		addrs := d.Breakpoints()
+		sort.Slice(addrs, func(i, j int) bool { return addrs[i] < addrs[j] })
+		for _, addr := range addrs {
+			_, _ = fmt.Fprintf(out, "0x%08x in %s\n", addr, d.Symbol(addr))
+		}
+	case "regs":
+		printRegisters(d.Registers(), out)
+	case "mem":
+		if len(args) < 1 || len(args) > 2 {
+			return invalidCommand("usage: mem <addr|symbol> [len]")
+		}
+		addr, err := d.ResolveAddress(args[0])
+		if err != nil {
+			return invalidCommand("%w", err)
+		}
+		length := uint64(64)
+		if len(args) == 2 {
+			length, err = strconv.ParseUint(args[1], 0, 32)
+			if err != nil {
+				return invalidCommand("invalid length %q: %w", args[1], err)
+			}
+		}
+		data, err := d.ReadMemory(addr, uint32(length))
+		if err != nil {
+			return err
+		}
+		printMemory(addr, data, out)
+	case "info":
+		printLocation(d, out)
+	default:
+		return invalidCommand("unknown command %q, enter help for a list of commands", cmd)
+	}
+	return nil
+}
+
+func printStop(d *Debugger, out io.Writer, reason StopReason) {
+	if reason != StopStep && reason != StopExited {
+		_, _ = fmt.Fprintf(out, "stopped: %v\n", reason)
+	}
+	printLocation(d, out)
+}
+
+func printLocation(d *Debugger, out io.Writer) {
+	state := d.State()
+	if state.GetExited() {
+		_, _ = fmt.Fprintf(out, "exited at step %d\n", state.GetStep())
+		return
+	}
+	pc := state.GetPC()
+	_, _ = fmt.Fprintf(out, "step %d pc 0x%08x insn 0x%08x in %s\n",
+		state.GetStep(), pc, state.GetMemory().GetMemory(pc&^3), d.Symbol(pc))
+}
+
+var registerNames = [32]string{
+	"zero", "at", "v0", "v1", "a0", "a1", "a2", "a3",
+	"t0", "t1", "t2", "t3", "t4", "t5", "t6", "t7",
+	"s0", "s1", "s2", "s3", "s4", "s5", "s6", "s7",
+	"t8", "t9", "k0", "k1", "gp", "sp", "fp", "ra",
+}
+
+func printRegisters(regs Registers, out io.Writer) {
+	for i, v := range regs.GPR {
+		_, _ = fmt.Fprintf(out, "%-4s 0x%08x", registerNames[i], v)
+		if i%4 == 3 {
+			_, _ = fmt.Fprintln(out)
+		} else {
+			_, _ = fmt.Fprint(out, "  ")
+		}
+	}
+	_, _ = fmt.Fprintf(out, "lo   0x%08x  hi   0x%08x  pc   0x%08x  npc  0x%08x\n", regs.LO, regs.HI, regs.PC, regs.NextPC)
+}
+
+func printMemory(addr uint32, data []byte, out io.Writer) {
+	for i := 0; i < len(data); i += 16 {
+		end := i + 16
+		if end > len(data) {
+			end = len(data)
+		}
+		_, _ = fmt.Fprintf(out, "0x%08x: %s\n", addr+uint32(i), hex.EncodeToString(data[i:end]))
+	}
+}
@@ -0,0 +1,71 @@
+package debugger
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func runREPL(t *testing.T, d *Debugger, commands ...string) string {
+	var out bytes.Buffer
+	err := RunREPL(context.Background(), d, strings.NewReader(strings.Join(commands, "\n")), &out)
+	require.NoError(t, err)
+	return out.String()
+}
+
+func TestREPL(t *testing.T) {
+	t.Run("Step", func(t *testing.T) {
+		d := newTestDebugger(t, loopAddr)
+		out := runREPL(t, d, "step", "s 3")
+		require.Contains(t, out, "step 1 pc 0x00001004 insn 0x08000400 in loop")
+		require.Contains(t, out, "step 4 pc 0x00001004 insn 0x08000400 in loop")
+		require.Equal(t, uint64(4), d.State().GetStep())
+	})
+
+	t.Run("Breakpoints", func(t *testing.T) {
+		d := newTestDebugger(t, loopAddr)
+		out := runREPL(t, d, "b 0x1008", "break exit", "breakpoints", "c", "delete exit", "breakpoints")
+		require.Contains(t, out, "breakpoint at 0x00001008 in loop")
+		require.Contains(t, out, "breakpoint at 0x00002000 in exit")
+		require.Contains(t, out, "0x00001008 in loop\n0x00002000 in exit\n")
+		require.Contains(t, out, "stopped: breakpoint\nstep 2 pc 0x00001008")
+		require.Contains(t, out, "deleted breakpoint at 0x00002000")
+		require.Equal(t, []uint32{loopAddr + 8}, d.Breakpoints())
+	})
+
+	t.Run("Until", func(t *testing.T) {
+		d := newTestDebugger(t, loopAddr)
+		d.State().GetMemory().SetMemory(loopAddr+4, 0x08000800) // j 0x2000
+		out := runREPL(t, d, "until exit", "c")
+		require.Contains(t, out, "stopped: symbol\nstep 3 pc 0x00002000")
+		require.Contains(t, out, "exited at step 6")
+	})
+
+	t.Run("Inspect", func(t *testing.T) {
+		d := newTestDebugger(t, loopAddr)
+		out := runREPL(t, d, "s 2", "regs", "mem loop 12")
+		require.Contains(t, out, "t0   0x00000001")
+		require.Contains(t, out, "pc   0x00001008  npc  0x00001000")
+		require.Contains(t, out, "0x00001000: 250800010800040000000000\n")
+	})
+
+	t.Run("InvalidCommands", func(t *testing.T) {
+		d := newTestDebugger(t, loopAddr)
+		out := runREPL(t, d, "jump", "step x", "break", "until unknown", "delete 0x1000", "info")
+		require.Contains(t, out, `error: unknown command "jump"`)
+		require.Contains(t, out, `error: invalid step count "x"`)
+		require.Contains(t, out, "error: usage: break <addr|symbol>")
+		require.Contains(t, out, `error: unknown symbol: "unknown"`)
+		require.Contains(t, out, "error: no breakpoint at 0x00001000")
+		require.Equal(t, uint64(0), d.State().GetStep())
+	})
+
+	t.Run("Quit", func(t *testing.T) {
+		d := newTestDebugger(t, loopAddr)
+		runREPL(t, d, "q", "step")
+		require.Equal(t, uint64(0), d.State().GetStep())
+	})
+}
@@ -0,0 +1,3 @@
+module multithreaded
+
+go 1.20
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+)
+
+func main() {
+	// Lock the main goroutine to its thread, so the other goroutines have to run on new threads.
+	runtime.LockOSThread()
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	total := 0
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			// Allocate enough to trigger the garbage collector
+			sum := 0
+			for j := 0; j < 1000; j++ {
+				buf := make([]byte, 1024)
+				buf[j%len(buf)] = byte(i)
+				sum += int(buf[j%len(buf)])
+			}
+			mu.Lock()
+			total += sum
+			mu.Unlock()
+		}(i)
+	}
+	wg.Wait()
+	runtime.GC()
+
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+	if stats.NumGC == 0 {
+		_, _ = os.Stderr.Write([]byte("garbage collector did not run\n"))
+		os.Exit(1)
+	}
+	_, _ = os.Stdout.Write([]byte(fmt.Sprintf("total %d\n", total)))
+}
@@ -22,6 +22,7 @@ func main() {
 		cmd.LoadELFCommand,
 		cmd.WitnessCommand,
 		cmd.RunCommand,
+		cmd.DebugCommand,
 	}
 	ctx, cancel := context.WithCancel(context.Background())
 
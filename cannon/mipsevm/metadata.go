@@ -65,6 +65,16 @@ func (m *Metadata) SymbolMatcher(name string) func(addr uint32) bool {
 	}
 }
 
+// LookupSymbolAddress returns the start address of the symbol with the given name.
+func (m *Metadata) LookupSymbolAddress(name string) (uint32, bool) {
+	for _, s := range m.Symbols {
+		if s.Name == name {
+			return s.Start, true
+		}
+	}
+	return 0, false
+}
+
 // HexU32 to lazy-format integer attributes for logging
 type HexU32 uint32
 
@@ -0,0 +1,61 @@
+package mipsevm
+
+import (
+	"io"
+)
+
+// MTInstrumentedState steps through the multi-threaded VM.
+// Instructions of the current thread are executed by an InstrumentedState,
+// with the thread loaded into a State that shares the memory and pre-image state of the VM.
+type MTInstrumentedState struct {
+	state *MTState
+
+	cpu *InstrumentedState
+}
+
+func NewMTInstrumentedState(state *MTState, po PreimageOracle, stdOut, stdErr io.Writer) *MTInstrumentedState {
+	return &MTInstrumentedState{
+		state: state,
+		cpu:   NewInstrumentedState(&State{Memory: state.Memory}, po, stdOut, stdErr),
+	}
+}
+
+// Step executes a single step of the VM.
+// The proof data of the step is the witness of the current thread, the root of the threads below it on the
+// active stack, and the memory proofs of the instruction and of the memory accessed by the step.
+func (m *MTInstrumentedState) Step(proof bool) (wit *StepWitness, err error) {
+	m.cpu.memProofEnabled = proof
+	m.cpu.lastMemAccess = ^uint32(0)
+	m.cpu.lastPreimageOffset = ^uint32(0)
+
+	if proof {
+		thread := m.state.CurrentThread()
+		if thread == nil {
+			thread = &ThreadState{}
+		}
+		innerRoot := m.state.innerThreadRoot()
+		insnProof := m.state.Memory.MerkleProof(thread.PC)
+		var proofData []byte
+		proofData = append(proofData, thread.EncodeWitness()...)
+		proofData = append(proofData, innerRoot[:]...)
+		proofData = append(proofData, insnProof[:]...)
+		wit = &StepWitness{
+			State:    m.state.EncodeWitness(),
+			MemProof: proofData,
+		}
+	}
+	err = m.mtStep()
+	if err != nil {
+		return nil, err
+	}
+
+	if proof {
+		wit.MemProof = append(wit.MemProof, m.cpu.memProof[:]...)
+		if m.cpu.lastPreimageOffset != ^uint32(0) {
+			wit.PreimageOffset = m.cpu.lastPreimageOffset
+			wit.PreimageKey = m.cpu.lastPreimageKey
+			wit.PreimageValue = m.cpu.lastPreimage
+		}
+	}
+	return
+}
@@ -0,0 +1,776 @@
+package mipsevm
+
+import (
+	"bytes"
+	"debug/elf"
+	"encoding/binary"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	llInsn = uint32(0xc0_88_00_00) // ll $t0, 0($a0)
+	scInsn = uint32(0xe0_88_00_00) // sc $t0, 0($a0)
+)
+
+func TestMTState(t *testing.T) {
+	testFiles, err := os.ReadDir("open_mips_tests/test/bin")
+	require.NoError(t, err)
+
+	for _, f := range testFiles {
+		// clone is handled by the multi-threaded VM, and only supports the flags used by the Go runtime
+		if f.Name() == "clone.bin" {
+			continue
+		}
+		t.Run(f.Name(), func(t *testing.T) {
+			var oracle PreimageOracle
+			if strings.HasPrefix(f.Name(), "oracle") {
+				oracle = staticOracle(t, []byte("hello world"))
+			}
+			exitGroup := f.Name() == "exit_group.bin"
+
+			programMem, err := os.ReadFile(path.Join("open_mips_tests/test/bin", f.Name()))
+			require.NoError(t, err)
+			state := &State{PC: 0, NextPC: 4, Memory: NewMemory()}
+			require.NoError(t, state.Memory.SetMemoryRange(0, bytes.NewReader(programMem)), "load program into state")
+			state.Registers[31] = endAddr
+			mtState := NewMTState(state)
+
+			us := NewMTInstrumentedState(mtState, oracle, os.Stdout, os.Stderr)
+			for i := 0; i < 1000; i++ {
+				if mtState.GetPC() == endAddr || mtState.Exited {
+					break
+				}
+				_, err := us.Step(false)
+				require.NoError(t, err)
+			}
+
+			if exitGroup {
+				require.True(t, mtState.Exited, "must set exited state")
+				require.Equal(t, uint8(1), mtState.ExitCode, "must exit with 1")
+			} else {
+				require.Equal(t, uint32(endAddr), mtState.GetPC(), "must reach end")
+				done, result := mtState.Memory.GetMemory(baseAddrEnd+4), mtState.Memory.GetMemory(baseAddrEnd+8)
+				require.Equal(t, done, uint32(1), "must be done")
+				require.Equal(t, result, uint32(1), "must have success result")
+			}
+		})
+	}
+}
+
+func TestMTStateHash(t *testing.T) {
+	state := NewMTState(&State{Memory: NewMemory(), PC: 4, NextPC: 8})
+	witness := state.EncodeWitness()
+	require.Len(t, witness, MTStateWitnessSize)
+	require.Len(t, state.CurrentThread().EncodeWitness(), ThreadWitnessSize)
+
+	hash, err := witness.StateHash()
+	require.NoError(t, err)
+	expected := crypto.Keccak256Hash(witness)
+	expected[0] = VMStatusUnfinished
+	require.Equal(t, expected, hash)
+
+	state.Exited = true
+	state.ExitCode = 1
+	hash, err = state.EncodeWitness().StateHash()
+	require.NoError(t, err)
+	require.Equal(t, uint8(VMStatusInvalid), hash[0])
+
+	leftRoot := common.BytesToHash(witness[MTStateWitnessSize-68 : MTStateWitnessSize-36])
+	rightRoot := common.BytesToHash(witness[MTStateWitnessSize-36 : MTStateWitnessSize-4])
+	threadHash := crypto.Keccak256Hash(state.CurrentThread().EncodeWitness())
+	require.Equal(t, crypto.Keccak256Hash(EmptyThreadsRoot[:], threadHash[:]), leftRoot)
+	require.Equal(t, EmptyThreadsRoot, rightRoot)
+}
+
+func TestMTClone(t *testing.T) {
+	state, us := setupMTSyscall(sysClone, 0x1000)
+	parent := state.CurrentThread()
+	parent.Registers[4] = ValidCloneFlags
+	parent.Registers[5] = 0xbeef0
+	state.StepsSinceLastContextSwitch = 10
+	_, err := us.Step(false)
+	require.NoError(t, err)
+
+	require.Equal(t, 2, state.ThreadCount())
+	require.Equal(t, uint32(2), state.NextThreadID)
+	child := state.CurrentThread()
+	require.NotSame(t, parent, child)
+	require.Equal(t, uint32(1), child.ThreadID)
+	require.Equal(t, uint32(0x1004), child.PC)
+	require.Equal(t, uint32(0x1008), child.NextPC)
+	require.Equal(t, uint32(0), child.Registers[2])
+	require.Equal(t, uint32(0xbeef0), child.Registers[29])
+	require.Equal(t, FutexEmptyAddr, child.FutexAddr)
+	require.Equal(t, uint64(0), state.StepsSinceLastContextSwitch)
+
+	require.Equal(t, uint32(1), parent.Registers[2])
+	require.Equal(t, uint32(0x1004), parent.PC)
+	require.Equal(t, uint32(0), parent.Registers[29])
+}
+
+func TestMTCloneInvalidFlags(t *testing.T) {
+	state, us := setupMTSyscall(sysClone, 0x1000)
+	state.CurrentThread().Registers[4] = 0x11 // SIGCHLD, as used by fork
+	_, err := us.Step(false)
+	require.NoError(t, err)
+	require.Equal(t, 1, state.ThreadCount())
+	require.Equal(t, uint32(0xFFffFFff), state.CurrentThread().Registers[2])
+	require.Equal(t, uint32(MipsEINVAL), state.CurrentThread().Registers[7])
+}
+
+func TestMTFutexWait(t *testing.T) {
+	t.Run("ValueMismatch", func(t *testing.T) {
+		state, us := setupMTSyscall(sysFutex, 0x1000)
+		state.Memory.SetMemory(0x2000, 5)
+		setFutexArgs(state.CurrentThread(), 0x2000, futexWaitPrivate, 6, 0)
+		_, err := us.Step(false)
+		require.NoError(t, err)
+		thread := state.CurrentThread()
+		require.Equal(t, FutexEmptyAddr, thread.FutexAddr)
+		require.Equal(t, uint32(0xFFffFFff), thread.Registers[2])
+		require.Equal(t, uint32(MipsEAGAIN), thread.Registers[7])
+		require.Equal(t, uint32(0x1004), thread.PC)
+	})
+
+	t.Run("WokenByValueChange", func(t *testing.T) {
+		state, us := setupMTSyscall(sysFutex, 0x1000)
+		state.Memory.SetMemory(0x2000, 5)
+		thread := state.CurrentThread()
+		setFutexArgs(thread, 0x2000, futexWaitPrivate, 5, 0)
+		_, err := us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0x2000), thread.FutexAddr)
+		require.Equal(t, FutexNoTimeout, thread.FutexTimeoutStep)
+		require.Equal(t, uint32(0x1000), thread.PC, "must not return from the syscall while waiting")
+		require.Same(t, thread, state.CurrentThread())
+
+		_, err = us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0x2000), thread.FutexAddr, "must keep waiting while the value is unchanged")
+
+		state.Memory.SetMemory(0x2000, 6)
+		_, err = us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, FutexEmptyAddr, thread.FutexAddr)
+		require.Equal(t, uint32(0), thread.Registers[2])
+		require.Equal(t, uint32(0x1004), thread.PC)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		state, us := setupMTSyscall(sysFutex, 0x1000)
+		thread := state.CurrentThread()
+		setFutexArgs(thread, 0x2000, futexWaitPrivate, 0, 0x3000)
+		_, err := us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, uint64(1+FutexTimeoutSteps), thread.FutexTimeoutStep)
+
+		state.Step = thread.FutexTimeoutStep
+		_, err = us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, FutexEmptyAddr, thread.FutexAddr)
+		require.Equal(t, uint32(0xFFffFFff), thread.Registers[2])
+		require.Equal(t, uint32(MipsETIMEDOUT), thread.Registers[7])
+		require.Equal(t, uint32(0x1004), thread.PC)
+	})
+}
+
+func TestMTFutexWake(t *testing.T) {
+	state, us := setupMTSyscall(sysFutex, 0x1000)
+	waker := state.CurrentThread()
+	setFutexArgs(waker, 0x2000, futexWakePrivate, 1, 0)
+	// Threads waiting on other addresses are not woken
+	other := &ThreadState{ThreadID: 1, FutexAddr: 0x3000, FutexTimeoutStep: FutexNoTimeout, PC: 0x1000, NextPC: 0x1004}
+	waiter := &ThreadState{ThreadID: 2, FutexAddr: 0x2000, FutexTimeoutStep: FutexNoTimeout, PC: 0x1000, NextPC: 0x1004}
+	state.RightThreadStack = []*ThreadState{waiter, other}
+	state.NextThreadID = 3
+
+	_, err := us.Step(false)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0x2000), state.Wakeup)
+	require.Equal(t, uint32(0x1004), waker.PC)
+
+	for i := 0; i < 5 && state.Wakeup != FutexEmptyAddr; i++ {
+		_, err := us.Step(false)
+		require.NoError(t, err)
+	}
+	require.Equal(t, FutexEmptyAddr, state.Wakeup)
+	require.Equal(t, FutexEmptyAddr, waiter.FutexAddr)
+	require.Equal(t, uint32(0x1004), waiter.PC)
+	require.Equal(t, uint32(0x3000), other.FutexAddr)
+	require.Equal(t, uint32(0x1000), other.PC)
+}
+
+func TestMTFutexWakeNoWaiters(t *testing.T) {
+	state, us := setupMTSyscall(sysFutex, 0x1000)
+	setFutexArgs(state.CurrentThread(), 0x2000, futexWake, 1, 0)
+	state.RightThreadStack = []*ThreadState{{ThreadID: 1, FutexAddr: FutexEmptyAddr, PC: 0x1000, NextPC: 0x1004}}
+	state.NextThreadID = 2
+
+	_, err := us.Step(false)
+	require.NoError(t, err)
+	for i := 0; i < 5 && state.Wakeup != FutexEmptyAddr; i++ {
+		_, err := us.Step(false)
+		require.NoError(t, err)
+	}
+	require.Equal(t, FutexEmptyAddr, state.Wakeup, "must end the traversal after visiting all threads")
+	require.Equal(t, 2, state.ThreadCount())
+}
+
+func TestMTYield(t *testing.T) {
+	for _, syscallNum := range []uint32{sysSchedYield, sysNanosleep} {
+		state, us := setupMTSyscall(syscallNum, 0x1000)
+		thread := state.CurrentThread()
+		other := &ThreadState{ThreadID: 1, FutexAddr: FutexEmptyAddr, PC: 0x1000, NextPC: 0x1004}
+		state.LeftThreadStack = []*ThreadState{other, thread}
+		state.NextThreadID = 2
+
+		_, err := us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0x1004), thread.PC)
+		require.Equal(t, uint32(0), thread.Registers[2])
+		require.Same(t, other, state.CurrentThread())
+		require.Equal(t, []*ThreadState{thread}, state.RightThreadStack)
+	}
+}
+
+func TestMTGetTID(t *testing.T) {
+	state, us := setupMTSyscall(sysGetTID, 0x1000)
+	state.CurrentThread().ThreadID = 7
+	_, err := us.Step(false)
+	require.NoError(t, err)
+	require.Equal(t, uint32(7), state.CurrentThread().Registers[2])
+}
+
+func TestMTExit(t *testing.T) {
+	state, us := setupMTSyscall(sysExit, 0x1000)
+	thread := state.CurrentThread()
+	thread.Registers[4] = 3
+	other := &ThreadState{ThreadID: 1, FutexAddr: FutexEmptyAddr, PC: 0x1000, NextPC: 0x1004, Registers: [32]uint32{2: sysExit, 4: 4}}
+	state.RightThreadStack = []*ThreadState{other}
+	state.NextThreadID = 2
+
+	_, err := us.Step(false)
+	require.NoError(t, err)
+	require.True(t, thread.Exited)
+	require.Equal(t, uint8(3), thread.ExitCode)
+	require.False(t, state.Exited, "VM must not exit while other threads remain")
+
+	// The exited thread is removed, then the other thread runs and exits too
+	_, err = us.Step(false)
+	require.NoError(t, err)
+	require.Equal(t, 1, state.ThreadCount())
+	require.Same(t, other, state.CurrentThread())
+	for i := 0; i < 2; i++ {
+		_, err = us.Step(false)
+		require.NoError(t, err)
+	}
+	require.True(t, state.Exited)
+	require.Equal(t, uint8(4), state.ExitCode)
+	require.Equal(t, 0, state.ThreadCount())
+}
+
+func TestMTExitGroup(t *testing.T) {
+	state, us := setupMTSyscall(sysExitGroup, 0x1000)
+	state.CurrentThread().Registers[4] = 2
+	state.RightThreadStack = []*ThreadState{{ThreadID: 1, FutexAddr: FutexEmptyAddr}}
+	_, err := us.Step(false)
+	require.NoError(t, err)
+	require.True(t, state.Exited)
+	require.Equal(t, uint8(2), state.ExitCode)
+}
+
+func TestMTPreemption(t *testing.T) {
+	state, us := setupMTSyscall(sysGetTID, 0x1000)
+	thread := state.CurrentThread()
+	other := &ThreadState{ThreadID: 1, FutexAddr: FutexEmptyAddr, PC: 0x1000, NextPC: 0x1004}
+	state.LeftThreadStack = []*ThreadState{other, thread}
+	state.NextThreadID = 2
+	state.StepsSinceLastContextSwitch = SchedQuantum
+
+	_, err := us.Step(false)
+	require.NoError(t, err)
+	require.Equal(t, uint32(0x1000), thread.PC, "must not execute the preempted thread")
+	require.Same(t, other, state.CurrentThread())
+	require.Equal(t, uint64(0), state.StepsSinceLastContextSwitch)
+
+	// Preempting the last thread on the left changes direction
+	state.StepsSinceLastContextSwitch = SchedQuantum
+	_, err = us.Step(false)
+	require.NoError(t, err)
+	require.True(t, state.TraversedRight)
+	require.Empty(t, state.LeftThreadStack)
+	require.Same(t, other, state.CurrentThread())
+}
+
+func TestMTLoadLinkedStoreConditional(t *testing.T) {
+	setup := func() (*MTState, *MTInstrumentedState) {
+		state := NewMTState(&State{Memory: NewMemory(), PC: 0x1000, NextPC: 0x1004})
+		state.Memory.SetMemory(0x1000, llInsn)
+		state.Memory.SetMemory(0x1004, scInsn)
+		state.Memory.SetMemory(0x2000, 5)
+		thread := state.CurrentThread()
+		thread.Registers[4] = 0x2000
+		return state, NewMTInstrumentedState(state, nil, os.Stdout, os.Stderr)
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		state, us := setup()
+		thread := state.CurrentThread()
+		_, err := us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, uint32(5), thread.Registers[8])
+		require.True(t, state.LLReservationActive)
+		require.Equal(t, uint32(0x2000), state.LLAddress)
+
+		thread.Registers[8] = 9
+		_, err = us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, uint32(1), thread.Registers[8])
+		require.Equal(t, uint32(9), state.Memory.GetMemory(0x2000))
+		require.False(t, state.LLReservationActive)
+	})
+
+	t.Run("FailsAfterContextSwitch", func(t *testing.T) {
+		state, us := setup()
+		thread := state.CurrentThread()
+		_, err := us.Step(false)
+		require.NoError(t, err)
+
+		// Another thread runs in between
+		other := &ThreadState{ThreadID: 1, FutexAddr: FutexEmptyAddr, PC: 0x3000, NextPC: 0x3004}
+		state.LeftThreadStack = []*ThreadState{other, thread}
+		state.NextThreadID = 2
+		state.StepsSinceLastContextSwitch = SchedQuantum
+		_, err = us.Step(false)
+		require.NoError(t, err)
+		require.Same(t, other, state.CurrentThread())
+		require.False(t, state.LLReservationActive)
+		for state.CurrentThread() != thread {
+			state.StepsSinceLastContextSwitch = SchedQuantum
+			_, err = us.Step(false)
+			require.NoError(t, err)
+		}
+
+		thread.Registers[8] = 9
+		_, err = us.Step(false)
+		require.NoError(t, err)
+		require.Equal(t, uint32(0), thread.Registers[8])
+		require.Equal(t, uint32(5), state.Memory.GetMemory(0x2000), "must not write memory")
+		require.Equal(t, uint32(0x100c), thread.NextPC)
+	})
+}
+
+func TestMTStepWitness(t *testing.T) {
+	state, us := setupMTSyscall(sysFutex, 0x1000)
+	thread := state.CurrentThread()
+	below := &ThreadState{ThreadID: 1, FutexAddr: FutexEmptyAddr}
+	state.LeftThreadStack = []*ThreadState{below, thread}
+	state.NextThreadID = 2
+	state.Memory.SetMemory(0x2000, 5)
+	setFutexArgs(thread, 0x2000, futexWaitPrivate, 5, 0)
+
+	preState := state.EncodeWitness()
+	threadWitness := thread.EncodeWitness()
+	wit, err := us.Step(true)
+	require.NoError(t, err)
+	require.Equal(t, []byte(preState), wit.State)
+	require.Len(t, wit.MemProof, ThreadWitnessSize+32+28*32*2)
+
+	// The thread witness and inner root reconstruct the left stack root in the pre-state witness
+	require.Equal(t, threadWitness, wit.MemProof[:ThreadWitnessSize])
+	innerRoot := common.BytesToHash(wit.MemProof[ThreadWitnessSize : ThreadWitnessSize+32])
+	require.Equal(t, threadStackRoot([]*ThreadState{below}), innerRoot)
+	leftRoot := common.BytesToHash(preState[MTStateWitnessSize-68 : MTStateWitnessSize-36])
+	require.Equal(t, leftRoot, crypto.Keccak256Hash(innerRoot[:], crypto.Keccak256(threadWitness)))
+
+	// The futex word is proven by the data memory proof
+	dataProof := wit.MemProof[ThreadWitnessSize+32+28*32:]
+	expected := state.Memory.MerkleProof(0x2000)
+	require.Equal(t, expected[:], dataProof)
+}
+
+func TestMTStepReplay(t *testing.T) {
+	t.Run("open_mips_tests", func(t *testing.T) {
+		testFiles, err := os.ReadDir("open_mips_tests/test/bin")
+		require.NoError(t, err)
+		for _, f := range testFiles {
+			if f.Name() == "clone.bin" || strings.HasPrefix(f.Name(), "oracle") {
+				continue
+			}
+			programMem, err := os.ReadFile(path.Join("open_mips_tests/test/bin", f.Name()))
+			require.NoError(t, err)
+			state := &State{PC: 0, NextPC: 4, Memory: NewMemory()}
+			require.NoError(t, state.Memory.SetMemoryRange(0, bytes.NewReader(programMem)), "load program into state")
+			state.Registers[31] = endAddr
+			mtState := NewMTState(state)
+			us := NewMTInstrumentedState(mtState, nil, io.Discard, io.Discard)
+			for i := 0; i < 1000 && mtState.GetPC() != endAddr && !mtState.Exited; i++ {
+				stepAndReplay(t, us, mtState)
+			}
+		}
+	})
+	t.Run("oracle", func(t *testing.T) {
+		programMem, err := os.ReadFile("open_mips_tests/test/bin/oracle.bin")
+		require.NoError(t, err)
+		state := &State{PC: 0, NextPC: 4, Memory: NewMemory()}
+		require.NoError(t, state.Memory.SetMemoryRange(0, bytes.NewReader(programMem)), "load program into state")
+		state.Registers[31] = endAddr
+		mtState := NewMTState(state)
+		us := NewMTInstrumentedState(mtState, staticOracle(t, []byte("hello world")), io.Discard, io.Discard)
+		for i := 0; i < 1000 && mtState.GetPC() != endAddr; i++ {
+			stepAndReplay(t, us, mtState)
+		}
+		require.Equal(t, uint32(endAddr), mtState.GetPC(), "must reach end")
+	})
+	t.Run("multithreaded", func(t *testing.T) {
+		elfProgram, err := elf.Open("../example/bin/multithreaded.elf")
+		require.NoError(t, err, "open ELF file")
+		state, err := LoadELF(elfProgram)
+		require.NoError(t, err, "load ELF into state")
+		require.NoError(t, PatchGoMT(elfProgram, state), "apply Go runtime patches")
+		require.NoError(t, PatchStack(state), "add initial stack")
+		mtState := NewMTState(state)
+		us := NewMTInstrumentedState(mtState, nil, io.Discard, io.Discard)
+		// Only the steps of the scheduler and syscalls, and a sample of the other instructions, are replayed,
+		// as generating the proofs of every step is slow.
+		for i := 0; i < 20_000_000 && !mtState.Exited; i++ {
+			thread := mtState.CurrentThread()
+			insn := mtState.Memory.GetMemory(thread.PC)
+			if mtState.Wakeup != FutexEmptyAddr || thread.Exited || thread.FutexAddr != FutexEmptyAddr ||
+				mtState.StepsSinceLastContextSwitch >= SchedQuantum || (insn>>26 == 0 && insn&0x3f == 0xC) ||
+				i%1_000 == 0 {
+				stepAndReplay(t, us, mtState)
+			} else {
+				_, err := us.Step(false)
+				require.NoError(t, err)
+			}
+		}
+		require.True(t, mtState.Exited, "must complete program")
+	})
+	t.Run("Clone", func(t *testing.T) {
+		state, us := setupMTSyscall(sysClone, 0x1000)
+		state.CurrentThread().Registers[4] = ValidCloneFlags
+		state.RightThreadStack = []*ThreadState{{ThreadID: 1, FutexAddr: FutexEmptyAddr}}
+		state.NextThreadID = 2
+		stepAndReplay(t, us, state)
+		require.Equal(t, 3, state.ThreadCount())
+	})
+	t.Run("FutexWaitAndWake", func(t *testing.T) {
+		state, us := setupMTSyscall(sysFutex, 0x1000)
+		waiter := state.CurrentThread()
+		state.Memory.SetMemory(0x2000, 5)
+		setFutexArgs(waiter, 0x2000, futexWaitPrivate, 5, 0)
+		waker := &ThreadState{ThreadID: 1, FutexAddr: FutexEmptyAddr, PC: 0x1000, NextPC: 0x1004}
+		waker.Registers[2] = sysFutex
+		setFutexArgs(waker, 0x2000, futexWakePrivate, 1, 0)
+		idle := &ThreadState{ThreadID: 2, FutexAddr: FutexEmptyAddr, PC: 0x1000, NextPC: 0x1004, Registers: [32]uint32{2: sysGetTID}}
+		state.LeftThreadStack = []*ThreadState{idle, waker, waiter}
+		state.NextThreadID = 3
+
+		// wait, wake, then traverse the threads until the waiter is woken
+		for i := 0; i < 6; i++ {
+			stepAndReplay(t, us, state)
+		}
+		require.Equal(t, FutexEmptyAddr, waiter.FutexAddr, "must wake the waiter")
+		require.Equal(t, FutexEmptyAddr, state.Wakeup)
+	})
+	t.Run("FutexTimeout", func(t *testing.T) {
+		state, us := setupMTSyscall(sysFutex, 0x1000)
+		thread := state.CurrentThread()
+		state.Memory.SetMemory(0x2000, 5)
+		setFutexArgs(thread, 0x2000, futexWaitPrivate, 5, 1)
+		stepAndReplay(t, us, state)
+		state.Step += FutexTimeoutSteps
+		stepAndReplay(t, us, state)
+		require.Equal(t, uint32(MipsETIMEDOUT), thread.Registers[7])
+	})
+	t.Run("Preemption", func(t *testing.T) {
+		state, us := setupMTSyscall(sysGetTID, 0x1000)
+		other := &ThreadState{ThreadID: 1, FutexAddr: FutexEmptyAddr, PC: 0x1000, NextPC: 0x1004}
+		state.LeftThreadStack = []*ThreadState{other, state.CurrentThread()}
+		state.NextThreadID = 2
+		for i := 0; i < 3; i++ {
+			state.StepsSinceLastContextSwitch = SchedQuantum
+			stepAndReplay(t, us, state)
+		}
+	})
+	t.Run("Exit", func(t *testing.T) {
+		state, us := setupMTSyscall(sysExit, 0x1000)
+		other := &ThreadState{ThreadID: 1, FutexAddr: FutexEmptyAddr, PC: 0x1000, NextPC: 0x1004, Registers: [32]uint32{2: sysExit, 4: 4}}
+		state.RightThreadStack = []*ThreadState{other}
+		state.NextThreadID = 2
+		for i := 0; i < 5; i++ {
+			stepAndReplay(t, us, state)
+		}
+		require.True(t, state.Exited)
+	})
+	t.Run("LoadLinkedStoreConditional", func(t *testing.T) {
+		state := NewMTState(&State{Memory: NewMemory(), PC: 0x1000, NextPC: 0x1004})
+		state.Memory.SetMemory(0x1000, llInsn)
+		state.Memory.SetMemory(0x1004, scInsn)
+		state.Memory.SetMemory(0x1008, scInsn)
+		state.Memory.SetMemory(0x2000, 5)
+		state.CurrentThread().Registers[4] = 0x2000
+		us := NewMTInstrumentedState(state, nil, io.Discard, io.Discard)
+		for i := 0; i < 3; i++ {
+			stepAndReplay(t, us, state)
+		}
+	})
+}
+
+// stepAndReplay steps the VM with a proof, and checks that re-executing the step from only its witness and proof
+// results in the same post-state.
+func stepAndReplay(t *testing.T, us *MTInstrumentedState, state *MTState) {
+	wit, err := us.Step(true)
+	require.NoError(t, err)
+	require.Equal(t, state.EncodeWitness(), replayMTStep(t, wit), "replayed step must match post-state")
+}
+
+// replayMTStep re-executes a step of the multi-threaded VM from only the pre-state witness and proof of the step,
+// and returns the witness of the post-state.
+// The memory only holds the proven leaves, and the threads below the current thread are only known by their roots,
+// so the threads of each stack that are not part of the proof are represented by a single placeholder thread.
+func replayMTStep(t *testing.T, wit *StepWitness) StateWitness {
+	pre := wit.State
+	require.Len(t, pre, MTStateWitnessSize)
+	require.Len(t, wit.MemProof, ThreadWitnessSize+32+28*32*2)
+	if pre[73] != 0 {
+		// an exited VM does not step
+		return pre
+	}
+	memRoot := *(*[32]byte)(pre[:32])
+	leftRoot := common.BytesToHash(pre[100:132])
+	rightRoot := common.BytesToHash(pre[132:164])
+	threadWitness := wit.MemProof[:ThreadWitnessSize]
+	innerRoot := common.BytesToHash(wit.MemProof[ThreadWitnessSize : ThreadWitnessSize+32])
+	insnProof := wit.MemProof[ThreadWitnessSize+32 : ThreadWitnessSize+32+28*32]
+	dataProof := wit.MemProof[ThreadWitnessSize+32+28*32:]
+
+	thread := decodeThreadWitness(threadWitness)
+	activeRoot, otherRoot := leftRoot, rightRoot
+	if pre[99] != 0 {
+		activeRoot, otherRoot = rightRoot, leftRoot
+	}
+	require.Equal(t, activeRoot, pushThreadRoot(innerRoot, thread), "thread witness and inner root must match the active stack")
+	require.Equal(t, memRoot, proofRoot(thread.PC, insnProof), "instruction proof must match the memory root")
+
+	placeholders := make(map[*ThreadState]common.Hash)
+	stack := func(root common.Hash, top ...*ThreadState) []*ThreadState {
+		var out []*ThreadState
+		if root != EmptyThreadsRoot {
+			placeholder := &ThreadState{}
+			placeholders[placeholder] = root
+			out = append(out, placeholder)
+		}
+		return append(out, top...)
+	}
+	replay := func(dataAddr uint32) (*MTState, *MTInstrumentedState) {
+		state := &MTState{
+			Memory:                      NewMemory(),
+			PreimageKey:                 common.BytesToHash(pre[32:64]),
+			PreimageOffset:              binary.BigEndian.Uint32(pre[64:68]),
+			Heap:                        binary.BigEndian.Uint32(pre[68:72]),
+			ExitCode:                    pre[72],
+			LLReservationActive:         pre[74] != 0,
+			LLAddress:                   binary.BigEndian.Uint32(pre[75:79]),
+			Step:                        binary.BigEndian.Uint64(pre[79:87]),
+			StepsSinceLastContextSwitch: binary.BigEndian.Uint64(pre[87:95]),
+			Wakeup:                      binary.BigEndian.Uint32(pre[95:99]),
+			TraversedRight:              pre[99] != 0,
+			NextThreadID:                binary.BigEndian.Uint32(pre[164:168]),
+		}
+		active := stack(innerRoot, decodeThreadWitness(threadWitness))
+		other := stack(otherRoot)
+		if state.TraversedRight {
+			state.LeftThreadStack, state.RightThreadStack = other, active
+		} else {
+			state.LeftThreadStack, state.RightThreadStack = active, other
+		}
+		setLeaf(state.Memory, thread.PC, insnProof)
+		if dataAddr != ^uint32(0) {
+			setLeaf(state.Memory, dataAddr, dataProof)
+		}
+		us := NewMTInstrumentedState(state, &testOracle{
+			hint: func(v []byte) {},
+			getPreimage: func(k [32]byte) []byte {
+				t.Fatalf("preimage %x is not part of the witness", k)
+				return nil
+			},
+		}, io.Discard, io.Discard)
+		if wit.HasPreimage() {
+			us.cpu.lastPreimageKey = wit.PreimageKey
+			us.cpu.lastPreimage = wit.PreimageValue
+		}
+		return state, us
+	}
+
+	// The first run finds the memory accessed by the step, which must be proven by the data proof.
+	_, us := replay(^uint32(0))
+	_, err := us.Step(true)
+	require.NoError(t, err)
+	dataAddr := us.cpu.lastMemAccess
+	if dataAddr != ^uint32(0) {
+		require.Equal(t, memRoot, proofRoot(dataAddr, dataProof), "data proof must match the memory root")
+	}
+
+	state, us := replay(dataAddr)
+	_, err = us.Step(false)
+	require.NoError(t, err)
+
+	stackRoot := func(stack []*ThreadState) common.Hash {
+		root := EmptyThreadsRoot
+		for _, thread := range stack {
+			if base, ok := placeholders[thread]; ok {
+				root = base
+				continue
+			}
+			root = pushThreadRoot(root, thread)
+		}
+		return root
+	}
+	post := state.EncodeWitness()
+	if dataAddr != ^uint32(0) {
+		// only the leaf of the data proof can be written
+		leaf := state.Memory.MerkleProof(dataAddr)
+		postMemRoot := proofRoot(dataAddr, append(leaf[:32:32], dataProof[32:]...))
+		copy(post[:32], postMemRoot[:])
+	} else {
+		copy(post[:32], memRoot[:])
+	}
+	postLeftRoot := stackRoot(state.LeftThreadStack)
+	copy(post[100:132], postLeftRoot[:])
+	postRightRoot := stackRoot(state.RightThreadStack)
+	copy(post[132:164], postRightRoot[:])
+	return post
+}
+
+func decodeThreadWitness(data []byte) *ThreadState {
+	thread := &ThreadState{
+		ThreadID:         binary.BigEndian.Uint32(data[0:4]),
+		ExitCode:         data[4],
+		Exited:           data[5] != 0,
+		FutexAddr:        binary.BigEndian.Uint32(data[6:10]),
+		FutexVal:         binary.BigEndian.Uint32(data[10:14]),
+		FutexTimeoutStep: binary.BigEndian.Uint64(data[14:22]),
+		PC:               binary.BigEndian.Uint32(data[22:26]),
+		NextPC:           binary.BigEndian.Uint32(data[26:30]),
+		LO:               binary.BigEndian.Uint32(data[30:34]),
+		HI:               binary.BigEndian.Uint32(data[34:38]),
+	}
+	for i := range thread.Registers {
+		thread.Registers[i] = binary.BigEndian.Uint32(data[38+i*4:])
+	}
+	return thread
+}
+
+// proofRoot computes the memory root from a memory proof of the given address.
+func proofRoot(addr uint32, proof []byte) [32]byte {
+	node := *(*[32]byte)(proof[:32])
+	path := addr >> 5
+	for i := 32; i < len(proof); i += 32 {
+		sib := *(*[32]byte)(proof[i : i+32])
+		if path&1 != 0 {
+			node = HashPair(sib, node)
+		} else {
+			node = HashPair(node, sib)
+		}
+		path >>= 1
+	}
+	return node
+}
+
+// setLeaf writes the leaf of a memory proof to the memory.
+func setLeaf(mem *Memory, addr uint32, proof []byte) {
+	for i := uint32(0); i < 32; i += 4 {
+		mem.SetMemory(addr&^31+i, binary.BigEndian.Uint32(proof[i:]))
+	}
+}
+
+func FuzzMTStateSyscallClone(f *testing.F) {
+	f.Fuzz(func(t *testing.T, pc uint32, step uint64, stackPtr uint32, nextThreadID uint32) {
+		pc = pc & 0xFF_FF_FF_FC // align PC
+		state := NewMTState(&State{PC: pc, NextPC: pc + 4, Memory: NewMemory(), Step: step})
+		state.Memory.SetMemory(pc, syscallInsn)
+		state.NextThreadID = nextThreadID
+		parent := state.CurrentThread()
+		parent.Registers[2] = sysClone
+		parent.Registers[4] = ValidCloneFlags
+		parent.Registers[5] = stackPtr
+		preStateRoot := state.Memory.MerkleRoot()
+
+		us := NewMTInstrumentedState(state, nil, os.Stdout, os.Stderr)
+		_, err := us.Step(true)
+		require.NoError(t, err)
+
+		require.Equal(t, step+1, state.Step)
+		require.Equal(t, nextThreadID+1, state.NextThreadID)
+		require.Equal(t, preStateRoot, state.Memory.MerkleRoot())
+		require.Len(t, state.LeftThreadStack, 2)
+		child := state.CurrentThread()
+		require.Equal(t, nextThreadID, child.ThreadID)
+		require.Equal(t, nextThreadID, parent.Registers[2])
+		require.Equal(t, uint32(0), child.Registers[2])
+		require.Equal(t, pc+4, child.PC)
+		require.Equal(t, pc+4, parent.PC)
+		if stackPtr != 0 {
+			require.Equal(t, stackPtr, child.Registers[29])
+		}
+	})
+}
+
+func FuzzMTStateSyscallFutex(f *testing.F) {
+	f.Fuzz(func(t *testing.T, addr uint32, op uint32, val uint32, mem uint32, timeout uint32) {
+		pc := uint32(0x1000)
+		addr = addr&0xFF_FF_FF_FC | 0x10_00_00_00 // keep the futex word away from the instruction
+		state := NewMTState(&State{PC: pc, NextPC: pc + 4, Memory: NewMemory()})
+		state.Memory.SetMemory(pc, syscallInsn)
+		state.Memory.SetMemory(addr, mem)
+		thread := state.CurrentThread()
+		thread.Registers[2] = sysFutex
+		setFutexArgs(thread, addr, op, val, timeout)
+
+		us := NewMTInstrumentedState(state, nil, os.Stdout, os.Stderr)
+		_, err := us.Step(true)
+		require.NoError(t, err)
+
+		require.Equal(t, uint64(1), state.Step)
+		require.Equal(t, mem, state.Memory.GetMemory(addr), "futex must not write memory")
+		switch {
+		case (op == futexWait || op == futexWaitPrivate) && mem == val:
+			require.Equal(t, addr, thread.FutexAddr)
+			require.Equal(t, pc, thread.PC)
+		case op == futexWait || op == futexWaitPrivate:
+			require.Equal(t, uint32(MipsEAGAIN), thread.Registers[7])
+			require.Equal(t, pc+4, thread.PC)
+		case op == futexWake || op == futexWakePrivate:
+			require.Equal(t, addr, state.Wakeup)
+			require.Equal(t, uint32(0), thread.Registers[2])
+			require.Equal(t, pc+4, thread.PC)
+		default:
+			require.Equal(t, uint32(MipsEINVAL), thread.Registers[7])
+			require.Equal(t, pc+4, thread.PC)
+		}
+	})
+}
+
+func setupMTSyscall(syscallNum uint32, pc uint32) (*MTState, *MTInstrumentedState) {
+	state := NewMTState(&State{Memory: NewMemory(), PC: pc, NextPC: pc + 4})
+	state.Memory.SetMemory(pc, syscallInsn)
+	state.CurrentThread().Registers[2] = syscallNum
+	return state, NewMTInstrumentedState(state, nil, os.Stdout, os.Stderr)
+}
+
+func setFutexArgs(thread *ThreadState, addr uint32, op uint32, val uint32, timeout uint32) {
+	thread.Registers[4] = addr
+	thread.Registers[5] = op
+	thread.Registers[6] = val
+	thread.Registers[7] = timeout
+}
@@ -0,0 +1,232 @@
+package mipsevm
+
+import (
+	"encoding/binary"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ThreadWitnessSize is the size of the thread witness encoding in bytes.
+const ThreadWitnessSize = 166
+
+// MTStateWitnessSize is the size of the multi-threaded state witness encoding in bytes.
+const MTStateWitnessSize = 168
+
+const (
+	// FutexEmptyAddr is the futex address of a thread that is not waiting, and the wakeup address when no wakeup is in progress.
+	FutexEmptyAddr = ^uint32(0)
+	// FutexNoTimeout is the futex timeout step of a thread that waits without a timeout.
+	FutexNoTimeout = ^uint64(0)
+)
+
+// EmptyThreadsRoot is the root of an empty thread stack.
+var EmptyThreadsRoot = crypto.Keccak256Hash(make([]byte, 64))
+
+// ThreadState is the CPU state of a single thread of the multi-threaded VM.
+type ThreadState struct {
+	ThreadID uint32 `json:"threadId"`
+	ExitCode uint8  `json:"exit"`
+	Exited   bool   `json:"exited"`
+
+	// FutexAddr is the address the thread is waiting on, or FutexEmptyAddr if the thread is not waiting.
+	FutexAddr uint32 `json:"futexAddr"`
+	// FutexVal is the value the thread expects at FutexAddr while it waits.
+	FutexVal uint32 `json:"futexVal"`
+	// FutexTimeoutStep is the step after which the wait times out.
+	FutexTimeoutStep uint64 `json:"futexTimeoutStep"`
+
+	PC     uint32 `json:"pc"`
+	NextPC uint32 `json:"nextPC"`
+	LO     uint32 `json:"lo"`
+	HI     uint32 `json:"hi"`
+
+	Registers [32]uint32 `json:"registers"`
+}
+
+func (t *ThreadState) EncodeWitness() []byte {
+	out := make([]byte, 0, ThreadWitnessSize)
+	out = binary.BigEndian.AppendUint32(out, t.ThreadID)
+	out = append(out, t.ExitCode)
+	out = appendBool(out, t.Exited)
+	out = binary.BigEndian.AppendUint32(out, t.FutexAddr)
+	out = binary.BigEndian.AppendUint32(out, t.FutexVal)
+	out = binary.BigEndian.AppendUint64(out, t.FutexTimeoutStep)
+	out = binary.BigEndian.AppendUint32(out, t.PC)
+	out = binary.BigEndian.AppendUint32(out, t.NextPC)
+	out = binary.BigEndian.AppendUint32(out, t.LO)
+	out = binary.BigEndian.AppendUint32(out, t.HI)
+	for _, r := range t.Registers {
+		out = binary.BigEndian.AppendUint32(out, r)
+	}
+	return out
+}
+
+// MTState is the state of the multi-threaded VM.
+//
+// Threads are kept on two stacks. The current thread is the top of the left stack while traversing left,
+// or the top of the right stack while traversing right. Preempting the current thread moves it to the top of the
+// other stack, and the traversal changes direction once the active stack is empty.
+// Each stack is committed to in the witness by a hash onion of the thread witnesses,
+// so a single step only needs the current thread and the root of the threads below it.
+type MTState struct {
+	Memory *Memory `json:"memory"`
+
+	PreimageKey    common.Hash `json:"preimageKey"`
+	PreimageOffset uint32      `json:"preimageOffset"` // note that the offset includes the 8-byte length prefix
+
+	Heap uint32 `json:"heap"` // to handle mmap growth
+
+	// LLReservationActive is set by ll and cleared by sc and on every context switch.
+	// sc only succeeds while the reservation made by the same thread is active.
+	LLReservationActive bool   `json:"llReservationActive"`
+	LLAddress           uint32 `json:"llAddress"`
+
+	ExitCode uint8 `json:"exit"`
+	Exited   bool  `json:"exited"`
+
+	Step                        uint64 `json:"step"`
+	StepsSinceLastContextSwitch uint64 `json:"stepsSinceLastContextSwitch"`
+
+	// Wakeup is the address of a futex being woken, or FutexEmptyAddr when no wakeup is in progress.
+	Wakeup uint32 `json:"wakeup"`
+
+	TraversedRight   bool           `json:"traversedRight"`
+	LeftThreadStack  []*ThreadState `json:"leftThreadStack"`
+	RightThreadStack []*ThreadState `json:"rightThreadStack"`
+	NextThreadID     uint32         `json:"nextThreadId"`
+
+	// LastHint is optional metadata, and not part of the VM state itself. See State.LastHint.
+	LastHint hexutil.Bytes `json:"lastHint,omitempty"`
+}
+
+// NewMTState converts the state of a loaded program into a multi-threaded state with the program as its only thread.
+func NewMTState(s *State) *MTState {
+	thread := &ThreadState{
+		ThreadID:         0,
+		ExitCode:         s.ExitCode,
+		Exited:           s.Exited,
+		FutexAddr:        FutexEmptyAddr,
+		FutexVal:         0,
+		FutexTimeoutStep: 0,
+		PC:               s.PC,
+		NextPC:           s.NextPC,
+		LO:               s.LO,
+		HI:               s.HI,
+		Registers:        s.Registers,
+	}
+	return &MTState{
+		Memory:          s.Memory,
+		PreimageKey:     s.PreimageKey,
+		PreimageOffset:  s.PreimageOffset,
+		Heap:            s.Heap,
+		ExitCode:        s.ExitCode,
+		Exited:          s.Exited,
+		Step:            s.Step,
+		Wakeup:          FutexEmptyAddr,
+		LeftThreadStack: []*ThreadState{thread},
+		NextThreadID:    1,
+		LastHint:        s.LastHint,
+	}
+}
+
+func (s *MTState) VMStatus() uint8 {
+	return vmStatus(s.Exited, s.ExitCode)
+}
+
+func (s *MTState) GetStep() uint64 {
+	return s.Step
+}
+
+func (s *MTState) GetExited() bool {
+	return s.Exited
+}
+
+func (s *MTState) GetMemory() *Memory {
+	return s.Memory
+}
+
+// GetPC returns the PC of the current thread, or 0 if all threads have exited.
+func (s *MTState) GetPC() uint32 {
+	thread := s.CurrentThread()
+	if thread == nil {
+		return 0
+	}
+	return thread.PC
+}
+
+// CurrentThread returns the thread that executes the next step, or nil if all threads have exited.
+func (s *MTState) CurrentThread() *ThreadState {
+	stack := s.activeStack()
+	if len(stack) == 0 {
+		return nil
+	}
+	return stack[len(stack)-1]
+}
+
+// ThreadCount returns the number of threads that have not been removed from the VM.
+func (s *MTState) ThreadCount() int {
+	return len(s.LeftThreadStack) + len(s.RightThreadStack)
+}
+
+func (s *MTState) activeStack() []*ThreadState {
+	if s.TraversedRight {
+		return s.RightThreadStack
+	}
+	return s.LeftThreadStack
+}
+
+// innerThreadRoot is the root of the active stack without the current thread.
+func (s *MTState) innerThreadRoot() common.Hash {
+	stack := s.activeStack()
+	if len(stack) == 0 {
+		return EmptyThreadsRoot
+	}
+	return threadStackRoot(stack[:len(stack)-1])
+}
+
+func (s *MTState) EncodeWitness() StateWitness {
+	out := make([]byte, 0, MTStateWitnessSize)
+	memRoot := s.Memory.MerkleRoot()
+	out = append(out, memRoot[:]...)
+	out = append(out, s.PreimageKey[:]...)
+	out = binary.BigEndian.AppendUint32(out, s.PreimageOffset)
+	out = binary.BigEndian.AppendUint32(out, s.Heap)
+	out = append(out, s.ExitCode)
+	out = appendBool(out, s.Exited)
+	out = appendBool(out, s.LLReservationActive)
+	out = binary.BigEndian.AppendUint32(out, s.LLAddress)
+	out = binary.BigEndian.AppendUint64(out, s.Step)
+	out = binary.BigEndian.AppendUint64(out, s.StepsSinceLastContextSwitch)
+	out = binary.BigEndian.AppendUint32(out, s.Wakeup)
+	out = appendBool(out, s.TraversedRight)
+	leftRoot := threadStackRoot(s.LeftThreadStack)
+	out = append(out, leftRoot[:]...)
+	rightRoot := threadStackRoot(s.RightThreadStack)
+	out = append(out, rightRoot[:]...)
+	out = binary.BigEndian.AppendUint32(out, s.NextThreadID)
+	return out
+}
+
+// threadStackRoot computes the root of a thread stack, with the top of the stack at the end of the slice.
+func threadStackRoot(stack []*ThreadState) common.Hash {
+	root := EmptyThreadsRoot
+	for _, thread := range stack {
+		root = pushThreadRoot(root, thread)
+	}
+	return root
+}
+
+// pushThreadRoot returns the root of a thread stack with the given root after the thread is pushed onto it.
+func pushThreadRoot(root common.Hash, thread *ThreadState) common.Hash {
+	threadHash := crypto.Keccak256Hash(thread.EncodeWitness())
+	return crypto.Keccak256Hash(root[:], threadHash[:])
+}
+
+func appendBool(out []byte, b bool) []byte {
+	if b {
+		return append(out, 1)
+	}
+	return append(out, 0)
+}
@@ -0,0 +1,41 @@
+package mipsevm
+
+import (
+	"bytes"
+	"debug/elf"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMultithreaded(t *testing.T) {
+	elfProgram, err := elf.Open("../example/bin/multithreaded.elf")
+	require.NoError(t, err, "open ELF file")
+
+	state, err := LoadELF(elfProgram)
+	require.NoError(t, err, "load ELF into state")
+
+	err = PatchGoMT(elfProgram, state)
+	require.NoError(t, err, "apply Go runtime patches")
+	require.NoError(t, PatchStack(state), "add initial stack")
+
+	mtState := NewMTState(state)
+	var stdOutBuf, stdErrBuf bytes.Buffer
+	us := NewMTInstrumentedState(mtState, nil, io.MultiWriter(&stdOutBuf, os.Stdout), io.MultiWriter(&stdErrBuf, os.Stderr))
+
+	for i := 0; i < 20_000_000; i++ {
+		if mtState.Exited {
+			break
+		}
+		_, err := us.Step(false)
+		require.NoError(t, err)
+	}
+
+	require.True(t, mtState.Exited, "must complete program")
+	require.Equal(t, uint8(0), mtState.ExitCode, "exit with 0")
+	require.Greater(t, mtState.NextThreadID, uint32(1), "must run the program on multiple threads")
+	require.Equal(t, "total 6000\n", stdOutBuf.String(), "stdout")
+	require.Equal(t, "", stdErrBuf.String(), "stderr silent")
+}
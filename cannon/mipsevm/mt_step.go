@@ -0,0 +1,325 @@
+package mipsevm
+
+const (
+	sysExit       = 4001
+	sysSchedYield = 4162
+	sysNanosleep  = 4166
+	sysGetTID     = 4222
+	sysFutex      = 4238
+)
+
+const (
+	futexWait        = 0
+	futexWake        = 1
+	futexWaitPrivate = 128
+	futexWakePrivate = 129
+)
+
+const (
+	MipsEAGAIN    = 0xb
+	MipsETIMEDOUT = 0x91
+)
+
+const (
+	// ValidCloneFlags are the clone flags the Go runtime uses to create threads. Other uses of clone are not supported.
+	ValidCloneFlags = 0x100 | // CLONE_VM
+		0x200 | // CLONE_FS
+		0x400 | // CLONE_FILES
+		0x800 | // CLONE_SIGHAND
+		0x10000 | // CLONE_THREAD
+		0x40000 // CLONE_SYSVSEM
+
+	// SchedQuantum is the number of steps a thread executes before it is preempted.
+	SchedQuantum = 100_000
+	// FutexTimeoutSteps is the number of steps after which a futex wait with a timeout times out.
+	FutexTimeoutSteps = 10_000
+)
+
+// mtStep performs a single action of the scheduler: waking a thread, removing an exited thread,
+// switching to another thread, or executing an instruction of the current thread.
+func (m *MTInstrumentedState) mtStep() error {
+	if m.state.Exited {
+		return nil
+	}
+	m.state.Step += 1
+	thread := m.state.CurrentThread()
+
+	// A wakeup traverses all threads, waking the first thread found to be waiting on the wakeup address.
+	if m.state.Wakeup != FutexEmptyAddr {
+		if thread.FutexAddr == m.state.Wakeup {
+			m.state.Wakeup = FutexEmptyAddr
+			m.onWaitComplete(thread, false)
+			return nil
+		}
+		traversingRight := m.state.TraversedRight
+		changedDirections := m.preemptThread()
+		if traversingRight && changedDirections {
+			// all threads have been visited without finding a waiting thread
+			m.state.Wakeup = FutexEmptyAddr
+		}
+		return nil
+	}
+
+	if thread.Exited {
+		m.popThread()
+		if m.state.ThreadCount() == 0 {
+			m.state.Exited = true
+			m.state.ExitCode = thread.ExitCode
+		}
+		return nil
+	}
+
+	if thread.FutexAddr != FutexEmptyAddr {
+		if m.state.Step > thread.FutexTimeoutStep {
+			m.onWaitComplete(thread, true)
+			return nil
+		}
+		m.cpu.trackMemAccess(thread.FutexAddr)
+		if m.state.Memory.GetMemory(thread.FutexAddr) != thread.FutexVal {
+			m.onWaitComplete(thread, false)
+		} else {
+			m.preemptThread()
+		}
+		return nil
+	}
+
+	if m.state.StepsSinceLastContextSwitch >= SchedQuantum {
+		m.preemptThread()
+		return nil
+	}
+	m.state.StepsSinceLastContextSwitch += 1
+
+	insn := m.state.Memory.GetMemory(thread.PC)
+	opcode := insn >> 26
+	if opcode == 0 && insn&0x3f == 0xC { // syscall
+		if m.handleThreadSyscall(thread) {
+			return nil
+		}
+	} else if opcode == 0x30 || opcode == 0x38 { // ll/sc
+		addr := (thread.Registers[(insn>>21)&0x1F] + SE(insn&0xFFFF, 16)) & 0xFFFFFFFC
+		if opcode == 0x30 {
+			m.state.LLReservationActive = true
+			m.state.LLAddress = addr
+		} else {
+			reserved := m.state.LLReservationActive && m.state.LLAddress == addr
+			m.state.LLReservationActive = false
+			m.state.LLAddress = 0
+			if !reserved {
+				// sc fails without writing memory
+				if rtReg := (insn >> 16) & 0x1F; rtReg != 0 {
+					thread.Registers[rtReg] = 0
+				}
+				thread.PC = thread.NextPC
+				thread.NextPC = thread.NextPC + 4
+				return nil
+			}
+		}
+	}
+	return m.execute(thread)
+}
+
+// execute runs the instruction at the PC of the thread with the single-threaded VM.
+func (m *MTInstrumentedState) execute(thread *ThreadState) error {
+	cpu := m.cpu.state
+	cpu.Memory = m.state.Memory
+	cpu.PreimageKey = m.state.PreimageKey
+	cpu.PreimageOffset = m.state.PreimageOffset
+	cpu.Heap = m.state.Heap
+	cpu.LastHint = m.state.LastHint
+	cpu.Step = m.state.Step - 1
+	cpu.PC = thread.PC
+	cpu.NextPC = thread.NextPC
+	cpu.LO = thread.LO
+	cpu.HI = thread.HI
+	cpu.Registers = thread.Registers
+
+	if err := m.cpu.mipsStep(); err != nil {
+		return err
+	}
+
+	m.state.PreimageKey = cpu.PreimageKey
+	m.state.PreimageOffset = cpu.PreimageOffset
+	m.state.Heap = cpu.Heap
+	m.state.LastHint = cpu.LastHint
+	thread.PC = cpu.PC
+	thread.NextPC = cpu.NextPC
+	thread.LO = cpu.LO
+	thread.HI = cpu.HI
+	thread.Registers = cpu.Registers
+	return nil
+}
+
+// handleThreadSyscall handles the syscalls that interact with threads, and returns false for any other syscall.
+func (m *MTInstrumentedState) handleThreadSyscall(thread *ThreadState) bool {
+	syscallNum := thread.Registers[2] // v0
+	v0 := uint32(0)
+	v1 := uint32(0)
+
+	a0 := thread.Registers[4]
+	a1 := thread.Registers[5]
+	a2 := thread.Registers[6]
+	a3 := thread.Registers[7]
+
+	switch syscallNum {
+	case sysClone:
+		if a0 != ValidCloneFlags {
+			v0 = 0xFFffFFff
+			v1 = MipsEINVAL
+			break
+		}
+		child := *thread
+		child.ThreadID = m.state.NextThreadID
+		child.FutexAddr = FutexEmptyAddr
+		child.FutexVal = 0
+		child.FutexTimeoutStep = 0
+		child.PC = thread.NextPC
+		child.NextPC = thread.NextPC + 4
+		if a1 != 0 {
+			child.Registers[29] = a1 // stack pointer of the new thread
+		}
+		// the new thread returns 0 from clone
+		child.Registers[2] = 0
+		child.Registers[7] = 0
+		m.state.NextThreadID += 1
+
+		thread.Registers[2] = child.ThreadID
+		thread.Registers[7] = 0
+		thread.PC = thread.NextPC
+		thread.NextPC = thread.NextPC + 4
+
+		// the new thread runs first
+		m.pushThread(&child)
+		return true
+	case sysExit:
+		thread.Exited = true
+		thread.ExitCode = uint8(a0)
+		return true
+	case sysExitGroup:
+		m.state.Exited = true
+		m.state.ExitCode = uint8(a0)
+		return true
+	case sysFutex:
+		// args: a0 = addr, a1 = op, a2 = val, a3 = timeout
+		effAddr := a0 & 0xFFffFFfc
+		switch a1 {
+		case futexWait, futexWaitPrivate:
+			m.cpu.trackMemAccess(effAddr)
+			if m.state.Memory.GetMemory(effAddr) != a2 {
+				v0 = 0xFFffFFff
+				v1 = MipsEAGAIN
+				break
+			}
+			thread.FutexAddr = effAddr
+			thread.FutexVal = a2
+			if a3 == 0 {
+				thread.FutexTimeoutStep = FutexNoTimeout
+			} else {
+				thread.FutexTimeoutStep = m.state.Step + FutexTimeoutSteps
+			}
+			// the PC is advanced and the result set once the wait completes
+			m.preemptThread()
+			return true
+		case futexWake, futexWakePrivate:
+			m.state.Wakeup = effAddr
+			thread.Registers[2] = 0
+			thread.Registers[7] = 0
+			thread.PC = thread.NextPC
+			thread.NextPC = thread.NextPC + 4
+			m.preemptThread()
+			// Start the traversal for a waiting thread from the left, so it visits every thread
+			if len(m.state.LeftThreadStack) > 0 {
+				m.state.TraversedRight = false
+			}
+			return true
+		default:
+			v0 = 0xFFffFFff
+			v1 = MipsEINVAL
+		}
+	case sysSchedYield, sysNanosleep:
+		thread.Registers[2] = 0
+		thread.Registers[7] = 0
+		thread.PC = thread.NextPC
+		thread.NextPC = thread.NextPC + 4
+		m.preemptThread()
+		return true
+	case sysGetTID:
+		v0 = thread.ThreadID
+	default:
+		return false
+	}
+	thread.Registers[2] = v0
+	thread.Registers[7] = v1
+	thread.PC = thread.NextPC
+	thread.NextPC = thread.NextPC + 4
+	return true
+}
+
+// onWaitComplete resumes a thread waiting on a futex, returning from the futex syscall.
+func (m *MTInstrumentedState) onWaitComplete(thread *ThreadState, timedOut bool) {
+	thread.FutexAddr = FutexEmptyAddr
+	thread.FutexVal = 0
+	thread.FutexTimeoutStep = 0
+	v0 := uint32(0)
+	v1 := uint32(0)
+	if timedOut {
+		v0 = 0xFFffFFff
+		v1 = MipsETIMEDOUT
+	}
+	thread.Registers[2] = v0
+	thread.Registers[7] = v1
+	thread.PC = thread.NextPC
+	thread.NextPC = thread.NextPC + 4
+}
+
+// preemptThread moves the current thread to the top of the other stack,
+// and returns true if the traversal changed direction because the active stack is now empty.
+func (m *MTInstrumentedState) preemptThread() bool {
+	thread := m.removeCurrentThread()
+	if m.state.TraversedRight {
+		m.state.LeftThreadStack = append(m.state.LeftThreadStack, thread)
+	} else {
+		m.state.RightThreadStack = append(m.state.RightThreadStack, thread)
+	}
+	changedDirections := false
+	if len(m.state.activeStack()) == 0 {
+		m.state.TraversedRight = !m.state.TraversedRight
+		changedDirections = true
+	}
+	m.onContextSwitch()
+	return changedDirections
+}
+
+// popThread removes the current thread from the VM, changing direction if the active stack is now empty.
+func (m *MTInstrumentedState) popThread() {
+	m.removeCurrentThread()
+	if len(m.state.activeStack()) == 0 && m.state.ThreadCount() > 0 {
+		m.state.TraversedRight = !m.state.TraversedRight
+	}
+	m.onContextSwitch()
+}
+
+// pushThread makes the thread the current thread.
+func (m *MTInstrumentedState) pushThread(thread *ThreadState) {
+	if m.state.TraversedRight {
+		m.state.RightThreadStack = append(m.state.RightThreadStack, thread)
+	} else {
+		m.state.LeftThreadStack = append(m.state.LeftThreadStack, thread)
+	}
+	m.onContextSwitch()
+}
+
+func (m *MTInstrumentedState) removeCurrentThread() *ThreadState {
+	thread := m.state.CurrentThread()
+	if m.state.TraversedRight {
+		m.state.RightThreadStack = m.state.RightThreadStack[:len(m.state.RightThreadStack)-1]
+	} else {
+		m.state.LeftThreadStack = m.state.LeftThreadStack[:len(m.state.LeftThreadStack)-1]
+	}
+	return thread
+}
+
+func (m *MTInstrumentedState) onContextSwitch() {
+	m.state.StepsSinceLastContextSwitch = 0
+	m.state.LLReservationActive = false
+	m.state.LLAddress = 0
+}
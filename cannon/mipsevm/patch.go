@@ -77,14 +77,8 @@ func PatchGo(f *elf.File, st *State) error {
 			"flag.init",
 			// We need to patch this out, we don't pass float64nan because we don't support floats
 			"runtime.check":
-			// MIPS32 patch: ret (pseudo instruction)
-			// 03e00008 = jr $ra = ret (pseudo instruction)
-			// 00000000 = nop (executes with delay-slot, but does nothing)
-			if err := st.Memory.SetMemoryRange(uint32(s.Value), bytes.NewReader([]byte{
-				0x03, 0xe0, 0x00, 0x08,
-				0, 0, 0, 0,
-			})); err != nil {
-				return fmt.Errorf("failed to patch Go runtime.gcenable: %w", err)
+			if err := patchReturn(st.Memory, s); err != nil {
+				return err
 			}
 		case "runtime.MemProfileRate":
 			if err := st.Memory.SetMemoryRange(uint32(s.Value), bytes.NewReader(make([]byte, 4))); err != nil { // disable mem profiling, to avoid a lot of unnecessary floating point ops
@@ -95,6 +89,47 @@ func PatchGo(f *elf.File, st *State) error {
 	return nil
 }
 
+// PatchGoMT patches a Go program to run on the multi-threaded VM.
+// Unlike PatchGo, the garbage collector and the background goroutines of the Go runtime are left enabled,
+// as the multi-threaded VM supports the threads the runtime creates to run them.
+func PatchGoMT(f *elf.File, st *State) error {
+	symbols, err := f.Symbols()
+	if err != nil {
+		return fmt.Errorf("failed to read symbols data, cannot patch program: %w", err)
+	}
+
+	for _, s := range symbols {
+		switch s.Name {
+		// skip flag pkg init, we need to debug arg-processing more to see why this fails
+		case "flag.init",
+			// We need to patch this out, we don't pass float64nan because we don't support floats
+			"runtime.check":
+			if err := patchReturn(st.Memory, s); err != nil {
+				return err
+			}
+		case "runtime.MemProfileRate":
+			if err := st.Memory.SetMemoryRange(uint32(s.Value), bytes.NewReader(make([]byte, 4))); err != nil { // disable mem profiling, to avoid a lot of unnecessary floating point ops
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// patchReturn replaces the start of the function with an immediate return.
+func patchReturn(mem *Memory, s elf.Symbol) error {
+	// MIPS32 patch: ret (pseudo instruction)
+	// 03e00008 = jr $ra = ret (pseudo instruction)
+	// 00000000 = nop (executes with delay-slot, but does nothing)
+	if err := mem.SetMemoryRange(uint32(s.Value), bytes.NewReader([]byte{
+		0x03, 0xe0, 0x00, 0x08,
+		0, 0, 0, 0,
+	})); err != nil {
+		return fmt.Errorf("failed to patch Go %s: %w", s.Name, err)
+	}
+	return nil
+}
+
 func PatchStack(st *State) error {
 	// setup stack pointer
 	sp := uint32(0x7f_ff_d0_00)
@@ -0,0 +1,249 @@
+package mipsevm
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// SerializedStateMagic starts every state in the binary format.
+var SerializedStateMagic = [4]byte{'C', 'A', 'N', 'N'}
+
+// SerializedStateVersion is the version of the binary state format.
+const SerializedStateVersion = 1
+
+const (
+	serializedTypeSingleThreaded = 1
+	serializedTypeMultiThreaded  = 2
+)
+
+// Serialize writes the state in the binary format.
+// The binary format holds exactly the same data as the JSON format, but is much faster to read and write.
+func (s *State) Serialize(out io.Writer) error {
+	w := &serializer{w: out}
+	w.writeHeader(serializedTypeSingleThreaded)
+	w.writeMemory(s.Memory)
+	w.write(s.PreimageKey)
+	w.write(s.PreimageOffset)
+	w.write(s.PC)
+	w.write(s.NextPC)
+	w.write(s.LO)
+	w.write(s.HI)
+	w.write(s.Heap)
+	w.write(s.ExitCode)
+	w.write(s.Exited)
+	w.write(s.Step)
+	w.write(s.Registers)
+	w.writeBytes(s.LastHint)
+	return w.err
+}
+
+// Deserialize reads a state in the binary format written by Serialize.
+func (s *State) Deserialize(in io.Reader) error {
+	r := &deserializer{r: in}
+	r.readHeader(serializedTypeSingleThreaded)
+	s.Memory = r.readMemory()
+	r.read(&s.PreimageKey)
+	r.read(&s.PreimageOffset)
+	r.read(&s.PC)
+	r.read(&s.NextPC)
+	r.read(&s.LO)
+	r.read(&s.HI)
+	r.read(&s.Heap)
+	r.read(&s.ExitCode)
+	r.read(&s.Exited)
+	r.read(&s.Step)
+	r.read(&s.Registers)
+	s.LastHint = r.readBytes()
+	return r.err
+}
+
+// Serialize writes the state in the binary format.
+func (s *MTState) Serialize(out io.Writer) error {
+	w := &serializer{w: out}
+	w.writeHeader(serializedTypeMultiThreaded)
+	w.writeMemory(s.Memory)
+	w.write(s.PreimageKey)
+	w.write(s.PreimageOffset)
+	w.write(s.Heap)
+	w.write(s.LLReservationActive)
+	w.write(s.LLAddress)
+	w.write(s.ExitCode)
+	w.write(s.Exited)
+	w.write(s.Step)
+	w.write(s.StepsSinceLastContextSwitch)
+	w.write(s.Wakeup)
+	w.write(s.TraversedRight)
+	w.writeThreads(s.LeftThreadStack)
+	w.writeThreads(s.RightThreadStack)
+	w.write(s.NextThreadID)
+	w.writeBytes(s.LastHint)
+	return w.err
+}
+
+// Deserialize reads a state in the binary format written by Serialize.
+func (s *MTState) Deserialize(in io.Reader) error {
+	r := &deserializer{r: in}
+	r.readHeader(serializedTypeMultiThreaded)
+	s.Memory = r.readMemory()
+	r.read(&s.PreimageKey)
+	r.read(&s.PreimageOffset)
+	r.read(&s.Heap)
+	r.read(&s.LLReservationActive)
+	r.read(&s.LLAddress)
+	r.read(&s.ExitCode)
+	r.read(&s.Exited)
+	r.read(&s.Step)
+	r.read(&s.StepsSinceLastContextSwitch)
+	r.read(&s.Wakeup)
+	r.read(&s.TraversedRight)
+	s.LeftThreadStack = r.readThreads()
+	s.RightThreadStack = r.readThreads()
+	r.read(&s.NextThreadID)
+	s.LastHint = r.readBytes()
+	return r.err
+}
+
+type serializer struct {
+	w   io.Writer
+	err error
+}
+
+func (s *serializer) write(v any) {
+	if s.err != nil {
+		return
+	}
+	s.err = binary.Write(s.w, binary.BigEndian, v)
+}
+
+func (s *serializer) writeRaw(data []byte) {
+	if s.err != nil {
+		return
+	}
+	_, s.err = s.w.Write(data)
+}
+
+func (s *serializer) writeHeader(vmType uint8) {
+	s.writeRaw(SerializedStateMagic[:])
+	s.write(uint8(SerializedStateVersion))
+	s.write(vmType)
+}
+
+// writeBytes writes the data with a uint32 length prefix.
+func (s *serializer) writeBytes(data []byte) {
+	s.write(uint32(len(data)))
+	s.writeRaw(data)
+}
+
+// writeMemory writes the number of pages, followed by the index and data of each page in order of index.
+func (s *serializer) writeMemory(m *Memory) {
+	indices := make([]uint32, 0, len(m.pages))
+	for index := range m.pages {
+		indices = append(indices, index)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	s.write(uint32(len(indices)))
+	for _, index := range indices {
+		s.write(index)
+		s.writeRaw(m.pages[index].Data[:])
+	}
+}
+
+func (s *serializer) writeThreads(threads []*ThreadState) {
+	s.write(uint32(len(threads)))
+	for _, thread := range threads {
+		s.writeRaw(thread.EncodeWitness())
+	}
+}
+
+type deserializer struct {
+	r   io.Reader
+	err error
+}
+
+func (d *deserializer) read(v any) {
+	if d.err != nil {
+		return
+	}
+	d.err = binary.Read(d.r, binary.BigEndian, v)
+}
+
+func (d *deserializer) readRaw(data []byte) {
+	if d.err != nil {
+		return
+	}
+	_, d.err = io.ReadFull(d.r, data)
+}
+
+func (d *deserializer) readHeader(vmType uint8) {
+	var magic [4]byte
+	d.readRaw(magic[:])
+	if d.err == nil && magic != SerializedStateMagic {
+		d.err = errors.New("not a binary cannon state")
+	}
+	var version, actualType uint8
+	d.read(&version)
+	if d.err == nil && version != SerializedStateVersion {
+		d.err = fmt.Errorf("unsupported state version %d, expected %d", version, SerializedStateVersion)
+	}
+	d.read(&actualType)
+	if d.err == nil && actualType != vmType {
+		d.err = fmt.Errorf("unexpected VM type %d, expected %d", actualType, vmType)
+	}
+}
+
+func (d *deserializer) readBytes() []byte {
+	var length uint32
+	d.read(&length)
+	if d.err != nil || length == 0 {
+		return nil
+	}
+	// Read through a limit rather than allocating the length up front, as the length of a corrupt file can't be trusted
+	data, err := io.ReadAll(io.LimitReader(d.r, int64(length)))
+	if err != nil {
+		d.err = err
+	} else if uint32(len(data)) != length {
+		d.err = io.ErrUnexpectedEOF
+	}
+	return data
+}
+
+func (d *deserializer) readMemory() *Memory {
+	m := NewMemory()
+	var count uint32
+	d.read(&count)
+	for i := uint32(0); i < count && d.err == nil; i++ {
+		var index uint32
+		d.read(&index)
+		if _, ok := m.pages[index]; ok && d.err == nil {
+			d.err = fmt.Errorf("cannot load duplicate page, entry %d, page index %d", i, index)
+		}
+		p := m.AllocPage(index)
+		d.readRaw(p.Data[:])
+	}
+	return m
+}
+
+func (d *deserializer) readThreads() []*ThreadState {
+	var count uint32
+	d.read(&count)
+	threads := make([]*ThreadState, 0, min(count, 1024))
+	for i := uint32(0); i < count && d.err == nil; i++ {
+		thread := &ThreadState{}
+		d.read(&thread.ThreadID)
+		d.read(&thread.ExitCode)
+		d.read(&thread.Exited)
+		d.read(&thread.FutexAddr)
+		d.read(&thread.FutexVal)
+		d.read(&thread.FutexTimeoutStep)
+		d.read(&thread.PC)
+		d.read(&thread.NextPC)
+		d.read(&thread.LO)
+		d.read(&thread.HI)
+		d.read(&thread.Registers)
+		threads = append(threads, thread)
+	}
+	return threads
+}
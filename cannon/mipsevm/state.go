@@ -3,6 +3,7 @@ package mipsevm
 import (
 	"encoding/binary"
 	"fmt"
+	"io"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -46,6 +47,22 @@ func (s *State) VMStatus() uint8 {
 	return vmStatus(s.Exited, s.ExitCode)
 }
 
+func (s *State) GetStep() uint64 {
+	return s.Step
+}
+
+func (s *State) GetExited() bool {
+	return s.Exited
+}
+
+func (s *State) GetMemory() *Memory {
+	return s.Memory
+}
+
+func (s *State) GetPC() uint32 {
+	return s.PC
+}
+
 func (s *State) EncodeWitness() StateWitness {
 	out := make([]byte, 0)
 	memRoot := s.Memory.MerkleRoot()
@@ -70,6 +87,30 @@ func (s *State) EncodeWitness() StateWitness {
 	return out
 }
 
+// FPVMState is the state of either the single-threaded or the multi-threaded VM.
+type FPVMState interface {
+	GetStep() uint64
+	GetExited() bool
+	GetMemory() *Memory
+	// GetPC returns the PC of the instruction executed next.
+	GetPC() uint32
+	EncodeWitness() StateWitness
+	// Serialize writes the state in the binary format.
+	Serialize(out io.Writer) error
+}
+
+// FPVM steps through the execution of a program.
+type FPVM interface {
+	Step(proof bool) (*StepWitness, error)
+}
+
+var (
+	_ FPVMState = (*State)(nil)
+	_ FPVMState = (*MTState)(nil)
+	_ FPVM      = (*InstrumentedState)(nil)
+	_ FPVM      = (*MTInstrumentedState)(nil)
+)
+
 type StateWitness []byte
 
 const (
@@ -80,12 +121,17 @@ const (
 )
 
 func (sw StateWitness) StateHash() (common.Hash, error) {
-	if len(sw) != 226 {
-		return common.Hash{}, fmt.Errorf("Invalid witness length. Got %d, expected at least 88", len(sw))
+	var offset int
+	switch len(sw) {
+	case StateWitnessSize:
+		offset = 32*2 + 4*6
+	case MTStateWitnessSize:
+		offset = 32*2 + 4*2
+	default:
+		return common.Hash{}, fmt.Errorf("Invalid witness length. Got %d, expected %d or %d", len(sw), StateWitnessSize, MTStateWitnessSize)
 	}
 
 	hash := crypto.Keccak256Hash(sw)
-	offset := 32*2 + 4*6
 	exitCode := sw[offset]
 	exited := sw[offset+1]
 	status := vmStatus(exited == 1, exitCode)
@@ -0,0 +1,242 @@
+package profiler
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/pprof/profile"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+// maxStackDepth limits the tracked call stack, so a program that calls without ever returning,
+// or returns in a way that isn't detected, can't grow the stack without bound.
+const maxStackDepth = 1024
+
+type stackOp uint8
+
+const (
+	opNone stackOp = iota
+	opCall
+	opReturn
+)
+
+// threadStack is the call stack of a thread, tracked from the calls and returns it executes.
+type threadStack struct {
+	// frames holds the return address of each call, outermost first
+	frames []uint32
+
+	// pending is a call or return that takes effect after the instruction in its delay slot
+	pending       stackOp
+	pendingAddr   uint32
+	nextIsDelayed bool
+}
+
+func (t *threadStack) apply() {
+	op := t.pending
+	t.pending = opNone
+	switch op {
+	case opCall:
+		if len(t.frames) == maxStackDepth {
+			copy(t.frames, t.frames[1:])
+			t.frames = t.frames[:len(t.frames)-1]
+		}
+		t.frames = append(t.frames, t.pendingAddr)
+	case opReturn:
+		// Unwind to the frame returned to. Returns that don't match any frame are caused by
+		// stack switches of the Go runtime (e.g. goroutine switches), which make the tracked stack invalid.
+		for i := len(t.frames) - 1; i >= 0; i-- {
+			if t.frames[i] == t.pendingAddr {
+				t.frames = t.frames[:i]
+				return
+			}
+		}
+		t.frames = t.frames[:0]
+	}
+}
+
+type sample struct {
+	stack []uint32
+	count int64
+}
+
+// Profiler samples the PC and call stack of the program running in a VM,
+// to build a pprof profile of the instructions executed by each function of the program.
+//
+// Call stacks are tracked from the jal, jalr and jr $ra instructions the program executes,
+// as unwinding the stack in memory needs the frame sizes of the Go runtime tables.
+// The stack of a Go program is only approximate after the runtime switches stacks,
+// and samples from before the first observed call are attributed to the function they are in only.
+type Profiler struct {
+	meta *mipsevm.Metadata
+	rate uint64
+
+	threads      map[uint32]*threadStack
+	samples      map[string]*sample
+	start        time.Time
+	instructions uint64
+}
+
+// NewProfiler creates a profiler that takes a sample every rate steps.
+func NewProfiler(meta *mipsevm.Metadata, rate uint64) *Profiler {
+	if meta == nil {
+		meta = &mipsevm.Metadata{}
+	}
+	if rate == 0 {
+		rate = 1
+	}
+	return &Profiler{
+		meta:    meta,
+		rate:    rate,
+		threads: make(map[uint32]*threadStack),
+		samples: make(map[string]*sample),
+		start:   time.Now(),
+	}
+}
+
+// Observe must be called before every step of the VM, with the state the step executes from.
+// Steps of the multi-threaded VM that only schedule threads are not instructions of the program, and are skipped.
+func (p *Profiler) Observe(state mipsevm.FPVMState) {
+	if state.GetExited() {
+		return
+	}
+	threadID, registers, ok := currentThread(state)
+	if !ok {
+		return
+	}
+	t, ok := p.threads[threadID]
+	if !ok {
+		t = &threadStack{}
+		p.threads[threadID] = t
+	}
+
+	pc := state.GetPC()
+	if p.instructions%p.rate == 0 {
+		p.addSample(pc, t.frames)
+	}
+	p.instructions++
+
+	if t.nextIsDelayed {
+		// This is the delay slot of a call or return, which is executed before the jump takes effect
+		t.nextIsDelayed = false
+		t.apply()
+		return
+	}
+	insn := state.GetMemory().GetMemory(pc)
+	opcode := insn >> 26
+	switch {
+	case opcode == 3: // jal
+		t.pending, t.pendingAddr = opCall, pc+8
+	case opcode == 0 && insn&0x3f == 9 && (insn>>11)&0x1f != 0: // jalr
+		t.pending, t.pendingAddr = opCall, pc+8
+	case opcode == 0 && insn&0x3f == 8 && (insn>>21)&0x1f == 31: // jr $ra
+		t.pending, t.pendingAddr = opReturn, registers[31]
+	default:
+		return
+	}
+	t.nextIsDelayed = true
+}
+
+// currentThread returns the ID and registers of the thread executing the next step,
+// or false if the next step does not execute an instruction.
+func currentThread(state mipsevm.FPVMState) (uint32, *[32]uint32, bool) {
+	switch state := state.(type) {
+	case *mipsevm.State:
+		return 0, &state.Registers, true
+	case *mipsevm.MTState:
+		thread := state.CurrentThread()
+		if thread == nil || schedulerStep(state, thread) {
+			return 0, nil, false
+		}
+		return thread.ThreadID, &thread.Registers, true
+	default:
+		return 0, nil, false
+	}
+}
+
+// schedulerStep returns true if the next step of the multi-threaded VM only schedules threads:
+// completing a wakeup traversal, removing an exited thread, polling a futex, or preempting the current thread.
+// These steps happen between the instructions of a thread, and may separate a jump from its delay slot.
+func schedulerStep(state *mipsevm.MTState, thread *mipsevm.ThreadState) bool {
+	return state.Wakeup != mipsevm.FutexEmptyAddr || thread.Exited || thread.FutexAddr != mipsevm.FutexEmptyAddr ||
+		state.StepsSinceLastContextSwitch >= mipsevm.SchedQuantum
+}
+
+func (p *Profiler) addSample(pc uint32, frames []uint32) {
+	key := make([]byte, 4*(len(frames)+1))
+	binary.BigEndian.PutUint32(key, pc)
+	for i, addr := range frames {
+		binary.BigEndian.PutUint32(key[4*(i+1):], addr)
+	}
+	s, ok := p.samples[string(key)]
+	if !ok {
+		stack := make([]uint32, 0, len(frames)+1)
+		stack = append(stack, pc)
+		// Stacks are leaf first in pprof, and the caller is at the call instruction rather than the return address
+		for i := len(frames) - 1; i >= 0; i-- {
+			stack = append(stack, frames[i]-8)
+		}
+		s = &sample{stack: stack}
+		p.samples[string(key)] = s
+	}
+	s.count++
+}
+
+// Profile builds the profile of the steps observed so far.
+func (p *Profiler) Profile() *profile.Profile {
+	prof := &profile.Profile{
+		SampleType: []*profile.ValueType{
+			{Type: "samples", Unit: "count"},
+			{Type: "instructions", Unit: "count"},
+		},
+		PeriodType:    &profile.ValueType{Type: "instructions", Unit: "count"},
+		Period:        int64(p.rate),
+		TimeNanos:     p.start.UnixNano(),
+		DurationNanos: time.Since(p.start).Nanoseconds(),
+		Comments:      []string{fmt.Sprintf("%d instructions observed", p.instructions)},
+	}
+	functions := make(map[string]*profile.Function)
+	locations := make(map[uint32]*profile.Location)
+	location := func(addr uint32) *profile.Location {
+		if loc, ok := locations[addr]; ok {
+			return loc
+		}
+		name := p.meta.LookupSymbol(addr)
+		fn, ok := functions[name]
+		if !ok {
+			fn = &profile.Function{ID: uint64(len(prof.Function) + 1), Name: name, SystemName: name}
+			functions[name] = fn
+			prof.Function = append(prof.Function, fn)
+		}
+		loc := &profile.Location{ID: uint64(len(prof.Location) + 1), Address: uint64(addr), Line: []profile.Line{{Function: fn}}}
+		locations[addr] = loc
+		prof.Location = append(prof.Location, loc)
+		return loc
+	}
+	for _, s := range p.samples {
+		locs := make([]*profile.Location, len(s.stack))
+		for i, addr := range s.stack {
+			locs[i] = location(addr)
+		}
+		prof.Sample = append(prof.Sample, &profile.Sample{
+			Location: locs,
+			Value:    []int64{s.count, s.count * int64(p.rate)},
+		})
+	}
+	return prof
+}
+
+// WriteProfile writes the profile of the steps observed so far to a file, in the gzip compressed pprof format.
+func (p *Profiler) WriteProfile(path string) error {
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create profile file %q: %w", path, err)
+	}
+	defer f.Close()
+	if err := p.Profile().Write(f); err != nil {
+		return fmt.Errorf("failed to write profile: %w", err)
+	}
+	return f.Close()
+}
@@ -0,0 +1,186 @@
+package profiler
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/pprof/profile"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+const (
+	mainAddr = 0x1000
+	funcAddr = 0x2000
+)
+
+// newTestProgram creates a program where main calls a function twice then exits.
+func newTestProgram() (*mipsevm.State, *mipsevm.Metadata) {
+	state := &mipsevm.State{Memory: mipsevm.NewMemory(), PC: mainAddr, NextPC: mainAddr + 4}
+	state.Memory.SetMemory(mainAddr, 0x0c000800)    // jal 0x2000
+	state.Memory.SetMemory(mainAddr+4, 0)           // nop
+	state.Memory.SetMemory(mainAddr+8, 0x0c000800)  // jal 0x2000
+	state.Memory.SetMemory(mainAddr+12, 0)          // nop
+	state.Memory.SetMemory(mainAddr+16, 0x24040000) // addiu a0, zero, 0
+	state.Memory.SetMemory(mainAddr+20, 0x24021096) // addiu v0, zero, 4246 (exit_group)
+	state.Memory.SetMemory(mainAddr+24, 0x0000000c) // syscall
+	state.Memory.SetMemory(funcAddr, 0x25080001)    // addiu t0, t0, 1
+	state.Memory.SetMemory(funcAddr+4, 0x03e00008)  // jr ra
+	state.Memory.SetMemory(funcAddr+8, 0)           // nop
+	meta := &mipsevm.Metadata{Symbols: []mipsevm.Symbol{
+		{Name: "main", Start: mainAddr, Size: 28},
+		{Name: "f", Start: funcAddr, Size: 12},
+	}}
+	return state, meta
+}
+
+func runProfiled(t *testing.T, state mipsevm.FPVMState, vm mipsevm.FPVM, prof *Profiler) {
+	for !state.GetExited() {
+		prof.Observe(state)
+		_, err := vm.Step(false)
+		require.NoError(t, err)
+	}
+}
+
+// functionTotals sums the instructions executed in each function, and in each function with the functions it calls.
+func functionTotals(prof *profile.Profile) (flat map[string]int64, cum map[string]int64) {
+	flat = make(map[string]int64)
+	cum = make(map[string]int64)
+	for _, s := range prof.Sample {
+		flat[s.Location[0].Line[0].Function.Name] += s.Value[1]
+		seen := make(map[string]bool)
+		for _, loc := range s.Location {
+			name := loc.Line[0].Function.Name
+			if !seen[name] {
+				cum[name] += s.Value[1]
+				seen[name] = true
+			}
+		}
+	}
+	return flat, cum
+}
+
+func TestProfile(t *testing.T) {
+	state, meta := newTestProgram()
+	prof := NewProfiler(meta, 1)
+	runProfiled(t, state, mipsevm.NewInstrumentedState(state, nil, io.Discard, io.Discard), prof)
+	require.Equal(t, uint64(13), state.Step)
+
+	p := prof.Profile()
+	require.NoError(t, p.CheckValid())
+	flat, cum := functionTotals(p)
+	require.Equal(t, map[string]int64{"main": 7, "f": 6}, flat)
+	require.Equal(t, map[string]int64{"main": 13, "f": 6}, cum)
+
+	// The delay slot of the return is still in f, called from the first call instruction
+	for _, s := range p.Sample {
+		if s.Location[0].Address == funcAddr+8 {
+			require.Len(t, s.Location, 2)
+			require.Contains(t, []uint64{mainAddr, mainAddr + 8}, s.Location[1].Address)
+		}
+	}
+}
+
+func TestProfileSampleRate(t *testing.T) {
+	state, meta := newTestProgram()
+	prof := NewProfiler(meta, 4)
+	runProfiled(t, state, mipsevm.NewInstrumentedState(state, nil, io.Discard, io.Discard), prof)
+
+	p := prof.Profile()
+	require.NoError(t, p.CheckValid())
+	require.Equal(t, int64(4), p.Period)
+	var samples, instructions int64
+	for _, s := range p.Sample {
+		samples += s.Value[0]
+		instructions += s.Value[1]
+	}
+	// Instructions 0, 4, 8 and 12 are sampled
+	require.Equal(t, int64(4), samples)
+	require.Equal(t, int64(16), instructions)
+}
+
+func TestProfileMultiThreaded(t *testing.T) {
+	st, meta := newTestProgram()
+	state := mipsevm.NewMTState(st)
+	prof := NewProfiler(meta, 1)
+	runProfiled(t, state, mipsevm.NewMTInstrumentedState(state, nil, io.Discard, io.Discard), prof)
+
+	_, cum := functionTotals(prof.Profile())
+	require.Equal(t, map[string]int64{"main": 13, "f": 6}, cum)
+}
+
+func TestProfileSkipsSchedulerSteps(t *testing.T) {
+	st, meta := newTestProgram()
+	state := mipsevm.NewMTState(st)
+	vm := mipsevm.NewMTInstrumentedState(state, nil, io.Discard, io.Discard)
+	prof := NewProfiler(meta, 1)
+
+	// Execute the first call, then traverse the threads for a wakeup before its delay slot
+	prof.Observe(state)
+	_, err := vm.Step(false)
+	require.NoError(t, err)
+	state.Wakeup = 0x4000
+	runProfiled(t, state, vm, prof)
+	require.Equal(t, uint64(15), state.Step, "must take two steps to traverse the thread")
+
+	p := prof.Profile()
+	require.NoError(t, p.CheckValid())
+	flat, cum := functionTotals(p)
+	require.Equal(t, map[string]int64{"main": 7, "f": 6}, flat)
+	require.Equal(t, map[string]int64{"main": 13, "f": 6}, cum)
+	require.Equal(t, []string{"13 instructions observed"}, p.Comments)
+
+	// The delay slot of the first call is still executed in main
+	for _, s := range p.Sample {
+		if s.Location[0].Address == mainAddr+4 {
+			require.Len(t, s.Location, 1)
+		}
+	}
+}
+
+func TestThreadStack(t *testing.T) {
+	t.Run("ReturnToOuterFrame", func(t *testing.T) {
+		s := &threadStack{frames: []uint32{0x100, 0x200, 0x300}}
+		s.pending, s.pendingAddr = opReturn, 0x200
+		s.apply()
+		require.Equal(t, []uint32{0x100}, s.frames)
+		require.Equal(t, opNone, s.pending)
+	})
+
+	t.Run("UnmatchedReturn", func(t *testing.T) {
+		s := &threadStack{frames: []uint32{0x100, 0x200}}
+		s.pending, s.pendingAddr = opReturn, 0x400
+		s.apply()
+		require.Empty(t, s.frames)
+	})
+
+	t.Run("MaxDepth", func(t *testing.T) {
+		s := &threadStack{}
+		for i := uint32(0); i < maxStackDepth+2; i++ {
+			s.pending, s.pendingAddr = opCall, i
+			s.apply()
+		}
+		require.Len(t, s.frames, maxStackDepth)
+		require.Equal(t, uint32(2), s.frames[0])
+		require.Equal(t, uint32(maxStackDepth+1), s.frames[maxStackDepth-1])
+	})
+}
+
+func TestWriteProfile(t *testing.T) {
+	state, meta := newTestProgram()
+	prof := NewProfiler(meta, 1)
+	runProfiled(t, state, mipsevm.NewInstrumentedState(state, nil, io.Discard, io.Discard), prof)
+
+	path := filepath.Join(t.TempDir(), "prof.pb.gz")
+	require.NoError(t, prof.WriteProfile(path))
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	p, err := profile.Parse(f)
+	require.NoError(t, err)
+	flat, _ := functionTotals(p)
+	require.Equal(t, map[string]int64{"main": 7, "f": 6}, flat)
+}
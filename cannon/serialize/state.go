@@ -0,0 +1,124 @@
+package serialize
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-service/ioutil"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// Serializable is a state that can be written in the binary format.
+type Serializable interface {
+	Serialize(out io.Writer) error
+}
+
+// Deserializable is a state that can be read from the binary format.
+type Deserializable interface {
+	Deserialize(in io.Reader) error
+}
+
+// IsBinaryFile returns true if the state at path is written in the binary format, based on its file extension.
+func IsBinaryFile(path string) bool {
+	return strings.HasSuffix(path, ".bin") || strings.HasSuffix(path, ".bin.gz")
+}
+
+// LoadState reads a state in either the JSON or the binary format, optionally gzip compressed.
+// The format and compression are detected from the content of the file, so the file extension does not matter.
+func LoadState[X any, PX interface {
+	*X
+	Deserializable
+}](inputPath string) (*X, error) {
+	if inputPath == "" {
+		return nil, errors.New("no path specified")
+	}
+	f, err := os.Open(inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %q: %w", inputPath, err)
+	}
+	defer f.Close()
+	in := bufio.NewReader(f)
+	if isPrefixed(in, gzipMagic) {
+		gz, err := gzip.NewReader(in)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create gzip reader for %q: %w", inputPath, err)
+		}
+		defer gz.Close()
+		in = bufio.NewReader(gz)
+	}
+	var state X
+	if isPrefixed(in, mipsevm.SerializedStateMagic[:]) {
+		if err := PX(&state).Deserialize(in); err != nil {
+			return nil, fmt.Errorf("failed to deserialize file %q: %w", inputPath, err)
+		}
+	} else if err := json.NewDecoder(in).Decode(&state); err != nil {
+		return nil, fmt.Errorf("failed to decode file %q: %w", inputPath, err)
+	}
+	return &state, nil
+}
+
+// WriteState writes a state in the binary format if the path has a .bin or .bin.gz extension, and in JSON otherwise.
+// The output is gzip compressed if the path has a .gz extension, and is written to stdout if the path is -.
+// Nothing is written if the path is empty.
+func WriteState(outputPath string, state Serializable) error {
+	if outputPath == "" {
+		return nil
+	}
+	var out io.Writer
+	finish := func() error { return nil }
+	if outputPath != "-" {
+		// Write to a tmp file but reserve the file extension if present
+		tmpPath := outputPath + "-tmp" + path.Ext(outputPath)
+		f, err := ioutil.OpenCompressed(tmpPath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0755)
+		if err != nil {
+			return fmt.Errorf("failed to open output file: %w", err)
+		}
+		defer f.Close()
+		out = f
+		finish = func() error {
+			// Close before renaming so the compressed data is flushed
+			if err := f.Close(); err != nil {
+				return err
+			}
+			// Rename the file into place as atomically as the OS will allow
+			return os.Rename(tmpPath, outputPath)
+		}
+	} else {
+		out = os.Stdout
+	}
+	buffered := bufio.NewWriter(out)
+	if IsBinaryFile(outputPath) {
+		if err := state.Serialize(buffered); err != nil {
+			return fmt.Errorf("failed to serialize state: %w", err)
+		}
+	} else {
+		if err := json.NewEncoder(buffered).Encode(state); err != nil {
+			return fmt.Errorf("failed to encode to JSON: %w", err)
+		}
+		if _, err := buffered.Write([]byte{'\n'}); err != nil {
+			return fmt.Errorf("failed to append new-line: %w", err)
+		}
+	}
+	if err := buffered.Flush(); err != nil {
+		return fmt.Errorf("failed to flush output: %w", err)
+	}
+	if err := finish(); err != nil {
+		return fmt.Errorf("failed to finish write: %w", err)
+	}
+	return nil
+}
+
+func isPrefixed(in *bufio.Reader, prefix []byte) bool {
+	data, err := in.Peek(len(prefix))
+	return err == nil && bytes.Equal(data, prefix)
+}
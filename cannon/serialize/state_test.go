@@ -0,0 +1,119 @@
+package serialize
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+)
+
+func TestRoundTripState(t *testing.T) {
+	for _, name := range []string{"state.json", "state.json.gz", "state.bin", "state.bin.gz"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			state := testState()
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, WriteState(path, state))
+
+			loaded, err := LoadState[mipsevm.State](path)
+			require.NoError(t, err)
+			requireSameState(t, state, loaded)
+		})
+	}
+}
+
+func TestRoundTripMTState(t *testing.T) {
+	for _, name := range []string{"state.json", "state.bin.gz"} {
+		name := name
+		t.Run(name, func(t *testing.T) {
+			state := mipsevm.NewMTState(testState())
+			state.RightThreadStack = []*mipsevm.ThreadState{{
+				ThreadID:         1,
+				FutexAddr:        0x1000,
+				FutexVal:         3,
+				FutexTimeoutStep: 42,
+				PC:               0x20,
+				NextPC:           0x24,
+				Registers:        [32]uint32{29: 0xbeef},
+			}}
+			state.NextThreadID = 2
+			state.Wakeup = 0x2000
+			state.LLReservationActive = true
+			state.LLAddress = 0x3000
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, WriteState(path, state))
+
+			loaded, err := LoadState[mipsevm.MTState](path)
+			require.NoError(t, err)
+			requireSameState(t, state, loaded)
+		})
+	}
+}
+
+func TestDetectFormatFromContent(t *testing.T) {
+	state := testState()
+	dir := t.TempDir()
+	binPath := filepath.Join(dir, "state.bin.gz")
+	require.NoError(t, WriteState(binPath, state))
+	// The file extension doesn't match the content
+	misnamed := filepath.Join(dir, "state.json")
+	require.NoError(t, os.Rename(binPath, misnamed))
+
+	loaded, err := LoadState[mipsevm.State](misnamed)
+	require.NoError(t, err)
+	requireSameState(t, state, loaded)
+}
+
+func TestRejectWrongVMType(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.bin")
+	require.NoError(t, WriteState(path, testState()))
+	_, err := LoadState[mipsevm.MTState](path)
+	require.ErrorContains(t, err, "unexpected VM type")
+}
+
+func TestRejectTruncatedBinary(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, testState().Serialize(&buf))
+	data := buf.Bytes()
+	for _, length := range []int{3, 6, 100, len(data) - 1} {
+		var state mipsevm.State
+		require.Error(t, state.Deserialize(bytes.NewReader(data[:length])), "length %d", length)
+	}
+}
+
+func testState() *mipsevm.State {
+	state := &mipsevm.State{
+		Memory:         mipsevm.NewMemory(),
+		PreimageKey:    common.Hash{0xaa},
+		PreimageOffset: 12,
+		PC:             0x1000,
+		NextPC:         0x1004,
+		LO:             1,
+		HI:             2,
+		Heap:           0x20000000,
+		ExitCode:       1,
+		Exited:         true,
+		Step:           123456,
+		Registers:      [32]uint32{1: 1, 31: 0xffff},
+		LastHint:       []byte{0, 0, 0, 5, 'h', 'e'},
+	}
+	state.Memory.SetMemory(0x1000, 0x12345678)
+	state.Memory.SetMemory(0x7f000000, 0xdeadbeef)
+	return state
+}
+
+// requireSameState checks the loaded state has the same witness and the same JSON encoding as the expected state.
+func requireSameState(t *testing.T, expected mipsevm.FPVMState, actual mipsevm.FPVMState) {
+	require.Equal(t, expected.EncodeWitness(), actual.EncodeWitness())
+	expectedJSON, err := json.Marshal(expected)
+	require.NoError(t, err)
+	actualJSON, err := json.Marshal(actual)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expectedJSON), string(actualJSON))
+}
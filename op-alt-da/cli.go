@@ -0,0 +1,49 @@
+package altda
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+)
+
+const DAServerURLFlagName = "altda.da-server"
+
+func CLIFlags(envPrefix string) []cli.Flag {
+	return []cli.Flag{
+		&cli.StringFlag{
+			Name:    DAServerURLFlagName,
+			Usage:   "HTTP address of the alt-DA server. Required if the rollup uses alt-DA.",
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "ALTDA_DA_SERVER"),
+		},
+	}
+}
+
+type CLIConfig struct {
+	DAServerURL string
+}
+
+// Enabled returns whether a DA server is configured.
+func (c CLIConfig) Enabled() bool {
+	return c.DAServerURL != ""
+}
+
+func (c CLIConfig) Check() error {
+	if c.Enabled() && !strings.HasPrefix(c.DAServerURL, "http://") && !strings.HasPrefix(c.DAServerURL, "https://") {
+		return errors.New("alt-DA server URL must be an http or https URL")
+	}
+	return nil
+}
+
+// NewDAClient returns a client for the configured DA server.
+func (c CLIConfig) NewDAClient() *DAClient {
+	return NewDAClient(c.DAServerURL)
+}
+
+func ReadCLIConfig(ctx *cli.Context) CLIConfig {
+	return CLIConfig{
+		DAServerURL: ctx.String(DAServerURLFlagName),
+	}
+}
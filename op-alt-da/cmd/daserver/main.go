@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/opio"
+)
+
+const envVarPrefix = "OP_ALTDA_SERVER"
+
+var (
+	Version   = ""
+	GitCommit = ""
+	GitDate   = ""
+)
+
+var (
+	ListenAddrFlag = &cli.StringFlag{
+		Name:    "addr",
+		Usage:   "Address to listen on",
+		Value:   "127.0.0.1",
+		EnvVars: opservice.PrefixEnvVar(envVarPrefix, "ADDR"),
+	}
+	PortFlag = &cli.IntFlag{
+		Name:    "port",
+		Usage:   "Port to listen on",
+		Value:   3100,
+		EnvVars: opservice.PrefixEnvVar(envVarPrefix, "PORT"),
+	}
+	FileStorePathFlag = &cli.StringFlag{
+		Name:    "file.path",
+		Usage:   "Directory to store inputs in. Inputs are kept in memory if empty.",
+		EnvVars: opservice.PrefixEnvVar(envVarPrefix, "FILE_PATH"),
+	}
+)
+
+func main() {
+	oplog.SetupDefaults()
+
+	app := cli.NewApp()
+	app.Flags = append([]cli.Flag{ListenAddrFlag, PortFlag, FileStorePathFlag}, oplog.CLIFlags(envVarPrefix)...)
+	app.Version = fmt.Sprintf("%s-%s-%s", Version, GitCommit, GitDate)
+	app.Name = "da-server"
+	app.Usage = "Reference alt-DA server"
+	app.Description = "Stores and serves the inputs of alt-DA commitments, for development and testing"
+	app.Action = run
+	if err := app.Run(os.Args); err != nil {
+		log.Crit("Application failed", "message", err)
+	}
+}
+
+func run(ctx *cli.Context) error {
+	logCfg := oplog.ReadCLIConfig(ctx)
+	if err := logCfg.Check(); err != nil {
+		return err
+	}
+	l := oplog.NewLogger(logCfg)
+
+	var store altda.KVStore
+	if path := ctx.String(FileStorePathFlag.Name); path != "" {
+		fileStore, err := altda.NewFileStore(path)
+		if err != nil {
+			return err
+		}
+		store = fileStore
+		l.Info("Using file store", "path", path)
+	} else {
+		store = altda.NewMemStore()
+		l.Info("Using in-memory store")
+	}
+
+	server := altda.NewDAServer(ctx.String(ListenAddrFlag.Name), ctx.Int(PortFlag.Name), store, l)
+	if err := server.Start(); err != nil {
+		return fmt.Errorf("failed to start DA server: %w", err)
+	}
+	opio.BlockOnInterrupts()
+	return server.Stop()
+}
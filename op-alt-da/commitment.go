@@ -0,0 +1,65 @@
+package altda
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TxDataVersion1 is the version byte of batcher transaction data that carries an alt-DA
+// commitment instead of frames. Frames are posted with version 0 (derive.DerivationVersion0).
+const TxDataVersion1 = 0x01
+
+// CommitmentType is the type of an alt-DA commitment, encoded as its first byte.
+type CommitmentType byte
+
+// Keccak256CommitmentType commits to the input with its keccak256 hash.
+const Keccak256CommitmentType CommitmentType = 0
+
+var ErrInvalidCommitment = errors.New("invalid commitment")
+var ErrCommitmentMismatch = errors.New("commitment does not match input")
+
+// Keccak256Commitment is the keccak256 hash of an input that is stored on the DA server.
+type Keccak256Commitment [32]byte
+
+// Keccak256 computes the commitment to the given input.
+func Keccak256(input []byte) Keccak256Commitment {
+	return Keccak256Commitment(crypto.Keccak256Hash(input))
+}
+
+// DecodeKeccak256 decodes an encoded commitment, see Encode.
+func DecodeKeccak256(commitment []byte) (Keccak256Commitment, error) {
+	if len(commitment) != 33 {
+		return Keccak256Commitment{}, fmt.Errorf("%w: expected 33 bytes, got %d", ErrInvalidCommitment, len(commitment))
+	}
+	if CommitmentType(commitment[0]) != Keccak256CommitmentType {
+		return Keccak256Commitment{}, fmt.Errorf("%w: unknown commitment type %d", ErrInvalidCommitment, commitment[0])
+	}
+	return Keccak256Commitment(commitment[1:]), nil
+}
+
+// Encode returns the commitment type byte followed by the hash. This is the key the input
+// is stored under on the DA server.
+func (c Keccak256Commitment) Encode() []byte {
+	return append([]byte{byte(Keccak256CommitmentType)}, c[:]...)
+}
+
+// TxData returns the data of a batcher transaction that posts the commitment.
+func (c Keccak256Commitment) TxData() []byte {
+	return append([]byte{TxDataVersion1}, c.Encode()...)
+}
+
+// Verify checks that the commitment commits to the given input.
+func (c Keccak256Commitment) Verify(input []byte) error {
+	if actual := Keccak256(input); !bytes.Equal(actual[:], c[:]) {
+		return fmt.Errorf("%w: expected %s, got %s", ErrCommitmentMismatch, c, actual)
+	}
+	return nil
+}
+
+func (c Keccak256Commitment) String() string {
+	return hexutil.Encode(c.Encode())
+}
@@ -0,0 +1,81 @@
+package altda
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ErrNotFound is returned when the DA server does not have the input of a commitment.
+var ErrNotFound = errors.New("not found")
+
+// ErrInputTooLarge is returned when the DA server returns an input larger than MaxInputSize.
+var ErrInputTooLarge = errors.New("input too large")
+
+// DAClient stores and retrieves inputs on a DA server. The server stores the input of a commitment
+// with a PUT request to /put/<commitment>, and returns it with a GET request to /get/<commitment>,
+// with the commitment hex-encoded, see Keccak256Commitment.String.
+type DAClient struct {
+	url string
+	cl  *http.Client
+}
+
+func NewDAClient(url string) *DAClient {
+	return &DAClient{url: strings.TrimSuffix(url, "/"), cl: &http.Client{}}
+}
+
+// GetInput returns the input of the given commitment. The input is verified against the commitment.
+// It returns ErrNotFound if the DA server does not have the input.
+func (c *DAClient) GetInput(ctx context.Context, comm Keccak256Commitment) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url+"/get/"+comm.String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	resp, err := c.cl.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: commitment %s", ErrNotFound, comm)
+	} else if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get input of commitment %s: status %d", comm, resp.StatusCode)
+	}
+	input, err := io.ReadAll(io.LimitReader(resp.Body, MaxInputSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read input of commitment %s: %w", comm, err)
+	}
+	if len(input) > MaxInputSize {
+		return nil, fmt.Errorf("%w: commitment %s has input larger than %d bytes", ErrInputTooLarge, comm, MaxInputSize)
+	}
+	if err := comm.Verify(input); err != nil {
+		return nil, err
+	}
+	return input, nil
+}
+
+// SetInput stores the input on the DA server and returns its commitment.
+func (c *DAClient) SetInput(ctx context.Context, input []byte) (Keccak256Commitment, error) {
+	if len(input) == 0 {
+		return Keccak256Commitment{}, errors.New("input is empty")
+	}
+	comm := Keccak256(input)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, c.url+"/put/"+comm.String(), bytes.NewReader(input))
+	if err != nil {
+		return Keccak256Commitment{}, fmt.Errorf("failed to create HTTP request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/octet-stream")
+	resp, err := c.cl.Do(req)
+	if err != nil {
+		return Keccak256Commitment{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Keccak256Commitment{}, fmt.Errorf("failed to store input of commitment %s: status %d", comm, resp.StatusCode)
+	}
+	return comm, nil
+}
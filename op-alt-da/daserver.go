@@ -0,0 +1,200 @@
+package altda
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/httputil"
+)
+
+// MaxInputSize is the max size of an input that the DA server accepts.
+const MaxInputSize = 4 * 1024 * 1024
+
+// KVStore stores the inputs of the DA server, keyed by their encoded commitment.
+type KVStore interface {
+	// Get returns the value of the key, or ErrNotFound if it is not stored.
+	Get(ctx context.Context, key []byte) ([]byte, error)
+	Put(ctx context.Context, key []byte, value []byte) error
+}
+
+// MemStore is a KVStore that keeps all values in memory.
+type MemStore struct {
+	mu   sync.RWMutex
+	data map[string][]byte
+}
+
+func NewMemStore() *MemStore {
+	return &MemStore{data: make(map[string][]byte)}
+}
+
+func (s *MemStore) Get(_ context.Context, key []byte) ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.data[string(key)]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return value, nil
+}
+
+func (s *MemStore) Put(_ context.Context, key []byte, value []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.data[string(key)] = value
+	return nil
+}
+
+// FileStore is a KVStore that stores every value in a file of the given directory.
+type FileStore struct {
+	dir string
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create store dir %v: %w", dir, err)
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) fileName(key []byte) string {
+	return filepath.Join(s.dir, hexutil.Encode(key))
+}
+
+func (s *FileStore) Get(_ context.Context, key []byte) ([]byte, error) {
+	value, err := os.ReadFile(s.fileName(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	return value, err
+}
+
+func (s *FileStore) Put(_ context.Context, key []byte, value []byte) error {
+	// write to a temp file first, so a value is never read partially written
+	tmpFile := s.fileName(key) + ".tmp"
+	if err := os.WriteFile(tmpFile, value, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile, s.fileName(key))
+}
+
+// DAServer is a reference DA server, which serves the protocol of the DAClient from a KVStore.
+type DAServer struct {
+	log      log.Logger
+	endpoint string
+	store    KVStore
+
+	httpSrv *http.Server
+}
+
+func NewDAServer(host string, port int, store KVStore, log log.Logger) *DAServer {
+	return &DAServer{
+		log:      log,
+		endpoint: net.JoinHostPort(host, strconv.Itoa(port)),
+		store:    store,
+	}
+}
+
+// Start starts serving in the background. The server listens on a random port if the port is 0.
+func (d *DAServer) Start() error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/get/", d.handleGet)
+	mux.HandleFunc("/put/", d.handlePut)
+	d.httpSrv = httputil.NewHttpServer(mux)
+
+	listener, err := net.Listen("tcp", d.endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to listen: %w", err)
+	}
+	d.endpoint = listener.Addr().String()
+	go func() {
+		if err := d.httpSrv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			d.log.Error("DA server stopped", "err", err)
+		}
+	}()
+	d.log.Info("Started DA server", "endpoint", d.endpoint)
+	return nil
+}
+
+// Endpoint returns the HTTP URL of the server.
+func (d *DAServer) Endpoint() string {
+	return "http://" + d.endpoint
+}
+
+func (d *DAServer) Stop() error {
+	if d.httpSrv == nil {
+		return nil
+	}
+	return d.httpSrv.Shutdown(context.Background())
+}
+
+func (d *DAServer) handleGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	key, err := hexutil.Decode(strings.TrimPrefix(r.URL.Path, "/get/"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	input, err := d.store.Get(r.Context(), key)
+	if errors.Is(err, ErrNotFound) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	} else if err != nil {
+		d.log.Error("Failed to read input", "key", hexutil.Encode(key), "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	if _, err := w.Write(input); err != nil {
+		d.log.Warn("Failed to write response", "err", err)
+	}
+}
+
+func (d *DAServer) handlePut(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut && r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+	key, err := hexutil.Decode(strings.TrimPrefix(r.URL.Path, "/put/"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	comm, err := DecodeKeccak256(key)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	input, err := io.ReadAll(io.LimitReader(r.Body, MaxInputSize+1))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if len(input) > MaxInputSize {
+		w.WriteHeader(http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err := comm.Verify(input); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if err := d.store.Put(r.Context(), key, input); err != nil {
+		d.log.Error("Failed to store input", "commitment", comm, "err", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,104 @@
+package altda
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+)
+
+func TestDAClientServer(t *testing.T) {
+	fileStore, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+	stores := map[string]KVStore{
+		"mem":  NewMemStore(),
+		"file": fileStore,
+	}
+	for name, store := range stores {
+		store := store
+		t.Run(name, func(t *testing.T) {
+			ctx := context.Background()
+			server := NewDAServer("127.0.0.1", 0, store, testlog.Logger(t, log.LvlInfo))
+			require.NoError(t, server.Start())
+			t.Cleanup(func() { require.NoError(t, server.Stop()) })
+			client := NewDAClient(server.Endpoint())
+
+			rng := rand.New(rand.NewSource(1234))
+			input := make([]byte, 1000)
+			rng.Read(input)
+
+			comm, err := client.SetInput(ctx, input)
+			require.NoError(t, err)
+			require.Equal(t, Keccak256(input), comm)
+
+			stored, err := client.GetInput(ctx, comm)
+			require.NoError(t, err)
+			require.Equal(t, input, stored)
+
+			_, err = client.GetInput(ctx, Keccak256([]byte("unknown")))
+			require.ErrorIs(t, err, ErrNotFound)
+
+			_, err = client.SetInput(ctx, nil)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestDAClientVerifiesInput(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemStore()
+	server := NewDAServer("127.0.0.1", 0, store, testlog.Logger(t, log.LvlInfo))
+	require.NoError(t, server.Start())
+	t.Cleanup(func() { require.NoError(t, server.Stop()) })
+	client := NewDAClient(server.Endpoint())
+
+	comm := Keccak256([]byte("input"))
+	require.NoError(t, store.Put(ctx, comm.Encode(), []byte("tampered")))
+	_, err := client.GetInput(ctx, comm)
+	require.ErrorIs(t, err, ErrCommitmentMismatch)
+}
+
+func TestDAClientLimitsInputSize(t *testing.T) {
+	ctx := context.Background()
+	store := NewMemStore()
+	server := NewDAServer("127.0.0.1", 0, store, testlog.Logger(t, log.LvlInfo))
+	require.NoError(t, server.Start())
+	t.Cleanup(func() { require.NoError(t, server.Stop()) })
+	client := NewDAClient(server.Endpoint())
+
+	input := make([]byte, MaxInputSize+1)
+	comm := Keccak256(input)
+	require.NoError(t, store.Put(ctx, comm.Encode(), input))
+	_, err := client.GetInput(ctx, comm)
+	require.ErrorIs(t, err, ErrInputTooLarge)
+
+	input = input[:MaxInputSize]
+	comm = Keccak256(input)
+	require.NoError(t, store.Put(ctx, comm.Encode(), input))
+	stored, err := client.GetInput(ctx, comm)
+	require.NoError(t, err)
+	require.Equal(t, input, stored)
+}
+
+func TestKeccak256Commitment(t *testing.T) {
+	comm := Keccak256([]byte("input"))
+	require.NoError(t, comm.Verify([]byte("input")))
+	require.ErrorIs(t, comm.Verify([]byte("other")), ErrCommitmentMismatch)
+
+	txData := comm.TxData()
+	require.Equal(t, byte(TxDataVersion1), txData[0])
+	decoded, err := DecodeKeccak256(txData[1:])
+	require.NoError(t, err)
+	require.Equal(t, comm, decoded)
+
+	_, err = DecodeKeccak256(txData[1:10])
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+	invalidType := comm.Encode()
+	invalidType[0] = 0xff
+	_, err = DecodeKeccak256(invalidType)
+	require.ErrorIs(t, err, ErrInvalidCommitment)
+}
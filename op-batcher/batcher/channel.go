@@ -3,11 +3,15 @@ package batcher
 import (
 	"fmt"
 	"math"
+	"sort"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 )
 
@@ -24,6 +28,10 @@ type channel struct {
 	pendingTransactions map[txID]txData
 	// Set of confirmed txID -> inclusion block. For determining if the channel is timed out
 	confirmedTransactions map[txID]eth.BlockID
+	// Number of the first L1 block registered with the channel, 0 if none yet. Used for the journal.
+	firstL1Block uint64
+	// Set of submitted txID -> hash of the frame data. Used for the journal.
+	frameHashes map[txID]common.Hash
 }
 
 func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig) (*channel, error) {
@@ -38,6 +46,7 @@ func newChannel(log log.Logger, metr metrics.Metricer, cfg ChannelConfig) (*chan
 		channelBuilder:        cb,
 		pendingTransactions:   make(map[txID]txData),
 		confirmedTransactions: make(map[txID]eth.BlockID),
+		frameHashes:           make(map[txID]common.Hash),
 	}, nil
 }
 
@@ -58,6 +67,24 @@ func (s *channel) TxFailed(id txID) {
 	s.metr.RecordBatchTxFailed()
 }
 
+// TxDeferred returns the frame of a transaction that was not sent to the front
+// of the frames queue, so it is the next one to be sent.
+func (s *channel) TxDeferred(id txID) {
+	if data, ok := s.pendingTransactions[id]; ok {
+		s.log.Trace("marked transaction as deferred", "id", id)
+		s.channelBuilder.PushFrameFront(data.Frame())
+		delete(s.pendingTransactions, id)
+	} else {
+		s.log.Warn("unknown transaction marked as deferred", "id", id)
+	}
+}
+
+// Deadline returns the L1 block number by which all frames of the channel must
+// be submitted, or 0 if there is no deadline yet.
+func (s *channel) Deadline() uint64 {
+	return s.channelBuilder.Deadline()
+}
+
 // TxConfirmed marks a transaction as confirmed on L1. Unfortunately even if all frames in
 // a channel have been marked as confirmed on L1 the channel may be invalid & need to be
 // resubmitted.
@@ -134,6 +161,7 @@ func (s *channel) NextTxData() txData {
 
 	s.log.Trace("returning next tx data", "id", id)
 	s.pendingTransactions[id] = txdata
+	s.frameHashes[id] = crypto.Keccak256Hash(frame.data)
 
 	return txdata
 }
@@ -151,6 +179,9 @@ func (s *channel) FullErr() error {
 }
 
 func (s *channel) RegisterL1Block(l1BlockNum uint64) {
+	if s.firstL1Block == 0 {
+		s.firstL1Block = l1BlockNum
+	}
 	s.channelBuilder.RegisterL1Block(l1BlockNum)
 }
 
@@ -185,3 +216,75 @@ func (s *channel) OutputFrames() error {
 func (s *channel) Close() {
 	s.channelBuilder.Close()
 }
+
+// Info returns the state of the channel and its frames, for the admin API.
+func (s *channel) Info() rpc.ChannelInfo {
+	info := rpc.ChannelInfo{
+		ID:          s.ID(),
+		Full:        s.IsFull(),
+		Blocks:      len(s.channelBuilder.Blocks()),
+		InputBytes:  s.InputBytes(),
+		OutputBytes: s.OutputBytes(),
+		Frames:      make([]rpc.FrameInfo, 0, s.TotalFrames()),
+	}
+	if err := s.FullErr(); err != nil {
+		info.FullReason = err.Error()
+	}
+	for id, inclusionBlock := range s.confirmedTransactions {
+		inclusionBlock := inclusionBlock
+		info.Frames = append(info.Frames, rpc.FrameInfo{Number: id.frameNumber, Status: rpc.FrameConfirmed, InclusionBlock: &inclusionBlock})
+	}
+	for id := range s.pendingTransactions {
+		info.Frames = append(info.Frames, rpc.FrameInfo{Number: id.frameNumber, Status: rpc.FramePending})
+	}
+	for _, frame := range s.channelBuilder.frames {
+		info.Frames = append(info.Frames, rpc.FrameInfo{Number: frame.id.frameNumber, Status: rpc.FrameQueued})
+	}
+	sort.Slice(info.Frames, func(i, j int) bool { return info.Frames[i].Number < info.Frames[j].Number })
+	return info
+}
+
+// restoreFrames removes the given confirmed and pending frames from the frames queue of a
+// channel that was rebuilt from the journal. Confirmed frames are recorded as confirmed
+// transactions, pending frames as pending transactions. An error is returned if the rebuilt
+// channel does not reproduce the submitted frames.
+func (s *channel) restoreFrames(confirmed, pending []JournalFrame) error {
+	submitted := make(map[uint16]JournalFrame, len(confirmed)+len(pending))
+	for _, f := range confirmed {
+		if f.InclusionBlock == nil {
+			return fmt.Errorf("confirmed frame %d has no inclusion block", f.FrameNumber)
+		}
+		submitted[f.FrameNumber] = f
+	}
+	for _, f := range pending {
+		submitted[f.FrameNumber] = f
+	}
+
+	var queued []frameData
+	for s.channelBuilder.HasFrame() {
+		frame := s.channelBuilder.NextFrame()
+		f, ok := submitted[frame.id.frameNumber]
+		if !ok {
+			queued = append(queued, frame)
+			continue
+		}
+		if hash := crypto.Keccak256Hash(frame.data); hash != f.Hash {
+			return fmt.Errorf("rebuilt frame %d has hash %s, journal expected %s", f.FrameNumber, hash, f.Hash)
+		}
+		s.frameHashes[frame.id] = f.Hash
+		if f.InclusionBlock != nil {
+			s.confirmedTransactions[frame.id] = *f.InclusionBlock
+			s.channelBuilder.FramePublished(f.InclusionBlock.Number)
+		} else {
+			s.pendingTransactions[frame.id] = txData{frame}
+		}
+	}
+	for _, frame := range queued {
+		s.channelBuilder.PushFrame(frame)
+	}
+
+	if restored := len(s.confirmedTransactions) + len(s.pendingTransactions); restored != len(confirmed)+len(pending) {
+		return fmt.Errorf("rebuilt channel only has %d of %d submitted frames", restored, len(confirmed)+len(pending))
+	}
+	return nil
+}
@@ -58,6 +58,25 @@ type ChannelConfig struct {
 
 	// CompressorConfig contains the configuration for creating new compressors.
 	CompressorConfig compressor.Config
+
+	// BatchType indicates whether the channel encodes its blocks as singular
+	// batches (derive.BatchV1Type) or as a single span batch (derive.SpanBatchType).
+	BatchType uint
+	// L2GenesisTime is the L2 genesis timestamp. Span batches encode their
+	// timestamp relative to it.
+	L2GenesisTime uint64
+}
+
+// SetMaxFrameSize sets the max frame size and targets the compressor at frames of that size.
+// The target number of frames is adjusted so that channels keep their target total size.
+func (cc *ChannelConfig) SetMaxFrameSize(size uint64) {
+	targetSize := cc.CompressorConfig.TargetFrameSize * uint64(cc.CompressorConfig.TargetNumFrames)
+	cc.MaxFrameSize = size
+	cc.CompressorConfig.TargetFrameSize = size
+	cc.CompressorConfig.TargetNumFrames = int((targetSize + size - 1) / size)
+	if cc.CompressorConfig.TargetNumFrames < 1 {
+		cc.CompressorConfig.TargetNumFrames = 1
+	}
 }
 
 // Check validates the [ChannelConfig] parameters.
@@ -83,6 +102,10 @@ func (cc *ChannelConfig) Check() error {
 		return fmt.Errorf("max frame size %d is less than the minimum 23", cc.MaxFrameSize)
 	}
 
+	if cc.BatchType > derive.SpanBatchType {
+		return fmt.Errorf("unrecognized batch type: %d", cc.BatchType)
+	}
+
 	return nil
 }
 
@@ -109,6 +132,11 @@ type channelBuilder struct {
 	timeout uint64
 	// reason for currently set timeout
 	timeoutReason error
+	// L1 block number by which the channel must be submitted: the earlier of the
+	// consensus channel timeout and the sequencing window timeout. Unlike timeout,
+	// it doesn't include the channel duration timeout.
+	// 0 if no deadline set yet.
+	deadline uint64
 
 	// Reason for the channel being full. Set by setFullErr so it's always
 	// guaranteed to be a ChannelFullError wrapping the specific reason.
@@ -132,7 +160,12 @@ func newChannelBuilder(cfg ChannelConfig) (*channelBuilder, error) {
 	if err != nil {
 		return nil, err
 	}
-	co, err := derive.NewChannelOut(c)
+	var co *derive.ChannelOut
+	if cfg.BatchType == derive.SpanBatchType {
+		co, err = derive.NewSpanChannelOut(c, cfg.L2GenesisTime)
+	} else {
+		co, err = derive.NewChannelOut(c)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -174,6 +207,7 @@ func (c *channelBuilder) Reset() error {
 	c.blocks = c.blocks[:0]
 	c.frames = c.frames[:0]
 	c.timeout = 0
+	c.deadline = 0
 	c.fullErr = nil
 	return c.co.Reset()
 }
@@ -199,7 +233,7 @@ func (c *channelBuilder) AddBlock(block *types.Block) (derive.L1BlockInfo, error
 		return l1info, fmt.Errorf("converting block to batch: %w", err)
 	}
 
-	if _, err = c.co.AddBatch(batch); errors.Is(err, derive.ErrTooManyRLPBytes) || errors.Is(err, derive.CompressorFullErr) {
+	if _, err = c.co.AddBatch(batch, l1info.SequenceNumber); errors.Is(err, derive.ErrTooManyRLPBytes) || errors.Is(err, derive.CompressorFullErr) {
 		c.setFullErr(err)
 		return l1info, c.FullErr()
 	} else if err != nil {
@@ -233,6 +267,7 @@ func (c *channelBuilder) RegisterL1Block(l1BlockNum uint64) {
 func (c *channelBuilder) FramePublished(l1BlockNum uint64) {
 	timeout := l1BlockNum + c.cfg.ChannelTimeout - c.cfg.SubSafetyMargin
 	c.updateTimeout(timeout, ErrChannelTimeoutClose)
+	c.updateDeadline(timeout)
 }
 
 // updateDurationTimeout updates the block timeout with the channel duration
@@ -255,6 +290,21 @@ func (c *channelBuilder) updateDurationTimeout(l1BlockNum uint64) {
 func (c *channelBuilder) updateSwTimeout(batch *derive.BatchData) {
 	timeout := uint64(batch.EpochNum) + c.cfg.SeqWindowSize - c.cfg.SubSafetyMargin
 	c.updateTimeout(timeout, ErrSeqWindowClose)
+	c.updateDeadline(timeout)
+}
+
+// updateDeadline moves the submission deadline to the given block number if it
+// is earlier than the current deadline, or if it is still unset.
+func (c *channelBuilder) updateDeadline(deadlineBlockNum uint64) {
+	if c.deadline == 0 || c.deadline > deadlineBlockNum {
+		c.deadline = deadlineBlockNum
+	}
+}
+
+// Deadline returns the L1 block number by which all frames of the channel must
+// be submitted. It returns 0 if no deadline is set yet.
+func (c *channelBuilder) Deadline() uint64 {
+	return c.deadline
 }
 
 // updateTimeout updates the timeout block to the given block number if it is
@@ -431,6 +481,15 @@ func (c *channelBuilder) NextFrame() frameData {
 	return f
 }
 
+// PushFrameFront adds the frame back to the front of the internal frames queue,
+// so it is returned by the next call to NextFrame. Panics if not of the same channel.
+func (c *channelBuilder) PushFrameFront(frame frameData) {
+	if frame.id.chID != c.ID() {
+		panic("wrong channel")
+	}
+	c.frames = append([]frameData{frame}, c.frames...)
+}
+
 // PushFrame adds the frame back to the internal frames queue. Panics if not of
 // the same channel.
 func (c *channelBuilder) PushFrame(frame frameData) {
@@ -35,6 +35,25 @@ var defaultTestChannelConfig = ChannelConfig{
 }
 
 // TestChannelConfig_Check tests the [ChannelConfig] [Check] function.
+func TestChannelConfig_SetMaxFrameSize(t *testing.T) {
+	cfg := ChannelConfig{
+		MaxFrameSize: 100_000,
+		CompressorConfig: compressor.Config{
+			TargetFrameSize: 90_000,
+			TargetNumFrames: 2,
+		},
+	}
+
+	cfg.SetMaxFrameSize(50_000)
+	require.Equal(t, uint64(50_000), cfg.MaxFrameSize)
+	require.Equal(t, uint64(50_000), cfg.CompressorConfig.TargetFrameSize)
+	require.Equal(t, 4, cfg.CompressorConfig.TargetNumFrames, "target channel size is kept")
+
+	cfg.SetMaxFrameSize(1_000_000)
+	require.Equal(t, uint64(1_000_000), cfg.CompressorConfig.TargetFrameSize)
+	require.Equal(t, 1, cfg.CompressorConfig.TargetNumFrames, "at least one frame is targeted")
+}
+
 func TestChannelConfig_Check(t *testing.T) {
 	type test struct {
 		input     ChannelConfig
@@ -47,6 +66,8 @@ func TestChannelConfig_Check(t *testing.T) {
 	timeoutChannelConfig := defaultTestChannelConfig
 	timeoutChannelConfig.ChannelTimeout = 0
 	timeoutChannelConfig.SubSafetyMargin = 1
+	batchTypeChannelConfig := defaultTestChannelConfig
+	batchTypeChannelConfig.BatchType = 2
 	tests := []test{
 		{
 			input: defaultTestChannelConfig,
@@ -66,6 +87,12 @@ func TestChannelConfig_Check(t *testing.T) {
 				require.EqualError(t, output, "max frame size cannot be zero")
 			},
 		},
+		{
+			input: batchTypeChannelConfig,
+			assertion: func(output error) {
+				require.EqualError(t, output, "unrecognized batch type: 2")
+			},
+		},
 	}
 	for i := 1; i < derive.FrameV0OverHeadSize; i++ {
 		smallChannelConfig := defaultTestChannelConfig
@@ -570,6 +597,32 @@ func TestChannelBuilder_AddBlock(t *testing.T) {
 	require.ErrorIs(t, addMiniBlock(cb), derive.CompressorFullErr)
 }
 
+// TestChannelBuilder_SpanBatch tests that a span batch channel only outputs
+// frames once it is closed.
+func TestChannelBuilder_SpanBatch(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.BatchType = derive.SpanBatchType
+	channelConfig.MaxFrameSize = 100
+
+	cb, err := newChannelBuilder(channelConfig)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		block := newMiniL2BlockWithNumberParent(2, big.NewInt(int64(i)), common.Hash{})
+		_, err := cb.AddBlock(block)
+		require.NoError(t, err)
+	}
+	require.Equal(t, 3, len(cb.blocks))
+
+	// open span batch channels have no ready data
+	require.NoError(t, cb.OutputFrames())
+	require.False(t, cb.HasFrame())
+
+	cb.Close()
+	require.NoError(t, cb.OutputFrames())
+	require.True(t, cb.HasFrame())
+}
+
 // TestChannelBuilder_Reset tests the [Reset] function
 func TestChannelBuilder_Reset(t *testing.T) {
 	channelConfig := defaultTestChannelConfig
@@ -682,6 +735,34 @@ func TestFramePublished(t *testing.T) {
 	require.Equal(t, uint64(1000), cb.timeout)
 }
 
+// TestChannelBuilder_Deadline tests that the deadline tracks the sequencing window
+// and channel timeouts, but not the max channel duration.
+func TestChannelBuilder_Deadline(t *testing.T) {
+	channelConfig := defaultTestChannelConfig
+	channelConfig.MaxChannelDuration = 10
+	channelConfig.SeqWindowSize = 3600
+	channelConfig.ChannelTimeout = 300
+	channelConfig.SubSafetyMargin = 100
+
+	cb, err := newChannelBuilder(channelConfig)
+	require.NoError(t, err)
+	require.Zero(t, cb.Deadline())
+
+	cb.RegisterL1Block(100)
+	require.Equal(t, uint64(110), cb.timeout)
+	require.Zero(t, cb.Deadline(), "max channel duration is no deadline")
+
+	// the L1 origin of the added block is 100, so the deadline is 100+3600-100
+	require.NoError(t, addMiniBlock(cb))
+	require.Equal(t, uint64(3600), cb.Deadline())
+
+	cb.FramePublished(120)
+	require.Equal(t, uint64(320), cb.Deadline())
+
+	require.NoError(t, cb.Reset())
+	require.Zero(t, cb.Deadline())
+}
+
 func TestChannelBuilder_PendingFrames_TotalFrames(t *testing.T) {
 	const tnf = 8
 	rng := rand.New(rand.NewSource(94572314))
@@ -7,6 +7,8 @@ import (
 	"sync"
 
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
@@ -28,11 +30,15 @@ type channelManager struct {
 	log  log.Logger
 	metr metrics.Metricer
 	cfg  ChannelConfig
+	// rollupCfg is used to check which upgrades are active when a channel is created
+	rollupCfg *rollup.Config
 
 	// All blocks since the last request for new tx data.
 	blocks []*types.Block
 	// last block hash - for reorg detection
 	tip common.Hash
+	// last block number - for the journal
+	tipNumber uint64
 
 	// channel to write new block data to
 	currentChannel *channel
@@ -43,25 +49,38 @@ type channelManager struct {
 
 	// if set to true, prevents production of any new channel frames
 	closed bool
+
+	// journal persists the state after every change, so it can be restored after a restart
+	journal Journal
 }
 
-func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig) *channelManager {
+func NewChannelManager(log log.Logger, metr metrics.Metricer, cfg ChannelConfig, rollupCfg *rollup.Config) *channelManager {
 	return &channelManager{
 		log:        log,
 		metr:       metr,
 		cfg:        cfg,
+		rollupCfg:  rollupCfg,
 		txChannels: make(map[txID]*channel),
+		journal:    DisabledJournal{},
 	}
 }
 
-// Clear clears the entire state of the channel manager.
+// Clear clears the entire state of the channel manager, including the journal.
 // It is intended to be used after an L2 reorg.
 func (s *channelManager) Clear() {
 	s.mu.Lock()
 	defer s.mu.Unlock()
+	s.clear()
+	if err := s.journal.Clear(); err != nil {
+		s.log.Error("failed to clear journal", "err", err)
+	}
+}
+
+func (s *channelManager) clear() {
 	s.log.Trace("clearing channel manager state")
 	s.blocks = s.blocks[:0]
 	s.tip = common.Hash{}
+	s.tipNumber = 0
 	s.closed = false
 	s.currentChannel = nil
 	s.channelQueue = nil
@@ -83,6 +102,32 @@ func (s *channelManager) TxFailed(id txID) {
 	} else {
 		s.log.Warn("transaction from unknown channel marked as failed", "id", id)
 	}
+	s.persist()
+}
+
+// TxDeferred records that a transaction was not sent, e.g. because the submission
+// policy held it back. Its frame is the next one that is returned by TxData.
+func (s *channelManager) TxDeferred(id txID) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if channel, ok := s.txChannels[id]; ok {
+		delete(s.txChannels, id)
+		channel.TxDeferred(id)
+	} else {
+		s.log.Warn("transaction from unknown channel marked as deferred", "id", id)
+	}
+	s.persist()
+}
+
+// TxDeadline returns the L1 block number by which the given pending transaction must be
+// submitted, or 0 if its channel has no deadline yet or the transaction is unknown.
+func (s *channelManager) TxDeadline(id txID) uint64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if channel, ok := s.txChannels[id]; ok {
+		return channel.Deadline()
+	}
+	return 0
 }
 
 // TxConfirmed marks a transaction as confirmed on L1. Unfortunately even if all frames in
@@ -104,6 +149,7 @@ func (s *channelManager) TxConfirmed(id txID, inclusionBlock eth.BlockID) {
 	}
 	s.metr.RecordBatchTxSubmitted()
 	s.log.Debug("marked transaction as confirmed", "id", id, "block", inclusionBlock)
+	s.persist()
 }
 
 // removePendingChannel removes the given completed channel from the manager's state.
@@ -133,6 +179,7 @@ func (s *channelManager) nextTxData(channel *channel) (txData, error) {
 	}
 	tx := channel.NextTxData()
 	s.txChannels[tx.ID()] = channel
+	s.persist()
 	return tx, nil
 }
 
@@ -141,7 +188,7 @@ func (s *channelManager) nextTxData(channel *channel) (txData, error) {
 // It currently only uses one frame per transaction. If the pending channel is
 // full, it only returns the remaining frames of this channel until it got
 // successfully fully sent to L1. It returns io.EOF if there's no pending frame.
-func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
+func (s *channelManager) TxData(l1Head eth.L1BlockRef) (txData, error) {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 	var firstWithFrame *channel
@@ -153,7 +200,7 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 	}
 
 	dataPending := firstWithFrame != nil && firstWithFrame.HasFrame()
-	s.log.Debug("Requested tx data", "l1Head", l1Head, "data_pending", dataPending, "blocks_pending", len(s.blocks))
+	s.log.Debug("Requested tx data", "l1Head", l1Head.ID(), "data_pending", dataPending, "blocks_pending", len(s.blocks))
 
 	// Short circuit if there is a pending frame or the channel manager is closed.
 	if dataPending || s.closed {
@@ -190,12 +237,24 @@ func (s *channelManager) TxData(l1Head eth.BlockID) (txData, error) {
 // ensureChannelWithSpace ensures currentChannel is populated with a channel that has
 // space for more data (i.e. channel.IsFull returns false). If currentChannel is nil
 // or full, a new channel is created.
-func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
+func (s *channelManager) ensureChannelWithSpace(l1Head eth.L1BlockRef) error {
 	if s.currentChannel != nil && !s.currentChannel.IsFull() {
 		return nil
 	}
 
-	pc, err := newChannel(s.log, s.metr, s.cfg)
+	cfg := s.cfg
+	if algo := cfg.CompressorConfig.CompressionAlgo; algo.IsBrotli() && !s.rollupCfg.IsFjord(l1Head.Time) {
+		// The channel is included in an L1 block after the current L1 head, so if Fjord is active
+		// at the L1 head, it is also active when the channel is derived.
+		s.log.Info("Fjord is not active yet, compressing channel with zlib", "l1Head", l1Head.ID(), "algo", algo)
+		cfg.CompressorConfig.CompressionAlgo = derive.Zlib
+	}
+	if cfg.BatchType == derive.SpanBatchType && !s.rollupCfg.IsDelta(l1Head.Time) {
+		// Span batches are dropped by derivation before Delta, so the channel is built from singular batches.
+		s.log.Info("Delta is not active yet, building channel with singular batches", "l1Head", l1Head.ID())
+		cfg.BatchType = derive.BatchV1Type
+	}
+	pc, err := newChannel(s.log, s.metr, cfg)
 	if err != nil {
 		return fmt.Errorf("creating new channel: %w", err)
 	}
@@ -203,7 +262,7 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 	s.channelQueue = append(s.channelQueue, pc)
 	s.log.Info("Created channel",
 		"id", pc.ID(),
-		"l1Head", l1Head,
+		"l1Head", l1Head.ID(),
 		"blocks_pending", len(s.blocks))
 	s.metr.RecordChannelOpened(pc.ID(), len(s.blocks))
 
@@ -211,10 +270,10 @@ func (s *channelManager) ensureChannelWithSpace(l1Head eth.BlockID) error {
 }
 
 // registerL1Block registers the given block at the pending channel.
-func (s *channelManager) registerL1Block(l1Head eth.BlockID) {
+func (s *channelManager) registerL1Block(l1Head eth.L1BlockRef) {
 	s.currentChannel.RegisterL1Block(l1Head.Number)
 	s.log.Debug("new L1-block registered at channel builder",
-		"l1Head", l1Head,
+		"l1Head", l1Head.ID(),
 		"channel_full", s.currentChannel.IsFull(),
 		"full_reason", s.currentChannel.FullErr(),
 	)
@@ -317,6 +376,7 @@ func (s *channelManager) AddL2Block(block *types.Block) error {
 	s.metr.RecordL2BlockInPendingQueue(block)
 	s.blocks = append(s.blocks, block)
 	s.tip = block.Hash()
+	s.tipNumber = block.NumberU64()
 
 	return nil
 }
@@ -359,3 +419,242 @@ func (s *channelManager) Close() error {
 
 	return s.outputFrames()
 }
+
+// ErrNoOpenChannel is returned by ForceCloseChannel if there is no open channel to close.
+var ErrNoOpenChannel = errors.New("no open channel")
+
+// Channels returns the state of all channels that are not yet fully submitted.
+func (s *channelManager) Channels() rpc.ChannelsResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	res := rpc.ChannelsResponse{
+		Channels:      make([]rpc.ChannelInfo, 0, len(s.channelQueue)),
+		PendingBlocks: len(s.blocks),
+	}
+	for _, ch := range s.channelQueue {
+		info := ch.Info()
+		info.Current = ch == s.currentChannel
+		res.Channels = append(res.Channels, info)
+	}
+	return res
+}
+
+// ForceCloseChannel closes the current channel, if it is still open, and outputs all its
+// remaining frames. Unlike Close, new channels can still be created afterwards.
+func (s *channelManager) ForceCloseChannel() (derive.ChannelID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.currentChannel == nil || s.currentChannel.IsFull() {
+		return derive.ChannelID{}, ErrNoOpenChannel
+	}
+	s.currentChannel.Close()
+	if err := s.outputFrames(); err != nil {
+		return derive.ChannelID{}, err
+	}
+	s.persist()
+	return s.currentChannel.ID(), nil
+}
+
+// Flush adds all pending blocks to channels and closes them, so that all data can be
+// submitted right away, irrespective of the max channel duration.
+func (s *channelManager) Flush(l1Head eth.L1BlockRef) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return nil
+	}
+	for len(s.blocks) > 0 || (s.currentChannel != nil && !s.currentChannel.IsFull()) {
+		if len(s.blocks) > 0 {
+			if err := s.ensureChannelWithSpace(l1Head); err != nil {
+				return err
+			}
+			if err := s.processBlocks(); err != nil {
+				return err
+			}
+			s.registerL1Block(l1Head)
+		}
+		s.currentChannel.Close()
+		if err := s.outputFrames(); err != nil {
+			return err
+		}
+	}
+	s.persist()
+	return nil
+}
+
+// Config returns the configuration that new channels are created with.
+func (s *channelManager) Config() ChannelConfig {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.cfg
+}
+
+// SetConfig changes the configuration that new channels are created with.
+// Channels that already exist keep their configuration.
+func (s *channelManager) SetConfig(cfg ChannelConfig) error {
+	if err := cfg.Check(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.cfg = cfg
+	return nil
+}
+
+// persist saves a snapshot of the current state to the journal. Failures are only logged:
+// the journal is an optimization, the batcher can always restart from the L2 safe head.
+func (s *channelManager) persist() {
+	if _, ok := s.journal.(DisabledJournal); ok {
+		return
+	}
+	if err := s.journal.Save(s.snapshot()); err != nil {
+		s.log.Error("failed to save channel manager state to journal", "err", err)
+	}
+}
+
+// snapshot returns the journal state of the channel manager.
+func (s *channelManager) snapshot() *JournalState {
+	state := &JournalState{
+		Version:  JournalVersion,
+		Tip:      eth.BlockID{Hash: s.tip, Number: s.tipNumber},
+		Blocks:   make([]eth.BlockID, 0, len(s.blocks)),
+		Channels: make([]JournalChannel, 0, len(s.channelQueue)),
+	}
+	for _, block := range s.blocks {
+		state.Blocks = append(state.Blocks, eth.ToBlockID(block))
+	}
+	for _, ch := range s.channelQueue {
+		jc := JournalChannel{
+			ID:           ch.ID(),
+			Config:       ch.cfg,
+			Full:         ch.IsFull(),
+			FirstL1Block: ch.firstL1Block,
+			Blocks:       make([]eth.BlockID, 0, len(ch.channelBuilder.Blocks())),
+			Confirmed:    make([]JournalFrame, 0, len(ch.confirmedTransactions)),
+			Pending:      make([]JournalFrame, 0, len(ch.pendingTransactions)),
+		}
+		for _, block := range ch.channelBuilder.Blocks() {
+			jc.Blocks = append(jc.Blocks, eth.ToBlockID(block))
+		}
+		for id, inclusionBlock := range ch.confirmedTransactions {
+			inclusionBlock := inclusionBlock
+			jc.Confirmed = append(jc.Confirmed, JournalFrame{FrameNumber: id.frameNumber, Hash: ch.frameHashes[id], InclusionBlock: &inclusionBlock})
+		}
+		for id := range ch.pendingTransactions {
+			jc.Pending = append(jc.Pending, JournalFrame{FrameNumber: id.frameNumber, Hash: ch.frameHashes[id]})
+		}
+		state.Channels = append(state.Channels, jc)
+	}
+	return state
+}
+
+// Restore replaces the state of the channel manager with the given journal state.
+// The channels are rebuilt with their journaled config from their L2 blocks, which are
+// retrieved with fetchBlock, and reproduce the same frames as before. Frames that were
+// confirmed are dropped, and frames that were pending are registered as in-flight
+// transactions again: their outcome must be reported with TxConfirmed or TxFailed, see
+// PendingTxData.
+//
+// If a channel cannot be rebuilt with the same submitted frames, it is dropped together with
+// all later channels, and their blocks are queued again to be put into new channels.
+//
+// If an error is returned, the state of the channel manager is undefined and must be cleared.
+func (s *channelManager) Restore(state *JournalState, fetchBlock func(eth.BlockID) (*types.Block, error)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.clear()
+
+	var requeued []*types.Block
+	for _, jc := range state.Channels {
+		blocks := make([]*types.Block, 0, len(jc.Blocks))
+		for _, id := range jc.Blocks {
+			block, err := fetchBlock(id)
+			if err != nil {
+				return fmt.Errorf("fetching block %s of channel %s: %w", id, jc.ID, err)
+			}
+			blocks = append(blocks, block)
+		}
+		if requeued != nil {
+			s.log.Warn("Dropping channel after dropped channel", "id", jc.ID, "blocks", len(blocks))
+			requeued = append(requeued, blocks...)
+			continue
+		}
+		ch, err := s.restoreChannel(jc, blocks)
+		if err != nil {
+			s.log.Warn("Dropping channel that does not match the journal", "id", jc.ID, "blocks", len(blocks), "err", err)
+			requeued = append(make([]*types.Block, 0, len(blocks)), blocks...)
+			continue
+		}
+		for id := range ch.pendingTransactions {
+			s.txChannels[id] = ch
+		}
+		s.channelQueue = append(s.channelQueue, ch)
+		if !ch.IsFull() {
+			s.currentChannel = ch
+		}
+		s.log.Info("Restored channel", "id", jc.ID, "blocks", len(jc.Blocks), "full", ch.IsFull(),
+			"confirmed_frames", len(jc.Confirmed), "pending_frames", len(jc.Pending))
+	}
+	if len(requeued) > 0 {
+		// the blocks of dropped channels must be added to a channel again, before the pending blocks
+		s.currentChannel = nil
+		s.blocks = requeued
+	}
+
+	for _, id := range state.Blocks {
+		block, err := fetchBlock(id)
+		if err != nil {
+			return fmt.Errorf("fetching pending block %s: %w", id, err)
+		}
+		s.blocks = append(s.blocks, block)
+	}
+	s.tip = state.Tip.Hash
+	s.tipNumber = state.Tip.Number
+	return nil
+}
+
+// restoreChannel rebuilds a journaled channel from its blocks, using the config it was
+// created with. It returns an error if the rebuilt channel does not match the journal.
+func (s *channelManager) restoreChannel(jc JournalChannel, blocks []*types.Block) (*channel, error) {
+	if err := jc.Config.Check(); err != nil {
+		return nil, fmt.Errorf("invalid channel config: %w", err)
+	}
+	ch, err := newChannel(s.log, s.metr, jc.Config)
+	if err != nil {
+		return nil, err
+	}
+	ch.channelBuilder.co.SetID(jc.ID)
+	for _, block := range blocks {
+		if _, err := ch.AddBlock(block); err != nil {
+			return nil, fmt.Errorf("adding block %s: %w", eth.ToBlockID(block), err)
+		}
+	}
+	if jc.FirstL1Block != 0 {
+		ch.RegisterL1Block(jc.FirstL1Block)
+	}
+	if jc.Full {
+		ch.Close()
+	} else if ch.IsFull() {
+		return nil, fmt.Errorf("rebuilt channel is full: %w", ch.FullErr())
+	}
+	if err := ch.OutputFrames(); err != nil {
+		return nil, fmt.Errorf("creating frames: %w", err)
+	}
+	if err := ch.restoreFrames(jc.Confirmed, jc.Pending); err != nil {
+		return nil, fmt.Errorf("restoring frames: %w", err)
+	}
+	return ch, nil
+}
+
+// PendingTxData returns the tx data of all transactions that are in flight.
+func (s *channelManager) PendingTxData() []txData {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var out []txData
+	for _, ch := range s.channelQueue {
+		for _, data := range ch.pendingTransactions {
+			out = append(out, data)
+		}
+	}
+	return out
+}
@@ -9,6 +9,8 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
@@ -19,11 +21,16 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+// defaultTestRollupConfig activates Delta at genesis.
+var defaultTestRollupConfig = rollup.Config{
+	DeltaTime: new(uint64),
+}
+
 // TestChannelManagerReturnsErrReorg ensures that the channel manager
 // detects a reorg when it has cached L1 blocks.
 func TestChannelManagerReturnsErrReorg(t *testing.T) {
 	log := testlog.Logger(t, log.LvlCrit)
-	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{})
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{}, &defaultTestRollupConfig)
 
 	a := types.NewBlock(&types.Header{
 		Number: big.NewInt(0),
@@ -61,16 +68,16 @@ func TestChannelManagerReturnsErrReorgWhenDrained(t *testing.T) {
 				TargetNumFrames:  1,
 				ApproxComprRatio: 1.0,
 			},
-		})
+		}, &defaultTestRollupConfig)
 
 	a := newMiniL2Block(0)
 	x := newMiniL2BlockWithNumberParent(0, big.NewInt(1), common.Hash{0xff})
 
 	require.NoError(t, m.AddL2Block(a))
 
-	_, err := m.TxData(eth.BlockID{})
+	_, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(t, err)
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(t, err, io.EOF)
 
 	require.ErrorIs(t, m.AddL2Block(x), ErrReorg)
@@ -96,7 +103,7 @@ func TestChannelManager_Clear(t *testing.T) {
 			TargetNumFrames:  1,
 			ApproxComprRatio: 1.0,
 		},
-	})
+	}, &defaultTestRollupConfig)
 
 	// Channel Manager state should be empty by default
 	require.Empty(m.blocks)
@@ -108,7 +115,7 @@ func TestChannelManager_Clear(t *testing.T) {
 	// Add a block to the channel manager
 	a, _ := derivetest.RandomL2Block(rng, 4)
 	newL1Tip := a.Hash()
-	l1BlockID := eth.BlockID{
+	l1BlockID := eth.L1BlockRef{
 		Hash:   a.Hash(),
 		Number: a.NumberU64(),
 	}
@@ -165,13 +172,13 @@ func TestChannelManager_TxResend(t *testing.T) {
 				TargetNumFrames:  1,
 				ApproxComprRatio: 1.0,
 			},
-		})
+		}, &defaultTestRollupConfig)
 
 	a, _ := derivetest.RandomL2Block(rng, 4)
 
 	require.NoError(m.AddL2Block(a))
 
-	txdata0, err := m.TxData(eth.BlockID{})
+	txdata0, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err)
 	txdata0bytes := txdata0.Bytes()
 	data0 := make([]byte, len(txdata0bytes))
@@ -179,13 +186,13 @@ func TestChannelManager_TxResend(t *testing.T) {
 	copy(data0, txdata0bytes)
 
 	// ensure channel is drained
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF)
 
 	// requeue frame
 	m.TxFailed(txdata0.ID())
 
-	txdata1, err := m.TxData(eth.BlockID{})
+	txdata1, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err)
 
 	data1 := txdata1.Bytes()
@@ -209,7 +216,7 @@ func TestChannelManagerCloseBeforeFirstUse(t *testing.T) {
 				TargetFrameSize:  0,
 				ApproxComprRatio: 1.0,
 			},
-		})
+		}, &defaultTestRollupConfig)
 
 	a, _ := derivetest.RandomL2Block(rng, 4)
 
@@ -218,7 +225,7 @@ func TestChannelManagerCloseBeforeFirstUse(t *testing.T) {
 	err := m.AddL2Block(a)
 	require.NoError(err, "Failed to add L2 block")
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to contain no tx data")
 }
 
@@ -237,19 +244,19 @@ func TestChannelManagerCloseNoPendingChannel(t *testing.T) {
 				TargetNumFrames:  1,
 				ApproxComprRatio: 1.0,
 			},
-		})
+		}, &defaultTestRollupConfig)
 	a := newMiniL2Block(0)
 	b := newMiniL2BlockWithNumberParent(0, big.NewInt(1), a.Hash())
 
 	err := m.AddL2Block(a)
 	require.NoError(err, "Failed to add L2 block")
 
-	txdata, err := m.TxData(eth.BlockID{})
+	txdata, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to return valid tx data")
 
 	m.TxConfirmed(txdata.ID(), eth.BlockID{})
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected channel manager to EOF")
 
 	m.Close()
@@ -257,7 +264,7 @@ func TestChannelManagerCloseNoPendingChannel(t *testing.T) {
 	err = m.AddL2Block(b)
 	require.NoError(err, "Failed to add L2 block")
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to return no new tx data")
 }
 
@@ -276,7 +283,7 @@ func TestChannelManagerClosePendingChannel(t *testing.T) {
 				TargetFrameSize:  1000,
 				ApproxComprRatio: 1.0,
 			},
-		})
+		}, &defaultTestRollupConfig)
 
 	a := newMiniL2Block(50_000)
 	b := newMiniL2BlockWithNumberParent(10, big.NewInt(1), a.Hash())
@@ -284,25 +291,25 @@ func TestChannelManagerClosePendingChannel(t *testing.T) {
 	err := m.AddL2Block(a)
 	require.NoError(err, "Failed to add L2 block")
 
-	txdata, err := m.TxData(eth.BlockID{})
+	txdata, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to produce valid tx data")
 
 	m.TxConfirmed(txdata.ID(), eth.BlockID{})
 
 	m.Close()
 
-	txdata, err = m.TxData(eth.BlockID{})
+	txdata, err = m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to produce tx data from remaining L2 block data")
 
 	m.TxConfirmed(txdata.ID(), eth.BlockID{})
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected channel manager to have no more tx data")
 
 	err = m.AddL2Block(b)
 	require.NoError(err, "Failed to add L2 block")
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
 
@@ -321,27 +328,255 @@ func TestChannelManagerCloseAllTxsFailed(t *testing.T) {
 				TargetFrameSize:  1000,
 				ApproxComprRatio: 1.0,
 			},
-		})
+		}, &defaultTestRollupConfig)
 
 	a := newMiniL2Block(50_000)
 
 	err := m.AddL2Block(a)
 	require.NoError(err, "Failed to add L2 block")
 
-	txdata, err := m.TxData(eth.BlockID{})
+	txdata, err := m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to produce valid tx data")
 
 	m.TxFailed(txdata.ID())
 
 	// Show that this data will continue to be emitted as long as the transaction
 	// fails and the channel manager is not closed
-	txdata, err = m.TxData(eth.BlockID{})
+	txdata, err = m.TxData(eth.L1BlockRef{})
 	require.NoError(err, "Expected channel manager to re-attempt the failed transaction")
 
 	m.TxFailed(txdata.ID())
 
 	m.Close()
 
-	_, err = m.TxData(eth.BlockID{})
+	_, err = m.TxData(eth.L1BlockRef{})
 	require.ErrorIs(err, io.EOF, "Expected closed channel manager to produce no more tx data")
 }
+
+// TestChannelManagerForceCloseChannel ensures that the current channel can be force-closed,
+// and that new channels are created afterwards.
+func TestChannelManagerForceCloseChannel(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:   10_000,
+			ChannelTimeout: 1000,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  10_000,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+			},
+		}, &defaultTestRollupConfig)
+
+	_, err := m.ForceCloseChannel()
+	require.ErrorIs(err, ErrNoOpenChannel)
+
+	a, _ := derivetest.RandomL2Block(rng, 4)
+	require.NoError(m.AddL2Block(a))
+	_, err = m.TxData(eth.L1BlockRef{})
+	require.ErrorIs(err, io.EOF, "channel not full yet")
+
+	channels := m.Channels()
+	require.Len(channels.Channels, 1)
+	require.True(channels.Channels[0].Current)
+	require.False(channels.Channels[0].Full)
+	require.Equal(1, channels.Channels[0].Blocks)
+
+	id, err := m.ForceCloseChannel()
+	require.NoError(err)
+	require.Equal(channels.Channels[0].ID, id)
+	_, err = m.ForceCloseChannel()
+	require.ErrorIs(err, ErrNoOpenChannel, "channel already closed")
+
+	channels = m.Channels()
+	require.True(channels.Channels[0].Full)
+	require.Contains(channels.Channels[0].FullReason, ErrTerminated.Error())
+	require.Len(channels.Channels[0].Frames, 1)
+	require.Equal(rpc.FrameQueued, channels.Channels[0].Frames[0].Status)
+
+	txdata, err := m.TxData(eth.L1BlockRef{})
+	require.NoError(err)
+	require.Equal(id, txdata.ID().chID)
+	require.Equal(rpc.FramePending, m.Channels().Channels[0].Frames[0].Status)
+
+	inclusion := eth.BlockID{Number: 1}
+	m.TxConfirmed(txdata.ID(), inclusion)
+	require.Empty(m.Channels().Channels, "fully submitted channel is removed")
+}
+
+// TestChannelManagerFlush ensures that flushing adds all pending blocks to
+// closed channels.
+func TestChannelManagerFlush(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:   10_000,
+			ChannelTimeout: 1000,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  10_000,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+			},
+		}, &defaultTestRollupConfig)
+
+	a, _ := derivetest.RandomL2Block(rng, 4)
+	require.NoError(m.AddL2Block(a))
+	require.Equal(1, m.Channels().PendingBlocks)
+
+	require.NoError(m.Flush(eth.L1BlockRef{}))
+	channels := m.Channels()
+	require.Zero(channels.PendingBlocks)
+	require.Len(channels.Channels, 1)
+	require.True(channels.Channels[0].Full)
+
+	_, err := m.TxData(eth.L1BlockRef{})
+	require.NoError(err)
+	require.NoError(m.Flush(eth.L1BlockRef{}), "flushing without pending data is a no-op")
+	require.Len(m.Channels().Channels, 1)
+}
+
+// TestChannelManagerBrotliBeforeFjord ensures that channels are only compressed
+// with brotli once Fjord is active at the L1 head.
+func TestChannelManagerBrotliBeforeFjord(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	fjordTime := uint64(100)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:   10_000,
+			ChannelTimeout: 1000,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  10_000,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+				CompressionAlgo:  derive.Brotli,
+			},
+		}, &rollup.Config{FjordTime: &fjordTime})
+
+	// channelVersion returns the first byte of the channel data in the next frame
+	channelVersion := func(l1Head eth.L1BlockRef) byte {
+		txdata, err := m.TxData(l1Head)
+		require.NoError(err)
+		frames, err := derive.ParseFrames(txdata.Bytes())
+		require.NoError(err)
+		require.Len(frames, 1)
+		require.Zero(frames[0].FrameNumber, "must start a new channel")
+		return frames[0].Data[0]
+	}
+
+	beforeFjord := eth.L1BlockRef{Time: fjordTime - 1}
+	a := newMiniL2BlockWithNumberParent(4, big.NewInt(1), common.Hash{})
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.Flush(beforeFjord))
+	require.Equal(derive.Zlib, m.currentChannel.cfg.CompressorConfig.CompressionAlgo)
+	require.Equal(byte(0x78), channelVersion(beforeFjord), "zlib stream must start with the zlib header")
+
+	afterFjord := eth.L1BlockRef{Time: fjordTime}
+	b := newMiniL2BlockWithNumberParent(4, big.NewInt(2), a.Hash())
+	require.NoError(m.AddL2Block(b))
+	require.NoError(m.Flush(afterFjord))
+	require.Equal(derive.Brotli, m.currentChannel.cfg.CompressorConfig.CompressionAlgo)
+	require.Equal(derive.ChannelVersionBrotli, channelVersion(afterFjord))
+	require.Equal(derive.Brotli, m.Config().CompressorConfig.CompressionAlgo, "config of new channels must not change")
+}
+
+// TestChannelManagerSingularBatchesBeforeDelta ensures that channels are only built
+// from span batches once Delta is active at the L1 head.
+func TestChannelManagerSingularBatchesBeforeDelta(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	deltaTime := uint64(100)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:   10_000,
+			ChannelTimeout: 1000,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  10_000,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+			},
+			BatchType: derive.SpanBatchType,
+		}, &rollup.Config{DeltaTime: &deltaTime})
+
+	a := newMiniL2BlockWithNumberParent(4, big.NewInt(1), common.Hash{})
+	require.NoError(m.AddL2Block(a))
+	require.NoError(m.Flush(eth.L1BlockRef{Time: deltaTime - 1}))
+	require.Equal(uint(derive.BatchV1Type), m.currentChannel.cfg.BatchType)
+
+	b := newMiniL2BlockWithNumberParent(4, big.NewInt(2), a.Hash())
+	require.NoError(m.AddL2Block(b))
+	require.NoError(m.Flush(eth.L1BlockRef{Time: deltaTime}))
+	require.Equal(uint(derive.SpanBatchType), m.currentChannel.cfg.BatchType)
+	require.Equal(uint(derive.SpanBatchType), m.Config().BatchType, "config of new channels must not change")
+}
+
+// TestChannelManagerSetConfig ensures that config changes only apply to new channels.
+func TestChannelManagerSetConfig(t *testing.T) {
+	require := require.New(t)
+	log := testlog.Logger(t, log.LvlCrit)
+	cfg := ChannelConfig{
+		MaxFrameSize:   10_000,
+		ChannelTimeout: 1000,
+		CompressorConfig: compressor.Config{
+			TargetFrameSize:  10_000,
+			TargetNumFrames:  1,
+			ApproxComprRatio: 1.0,
+		},
+	}
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+	require.NoError(m.AddL2Block(newMiniL2Block(1)))
+	require.NoError(m.ensureChannelWithSpace(eth.L1BlockRef{}))
+	oldCfg := cfg
+
+	cfg.MaxFrameSize = 0
+	require.Error(m.SetConfig(cfg), "invalid config is rejected")
+	require.Equal(uint64(10_000), m.Config().MaxFrameSize)
+
+	cfg.SetMaxFrameSize(1000)
+	cfg.CompressorConfig.ApproxComprRatio = 0.4
+	require.NoError(m.SetConfig(cfg))
+	require.Equal(cfg, m.Config())
+	require.Equal(oldCfg, m.currentChannel.cfg, "open channel keeps its config")
+
+	m.currentChannel.Close()
+	require.NoError(m.ensureChannelWithSpace(eth.L1BlockRef{}))
+	require.Equal(cfg, m.currentChannel.cfg, "new channel uses the new config")
+}
+
+// TestChannelManager_TxDeferred ensures that a deferred frame is the next frame
+// returned by TxData.
+func TestChannelManager_TxDeferred(t *testing.T) {
+	require := require.New(t)
+	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics,
+		ChannelConfig{
+			MaxFrameSize:   100,
+			ChannelTimeout: 1000,
+			SeqWindowSize:  3600,
+			CompressorConfig: compressor.Config{
+				TargetFrameSize:  1,
+				TargetNumFrames:  1,
+				ApproxComprRatio: 1.0,
+			},
+		}, &defaultTestRollupConfig)
+
+	a, _ := derivetest.RandomL2Block(rng, 4)
+	require.NoError(m.AddL2Block(a))
+
+	txdata0, err := m.TxData(eth.L1BlockRef{})
+	require.NoError(err)
+	require.NotZero(m.TxDeadline(txdata0.ID()))
+
+	m.TxDeferred(txdata0.ID())
+	require.Zero(m.TxDeadline(txdata0.ID()), "deferred tx is no longer pending")
+
+	txdata1, err := m.TxData(eth.L1BlockRef{})
+	require.NoError(err)
+	require.Equal(txdata0.ID(), txdata1.ID())
+	require.Equal(txdata0.Bytes(), txdata1.Bytes())
+}
@@ -1,17 +1,21 @@
 package batcher
 
 import (
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/urfave/cli/v2"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -36,6 +40,21 @@ type Config struct {
 
 	// Channel builder parameters
 	Channel ChannelConfig
+
+	// UseBlobs indicates that batches are posted as EIP-4844 blobs instead of calldata.
+	UseBlobs bool
+
+	// JournalPath is the file the channel state is persisted to, so that it survives restarts.
+	// Journaling is disabled if empty.
+	JournalPath string
+
+	// AltDA uploads frames to the alt-DA server, so that only commitments are posted to L1.
+	// Frames are posted to L1 directly if nil.
+	AltDA *altda.DAClient
+
+	// SubmissionPolicy decides when batcher transactions are sent.
+	// All transactions are sent right away if nil.
+	SubmissionPolicy SubmissionPolicy
 }
 
 // Check ensures that the [Config] is valid.
@@ -46,6 +65,21 @@ func (c *Config) Check() error {
 	if err := c.Channel.Check(); err != nil {
 		return err
 	}
+	if c.Channel.CompressorConfig.CompressionAlgo.IsBrotli() && c.Rollup.FjordTime == nil {
+		return errors.New("cannot compress channels with brotli: the Fjord upgrade is not scheduled in the rollup config")
+	}
+	if c.Channel.BatchType == derive.SpanBatchType && c.Rollup.DeltaTime == nil {
+		return errors.New("cannot submit span batches: the Delta upgrade is not scheduled in the rollup config")
+	}
+	if c.UseBlobs && c.Rollup.BlobsEnabledL1Timestamp == nil {
+		return errors.New("cannot post batches as blobs: blobs are not enabled in the rollup config")
+	}
+	if c.AltDA != nil && !c.Rollup.UseAltDA {
+		return errors.New("cannot post alt-DA commitments: alt-DA is not enabled in the rollup config")
+	}
+	if c.AltDA != nil && c.UseBlobs {
+		return errors.New("cannot post alt-DA commitments as blobs")
+	}
 	return nil
 }
 
@@ -85,6 +119,19 @@ type CLIConfig struct {
 	// MaxL1TxSize is the maximum size of a batch tx submitted to L1.
 	MaxL1TxSize uint64
 
+	// BatchType is the type of batches to submit: 0 for BatchV1, 1 for span batches.
+	BatchType uint
+
+	// DataAvailabilityType is the data availability type to use for posting batches, e.g. call data or blobs.
+	DataAvailabilityType flags.DataAvailabilityType
+
+	// JournalPath is the file the channel state is persisted to. Journaling is disabled if empty.
+	JournalPath string
+
+	// MaxL1BaseFeeGwei is the L1 base fee above which batcher transactions are held back,
+	// unless their channel is about to time out. Disabled if 0.
+	MaxL1BaseFeeGwei uint64
+
 	Stopped bool
 
 	TxMgrConfig      txmgr.CLIConfig
@@ -93,9 +140,13 @@ type CLIConfig struct {
 	MetricsConfig    opmetrics.CLIConfig
 	PprofConfig      oppprof.CLIConfig
 	CompressorConfig compressor.CLIConfig
+	AltDA            altda.CLIConfig
 }
 
 func (c CLIConfig) Check() error {
+	if !flags.ValidDataAvailabilityType(c.DataAvailabilityType) {
+		return fmt.Errorf("unknown data availability type: %q", c.DataAvailabilityType)
+	}
 	if err := c.RPCConfig.Check(); err != nil {
 		return err
 	}
@@ -111,6 +162,12 @@ func (c CLIConfig) Check() error {
 	if err := c.TxMgrConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.CompressorConfig.Check(); err != nil {
+		return err
+	}
+	if err := c.AltDA.Check(); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -128,6 +185,10 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 		MaxPendingTransactions: ctx.Uint64(flags.MaxPendingTransactionsFlag.Name),
 		MaxChannelDuration:     ctx.Uint64(flags.MaxChannelDurationFlag.Name),
 		MaxL1TxSize:            ctx.Uint64(flags.MaxL1TxSizeBytesFlag.Name),
+		BatchType:              ctx.Uint(flags.BatchTypeFlag.Name),
+		DataAvailabilityType:   flags.DataAvailabilityType(ctx.String(flags.DataAvailabilityTypeFlag.Name)),
+		JournalPath:            ctx.String(flags.JournalPathFlag.Name),
+		MaxL1BaseFeeGwei:       ctx.Uint64(flags.MaxL1BaseFeeGweiFlag.Name),
 		Stopped:                ctx.Bool(flags.StoppedFlag.Name),
 		TxMgrConfig:            txmgr.ReadCLIConfig(ctx),
 		RPCConfig:              rpc.ReadCLIConfig(ctx),
@@ -135,5 +196,6 @@ func NewConfig(ctx *cli.Context) CLIConfig {
 		MetricsConfig:          opmetrics.ReadCLIConfig(ctx),
 		PprofConfig:            oppprof.ReadCLIConfig(ctx),
 		CompressorConfig:       compressor.ReadCLIConfig(ctx),
+		AltDA:                  altda.ReadCLIConfig(ctx),
 	}
 }
@@ -1,6 +1,7 @@
 package batcher
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
@@ -10,7 +11,10 @@ import (
 	"sync"
 	"time"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-batcher/flags"
 	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	opclient "github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -18,6 +22,7 @@ import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 )
 
 // BatchSubmitter encapsulates a service responsible for submitting L2 tx
@@ -41,8 +46,23 @@ type BatchSubmitter struct {
 	lastL1Tip       eth.L1BlockRef
 
 	state *channelManager
+
+	// flushReqs passes flush requests of the admin API to the driver loop
+	flushReqs chan chan error
+
+	// deferredTxs tracks the transactions held back by the submission policy, for metrics
+	deferredTxs map[txID]deferredTx
 }
 
+// deferredTx records when a transaction was first held back by the submission policy.
+type deferredTx struct {
+	since   time.Time
+	baseFee *big.Int
+}
+
+// errTxDeferred is returned by publishTxToL1 if the submission policy held back the next transaction.
+var errTxDeferred = errors.New("transaction deferred by submission policy")
+
 // NewBatchSubmitterFromCLIConfig initializes the BatchSubmitter, gathering any resources
 // that will be needed during operation.
 func NewBatchSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metricer) (*BatchSubmitter, error) {
@@ -75,6 +95,23 @@ func NewBatchSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metri
 		return nil, err
 	}
 
+	channelCfg := ChannelConfig{
+		SeqWindowSize:      rcfg.SeqWindowSize,
+		ChannelTimeout:     rcfg.ChannelTimeout,
+		MaxChannelDuration: cfg.MaxChannelDuration,
+		SubSafetyMargin:    cfg.SubSafetyMargin,
+		MaxFrameSize:       cfg.MaxL1TxSize - 1, // subtract 1 byte for version
+		CompressorConfig:   cfg.CompressorConfig.Config(),
+		BatchType:          cfg.BatchType,
+		L2GenesisTime:      rcfg.Genesis.L2Time,
+	}
+	useBlobs := cfg.DataAvailabilityType == flags.BlobsType
+	if useBlobs {
+		// a blob fits a single frame, so the max and target L1 tx sizes are ignored
+		channelCfg.MaxFrameSize = eth.MaxBlobDataSize - 1 // subtract 1 byte for version
+		channelCfg.CompressorConfig.TargetFrameSize = eth.MaxBlobDataSize - 1
+	}
+
 	batcherCfg := Config{
 		L1Client:               l1Client,
 		L2Client:               l2Client,
@@ -84,14 +121,16 @@ func NewBatchSubmitterFromCLIConfig(cfg CLIConfig, l log.Logger, m metrics.Metri
 		NetworkTimeout:         cfg.TxMgrConfig.NetworkTimeout,
 		TxManager:              txManager,
 		Rollup:                 rcfg,
-		Channel: ChannelConfig{
-			SeqWindowSize:      rcfg.SeqWindowSize,
-			ChannelTimeout:     rcfg.ChannelTimeout,
-			MaxChannelDuration: cfg.MaxChannelDuration,
-			SubSafetyMargin:    cfg.SubSafetyMargin,
-			MaxFrameSize:       cfg.MaxL1TxSize - 1, // subtract 1 byte for version
-			CompressorConfig:   cfg.CompressorConfig.Config(),
-		},
+		Channel:                channelCfg,
+		UseBlobs:               useBlobs,
+		JournalPath:            cfg.JournalPath,
+	}
+	if cfg.AltDA.Enabled() {
+		batcherCfg.AltDA = cfg.AltDA.NewDAClient()
+	}
+	if cfg.MaxL1BaseFeeGwei > 0 {
+		maxBaseFee := new(big.Int).Mul(new(big.Int).SetUint64(cfg.MaxL1BaseFeeGwei), big.NewInt(params.GWei))
+		batcherCfg.SubmissionPolicy = &MaxBaseFeeSubmissionPolicy{MaxBaseFee: maxBaseFee}
 	}
 
 	// Validate the batcher config
@@ -115,10 +154,21 @@ func NewBatchSubmitter(ctx context.Context, cfg Config, l log.Logger, m metrics.
 
 	cfg.metr = m
 
+	if cfg.SubmissionPolicy == nil {
+		cfg.SubmissionPolicy = ImmediateSubmissionPolicy{}
+	}
+
+	state := NewChannelManager(l, m, cfg.Channel, cfg.Rollup)
+	if cfg.JournalPath != "" {
+		state.journal = NewFileJournal(l, cfg.JournalPath)
+	}
+
 	return &BatchSubmitter{
-		Config: cfg,
-		txMgr:  cfg.TxManager,
-		state:  NewChannelManager(l, m, cfg.Channel),
+		Config:      cfg,
+		txMgr:       cfg.TxManager,
+		state:       state,
+		flushReqs:   make(chan chan error),
+		deferredTxs: make(map[txID]deferredTx),
 	}, nil
 
 }
@@ -136,8 +186,14 @@ func (l *BatchSubmitter) Start() error {
 
 	l.shutdownCtx, l.cancelShutdownCtx = context.WithCancel(context.Background())
 	l.killCtx, l.cancelKillCtx = context.WithCancel(context.Background())
-	l.state.Clear()
-	l.lastStoredBlock = eth.BlockID{}
+	if restored, err := l.restoreState(l.shutdownCtx); err != nil {
+		l.log.Warn("Failed to restore channel state from journal, starting from the safe head", "err", err)
+		l.state.Clear()
+		l.lastStoredBlock = eth.BlockID{}
+	} else if !restored {
+		l.state.Clear()
+		l.lastStoredBlock = eth.BlockID{}
+	}
 
 	l.wg.Add(1)
 	go l.loop()
@@ -180,6 +236,79 @@ func (l *BatchSubmitter) Stop(ctx context.Context) error {
 	return nil
 }
 
+// ListChannels returns the state of the channel manager.
+func (l *BatchSubmitter) ListChannels() rpc.ChannelsResponse {
+	return l.state.Channels()
+}
+
+// ForceCloseChannel closes the current channel, so its frames are submitted right away.
+func (l *BatchSubmitter) ForceCloseChannel() (derive.ChannelID, error) {
+	id, err := l.state.ForceCloseChannel()
+	if err == nil {
+		l.log.Info("Force-closed channel", "id", id)
+	}
+	return id, err
+}
+
+// Flush makes the driver loop load the latest L2 blocks and submit all pending data
+// immediately. It returns once all frames are handed to the transaction queue.
+func (l *BatchSubmitter) Flush(ctx context.Context) error {
+	l.mutex.Lock()
+	running, shutdownCtx := l.running, l.shutdownCtx
+	l.mutex.Unlock()
+	if !running {
+		return errors.New("batcher is not running")
+	}
+
+	res := make(chan error, 1)
+	select {
+	case l.flushReqs <- res:
+	case <-shutdownCtx.Done():
+		return errors.New("batcher is stopping")
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-res:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetMaxL1TxSize changes the max size of batcher transactions for new channels. The compressor
+// targets frames of the new size, see ChannelConfig.SetMaxFrameSize.
+func (l *BatchSubmitter) SetMaxL1TxSize(size uint64) error {
+	if l.UseBlobs {
+		return errors.New("max L1 tx size does not apply to blob transactions")
+	}
+	if size <= 1 {
+		return fmt.Errorf("max L1 tx size %d too small", size)
+	}
+	cfg := l.state.Config()
+	cfg.SetMaxFrameSize(size - 1) // subtract 1 byte for version
+	if err := l.state.SetConfig(cfg); err != nil {
+		return err
+	}
+	l.log.Info("Changed max L1 tx size", "size", size,
+		"target_frame_size", cfg.CompressorConfig.TargetFrameSize, "target_num_frames", cfg.CompressorConfig.TargetNumFrames)
+	return nil
+}
+
+// SetApproxComprRatio changes the approximate compression ratio for new channels.
+func (l *BatchSubmitter) SetApproxComprRatio(ratio float64) error {
+	if ratio <= 0 {
+		return fmt.Errorf("compression ratio %v must be positive", ratio)
+	}
+	cfg := l.state.Config()
+	cfg.CompressorConfig.ApproxComprRatio = ratio
+	if err := l.state.SetConfig(cfg); err != nil {
+		return err
+	}
+	l.log.Info("Changed approximate compression ratio", "ratio", ratio)
+	return nil
+}
+
 // loadBlocksIntoState loads all blocks since the previous stored block
 // It does the following:
 // 1. Fetch the sync status of the sequencer
@@ -300,27 +429,47 @@ func (l *BatchSubmitter) loop() {
 				if err != nil {
 					l.log.Error("error closing the channel manager to handle a L2 reorg", "err", err)
 				}
-				l.publishStateToL1(queue, receiptsCh, true)
+				l.publishStateToL1(queue, receiptsCh, true, true)
 				l.state.Clear()
 				continue
 			}
-			l.publishStateToL1(queue, receiptsCh, false)
+			l.publishStateToL1(queue, receiptsCh, false, false)
 		case r := <-receiptsCh:
 			l.handleReceipt(r)
+		case res := <-l.flushReqs:
+			res <- l.flush(queue, receiptsCh)
 		case <-l.shutdownCtx.Done():
 			err := l.state.Close()
 			if err != nil {
 				l.log.Error("error closing the channel manager", "err", err)
 			}
-			l.publishStateToL1(queue, receiptsCh, true)
+			l.publishStateToL1(queue, receiptsCh, true, true)
 			return
 		}
 	}
 }
 
+// flush loads the latest L2 blocks, closes all channels and publishes all frames.
+func (l *BatchSubmitter) flush(queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) error {
+	// the regular loop handles reorgs, any other error just means there are no new blocks
+	if err := l.loadBlocksIntoState(l.shutdownCtx); errors.Is(err, ErrReorg) {
+		return fmt.Errorf("loading blocks: %w", err)
+	}
+	l1tip, err := l.l1Tip(l.shutdownCtx)
+	if err != nil {
+		return err
+	}
+	if err := l.state.Flush(l1tip); err != nil {
+		return fmt.Errorf("flushing channels: %w", err)
+	}
+	l.publishStateToL1(queue, receiptsCh, false, true)
+	return nil
+}
+
 // publishStateToL1 loops through the block data loaded into `state` and
 // submits the associated data to the L1 in the form of channel frames.
-func (l *BatchSubmitter) publishStateToL1(queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData], drain bool) {
+// If force is set, the submission policy is ignored and all frames are sent.
+func (l *BatchSubmitter) publishStateToL1(queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData], drain, force bool) {
 	txDone := make(chan struct{})
 	// send/wait and receipt reading must be on a separate goroutines to avoid deadlocks
 	go func() {
@@ -332,7 +481,7 @@ func (l *BatchSubmitter) publishStateToL1(queue *txmgr.Queue[txData], receiptsCh
 			close(txDone)
 		}()
 		for {
-			err := l.publishTxToL1(l.killCtx, queue, receiptsCh)
+			err := l.publishTxToL1(l.killCtx, queue, receiptsCh, force)
 			if err != nil {
 				if drain && err != io.EOF {
 					l.log.Error("error sending tx while draining state", "err", err)
@@ -352,18 +501,20 @@ func (l *BatchSubmitter) publishStateToL1(queue *txmgr.Queue[txData], receiptsCh
 	}
 }
 
-// publishTxToL1 submits a single state tx to the L1
-func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) error {
+// publishTxToL1 submits a single state tx to the L1. Unless force is set, it returns
+// errTxDeferred if the submission policy holds back the transaction.
+func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData], force bool) error {
 	// send all available transactions
-	l1tip, err := l.l1Tip(ctx)
+	head, err := l.l1Head(ctx)
 	if err != nil {
 		l.log.Error("Failed to query L1 tip", "error", err)
 		return err
 	}
+	l1tip := eth.InfoToL1BlockRef(eth.HeaderBlockInfo(head))
 	l.recordL1Tip(l1tip)
 
 	// Collect next transaction data
-	txdata, err := l.state.TxData(l1tip.ID())
+	txdata, err := l.state.TxData(l1tip)
 	if err == io.EOF {
 		l.log.Trace("no transaction data available")
 		return err
@@ -372,28 +523,70 @@ func (l *BatchSubmitter) publishTxToL1(ctx context.Context, queue *txmgr.Queue[t
 		return err
 	}
 
-	l.sendTransaction(txdata, queue, receiptsCh)
-	return nil
+	if !force {
+		deadline := l.state.TxDeadline(txdata.ID())
+		if !l.SubmissionPolicy.ShouldSubmit(l1tip, head.BaseFee, deadline) {
+			l.state.TxDeferred(txdata.ID())
+			l.recordDeferredTx(txdata.ID(), head.BaseFee, deadline)
+			return errTxDeferred
+		}
+	}
+	l.recordSubmittedTx(txdata, head.BaseFee)
+
+	return l.sendTransaction(txdata, queue, receiptsCh)
 }
 
 // sendTransaction creates & submits a transaction to the batch inbox address with the given `data`.
 // It currently uses the underlying `txmgr` to handle transaction sending & price management.
 // This is a blocking method. It should not be called concurrently.
-func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) {
-	// Do the gas estimation offline. A value of 0 will cause the [txmgr] to estimate the gas limit.
+// It returns an error if the next transactions should not be sent right away.
+func (l *BatchSubmitter) sendTransaction(txdata txData, queue *txmgr.Queue[txData], receiptsCh chan txmgr.TxReceipt[txData]) error {
 	data := txdata.Bytes()
-	intrinsicGas, err := core.IntrinsicGas(data, nil, false, true, true, false)
-	if err != nil {
-		l.log.Error("Failed to calculate intrinsic gas", "error", err)
-		return
+	var candidate txmgr.TxCandidate
+	if l.UseBlobs {
+		var blob eth.Blob
+		if err := blob.FromData(data); err != nil {
+			l.log.Error("Failed to encode batch data into blob", "error", err)
+			return nil
+		}
+		candidate = txmgr.TxCandidate{
+			To:    &l.Rollup.BatchInboxAddress,
+			Blobs: []*eth.Blob{&blob},
+		}
+	} else if l.AltDA != nil {
+		comm, err := l.uploadToAltDA(data)
+		if err != nil {
+			// requeue the frame, and stop publishing until the next poll of the driver loop
+			l.recordFailedTx(txdata.ID(), fmt.Errorf("uploading to alt-DA server: %w", err))
+			return err
+		}
+		candidate = txmgr.TxCandidate{
+			To:     &l.Rollup.BatchInboxAddress,
+			TxData: comm.TxData(),
+		}
+	} else {
+		candidate = txmgr.TxCandidate{
+			To:     &l.Rollup.BatchInboxAddress,
+			TxData: data,
+		}
 	}
 
-	candidate := txmgr.TxCandidate{
-		To:       &l.Rollup.BatchInboxAddress,
-		TxData:   data,
-		GasLimit: intrinsicGas,
+	// Do the gas estimation offline. A value of 0 will cause the [txmgr] to estimate the gas limit.
+	intrinsicGas, err := core.IntrinsicGas(candidate.TxData, nil, false, true, true, false)
+	if err != nil {
+		l.log.Error("Failed to calculate intrinsic gas", "error", err)
+		return nil
 	}
+	candidate.GasLimit = intrinsicGas
 	queue.Send(txdata, candidate, receiptsCh)
+	return nil
+}
+
+// uploadToAltDA stores the data on the alt-DA server, and returns the commitment to post to L1.
+func (l *BatchSubmitter) uploadToAltDA(data []byte) (altda.Keccak256Commitment, error) {
+	ctx, cancel := context.WithTimeout(l.killCtx, l.NetworkTimeout)
+	defer cancel()
+	return l.AltDA.SetInput(ctx, data)
 }
 
 func (l *BatchSubmitter) handleReceipt(r txmgr.TxReceipt[txData]) {
@@ -426,14 +619,196 @@ func (l *BatchSubmitter) recordConfirmedTx(id txID, receipt *types.Receipt) {
 	l.state.TxConfirmed(id, l1block)
 }
 
+// restoreState loads the channel state from the journal, if there is any. It returns whether
+// the state was restored. Transactions that were in flight when the batcher stopped are looked
+// up on L1: frames that were included are confirmed, all other frames are queued again.
+func (l *BatchSubmitter) restoreState(ctx context.Context) (bool, error) {
+	js, err := l.state.journal.Load()
+	if err != nil {
+		return false, err
+	} else if js == nil {
+		return false, nil
+	}
+
+	err = l.state.Restore(js, func(id eth.BlockID) (*types.Block, error) {
+		cCtx, cancel := context.WithTimeout(ctx, l.NetworkTimeout)
+		defer cancel()
+		block, err := l.L2Client.BlockByNumber(cCtx, new(big.Int).SetUint64(id.Number))
+		if err != nil {
+			return nil, err
+		}
+		if block.Hash() != id.Hash {
+			return nil, fmt.Errorf("block %d has hash %s, journal expected %s: %w", id.Number, block.Hash(), id.Hash, ErrReorg)
+		}
+		return block, nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("restoring channel manager: %w", err)
+	}
+
+	if err := l.recoverPendingTxs(ctx, js); err != nil {
+		return false, fmt.Errorf("recovering pending transactions: %w", err)
+	}
+	l.lastStoredBlock = js.Tip
+	l.log.Info("Restored channel state from journal", "tip", js.Tip, "channels", len(js.Channels), "blocks", len(js.Blocks))
+	return true, nil
+}
+
+// recoverPendingTxs resolves the transactions that were in flight when the journal was last
+// saved, by scanning L1 for batcher transactions that carry their data.
+func (l *BatchSubmitter) recoverPendingTxs(ctx context.Context, js *JournalState) error {
+	pending := l.state.PendingTxData()
+	if len(pending) == 0 {
+		return nil
+	}
+
+	// pending frames were submitted after the channel registered its first L1 block
+	start := uint64(0)
+	for _, jc := range js.Channels {
+		if len(jc.Pending) > 0 && (start == 0 || jc.FirstL1Block < start) {
+			start = jc.FirstL1Block
+		}
+	}
+
+	unresolved := make(map[txID]txData, len(pending))
+	for _, txdata := range pending {
+		unresolved[txdata.ID()] = txdata
+	}
+	matchers, err := l.pendingTxMatchers(pending)
+	if err != nil {
+		return err
+	}
+
+	tip, err := l.l1Tip(ctx)
+	if err != nil {
+		return err
+	}
+	signer := types.LatestSignerForChainID(l.Rollup.L1ChainID)
+	for num := start; num <= tip.Number && len(unresolved) > 0; num++ {
+		cCtx, cancel := context.WithTimeout(ctx, l.NetworkTimeout)
+		block, err := l.L1Client.BlockByNumber(cCtx, new(big.Int).SetUint64(num))
+		cancel()
+		if err != nil {
+			return fmt.Errorf("fetching L1 block %d: %w", num, err)
+		}
+		for _, tx := range block.Transactions() {
+			if to := tx.To(); to == nil || *to != l.Rollup.BatchInboxAddress {
+				continue
+			}
+			if from, err := types.Sender(signer, tx); err != nil || from != l.TxManager.From() {
+				continue
+			}
+			for id := range unresolved {
+				if matchers[id](tx) {
+					l.log.Info("Found pending transaction on L1", "id", id, "tx_hash", tx.Hash(), "block", eth.ToBlockID(block))
+					l.state.TxConfirmed(id, eth.ToBlockID(block))
+					delete(unresolved, id)
+					break
+				}
+			}
+		}
+	}
+
+	for id := range unresolved {
+		l.log.Info("Pending transaction not found on L1, resubmitting", "id", id)
+		l.state.TxFailed(id)
+	}
+	return nil
+}
+
+// pendingTxMatchers returns, for every pending transaction, a function that reports whether an
+// L1 transaction carries its data, either as calldata or as a blob.
+func (l *BatchSubmitter) pendingTxMatchers(pending []txData) (map[txID]func(*types.Transaction) bool, error) {
+	matchers := make(map[txID]func(*types.Transaction) bool, len(pending))
+	for _, txdata := range pending {
+		data := txdata.Bytes()
+		if l.AltDA != nil {
+			// only the commitment is posted to L1
+			data = altda.Keccak256(data).TxData()
+		}
+		if !l.UseBlobs {
+			matchers[txdata.ID()] = func(tx *types.Transaction) bool {
+				return bytes.Equal(tx.Data(), data)
+			}
+			continue
+		}
+		var blob eth.Blob
+		if err := blob.FromData(data); err != nil {
+			return nil, fmt.Errorf("encoding blob of tx %s: %w", txdata.ID(), err)
+		}
+		commitment, err := blob.ComputeKZGCommitment()
+		if err != nil {
+			return nil, fmt.Errorf("computing KZG commitment of tx %s: %w", txdata.ID(), err)
+		}
+		blobHash := eth.KZGToVersionedHash(commitment)
+		matchers[txdata.ID()] = func(tx *types.Transaction) bool {
+			for _, h := range tx.BlobHashes() {
+				if h == blobHash {
+					return true
+				}
+			}
+			return false
+		}
+	}
+	return matchers, nil
+}
+
+// recordDeferredTx records that a transaction was held back by the submission policy.
+func (l *BatchSubmitter) recordDeferredTx(id txID, baseFee *big.Int, deadline uint64) {
+	if _, ok := l.deferredTxs[id]; ok {
+		return
+	}
+	l.deferredTxs[id] = deferredTx{since: time.Now(), baseFee: baseFee}
+	l.metr.RecordBatchTxDeferred()
+	l.log.Info("Deferring batcher transaction", "id", id, "base_fee", baseFee, "deadline", deadline)
+}
+
+// recordSubmittedTx records the deferral time and the saved cost of a transaction that is about
+// to be sent, if it was held back by the submission policy before. The saved cost is estimated
+// from the intrinsic gas of the transaction.
+func (l *BatchSubmitter) recordSubmittedTx(txdata txData, baseFee *big.Int) {
+	d, ok := l.deferredTxs[txdata.ID()]
+	if !ok {
+		return
+	}
+	delete(l.deferredTxs, txdata.ID())
+
+	saved := new(big.Int)
+	if d.baseFee != nil && baseFee != nil {
+		var calldata []byte
+		if !l.UseBlobs {
+			calldata = txdata.Bytes()
+		}
+		gas, err := core.IntrinsicGas(calldata, nil, false, true, true, false)
+		if err != nil {
+			l.log.Warn("Failed to calculate intrinsic gas", "err", err)
+		} else {
+			saved.Mul(new(big.Int).Sub(d.baseFee, baseFee), new(big.Int).SetUint64(gas))
+		}
+	}
+	deferral := time.Since(d.since)
+	l.metr.RecordDeferredBatchTxSubmitted(deferral, saved)
+	l.log.Info("Submitting deferred batcher transaction", "id", txdata.ID(), "deferral", deferral, "saved_wei", saved)
+}
+
 // l1Tip gets the current L1 tip as a L1BlockRef. The passed context is assumed
 // to be a lifetime context, so it is internally wrapped with a network timeout.
 func (l *BatchSubmitter) l1Tip(ctx context.Context) (eth.L1BlockRef, error) {
+	head, err := l.l1Head(ctx)
+	if err != nil {
+		return eth.L1BlockRef{}, err
+	}
+	return eth.InfoToL1BlockRef(eth.HeaderBlockInfo(head)), nil
+}
+
+// l1Head gets the header of the current L1 tip. The passed context is assumed
+// to be a lifetime context, so it is internally wrapped with a network timeout.
+func (l *BatchSubmitter) l1Head(ctx context.Context) (*types.Header, error) {
 	tctx, cancel := context.WithTimeout(ctx, l.NetworkTimeout)
 	defer cancel()
 	head, err := l.L1Client.HeaderByNumber(tctx, nil)
 	if err != nil {
-		return eth.L1BlockRef{}, fmt.Errorf("getting latest L1 block: %w", err)
+		return nil, fmt.Errorf("getting latest L1 block: %w", err)
 	}
-	return eth.InfoToL1BlockRef(eth.HeaderBlockInfo(head)), nil
+	return head, nil
 }
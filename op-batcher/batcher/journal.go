@@ -0,0 +1,175 @@
+package batcher
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// JournalVersion is the version of the journal format.
+// Journals of other versions are discarded with a warning, and the batcher starts from the L2 safe head.
+const JournalVersion = 3
+
+// JournalState is a snapshot of the channel manager state, from which the channel manager can
+// be rebuilt after a restart. Only block and frame identifiers are persisted: the L2 blocks are
+// re-fetched on restore and the channels are rebuilt from them with their original config,
+// which deterministically reproduces the same frames.
+type JournalState struct {
+	Version uint64 `json:"version"`
+	// Tip is the last L2 block that was loaded into the channel manager.
+	Tip eth.BlockID `json:"tip"`
+	// Blocks are the pending L2 blocks that were not yet added to a channel.
+	Blocks []eth.BlockID `json:"blocks"`
+	// Channels are the channels that are not yet fully submitted, in order of creation.
+	Channels []JournalChannel `json:"channels"`
+}
+
+// JournalChannel is the persisted state of a single channel.
+type JournalChannel struct {
+	ID derive.ChannelID `json:"id"`
+	// Config is the configuration the channel was created with.
+	Config ChannelConfig `json:"config"`
+	// Blocks are the L2 blocks that were added to the channel, in order.
+	Blocks []eth.BlockID `json:"blocks"`
+	// Full is true if the channel was full, and thus closed, when the journal was written.
+	Full bool `json:"full"`
+	// FirstL1Block is the number of the first L1 block that was registered with the channel.
+	FirstL1Block uint64 `json:"first_l1_block"`
+	// Confirmed are the frames of the channel that were included on L1.
+	Confirmed []JournalFrame `json:"confirmed"`
+	// Pending are the frames of the channel that were handed out for submission,
+	// but not yet confirmed or failed.
+	Pending []JournalFrame `json:"pending"`
+}
+
+// JournalFrame identifies a submitted frame of a channel.
+type JournalFrame struct {
+	FrameNumber uint16 `json:"frame_number"`
+	// Hash is the keccak256 hash of the frame data, to check that the rebuilt frame is the same.
+	Hash common.Hash `json:"hash"`
+	// InclusionBlock is the L1 block the frame was included in, if it was confirmed.
+	InclusionBlock *eth.BlockID `json:"inclusion_block,omitempty"`
+}
+
+// Journal persists the state of the channel manager, so the batcher can resume
+// where it left off after a restart.
+type Journal interface {
+	// Save replaces the persisted state with the given state.
+	Save(state *JournalState) error
+	// Load returns the persisted state, or nil if there is none.
+	Load() (*JournalState, error)
+	// Clear removes any persisted state.
+	Clear() error
+}
+
+var _ Journal = (*FileJournal)(nil)
+var _ Journal = DisabledJournal{}
+
+// FileJournal is a Journal that stores the state as JSON in a single file.
+type FileJournal struct {
+	lock sync.Mutex
+	log  log.Logger
+	file string
+}
+
+func NewFileJournal(log log.Logger, file string) *FileJournal {
+	return &FileJournal{log: log, file: file}
+}
+
+// Save writes the state to the journal file as safely as possible.
+// It initially writes to a temp file, which is synced to disk and then renamed into place,
+// so the journal is never left partially written if the batcher crashes while saving.
+func (j *FileJournal) Save(state *JournalState) error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	data, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("marshal journal state: %w", err)
+	}
+	dir := filepath.Dir(j.file)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("create journal dir (%v): %w", j.file, err)
+	}
+	tmpFile := j.file + ".tmp"
+	file, err := os.OpenFile(tmpFile, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("open file (%v) for writing: %w", tmpFile, err)
+	}
+	defer file.Close() // Ensure file is closed even if write or sync fails
+	if _, err = file.Write(data); err != nil {
+		return fmt.Errorf("write journal to temp file (%v): %w", tmpFile, err)
+	}
+	if err := file.Sync(); err != nil {
+		return fmt.Errorf("sync journal temp file (%v): %w", tmpFile, err)
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("close journal temp file (%v): %w", tmpFile, err)
+	}
+	if err := os.Rename(tmpFile, j.file); err != nil {
+		return fmt.Errorf("rename temp journal file to final destination: %w", err)
+	}
+	return nil
+}
+
+func (j *FileJournal) Load() (*JournalState, error) {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	data, err := os.ReadFile(j.file)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("read journal file (%v): %w", j.file, err)
+	}
+	// The version is checked first, as other versions may not decode into the current format
+	var version struct {
+		Version uint64 `json:"version"`
+	}
+	if err := json.Unmarshal(data, &version); err != nil {
+		return nil, fmt.Errorf("invalid journal file (%v): %w", j.file, err)
+	}
+	if version.Version != JournalVersion {
+		j.log.Warn("Discarding journal of unsupported version", "file", j.file, "version", version.Version, "supported", JournalVersion)
+		return nil, nil
+	}
+	var state JournalState
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&state); err != nil {
+		return nil, fmt.Errorf("invalid journal file (%v): %w", j.file, err)
+	}
+	return &state, nil
+}
+
+func (j *FileJournal) Clear() error {
+	j.lock.Lock()
+	defer j.lock.Unlock()
+	if err := os.Remove(j.file); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("remove journal file (%v): %w", j.file, err)
+	}
+	return nil
+}
+
+// DisabledJournal is a Journal that does not persist anything.
+type DisabledJournal struct{}
+
+func (DisabledJournal) Save(*JournalState) error {
+	return nil
+}
+
+func (DisabledJournal) Load() (*JournalState, error) {
+	return nil, nil
+}
+
+func (DisabledJournal) Clear() error {
+	return nil
+}
@@ -0,0 +1,227 @@
+package batcher
+
+import (
+	"io"
+	"math/big"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
+	"github.com/ethereum-optimism/optimism/op-batcher/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	derivetest "github.com/ethereum-optimism/optimism/op-node/rollup/derive/test"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestFileJournal(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	file := filepath.Join(t.TempDir(), "journal", "batcher.json")
+	j := NewFileJournal(testlog.Logger(t, log.LvlCrit), file)
+
+	state, err := j.Load()
+	require.NoError(t, err)
+	require.Nil(t, state, "no state before the first save")
+
+	inclusion := testutils.RandomBlockID(rng)
+	var channelID derive.ChannelID
+	rng.Read(channelID[:])
+	expected := &JournalState{
+		Version: JournalVersion,
+		Tip:     testutils.RandomBlockID(rng),
+		Blocks:  []eth.BlockID{testutils.RandomBlockID(rng)},
+		Channels: []JournalChannel{{
+			ID: channelID,
+			Config: ChannelConfig{
+				MaxFrameSize:     1000,
+				CompressorConfig: compressor.Config{TargetNumFrames: 1, CompressionAlgo: derive.Brotli10},
+			},
+			Blocks:       []eth.BlockID{testutils.RandomBlockID(rng), testutils.RandomBlockID(rng)},
+			Full:         true,
+			FirstL1Block: 42,
+			Confirmed:    []JournalFrame{{FrameNumber: 0, Hash: testutils.RandomHash(rng), InclusionBlock: &inclusion}},
+			Pending:      []JournalFrame{{FrameNumber: 1, Hash: testutils.RandomHash(rng)}},
+		}},
+	}
+	require.NoError(t, j.Save(expected))
+	state, err = j.Load()
+	require.NoError(t, err)
+	require.Equal(t, expected, state)
+
+	require.NoError(t, j.Clear())
+	state, err = j.Load()
+	require.NoError(t, err)
+	require.Nil(t, state, "no state after clear")
+	require.NoError(t, j.Clear(), "clearing twice is fine")
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"version":0,"unknown":true}`), 0644))
+	state, err = j.Load()
+	require.NoError(t, err)
+	require.Nil(t, state, "journal of other version is discarded")
+
+	require.NoError(t, os.WriteFile(file, []byte(`{"version":0`), 0644))
+	_, err = j.Load()
+	require.ErrorContains(t, err, "invalid journal file")
+}
+
+// TestChannelManager_Restore tests that a channel manager that is restored from its journal
+// reproduces the same frames, and keeps track of confirmed and pending frames.
+func TestChannelManager_Restore(t *testing.T) {
+	rng := rand.New(rand.NewSource(4321))
+	log := testlog.Logger(t, log.LvlCrit)
+	cfg := ChannelConfig{
+		ChannelTimeout: 100,
+		MaxFrameSize:   1000,
+		CompressorConfig: compressor.Config{
+			TargetFrameSize:  1000,
+			TargetNumFrames:  4,
+			ApproxComprRatio: 1.0,
+		},
+	}
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+
+	blocks := make(map[eth.BlockID]*types.Block)
+	for i := 0; i < 10; i++ {
+		block, _ := derivetest.RandomL2Block(rng, 10)
+		header := block.Header()
+		header.Number = big.NewInt(int64(i))
+		header.ParentHash = m.tip
+		block = types.NewBlockWithHeader(header).WithBody(block.Transactions(), nil)
+		blocks[eth.ToBlockID(block)] = block
+		require.NoError(t, m.AddL2Block(block))
+	}
+
+	l1Head := eth.L1BlockRef{Number: 10}
+	confirmed, err := m.TxData(l1Head)
+	require.NoError(t, err)
+	pending, err := m.TxData(l1Head)
+	require.NoError(t, err)
+	m.TxConfirmed(confirmed.ID(), eth.BlockID{Number: 11})
+
+	state := m.snapshot()
+	require.Len(t, state.Channels, 1)
+
+	// channels are rebuilt with their own config, not the current one
+	newCfg := cfg
+	newCfg.MaxFrameSize = 500
+	restored := NewChannelManager(log, metrics.NoopMetrics, newCfg, &defaultTestRollupConfig)
+	require.NoError(t, restored.Restore(state, func(id eth.BlockID) (*types.Block, error) {
+		block, ok := blocks[id]
+		require.True(t, ok, "unknown block %s", id)
+		return block, nil
+	}))
+	require.Equal(t, state, restored.snapshot())
+
+	pendingTxs := restored.PendingTxData()
+	require.Len(t, pendingTxs, 1)
+	require.Equal(t, pending.ID(), pendingTxs[0].ID())
+	require.Equal(t, pending.Bytes(), pendingTxs[0].Bytes())
+
+	// the remaining frames of the channel are identical
+	chID := state.Channels[0].ID
+	compared := 0
+	for {
+		expected, err := m.TxData(l1Head)
+		require.NoError(t, err)
+		actual, err := restored.TxData(l1Head)
+		require.NoError(t, err)
+		if expected.ID().chID != chID {
+			break
+		}
+		require.Equal(t, expected.ID(), actual.ID())
+		require.Equal(t, expected.Bytes(), actual.Bytes())
+		compared++
+	}
+	require.NotZero(t, compared, "channel has more than the submitted frames")
+}
+
+func TestChannelManager_RestoreMissingBlock(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	m := NewChannelManager(log, metrics.NoopMetrics, ChannelConfig{}, &defaultTestRollupConfig)
+	state := &JournalState{
+		Version: JournalVersion,
+		Blocks:  []eth.BlockID{{Number: 1}},
+	}
+	err := m.Restore(state, func(id eth.BlockID) (*types.Block, error) {
+		return nil, io.ErrUnexpectedEOF
+	})
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+}
+
+// TestChannelManager_RestoreMismatch tests that channels that cannot be rebuilt with the same
+// frames are dropped, together with all later channels, and their blocks are queued again.
+func TestChannelManager_RestoreMismatch(t *testing.T) {
+	rng := rand.New(rand.NewSource(4322))
+	log := testlog.Logger(t, log.LvlCrit)
+	cfg := ChannelConfig{
+		ChannelTimeout: 100,
+		MaxFrameSize:   1000,
+		CompressorConfig: compressor.Config{
+			TargetFrameSize:  1000,
+			TargetNumFrames:  1,
+			ApproxComprRatio: 1.0,
+		},
+	}
+	m := NewChannelManager(log, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+
+	blocks := make(map[eth.BlockID]*types.Block)
+	for i := 0; i < 10; i++ {
+		block, _ := derivetest.RandomL2Block(rng, 10)
+		header := block.Header()
+		header.Number = big.NewInt(int64(i))
+		header.ParentHash = m.tip
+		block = types.NewBlockWithHeader(header).WithBody(block.Transactions(), nil)
+		blocks[eth.ToBlockID(block)] = block
+		require.NoError(t, m.AddL2Block(block))
+	}
+	fetchBlock := func(id eth.BlockID) (*types.Block, error) {
+		block, ok := blocks[id]
+		require.True(t, ok, "unknown block %s", id)
+		return block, nil
+	}
+
+	// submit a frame of each of the first two channels
+	l1Head := eth.L1BlockRef{Number: 10}
+	for len(m.channelQueue) < 2 || m.channelQueue[1].NoneSubmitted() {
+		_, err := m.TxData(l1Head)
+		require.NoError(t, err)
+	}
+	state := m.snapshot()
+	require.GreaterOrEqual(t, len(state.Channels), 2)
+
+	t.Run("frame hash", func(t *testing.T) {
+		state := m.snapshot()
+		state.Channels[1].Pending[0].Hash[0] ^= 1
+
+		restored := NewChannelManager(log, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+		require.NoError(t, restored.Restore(state, fetchBlock))
+		require.Len(t, restored.channelQueue, 1)
+		require.Len(t, restored.PendingTxData(), len(state.Channels[0].Pending))
+		requeued := len(state.Blocks)
+		for _, jc := range state.Channels[1:] {
+			requeued += len(jc.Blocks)
+		}
+		require.Len(t, restored.blocks, requeued)
+		require.Equal(t, state.Channels[1].Blocks[0], eth.ToBlockID(restored.blocks[0]))
+	})
+
+	t.Run("config", func(t *testing.T) {
+		state := m.snapshot()
+		state.Channels[0].Config.MaxFrameSize = 500
+
+		restored := NewChannelManager(log, metrics.NoopMetrics, cfg, &defaultTestRollupConfig)
+		require.NoError(t, restored.Restore(state, fetchBlock))
+		require.Empty(t, restored.channelQueue)
+		require.Empty(t, restored.PendingTxData())
+		require.Len(t, restored.blocks, len(blocks))
+		require.Equal(t, state.Channels[0].Blocks[0], eth.ToBlockID(restored.blocks[0]))
+		require.Equal(t, state.Tip, eth.BlockID{Hash: restored.tip, Number: restored.tipNumber})
+	})
+}
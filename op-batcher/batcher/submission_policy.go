@@ -0,0 +1,37 @@
+package batcher
+
+import (
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// SubmissionPolicy decides whether a batcher transaction is sent now, or held back
+// until L1 conditions are more favorable.
+type SubmissionPolicy interface {
+	// ShouldSubmit reports whether a transaction should be sent at the given L1 head, which has
+	// the given base fee. The deadline is the L1 block number by which the transaction must be
+	// sent so that its channel is still valid, or 0 if there is no deadline yet.
+	ShouldSubmit(l1Head eth.L1BlockRef, baseFee *big.Int, deadline uint64) bool
+}
+
+// ImmediateSubmissionPolicy sends all transactions as soon as their data is ready.
+type ImmediateSubmissionPolicy struct{}
+
+func (ImmediateSubmissionPolicy) ShouldSubmit(eth.L1BlockRef, *big.Int, uint64) bool {
+	return true
+}
+
+// MaxBaseFeeSubmissionPolicy holds back transactions while the L1 base fee is above
+// MaxBaseFee, unless the deadline of their channel is reached.
+type MaxBaseFeeSubmissionPolicy struct {
+	MaxBaseFee *big.Int
+}
+
+func (p *MaxBaseFeeSubmissionPolicy) ShouldSubmit(l1Head eth.L1BlockRef, baseFee *big.Int, deadline uint64) bool {
+	if deadline != 0 && l1Head.Number >= deadline {
+		return true
+	}
+	// pre-London L1 blocks have no base fee
+	return baseFee == nil || baseFee.Cmp(p.MaxBaseFee) <= 0
+}
@@ -0,0 +1,26 @@
+package batcher
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func TestMaxBaseFeeSubmissionPolicy(t *testing.T) {
+	p := &MaxBaseFeeSubmissionPolicy{MaxBaseFee: big.NewInt(100)}
+	head := eth.L1BlockRef{Number: 10}
+
+	require.True(t, p.ShouldSubmit(head, big.NewInt(99), 0))
+	require.True(t, p.ShouldSubmit(head, big.NewInt(100), 0))
+	require.False(t, p.ShouldSubmit(head, big.NewInt(101), 0))
+	require.True(t, p.ShouldSubmit(head, nil, 0), "no base fee")
+
+	require.False(t, p.ShouldSubmit(head, big.NewInt(101), 11), "deadline not reached")
+	require.True(t, p.ShouldSubmit(head, big.NewInt(101), 10), "deadline reached")
+	require.True(t, p.ShouldSubmit(head, big.NewInt(101), 9), "deadline passed")
+
+	require.True(t, ImmediateSubmissionPolicy{}.ShouldSubmit(head, big.NewInt(101), 0))
+}
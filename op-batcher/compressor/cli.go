@@ -1,9 +1,12 @@
 package compressor
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	"github.com/urfave/cli/v2"
 )
 
@@ -12,6 +15,7 @@ const (
 	TargetNumFramesFlagName     = "target-num-frames"
 	ApproxComprRatioFlagName    = "approx-compr-ratio"
 	KindFlagName                = "compressor"
+	CompressionAlgoFlagName     = "compression-algo"
 )
 
 func CLIFlags(envPrefix string) []cli.Flag {
@@ -40,6 +44,16 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVars: opservice.PrefixEnvVar(envPrefix, "COMPRESSOR"),
 			Value:   RatioKind,
 		},
+		&cli.GenericFlag{
+			Name: CompressionAlgoFlagName,
+			Usage: "The compression algorithm to use. Brotli requires the Fjord upgrade to be scheduled, zlib is used until it is active. Valid options: " +
+				openum.EnumString(derive.CompressionAlgos),
+			EnvVars: opservice.PrefixEnvVar(envPrefix, "COMPRESSION_ALGO"),
+			Value: func() *derive.CompressionAlgo {
+				out := derive.Zlib
+				return &out
+			}(),
+		},
 	}
 }
 
@@ -57,6 +71,15 @@ type CLIConfig struct {
 	ApproxComprRatio float64
 	// Type of compressor to use. Must be one of KindKeys.
 	Kind string
+	// CompressionAlgo to compress the channels with. Must be one of derive.CompressionAlgos.
+	CompressionAlgo derive.CompressionAlgo
+}
+
+func (c *CLIConfig) Check() error {
+	if !derive.ValidCompressionAlgo(c.CompressionAlgo) {
+		return fmt.Errorf("invalid compression algo %v", c.CompressionAlgo)
+	}
+	return nil
 }
 
 func (c *CLIConfig) Config() Config {
@@ -65,6 +88,7 @@ func (c *CLIConfig) Config() Config {
 		TargetNumFrames:  c.TargetNumFrames,
 		ApproxComprRatio: c.ApproxComprRatio,
 		Kind:             c.Kind,
+		CompressionAlgo:  c.CompressionAlgo,
 	}
 }
 
@@ -74,5 +98,6 @@ func ReadCLIConfig(ctx *cli.Context) CLIConfig {
 		TargetL1TxSizeBytes: ctx.Uint64(TargetL1TxSizeBytesFlagName),
 		TargetNumFrames:     ctx.Int(TargetNumFramesFlagName),
 		ApproxComprRatio:    ctx.Float64(ApproxComprRatioFlagName),
+		CompressionAlgo:     derive.CompressionAlgo(ctx.String(CompressionAlgoFlagName)),
 	}
 }
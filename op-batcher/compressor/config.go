@@ -19,6 +19,9 @@ type Config struct {
 	// Kind of compressor to use. Must be one of KindKeys. If unset, NewCompressor
 	// will default to RatioKind.
 	Kind string
+	// CompressionAlgo is the algorithm used to compress the channel data. Must be one of
+	// derive.CompressionAlgos. If unset, channels are compressed with zlib.
+	CompressionAlgo derive.CompressionAlgo
 }
 
 func (c Config) NewCompressor() (derive.Compressor, error) {
@@ -28,3 +31,12 @@ func (c Config) NewCompressor() (derive.Compressor, error) {
 	// default to RatioCompressor
 	return Kinds[RatioKind](c)
 }
+
+// newChannelCompressor creates the channel compressor for the configured algorithm,
+// defaulting to zlib if none is set.
+func (c Config) newChannelCompressor() (derive.ChannelCompressor, error) {
+	if c.CompressionAlgo == "" {
+		return derive.NewChannelCompressor(derive.Zlib)
+	}
+	return derive.NewChannelCompressor(c.CompressionAlgo)
+}
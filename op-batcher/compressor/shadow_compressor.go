@@ -1,20 +1,14 @@
 package compressor
 
 import (
-	"bytes"
-	"compress/zlib"
-
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 )
 
 type ShadowCompressor struct {
 	config Config
 
-	buf      bytes.Buffer
-	compress *zlib.Writer
-
-	shadowBuf      bytes.Buffer
-	shadowCompress *zlib.Writer
+	compressor       derive.ChannelCompressor
+	shadowCompressor derive.ChannelCompressor
 
 	fullErr error
 }
@@ -32,11 +26,11 @@ func NewShadowCompressor(config Config) (derive.Compressor, error) {
 	}
 
 	var err error
-	c.compress, err = zlib.NewWriterLevel(&c.buf, zlib.BestCompression)
+	c.compressor, err = config.newChannelCompressor()
 	if err != nil {
 		return nil, err
 	}
-	c.shadowCompress, err = zlib.NewWriterLevel(&c.shadowBuf, zlib.BestCompression)
+	c.shadowCompressor, err = config.newChannelCompressor()
 	if err != nil {
 		return nil, err
 	}
@@ -45,15 +39,15 @@ func NewShadowCompressor(config Config) (derive.Compressor, error) {
 }
 
 func (t *ShadowCompressor) Write(p []byte) (int, error) {
-	_, err := t.shadowCompress.Write(p)
+	_, err := t.shadowCompressor.Write(p)
 	if err != nil {
 		return 0, err
 	}
-	err = t.shadowCompress.Flush()
+	err = t.shadowCompressor.Flush()
 	if err != nil {
 		return 0, err
 	}
-	if uint64(t.shadowBuf.Len()) > t.config.TargetFrameSize*uint64(t.config.TargetNumFrames) {
+	if uint64(t.shadowCompressor.Len()) > t.config.TargetFrameSize*uint64(t.config.TargetNumFrames) {
 		t.fullErr = derive.CompressorFullErr
 		if t.Len() > 0 {
 			// only return an error if we've already written data to this compressor before
@@ -61,31 +55,29 @@ func (t *ShadowCompressor) Write(p []byte) (int, error) {
 			return 0, t.fullErr
 		}
 	}
-	return t.compress.Write(p)
+	return t.compressor.Write(p)
 }
 
 func (t *ShadowCompressor) Close() error {
-	return t.compress.Close()
+	return t.compressor.Close()
 }
 
 func (t *ShadowCompressor) Read(p []byte) (int, error) {
-	return t.buf.Read(p)
+	return t.compressor.Read(p)
 }
 
 func (t *ShadowCompressor) Reset() {
-	t.buf.Reset()
-	t.compress.Reset(&t.buf)
-	t.shadowBuf.Reset()
-	t.shadowCompress.Reset(&t.shadowBuf)
+	t.compressor.Reset()
+	t.shadowCompressor.Reset()
 	t.fullErr = nil
 }
 
 func (t *ShadowCompressor) Len() int {
-	return t.buf.Len()
+	return t.compressor.Len()
 }
 
 func (t *ShadowCompressor) Flush() error {
-	return t.compress.Flush()
+	return t.compressor.Flush()
 }
 
 func (t *ShadowCompressor) FullErr() error {
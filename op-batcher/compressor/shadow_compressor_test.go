@@ -7,6 +7,7 @@ import (
 	"io"
 	"testing"
 
+	"github.com/andybalholm/brotli"
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/stretchr/testify/require"
@@ -110,3 +111,23 @@ func TestShadowCompressor(t *testing.T) {
 		})
 	}
 }
+
+func TestShadowCompressorBrotli(t *testing.T) {
+	sc, err := compressor.NewShadowCompressor(compressor.Config{
+		TargetFrameSize: 1000,
+		TargetNumFrames: 1,
+		CompressionAlgo: derive.Brotli,
+	})
+	require.NoError(t, err)
+	data := bytes.Repeat([]byte{0x42}, 2048)
+	_, err = sc.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, sc.Close())
+
+	buf, err := io.ReadAll(sc)
+	require.NoError(t, err)
+	require.Equal(t, derive.ChannelVersionBrotli, buf[0])
+	uncompressed, err := io.ReadAll(brotli.NewReader(bytes.NewReader(buf[1:])))
+	require.NoError(t, err)
+	require.Equal(t, data, uncompressed)
+}
@@ -6,9 +6,11 @@ import (
 
 	"github.com/urfave/cli/v2"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-batcher/compressor"
 	"github.com/ethereum-optimism/optimism/op-batcher/rpc"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -72,6 +74,34 @@ var (
 		Value:   120_000,
 		EnvVars: prefixEnvVars("MAX_L1_TX_SIZE_BYTES"),
 	}
+	BatchTypeFlag = &cli.UintFlag{
+		Name:    "batch-type",
+		Usage:   "The batch type. 0 for BatchV1 and 1 for SpanBatch. Span batches require the Delta upgrade to be active.",
+		Value:   0,
+		EnvVars: prefixEnvVars("BATCH_TYPE"),
+	}
+	DataAvailabilityTypeFlag = &cli.GenericFlag{
+		Name: "data-availability-type",
+		Usage: "The data availability type to use for submitting batches to the L1. Valid options: " +
+			openum.EnumString(DataAvailabilityTypes),
+		Value: func() *DataAvailabilityType {
+			out := CalldataType
+			return &out
+		}(),
+		EnvVars: prefixEnvVars("DATA_AVAILABILITY_TYPE"),
+	}
+	JournalPathFlag = &cli.StringFlag{
+		Name: "journal-path",
+		Usage: "Path of the file the channel state is persisted to, so that channels that are in progress " +
+			"are resumed after a restart instead of being rebuilt from the safe head. Disabled if empty.",
+		EnvVars: prefixEnvVars("JOURNAL_PATH"),
+	}
+	MaxL1BaseFeeGweiFlag = &cli.Uint64Flag{
+		Name: "max-l1-base-fee-gwei",
+		Usage: "L1 base fee in gwei above which batcher transactions are held back, unless their channel " +
+			"is about to time out or reach the end of the sequencing window. Disabled if 0.",
+		EnvVars: prefixEnvVars("MAX_L1_BASE_FEE_GWEI"),
+	}
 	StoppedFlag = &cli.BoolFlag{
 		Name:    "stopped",
 		Usage:   "Initialize the batcher in a stopped state. The batcher can be started using the admin_startBatcher RPC",
@@ -93,6 +123,10 @@ var optionalFlags = []cli.Flag{
 	MaxPendingTransactionsFlag,
 	MaxChannelDurationFlag,
 	MaxL1TxSizeBytesFlag,
+	BatchTypeFlag,
+	DataAvailabilityTypeFlag,
+	JournalPathFlag,
+	MaxL1BaseFeeGweiFlag,
 	StoppedFlag,
 	SequencerHDPathFlag,
 }
@@ -105,6 +139,7 @@ func init() {
 	optionalFlags = append(optionalFlags, rpc.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
 	optionalFlags = append(optionalFlags, compressor.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, altda.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
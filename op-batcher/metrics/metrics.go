@@ -2,11 +2,14 @@ package metrics
 
 import (
 	"context"
+	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
@@ -40,6 +43,8 @@ type Metricer interface {
 	RecordBatchTxSubmitted()
 	RecordBatchTxSuccess()
 	RecordBatchTxFailed()
+	RecordBatchTxDeferred()
+	RecordDeferredBatchTxSubmitted(deferral time.Duration, savedWei *big.Int)
 
 	Document() []opmetrics.DocumentedMetric
 }
@@ -73,6 +78,10 @@ type Metrics struct {
 	channelOutputBytesTotal prometheus.Counter
 
 	batcherTxEvs opmetrics.EventVec
+
+	batcherTxDeferralSeconds prometheus.Histogram
+	batcherTxSavedGwei       prometheus.Counter
+	batcherTxExtraGwei       prometheus.Counter
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -173,6 +182,23 @@ func NewMetrics(procName string) *Metrics {
 		}),
 
 		batcherTxEvs: opmetrics.NewEventVec(factory, ns, "", "batcher_tx", "BatcherTx", []string{"stage"}),
+
+		batcherTxDeferralSeconds: factory.NewHistogram(prometheus.HistogramOpts{
+			Namespace: ns,
+			Name:      "batcher_tx_deferral_seconds",
+			Help:      "Time that batcher transactions were held back by the submission policy.",
+			Buckets:   []float64{12, 30, 60, 120, 300, 600, 1200, 1800, 3600, 7200},
+		}),
+		batcherTxSavedGwei: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "batcher_tx_saved_gwei_total",
+			Help:      "Estimated L1 base fee cost saved by holding back batcher transactions, for transactions where the base fee went down.",
+		}),
+		batcherTxExtraGwei: factory.NewCounter(prometheus.CounterOpts{
+			Namespace: ns,
+			Name:      "batcher_tx_extra_gwei_total",
+			Help:      "Estimated L1 base fee cost added by holding back batcher transactions, for transactions where the base fee went up.",
+		}),
 	}
 }
 
@@ -212,6 +238,7 @@ const (
 	TxStageSubmitted = "submitted"
 	TxStageSuccess   = "success"
 	TxStageFailed    = "failed"
+	TxStageDeferred  = "deferred"
 )
 
 func (m *Metrics) RecordLatestL1Block(l1ref eth.L1BlockRef) {
@@ -295,6 +322,21 @@ func (m *Metrics) RecordBatchTxFailed() {
 	m.batcherTxEvs.Record(TxStageFailed)
 }
 
+func (m *Metrics) RecordBatchTxDeferred() {
+	m.batcherTxEvs.Record(TxStageDeferred)
+}
+
+func (m *Metrics) RecordDeferredBatchTxSubmitted(deferral time.Duration, savedWei *big.Int) {
+	m.batcherTxDeferralSeconds.Observe(deferral.Seconds())
+	savedGwei, _ := new(big.Float).Quo(new(big.Float).SetInt(savedWei), big.NewFloat(params.GWei)).Float64()
+	// counters cannot decrease, so savings and extra costs are recorded separately
+	if savedGwei >= 0 {
+		m.batcherTxSavedGwei.Add(savedGwei)
+	} else {
+		m.batcherTxExtraGwei.Add(-savedGwei)
+	}
+}
+
 // estimateBatchSize estimates the size of the batch
 func estimateBatchSize(block *types.Block) uint64 {
 	size := uint64(70) // estimated overhead of batch metadata
@@ -2,11 +2,61 @@ package rpc
 
 import (
 	"context"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
+// FrameStatus is the submission status of a frame of a channel.
+type FrameStatus string
+
+const (
+	// FrameQueued frames are waiting to be submitted.
+	FrameQueued FrameStatus = "queued"
+	// FramePending frames are submitted, but not yet confirmed.
+	FramePending FrameStatus = "pending"
+	// FrameConfirmed frames are included on L1.
+	FrameConfirmed FrameStatus = "confirmed"
+)
+
+// FrameInfo describes a frame of a channel that was output by the channel builder.
+type FrameInfo struct {
+	Number uint16      `json:"number"`
+	Status FrameStatus `json:"status"`
+	// InclusionBlock is the L1 block the frame was included in, if it is confirmed.
+	InclusionBlock *eth.BlockID `json:"inclusionBlock,omitempty"`
+}
+
+// ChannelInfo describes a channel of the channel manager.
+type ChannelInfo struct {
+	ID derive.ChannelID `json:"id"`
+	// Current is true for the channel that new blocks are added to.
+	Current bool `json:"current"`
+	// Full is true if the channel is closed. FullReason gives the reason.
+	Full       bool   `json:"full"`
+	FullReason string `json:"fullReason,omitempty"`
+	// Blocks is the number of L2 blocks added to the channel.
+	Blocks      int         `json:"blocks"`
+	InputBytes  int         `json:"inputBytes"`
+	OutputBytes int         `json:"outputBytes"`
+	Frames      []FrameInfo `json:"frames"`
+}
+
+// ChannelsResponse is the state of the channel manager.
+type ChannelsResponse struct {
+	Channels []ChannelInfo `json:"channels"`
+	// PendingBlocks is the number of L2 blocks that are not yet added to a channel.
+	PendingBlocks int `json:"pendingBlocks"`
+}
+
 type batcherClient interface {
 	Start() error
 	Stop(ctx context.Context) error
+	ListChannels() ChannelsResponse
+	ForceCloseChannel() (derive.ChannelID, error)
+	Flush(ctx context.Context) error
+	SetMaxL1TxSize(size uint64) error
+	SetApproxComprRatio(ratio float64) error
 }
 
 type adminAPI struct {
@@ -26,3 +76,33 @@ func (a *adminAPI) StartBatcher(_ context.Context) error {
 func (a *adminAPI) StopBatcher(ctx context.Context) error {
 	return a.b.Stop(ctx)
 }
+
+// ListChannels returns the open and pending channels, with the status of their frames.
+func (a *adminAPI) ListChannels(_ context.Context) (ChannelsResponse, error) {
+	return a.b.ListChannels(), nil
+}
+
+// ForceCloseChannel closes the current channel, so that its remaining frames are submitted
+// right away. It returns the ID of the closed channel.
+func (a *adminAPI) ForceCloseChannel(_ context.Context) (derive.ChannelID, error) {
+	return a.b.ForceCloseChannel()
+}
+
+// Flush loads the latest L2 blocks and submits all pending data immediately, ignoring the max
+// channel duration.
+func (a *adminAPI) Flush(ctx context.Context) error {
+	return a.b.Flush(ctx)
+}
+
+// SetMaxL1TxSize changes the max size of batcher transactions, for all channels that are
+// opened afterwards. Their frames are also targeted at the new size. The change is not
+// persisted across restarts.
+func (a *adminAPI) SetMaxL1TxSize(_ context.Context, size uint64) error {
+	return a.b.SetMaxL1TxSize(size)
+}
+
+// SetApproxComprRatio changes the approximate compression ratio of the ratio compressor, for all
+// channels that are opened afterwards. The change is not persisted across restarts.
+func (a *adminAPI) SetApproxComprRatio(_ context.Context, ratio float64) error {
+	return a.b.SetApproxComprRatio(ratio)
+}
@@ -30,7 +30,7 @@ var (
 
 // PreimageOracleMetaData contains all meta data concerning the PreimageOracle contract.
 var PreimageOracleMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[],\"name\":\"PartOffsetOOB\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partOffset\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"key\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"part\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"size\",\"type\":\"uint256\"}],\"name\":\"cheat\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_partOffset\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_preimage\",\"type\":\"bytes\"}],\"name\":\"loadKeccak256PreimagePart\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_ident\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"_word\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_size\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_partOffset\",\"type\":\"uint256\"}],\"name\":\"loadLocalData\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"key_\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"preimageLengths\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"preimagePartOk\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"preimageParts\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_key\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_offset\",\"type\":\"uint256\"}],\"name\":\"readPreimage\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"dat_\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"datLen_\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
+	ABI: "[{\"inputs\":[],\"name\":\"InvalidInputOffset\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"InvalidInputSize\",\"type\":\"error\"},{\"inputs\":[],\"name\":\"PartOffsetOOB\",\"type\":\"error\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_uuid\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_offset\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_input\",\"type\":\"bytes\"},{\"internalType\":\"bool\",\"name\":\"_finalize\",\"type\":\"bool\"}],\"name\":\"addLargeKeccak256PreimageData\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"partOffset\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"key\",\"type\":\"bytes32\"},{\"internalType\":\"bytes32\",\"name\":\"part\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"size\",\"type\":\"uint256\"}],\"name\":\"cheat\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_uuid\",\"type\":\"uint256\"},{\"internalType\":\"uint64\",\"name\":\"_partOffset\",\"type\":\"uint64\"},{\"internalType\":\"uint64\",\"name\":\"_claimedSize\",\"type\":\"uint64\"}],\"name\":\"initLargeKeccak256Preimage\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_proposer\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"_uuid\",\"type\":\"uint256\"}],\"name\":\"largePreimageBytesProcessed\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"bytesProcessed_\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_partOffset\",\"type\":\"uint256\"},{\"internalType\":\"bytes\",\"name\":\"_preimage\",\"type\":\"bytes\"}],\"name\":\"loadKeccak256PreimagePart\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"_ident\",\"type\":\"uint256\"},{\"internalType\":\"bytes32\",\"name\":\"_word\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_size\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"_partOffset\",\"type\":\"uint256\"}],\"name\":\"loadLocalData\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"key_\",\"type\":\"bytes32\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"name\":\"preimageLengths\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"preimagePartOk\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"preimageParts\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"_key\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"_offset\",\"type\":\"uint256\"}],\"name\":\"readPreimage\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"dat_\",\"type\":\"bytes32\"},{\"internalType\":\"uint256\",\"name\":\"datLen_\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"}]",
 	Bin: "0x608060405234801561001057600080fd5b506106a5806100206000396000f3fe608060405234801561001057600080fd5b506004361061007d5760003560e01c8063e03110e11161005b578063e03110e114610111578063e159261114610139578063fe4ac08e1461014e578063fef2b4ed146101c357600080fd5b806361238bde146100825780638542cf50146100c05780639a1f5e7f146100fe575b600080fd5b6100ad610090366004610551565b600160209081526000928352604080842090915290825290205481565b6040519081526020015b60405180910390f35b6100ee6100ce366004610551565b600260209081526000928352604080842090915290825290205460ff1681565b60405190151581526020016100b7565b6100ad61010c366004610573565b6101e3565b61012461011f366004610551565b6102b6565b604080519283526020830191909152016100b7565b61014c6101473660046105a5565b6103a7565b005b61014c61015c366004610573565b6000838152600260209081526040808320878452825280832080547fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff001660019081179091558684528252808320968352958152858220939093559283529082905291902055565b6100ad6101d1366004610621565b60006020819052908152604090205481565b60006101ee856104b0565b90506101fb836008610669565b8211806102085750602083115b1561023f576040517ffe25498700000000000000000000000000000000000000000000000000000000815260040160405180910390fd5b6000602081815260c085901b82526008959095528251828252600286526040808320858452875280832080547fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff0016600190811790915584845287528083209483529386528382205581815293849052922055919050565b6000828152600260209081526040808320848452909152812054819060ff1661033f576040517f08c379a000000000000000000000000000000000000000000000000000000000815260206004820152601460248201527f7072652d696d616765206d757374206578697374000000000000000000000000604482015260640160405180910390fd5b506000838152602081815260409091205461035b816008610669565b610366856020610669565b106103845783610377826008610669565b6103819190610681565b91505b506000938452600160209081526040808620948652939052919092205492909150565b604435600080600883018611156103c65763fe2549876000526004601cfd5b60c083901b6080526088838682378087017ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80151908490207effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff167f02000000000000000000000000000000000000000000000000000000000000001760008181526002602090815260408083208b8452825280832080547fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff0016600190811790915584845282528083209a83529981528982209390935590815290819052959095209190915550505050565b7f01000000000000000000000000000000000000000000000000000000000000007effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff82161761054b81600090815233602052604090207effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff167f01000000000000000000000000000000000000000000000000000000000000001790565b92915050565b6000806040838503121561056457600080fd5b50508035926020909101359150565b6000806000806080858703121561058957600080fd5b5050823594602084013594506040840135936060013592509050565b6000806000604084860312156105ba57600080fd5b83359250602084013567ffffffffffffffff808211156105d957600080fd5b818601915086601f8301126105ed57600080fd5b8135818111156105fc57600080fd5b87602082850101111561060e57600080fd5b6020830194508093505050509250925092565b60006020828403121561063357600080fd5b5035919050565b7f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b6000821982111561067c5761067c61063a565b500190565b6000828210156106935761069361063a565b50039056fea164736f6c634300080f000a",
 }
 
@@ -201,6 +201,37 @@ func (_PreimageOracle *PreimageOracleTransactorRaw) Transact(opts *bind.Transact
 	return _PreimageOracle.Contract.contract.Transact(opts, method, params...)
 }
 
+// LargePreimageBytesProcessed is a free data retrieval call binding the contract method 0xf3a66bc0.
+//
+// Solidity: function largePreimageBytesProcessed(address _proposer, uint256 _uuid) view returns(uint256 bytesProcessed_)
+func (_PreimageOracle *PreimageOracleCaller) LargePreimageBytesProcessed(opts *bind.CallOpts, _proposer common.Address, _uuid *big.Int) (*big.Int, error) {
+	var out []interface{}
+	err := _PreimageOracle.contract.Call(opts, &out, "largePreimageBytesProcessed", _proposer, _uuid)
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// LargePreimageBytesProcessed is a free data retrieval call binding the contract method 0xf3a66bc0.
+//
+// Solidity: function largePreimageBytesProcessed(address _proposer, uint256 _uuid) view returns(uint256 bytesProcessed_)
+func (_PreimageOracle *PreimageOracleSession) LargePreimageBytesProcessed(_proposer common.Address, _uuid *big.Int) (*big.Int, error) {
+	return _PreimageOracle.Contract.LargePreimageBytesProcessed(&_PreimageOracle.CallOpts, _proposer, _uuid)
+}
+
+// LargePreimageBytesProcessed is a free data retrieval call binding the contract method 0xf3a66bc0.
+//
+// Solidity: function largePreimageBytesProcessed(address _proposer, uint256 _uuid) view returns(uint256 bytesProcessed_)
+func (_PreimageOracle *PreimageOracleCallerSession) LargePreimageBytesProcessed(_proposer common.Address, _uuid *big.Int) (*big.Int, error) {
+	return _PreimageOracle.Contract.LargePreimageBytesProcessed(&_PreimageOracle.CallOpts, _proposer, _uuid)
+}
+
 // PreimageLengths is a free data retrieval call binding the contract method 0xfef2b4ed.
 //
 // Solidity: function preimageLengths(bytes32 ) view returns(uint256)
@@ -339,6 +370,27 @@ func (_PreimageOracle *PreimageOracleCallerSession) ReadPreimage(_key [32]byte,
 	return _PreimageOracle.Contract.ReadPreimage(&_PreimageOracle.CallOpts, _key, _offset)
 }
 
+// AddLargeKeccak256PreimageData is a paid mutator transaction binding the contract method 0x7b56666f.
+//
+// Solidity: function addLargeKeccak256PreimageData(uint256 _uuid, uint256 _offset, bytes _input, bool _finalize) returns()
+func (_PreimageOracle *PreimageOracleTransactor) AddLargeKeccak256PreimageData(opts *bind.TransactOpts, _uuid *big.Int, _offset *big.Int, _input []byte, _finalize bool) (*types.Transaction, error) {
+	return _PreimageOracle.contract.Transact(opts, "addLargeKeccak256PreimageData", _uuid, _offset, _input, _finalize)
+}
+
+// AddLargeKeccak256PreimageData is a paid mutator transaction binding the contract method 0x7b56666f.
+//
+// Solidity: function addLargeKeccak256PreimageData(uint256 _uuid, uint256 _offset, bytes _input, bool _finalize) returns()
+func (_PreimageOracle *PreimageOracleSession) AddLargeKeccak256PreimageData(_uuid *big.Int, _offset *big.Int, _input []byte, _finalize bool) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.AddLargeKeccak256PreimageData(&_PreimageOracle.TransactOpts, _uuid, _offset, _input, _finalize)
+}
+
+// AddLargeKeccak256PreimageData is a paid mutator transaction binding the contract method 0x7b56666f.
+//
+// Solidity: function addLargeKeccak256PreimageData(uint256 _uuid, uint256 _offset, bytes _input, bool _finalize) returns()
+func (_PreimageOracle *PreimageOracleTransactorSession) AddLargeKeccak256PreimageData(_uuid *big.Int, _offset *big.Int, _input []byte, _finalize bool) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.AddLargeKeccak256PreimageData(&_PreimageOracle.TransactOpts, _uuid, _offset, _input, _finalize)
+}
+
 // Cheat is a paid mutator transaction binding the contract method 0xfe4ac08e.
 //
 // Solidity: function cheat(uint256 partOffset, bytes32 key, bytes32 part, uint256 size) returns()
@@ -360,6 +412,27 @@ func (_PreimageOracle *PreimageOracleTransactorSession) Cheat(partOffset *big.In
 	return _PreimageOracle.Contract.Cheat(&_PreimageOracle.TransactOpts, partOffset, key, part, size)
 }
 
+// InitLargeKeccak256Preimage is a paid mutator transaction binding the contract method 0x2c4ca6e5.
+//
+// Solidity: function initLargeKeccak256Preimage(uint256 _uuid, uint64 _partOffset, uint64 _claimedSize) returns()
+func (_PreimageOracle *PreimageOracleTransactor) InitLargeKeccak256Preimage(opts *bind.TransactOpts, _uuid *big.Int, _partOffset uint64, _claimedSize uint64) (*types.Transaction, error) {
+	return _PreimageOracle.contract.Transact(opts, "initLargeKeccak256Preimage", _uuid, _partOffset, _claimedSize)
+}
+
+// InitLargeKeccak256Preimage is a paid mutator transaction binding the contract method 0x2c4ca6e5.
+//
+// Solidity: function initLargeKeccak256Preimage(uint256 _uuid, uint64 _partOffset, uint64 _claimedSize) returns()
+func (_PreimageOracle *PreimageOracleSession) InitLargeKeccak256Preimage(_uuid *big.Int, _partOffset uint64, _claimedSize uint64) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.InitLargeKeccak256Preimage(&_PreimageOracle.TransactOpts, _uuid, _partOffset, _claimedSize)
+}
+
+// InitLargeKeccak256Preimage is a paid mutator transaction binding the contract method 0x2c4ca6e5.
+//
+// Solidity: function initLargeKeccak256Preimage(uint256 _uuid, uint64 _partOffset, uint64 _claimedSize) returns()
+func (_PreimageOracle *PreimageOracleTransactorSession) InitLargeKeccak256Preimage(_uuid *big.Int, _partOffset uint64, _claimedSize uint64) (*types.Transaction, error) {
+	return _PreimageOracle.Contract.InitLargeKeccak256Preimage(&_PreimageOracle.TransactOpts, _uuid, _partOffset, _claimedSize)
+}
+
 // LoadKeccak256PreimagePart is a paid mutator transaction binding the contract method 0xe1592611.
 //
 // Solidity: function loadKeccak256PreimagePart(uint256 _partOffset, bytes _preimage) returns()
@@ -109,6 +109,18 @@ type DeployConfig struct {
 	// L2GenesisRegolithTimeOffset is the number of seconds after genesis block that Regolith hard fork activates.
 	// Set it to 0 to activate at genesis. Nil to disable regolith.
 	L2GenesisRegolithTimeOffset *hexutil.Uint64 `json:"l2GenesisRegolithTimeOffset,omitempty"`
+	// L2GenesisCanyonTimeOffset is the number of seconds after genesis block that the Canyon hard fork activates.
+	// Set it to 0 to activate at genesis. Nil to disable Canyon.
+	L2GenesisCanyonTimeOffset *hexutil.Uint64 `json:"l2GenesisCanyonTimeOffset,omitempty"`
+	// L2GenesisDeltaTimeOffset is the number of seconds after genesis block that the Delta hard fork activates.
+	// Set it to 0 to activate at genesis. Nil to disable Delta.
+	L2GenesisDeltaTimeOffset *hexutil.Uint64 `json:"l2GenesisDeltaTimeOffset,omitempty"`
+	// L2GenesisFjordTimeOffset is the number of seconds after genesis block that the Fjord hard fork activates.
+	// Set it to 0 to activate at genesis. Nil to disable Fjord.
+	L2GenesisFjordTimeOffset *hexutil.Uint64 `json:"l2GenesisFjordTimeOffset,omitempty"`
+	// UseAltDA enables the alt-DA mode, in which the batcher posts commitments to frame data
+	// stored on a DA server, instead of the frame data itself.
+	UseAltDA bool `json:"useAltDA,omitempty"`
 	// L2GenesisBlockExtraData is configurable extradata. Will default to []byte("BEDROCK") if left unspecified.
 	L2GenesisBlockExtraData []byte `json:"l2GenesisBlockExtraData"`
 	// ProxyAdminOwner represents the owner of the ProxyAdmin predeploy on L2.
@@ -430,17 +442,54 @@ func (d *DeployConfig) GetDeployedAddresses(hh *hardhat.Hardhat) error {
 	return nil
 }
 
-func (d *DeployConfig) RegolithTime(genesisTime uint64) *uint64 {
-	if d.L2GenesisRegolithTimeOffset == nil {
+// forkTimeOffsets maps the network upgrades of the rollup.Forks schedule to their activation offsets.
+// Forks without an offset in the deploy config are not scheduled.
+func (d *DeployConfig) forkTimeOffsets() map[rollup.ForkName]*hexutil.Uint64 {
+	return map[rollup.ForkName]*hexutil.Uint64{
+		rollup.Regolith: d.L2GenesisRegolithTimeOffset,
+		rollup.Canyon:   d.L2GenesisCanyonTimeOffset,
+		rollup.Delta:    d.L2GenesisDeltaTimeOffset,
+		rollup.Fjord:    d.L2GenesisFjordTimeOffset,
+	}
+}
+
+// ForkTime returns the activation time of the given fork, for the given L2 genesis time,
+// or nil if the fork is not scheduled.
+func (d *DeployConfig) ForkTime(fork rollup.ForkName, genesisTime uint64) *uint64 {
+	offset := d.forkTimeOffsets()[fork]
+	if offset == nil {
 		return nil
 	}
 	v := uint64(0)
-	if offset := *d.L2GenesisRegolithTimeOffset; offset > 0 {
-		v = genesisTime + uint64(offset)
+	if *offset > 0 {
+		v = genesisTime + uint64(*offset)
 	}
 	return &v
 }
 
+// SetForkTimes schedules the forks of the rollup config, for the given L2 genesis time.
+func (d *DeployConfig) SetForkTimes(cfg *rollup.Config, genesisTime uint64) {
+	for _, fork := range rollup.Forks {
+		fork.SetTime(cfg, d.ForkTime(fork.Name, genesisTime))
+	}
+}
+
+func (d *DeployConfig) RegolithTime(genesisTime uint64) *uint64 {
+	return d.ForkTime(rollup.Regolith, genesisTime)
+}
+
+func (d *DeployConfig) CanyonTime(genesisTime uint64) *uint64 {
+	return d.ForkTime(rollup.Canyon, genesisTime)
+}
+
+func (d *DeployConfig) DeltaTime(genesisTime uint64) *uint64 {
+	return d.ForkTime(rollup.Delta, genesisTime)
+}
+
+func (d *DeployConfig) FjordTime(genesisTime uint64) *uint64 {
+	return d.ForkTime(rollup.Fjord, genesisTime)
+}
+
 // RollupConfig converts a DeployConfig to a rollup.Config
 func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHash common.Hash, l2GenesisBlockNumber uint64) (*rollup.Config, error) {
 	if d.OptimismPortalProxy == (common.Address{}) {
@@ -450,7 +499,7 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
 		return nil, errors.New("SystemConfigProxy cannot be address(0)")
 	}
 
-	return &rollup.Config{
+	cfg := &rollup.Config{
 		Genesis: rollup.Genesis{
 			L1: eth.BlockID{
 				Hash:   l1StartBlock.Hash(),
@@ -477,8 +526,10 @@ func (d *DeployConfig) RollupConfig(l1StartBlock *types.Block, l2GenesisBlockHas
 		BatchInboxAddress:      d.BatchInboxAddress,
 		DepositContractAddress: d.OptimismPortalProxy,
 		L1SystemConfigAddress:  d.SystemConfigProxy,
-		RegolithTime:           d.RegolithTime(l1StartBlock.Time()),
-	}, nil
+		UseAltDA:               d.UseAltDA,
+	}
+	d.SetForkTimes(cfg, l1StartBlock.Time())
+	return cfg, nil
 }
 
 // NewDeployConfig reads a config file given a path on the filesystem.
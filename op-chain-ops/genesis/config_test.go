@@ -12,6 +12,8 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 )
 
 func TestConfigDataMarshalUnmarshal(t *testing.T) {
@@ -49,6 +51,31 @@ func TestRegolithTimeAsOffset(t *testing.T) {
 	require.Equal(t, uint64(1500+5000), *config.RegolithTime(5000))
 }
 
+// TestForkTimeOffsets ensures that every fork of the rollup.Forks schedule has an activation
+// offset in the deploy config, which is used to schedule it.
+func TestForkTimeOffsets(t *testing.T) {
+	offset := func(v uint64) *hexutil.Uint64 {
+		return (*hexutil.Uint64)(&v)
+	}
+	config := &DeployConfig{
+		L2GenesisRegolithTimeOffset: offset(1),
+		L2GenesisCanyonTimeOffset:   offset(2),
+		L2GenesisDeltaTimeOffset:    offset(3),
+		L2GenesisFjordTimeOffset:    offset(4),
+	}
+	offsets := config.forkTimeOffsets()
+	require.Len(t, offsets, len(rollup.Forks))
+	for _, fork := range rollup.Forks {
+		require.Contains(t, offsets, fork.Name, "missing time offset of fork %s", fork.Name)
+	}
+
+	rollupCfg := &rollup.Config{}
+	config.SetForkTimes(rollupCfg, 1000)
+	for i, fork := range rollup.Forks {
+		require.Equal(t, uint64(1000+i+1), *fork.Time(rollupCfg), "fork %s", fork.Name)
+	}
+}
+
 // TestCopy will copy a DeployConfig and ensure that the copy is equal to the original.
 func TestCopy(t *testing.T) {
 	b, err := os.ReadFile("testdata/test-deploy-config-full.json")
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// claimInfo is the description of a claim that is output by the list-claims and visualize subcommands.
+type claimInfo struct {
+	Index          int          `json:"index"`
+	ParentIndex    *int         `json:"parentIndex,omitempty"`
+	Value          common.Hash  `json:"value"`
+	Move           string       `json:"move"`
+	Depth          int          `json:"depth"`
+	IndexAtDepth   int          `json:"indexAtDepth"`
+	GIndex         uint64       `json:"gindex"`
+	TraceIndex     uint64       `json:"traceIndex"`
+	Countered      bool         `json:"countered"`
+	ClockDuration  uint64       `json:"clockDuration"`
+	ClockTimestamp uint64       `json:"clockTimestamp"`
+	Children       []*claimInfo `json:"children,omitempty"`
+}
+
+// newClaimInfos converts the claims of a game, in contract order, to their descriptions and links each claim to
+// its children. The first claim is the root of the tree.
+func newClaimInfos(claims []types.Claim, maxDepth int) []*claimInfo {
+	infos := make([]*claimInfo, len(claims))
+	byIndex := make(map[int]*claimInfo, len(claims))
+	for i, claim := range claims {
+		info := &claimInfo{
+			Index:          claim.ContractIndex,
+			Value:          claim.Value,
+			Move:           "root",
+			Depth:          claim.Depth(),
+			IndexAtDepth:   claim.IndexAtDepth(),
+			GIndex:         claim.ToGIndex(),
+			TraceIndex:     claim.TraceIndex(maxDepth),
+			Countered:      claim.Countered,
+			ClockDuration:  uint64(claim.Clock.Duration / time.Second),
+			ClockTimestamp: uint64(claim.Clock.Timestamp.Unix()),
+		}
+		if !claim.IsRoot() {
+			parentIdx := claim.ParentContractIndex
+			info.ParentIndex = &parentIdx
+			if claim.Position == claim.Parent.Position.Attack() {
+				info.Move = "attack"
+			} else {
+				info.Move = "defend"
+			}
+		}
+		infos[i] = info
+		byIndex[info.Index] = info
+	}
+	for _, info := range infos {
+		if info.ParentIndex == nil {
+			continue
+		}
+		if parent, ok := byIndex[*info.ParentIndex]; ok {
+			parent.Children = append(parent.Children, info)
+		}
+	}
+	return infos
+}
+
+// writeTree draws the tree of claims starting from root, describing each claim with label.
+func writeTree(out io.Writer, root *claimInfo, label func(*claimInfo) string) error {
+	if _, err := fmt.Fprintln(out, label(root)); err != nil {
+		return err
+	}
+	return writeChildren(out, root, "", label)
+}
+
+func writeChildren(out io.Writer, parent *claimInfo, prefix string, label func(*claimInfo) string) error {
+	for i, child := range parent.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(parent.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+		if _, err := fmt.Fprintln(out, prefix+branch+label(child)); err != nil {
+			return err
+		}
+		if err := writeChildren(out, child, prefix+indent, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shortLabel describes a claim by its index, move and value.
+func shortLabel(info *claimInfo) string {
+	label := fmt.Sprintf("#%d %s %s", info.Index, info.Move, info.Value.Hex())
+	if info.Countered {
+		label += " (countered)"
+	}
+	return label
+}
+
+// detailedLabel describes a claim including its position, trace index and clock.
+func detailedLabel(info *claimInfo) string {
+	var label strings.Builder
+	fmt.Fprintf(&label, "#%d %s %s position=(%d,%d) gindex=%d trace=%d clock=%v@%v",
+		info.Index, info.Move, info.Value.Hex(),
+		info.Depth, info.IndexAtDepth, info.GIndex, info.TraceIndex,
+		time.Duration(info.ClockDuration)*time.Second, time.Unix(int64(info.ClockTimestamp), 0).UTC().Format(time.RFC3339))
+	if info.Countered {
+		label.WriteString(" countered")
+	}
+	return label.String()
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const testMaxDepth = 4
+
+func testClaims() []types.Claim {
+	root := types.Claim{
+		ClaimData: types.ClaimData{Value: common.Hash{0xaa}, Position: types.NewPosition(0, 0)},
+		Countered: true,
+		Clock:     types.NewClock(0, 1_700_000_000),
+	}
+	attack := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0xbb}, Position: root.Attack()},
+		Parent:              root.ClaimData,
+		Clock:               types.NewClock(0, 1_700_000_060),
+		ContractIndex:       1,
+		ParentContractIndex: 0,
+	}
+	defend := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0xcc}, Position: attack.Attack().Defend()},
+		Parent:              types.ClaimData{Value: common.Hash{0xdd}, Position: attack.Attack()},
+		Clock:               types.NewClock(60, 1_700_000_120),
+		ContractIndex:       3,
+		ParentContractIndex: 2,
+	}
+	counter := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0xdd}, Position: attack.Attack()},
+		Parent:              attack.ClaimData,
+		Clock:               types.NewClock(60, 1_700_000_090),
+		ContractIndex:       2,
+		ParentContractIndex: 1,
+	}
+	return []types.Claim{root, attack, counter, defend}
+}
+
+func TestNewClaimInfos(t *testing.T) {
+	infos := newClaimInfos(testClaims(), testMaxDepth)
+	require.Len(t, infos, 4)
+
+	root := infos[0]
+	require.Nil(t, root.ParentIndex)
+	require.Equal(t, "root", root.Move)
+	require.Equal(t, uint64(1), root.GIndex)
+	require.Equal(t, uint64(15), root.TraceIndex)
+	require.True(t, root.Countered)
+	require.Equal(t, []*claimInfo{infos[1]}, root.Children)
+
+	attack := infos[1]
+	require.Equal(t, 0, *attack.ParentIndex)
+	require.Equal(t, "attack", attack.Move)
+	require.Equal(t, 1, attack.Depth)
+	require.Equal(t, uint64(7), attack.TraceIndex)
+	require.Equal(t, []*claimInfo{infos[2]}, attack.Children)
+
+	defend := infos[3]
+	require.Equal(t, 2, *defend.ParentIndex)
+	require.Equal(t, "defend", defend.Move)
+	require.Equal(t, uint64(60), defend.ClockDuration)
+	require.Equal(t, uint64(1_700_000_120), defend.ClockTimestamp)
+	require.Equal(t, []*claimInfo{defend}, infos[2].Children)
+}
+
+func TestWriteTree(t *testing.T) {
+	infos := newClaimInfos(testClaims(), testMaxDepth)
+	var out bytes.Buffer
+	require.NoError(t, writeTree(&out, infos[0], func(info *claimInfo) string {
+		return info.Move
+	}))
+	expected := strings.Join([]string{
+		"root",
+		"└── attack",
+		"    └── attack",
+		"        └── defend",
+		"",
+	}, "\n")
+	require.Equal(t, expected, out.String())
+}
+
+func TestWriteTreeWithSiblings(t *testing.T) {
+	root := &claimInfo{Index: 0}
+	first := &claimInfo{Index: 1}
+	second := &claimInfo{Index: 2}
+	nested := &claimInfo{Index: 3}
+	root.Children = []*claimInfo{first, second}
+	first.Children = []*claimInfo{nested}
+
+	var out bytes.Buffer
+	require.NoError(t, writeTree(&out, root, func(info *claimInfo) string {
+		return string(rune('a' + info.Index))
+	}))
+	expected := strings.Join([]string{
+		"a",
+		"├── b",
+		"│   └── d",
+		"└── c",
+		"",
+	}, "\n")
+	require.Equal(t, expected, out.String())
+}
+
+func TestLabels(t *testing.T) {
+	infos := newClaimInfos(testClaims(), testMaxDepth)
+	require.Equal(t, "#0 root "+common.Hash{0xaa}.Hex()+" (countered)", shortLabel(infos[0]))
+	require.Equal(t, "#1 attack "+common.Hash{0xbb}.Hex(), shortLabel(infos[1]))
+
+	created := time.Unix(1_700_000_120, 0).UTC().Format(time.RFC3339)
+	require.Equal(t, "#3 defend "+common.Hash{0xcc}.Hex()+" position=(3,2) gindex=10 trace=5 clock=1m0s@"+created, detailedLabel(infos[3]))
+}
+
+func TestClaimInfoJSON(t *testing.T) {
+	infos := newClaimInfos(testClaims(), testMaxDepth)
+	var out bytes.Buffer
+	require.NoError(t, writeJSON(&out, infos[0]))
+
+	var decoded claimInfo
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	require.Equal(t, common.Hash{0xaa}, decoded.Value)
+	require.Len(t, decoded.Children, 1)
+	require.Equal(t, "attack", decoded.Children[0].Move)
+	require.Len(t, decoded.Children[0].Children, 1)
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var ListClaimsCommand = &cli.Command{
+	Name:        "list-claims",
+	Usage:       "List the claims in a dispute game",
+	Description: "Lists the claims in a dispute game, either as a tree of claims or as JSON in contract order.",
+	Action:      ListClaims,
+	Flags:       listClaimsFlags(),
+}
+
+func listClaimsFlags() []cli.Flag {
+	cliFlags := []cli.Flag{
+		flags.L1EthRpcFlag,
+		GameAddressFlag,
+		FormatFlag,
+	}
+	return append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
+}
+
+func ListClaims(ctx *cli.Context) error {
+	if err := checkRequired(ctx, flags.L1EthRpcFlag, GameAddressFlag); err != nil {
+		return err
+	}
+	format, err := parseFormat(ctx)
+	if err != nil {
+		return err
+	}
+	gameAddr, err := parseAddress(ctx, GameAddressFlag)
+	if err != nil {
+		return err
+	}
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	l1Client, err := dialL1(ctx, logger)
+	if err != nil {
+		return err
+	}
+	defer l1Client.Close()
+
+	loader, err := fault.NewLoaderFromBindings(gameAddr, l1Client)
+	if err != nil {
+		return fmt.Errorf("failed to bind game %v: %w", gameAddr, err)
+	}
+	maxDepth, err := loader.FetchGameDepth(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to fetch game depth: %w", err)
+	}
+	claims, err := loader.FetchClaims(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to fetch claims: %w", err)
+	}
+	infos := newClaimInfos(claims, int(maxDepth))
+
+	out := ctx.App.Writer
+	if format == formatJSON {
+		// Claims are listed in contract order, so omit the nested children
+		flat := make([]claimInfo, len(infos))
+		for i, info := range infos {
+			flat[i] = *info
+			flat[i].Children = nil
+		}
+		return writeJSON(out, flat)
+	}
+	if _, err := fmt.Fprintf(out, "Claim count: %d\n", len(infos)); err != nil {
+		return err
+	}
+	if len(infos) == 0 {
+		return nil
+	}
+	return writeTree(out, infos[0], shortLabel)
+}
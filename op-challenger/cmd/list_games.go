@@ -0,0 +1,167 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var ListGamesCommand = &cli.Command{
+	Name:        "list-games",
+	Usage:       "List the games created by a dispute game factory",
+	Description: "Lists the games created by a dispute game factory, either as a tree grouped by game type or as JSON.",
+	Action:      ListGames,
+	Flags:       listGamesFlags(),
+}
+
+func listGamesFlags() []cli.Flag {
+	cliFlags := []cli.Flag{
+		flags.L1EthRpcFlag,
+		flags.FactoryAddressFlag,
+		FormatFlag,
+	}
+	return append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
+}
+
+// gameInfo is the description of a game that is output by the list-games subcommand.
+type gameInfo struct {
+	Index     uint64         `json:"index"`
+	Address   common.Address `json:"address"`
+	GameType  uint8          `json:"gameType"`
+	Timestamp uint64         `json:"timestamp"`
+	Status    string         `json:"status"`
+	Claims    uint64         `json:"claims"`
+}
+
+func ListGames(ctx *cli.Context) error {
+	if err := checkRequired(ctx, flags.L1EthRpcFlag, flags.FactoryAddressFlag); err != nil {
+		return err
+	}
+	format, err := parseFormat(ctx)
+	if err != nil {
+		return err
+	}
+	factoryAddr, err := parseAddress(ctx, flags.FactoryAddressFlag)
+	if err != nil {
+		return err
+	}
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	l1Client, err := dialL1(ctx, logger)
+	if err != nil {
+		return err
+	}
+	defer l1Client.Close()
+
+	games, err := fetchGames(ctx.Context, l1Client, factoryAddr)
+	if err != nil {
+		return err
+	}
+
+	out := ctx.App.Writer
+	if format == formatJSON {
+		return writeJSON(out, games)
+	}
+	if _, err := fmt.Fprintf(out, "Game count: %d\n", len(games)); err != nil {
+		return err
+	}
+	return writeGameTree(out, games)
+}
+
+// fetchGames loads all games created by the factory, in the order they were created.
+func fetchGames(ctx context.Context, l1Client *ethclient.Client, factoryAddr common.Address) ([]gameInfo, error) {
+	factory, err := bindings.NewDisputeGameFactoryCaller(factoryAddr, l1Client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind the dispute game factory contract: %w", err)
+	}
+	head, err := l1Client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch L1 head: %w", err)
+	}
+	// Games are returned newest first
+	metadata, err := game.NewGameLoader(factory).FetchAllGamesAtBlock(ctx, 0, new(big.Int).SetUint64(head))
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]gameInfo, len(metadata))
+	for i, game := range metadata {
+		info, err := fetchGameInfo(ctx, l1Client, game)
+		if err != nil {
+			return nil, err
+		}
+		info.Index = uint64(len(metadata) - i - 1)
+		infos[info.Index] = info
+	}
+	return infos, nil
+}
+
+func fetchGameInfo(ctx context.Context, l1Client *ethclient.Client, game types.GameMetadata) (gameInfo, error) {
+	loader, err := fault.NewLoaderFromBindings(game.Proxy, l1Client)
+	if err != nil {
+		return gameInfo{}, fmt.Errorf("failed to bind game %v: %w", game.Proxy, err)
+	}
+	status, err := loader.GetGameStatus(ctx)
+	if err != nil {
+		return gameInfo{}, fmt.Errorf("failed to fetch status of game %v: %w", game.Proxy, err)
+	}
+	claims, err := loader.GetClaimCount(ctx)
+	if err != nil {
+		return gameInfo{}, fmt.Errorf("failed to fetch claim count of game %v: %w", game.Proxy, err)
+	}
+	return gameInfo{
+		Address:   game.Proxy,
+		GameType:  game.GameType,
+		Timestamp: game.Timestamp,
+		Status:    status.String(),
+		Claims:    claims,
+	}, nil
+}
+
+// writeGameTree writes the games grouped by their game type.
+func writeGameTree(out io.Writer, games []gameInfo) error {
+	var gameTypes []uint8
+	byType := make(map[uint8][]gameInfo)
+	for _, game := range games {
+		if _, ok := byType[game.GameType]; !ok {
+			gameTypes = append(gameTypes, game.GameType)
+		}
+		byType[game.GameType] = append(byType[game.GameType], game)
+	}
+	for _, gameType := range gameTypes {
+		name, ok := config.GameIdToString[gameType]
+		if !ok {
+			name = "Unknown"
+		}
+		if _, err := fmt.Fprintf(out, "%s (%d)\n", name, gameType); err != nil {
+			return err
+		}
+		typeGames := byType[gameType]
+		for i, game := range typeGames {
+			branch := "├── "
+			if i == len(typeGames)-1 {
+				branch = "└── "
+			}
+			created := time.Unix(int64(game.Timestamp), 0).UTC().Format(time.RFC3339)
+			if _, err := fmt.Fprintf(out, "%s#%d %v Created: %v Claims: %d Status: %v\n", branch, game.Index, game.Address, created, game.Claims, game.Status); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteGameTree(t *testing.T) {
+	games := []gameInfo{
+		{Index: 0, Address: common.Address{0xaa}, GameType: config.CannonFaultGameID, Timestamp: 1_700_000_000, Status: "Defender Won", Claims: 3},
+		{Index: 1, Address: common.Address{0xbb}, GameType: config.AlphabetFaultGameID, Timestamp: 1_700_000_060, Status: "In Progress", Claims: 1},
+		{Index: 2, Address: common.Address{0xcc}, GameType: config.CannonFaultGameID, Timestamp: 1_700_000_120, Status: "In Progress", Claims: 5},
+		{Index: 3, Address: common.Address{0xdd}, GameType: 42, Timestamp: 1_700_000_180, Status: "In Progress", Claims: 1},
+	}
+	var out bytes.Buffer
+	require.NoError(t, writeGameTree(&out, games))
+
+	created := func(ts uint64) string {
+		return time.Unix(int64(ts), 0).UTC().Format(time.RFC3339)
+	}
+	expected := strings.Join([]string{
+		"Cannon (0)",
+		"├── #0 " + games[0].Address.Hex() + " Created: " + created(1_700_000_000) + " Claims: 3 Status: Defender Won",
+		"└── #2 " + games[2].Address.Hex() + " Created: " + created(1_700_000_120) + " Claims: 5 Status: In Progress",
+		"Alphabet (255)",
+		"└── #1 " + games[1].Address.Hex() + " Created: " + created(1_700_000_060) + " Claims: 1 Status: In Progress",
+		"Unknown (42)",
+		"└── #3 " + games[3].Address.Hex() + " Created: " + created(1_700_000_180) + " Claims: 1 Status: In Progress",
+		"",
+	}, "\n")
+	require.Equal(t, expected, out.String())
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+const latestClaim = "latest"
+
+var (
+	AttackFlag = &cli.BoolFlag{
+		Name:    "attack",
+		Usage:   "An attack move. If true, the defend flag must not be set.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "ATTACK"),
+	}
+	DefendFlag = &cli.BoolFlag{
+		Name:    "defend",
+		Usage:   "A defending move. If true, the attack flag must not be set.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "DEFEND"),
+	}
+	ParentIndexFlag = &cli.StringFlag{
+		Name:    "parent-index",
+		Usage:   "The index of the claim to move on, or " + latestClaim + " to move on the most recent claim.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "PARENT_INDEX"),
+	}
+	ClaimFlag = &cli.StringFlag{
+		Name:    "claim",
+		Usage:   "The claim hash to post.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "CLAIM"),
+	}
+)
+
+var MoveCommand = &cli.Command{
+	Name:        "move",
+	Usage:       "Attack or defend a claim in a dispute game",
+	Description: "Posts a new claim that attacks or defends an existing claim in a dispute game.",
+	Action:      Move,
+	Flags:       moveFlags(),
+}
+
+func moveFlags() []cli.Flag {
+	cliFlags := []cli.Flag{
+		flags.L1EthRpcFlag,
+		GameAddressFlag,
+		AttackFlag,
+		DefendFlag,
+		ParentIndexFlag,
+		ClaimFlag,
+	}
+	cliFlags = append(cliFlags, txmgr.CLIFlags(flags.EnvVarPrefix)...)
+	return append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
+}
+
+func Move(ctx *cli.Context) error {
+	if err := checkRequired(ctx, flags.L1EthRpcFlag, GameAddressFlag, ParentIndexFlag, ClaimFlag); err != nil {
+		return err
+	}
+	attack := ctx.Bool(AttackFlag.Name)
+	if attack == ctx.Bool(DefendFlag.Name) {
+		return fmt.Errorf("exactly one of %s and %s must be set", AttackFlag.Name, DefendFlag.Name)
+	}
+	gameAddr, err := parseAddress(ctx, GameAddressFlag)
+	if err != nil {
+		return err
+	}
+	claim := common.HexToHash(ctx.String(ClaimFlag.Name))
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	l1Client, err := dialL1(ctx, logger)
+	if err != nil {
+		return err
+	}
+	defer l1Client.Close()
+
+	parentIdx, err := parseParentIndex(ctx, func() (uint64, error) {
+		loader, err := fault.NewLoaderFromBindings(gameAddr, l1Client)
+		if err != nil {
+			return 0, fmt.Errorf("failed to bind game %v: %w", gameAddr, err)
+		}
+		return loader.GetClaimCount(ctx.Context)
+	})
+	if err != nil {
+		return err
+	}
+
+	txMgr, err := newTxManager(ctx, logger)
+	if err != nil {
+		return err
+	}
+	gameResponder, err := responder.NewFaultResponder(logger, txMgr, gameAddr, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create the responder: %w", err)
+	}
+	action := types.Action{
+		Type:      types.ActionTypeMove,
+		ParentIdx: int(parentIdx),
+		IsAttack:  attack,
+		Value:     claim,
+	}
+	if err := gameResponder.PerformAction(ctx.Context, action); err != nil {
+		return fmt.Errorf("failed to send move: %w", err)
+	}
+	return nil
+}
+
+// parseParentIndex reads the index of the claim to move on, using the claim count to resolve the latest claim.
+func parseParentIndex(ctx *cli.Context, claimCount func() (uint64, error)) (uint64, error) {
+	value := ctx.String(ParentIndexFlag.Name)
+	if value != latestClaim {
+		idx, err := strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s: %w", ParentIndexFlag.Name, err)
+		}
+		return idx, nil
+	}
+	count, err := claimCount()
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch claim count: %w", err)
+	}
+	if count == 0 {
+		return 0, fmt.Errorf("game has no claims")
+	}
+	return count - 1, nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/urfave/cli/v2"
+)
+
+func TestSubcommandRequiredFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"ListGamesL1", []string{"list-games", "--game-factory-address=0x1234"}, "flag l1-eth-rpc is required"},
+		{"ListGamesFactory", []string{"list-games", "--l1-eth-rpc=http://localhost:8545"}, "flag game-factory-address is required"},
+		{"ListClaimsGame", []string{"list-claims", "--l1-eth-rpc=http://localhost:8545"}, "flag game-address is required"},
+		{"VisualizeGame", []string{"visualize", "--l1-eth-rpc=http://localhost:8545"}, "flag game-address is required"},
+		{"InvalidFormat", []string{"visualize", "--l1-eth-rpc=http://localhost:8545", "--game-address=0x1234", "--format=svg"}, "unknown format: \"svg\""},
+		{"MoveClaim", []string{"move", "--l1-eth-rpc=http://localhost:8545", "--game-address=0x1234", "--attack", "--parent-index=0"}, "flag claim is required"},
+		{"MoveNoDirection", []string{"move", "--l1-eth-rpc=http://localhost:8545", "--game-address=0x1234", "--parent-index=0", "--claim=0xaa"}, "exactly one of attack and defend must be set"},
+		{"MoveBothDirections", []string{"move", "--l1-eth-rpc=http://localhost:8545", "--game-address=0x1234", "--attack", "--defend", "--parent-index=0", "--claim=0xaa"}, "exactly one of attack and defend must be set"},
+		{"ResolveGame", []string{"resolve", "--l1-eth-rpc=http://localhost:8545"}, "flag game-address is required"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, _, err := runWithArgs(test.args)
+			require.ErrorContains(t, err, test.expected)
+		})
+	}
+}
+
+func TestParseParentIndex(t *testing.T) {
+	noCount := func() (uint64, error) {
+		return 0, errors.New("should not fetch claim count")
+	}
+
+	t.Run("Number", func(t *testing.T) {
+		idx, err := parseParentIndexArg(t, "5", noCount)
+		require.NoError(t, err)
+		require.Equal(t, uint64(5), idx)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := parseParentIndexArg(t, "abc", noCount)
+		require.ErrorContains(t, err, "invalid parent-index")
+	})
+
+	t.Run("Latest", func(t *testing.T) {
+		idx, err := parseParentIndexArg(t, latestClaim, func() (uint64, error) {
+			return 4, nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, uint64(3), idx)
+	})
+
+	t.Run("LatestWithoutClaims", func(t *testing.T) {
+		_, err := parseParentIndexArg(t, latestClaim, func() (uint64, error) {
+			return 0, nil
+		})
+		require.ErrorContains(t, err, "game has no claims")
+	})
+}
+
+// parseParentIndexArg runs parseParentIndex with the parent-index flag set to value.
+func parseParentIndexArg(t *testing.T, value string, claimCount func() (uint64, error)) (uint64, error) {
+	app := cli.NewApp()
+	var idx uint64
+	var parseErr error
+	app.Flags = []cli.Flag{ParentIndexFlag}
+	app.Action = func(ctx *cli.Context) error {
+		idx, parseErr = parseParentIndex(ctx, claimCount)
+		return nil
+	}
+	require.NoError(t, app.Run([]string{"test", "--parent-index", value}))
+	return idx, parseErr
+}
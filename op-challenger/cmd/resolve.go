@@ -0,0 +1,90 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+)
+
+var ResolveCommand = &cli.Command{
+	Name:        "resolve",
+	Usage:       "Resolve a dispute game",
+	Description: "Resolves any resolvable claims in a dispute game, deepest first, and then resolves the game.",
+	Action:      Resolve,
+	Flags:       resolveFlags(),
+}
+
+func resolveFlags() []cli.Flag {
+	cliFlags := []cli.Flag{
+		flags.L1EthRpcFlag,
+		GameAddressFlag,
+	}
+	cliFlags = append(cliFlags, txmgr.CLIFlags(flags.EnvVarPrefix)...)
+	return append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
+}
+
+func Resolve(ctx *cli.Context) error {
+	if err := checkRequired(ctx, flags.L1EthRpcFlag, GameAddressFlag); err != nil {
+		return err
+	}
+	gameAddr, err := parseAddress(ctx, GameAddressFlag)
+	if err != nil {
+		return err
+	}
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	l1Client, err := dialL1(ctx, logger)
+	if err != nil {
+		return err
+	}
+	defer l1Client.Close()
+
+	loader, err := fault.NewLoaderFromBindings(gameAddr, l1Client)
+	if err != nil {
+		return fmt.Errorf("failed to bind game %v: %w", gameAddr, err)
+	}
+	claimCount, err := loader.GetClaimCount(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to fetch claim count: %w", err)
+	}
+
+	txMgr, err := newTxManager(ctx, logger)
+	if err != nil {
+		return err
+	}
+	gameResponder, err := responder.NewFaultResponder(logger, txMgr, gameAddr, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create the responder: %w", err)
+	}
+
+	// Children always have a higher index than their parent, so resolve in reverse order to resolve subgames first.
+	for i := claimCount; i > 0; i-- {
+		claimIdx := i - 1
+		if err := gameResponder.CallResolveClaim(ctx.Context, claimIdx); err != nil {
+			logger.Debug("Claim not resolvable", "claimIdx", claimIdx, "err", err)
+			continue
+		}
+		logger.Info("Resolving claim", "claimIdx", claimIdx)
+		if err := gameResponder.ResolveClaim(ctx.Context, claimIdx); err != nil {
+			return fmt.Errorf("failed to resolve claim %d: %w", claimIdx, err)
+		}
+	}
+
+	status, err := gameResponder.CallResolve(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("game is not resolvable: %w", err)
+	}
+	if err := gameResponder.Resolve(ctx.Context); err != nil {
+		return fmt.Errorf("failed to resolve game: %w", err)
+	}
+	_, err = fmt.Fprintf(ctx.App.Writer, "Result: %v\n", status)
+	return err
+}
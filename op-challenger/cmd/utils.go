@@ -0,0 +1,98 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	opservice "github.com/ethereum-optimism/optimism/op-service"
+	"github.com/ethereum-optimism/optimism/op-service/client"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
+)
+
+const (
+	formatTree = "tree"
+	formatJSON = "json"
+)
+
+var outputFormats = []string{formatTree, formatJSON}
+
+var (
+	GameAddressFlag = &cli.StringFlag{
+		Name:    "game-address",
+		Usage:   "Address of the fault dispute game contract.",
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "GAME_ADDRESS"),
+	}
+	FormatFlag = &cli.StringFlag{
+		Name:    "format",
+		Usage:   "Output format. Valid options: " + openum.EnumString(outputFormats),
+		EnvVars: opservice.PrefixEnvVar(flags.EnvVarPrefix, "FORMAT"),
+		Value:   formatTree,
+	}
+)
+
+// checkRequired returns an error if any of the required flags are not set.
+func checkRequired(ctx *cli.Context, required ...cli.Flag) error {
+	for _, f := range required {
+		if !ctx.IsSet(f.Names()[0]) {
+			return fmt.Errorf("flag %s is required", f.Names()[0])
+		}
+	}
+	return nil
+}
+
+// parseFormat reads and validates the output format.
+func parseFormat(ctx *cli.Context) (string, error) {
+	format := ctx.String(FormatFlag.Name)
+	for _, valid := range outputFormats {
+		if format == valid {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf("unknown format: %q", format)
+}
+
+// parseAddress reads and validates an address flag.
+func parseAddress(ctx *cli.Context, f cli.Flag) (common.Address, error) {
+	addr, err := opservice.ParseAddress(ctx.String(f.Names()[0]))
+	if err != nil {
+		return common.Address{}, fmt.Errorf("invalid %s: %w", f.Names()[0], err)
+	}
+	return addr, nil
+}
+
+// dialL1 connects to the L1 node specified by the l1-eth-rpc flag.
+func dialL1(ctx *cli.Context, logger log.Logger) (*ethclient.Client, error) {
+	l1Client, err := client.DialEthClientWithTimeout(client.DefaultDialTimeout, logger, ctx.String(flags.L1EthRpcFlag.Name))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial L1: %w", err)
+	}
+	return l1Client, nil
+}
+
+// newTxManager creates a transaction manager from the txmgr flags for sending transactions to L1.
+func newTxManager(ctx *cli.Context, logger log.Logger) (txmgr.TxManager, error) {
+	txMgrConfig := txmgr.ReadCLIConfig(ctx)
+	if err := txMgrConfig.Check(); err != nil {
+		return nil, fmt.Errorf("invalid txmgr config: %w", err)
+	}
+	txMgr, err := txmgr.NewSimpleTxManager("challenger", logger, &txmetrics.NoopTxMetrics{}, txMgrConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the transaction manager: %w", err)
+	}
+	return txMgr, nil
+}
+
+func writeJSON(out io.Writer, value any) error {
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/flags"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+var VisualizeCommand = &cli.Command{
+	Name:        "visualize",
+	Usage:       "Draw the claims of a dispute game",
+	Description: "Draws the tree of claims in a dispute game, including the position, trace index and clock of each claim.",
+	Action:      Visualize,
+	Flags:       visualizeFlags(),
+}
+
+func visualizeFlags() []cli.Flag {
+	cliFlags := []cli.Flag{
+		flags.L1EthRpcFlag,
+		GameAddressFlag,
+		FormatFlag,
+	}
+	return append(cliFlags, oplog.CLIFlags(flags.EnvVarPrefix)...)
+}
+
+// gameVisualization is the JSON output of the visualize subcommand.
+type gameVisualization struct {
+	MaxDepth     uint64     `json:"maxDepth"`
+	GameDuration uint64     `json:"gameDuration"`
+	Status       string     `json:"status"`
+	Root         *claimInfo `json:"root,omitempty"`
+}
+
+func Visualize(ctx *cli.Context) error {
+	if err := checkRequired(ctx, flags.L1EthRpcFlag, GameAddressFlag); err != nil {
+		return err
+	}
+	format, err := parseFormat(ctx)
+	if err != nil {
+		return err
+	}
+	gameAddr, err := parseAddress(ctx, GameAddressFlag)
+	if err != nil {
+		return err
+	}
+	logger, err := setupLogging(ctx)
+	if err != nil {
+		return err
+	}
+	l1Client, err := dialL1(ctx, logger)
+	if err != nil {
+		return err
+	}
+	defer l1Client.Close()
+
+	loader, err := fault.NewLoaderFromBindings(gameAddr, l1Client)
+	if err != nil {
+		return fmt.Errorf("failed to bind game %v: %w", gameAddr, err)
+	}
+	maxDepth, err := loader.FetchGameDepth(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to fetch game depth: %w", err)
+	}
+	duration, err := loader.FetchGameDuration(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to fetch game duration: %w", err)
+	}
+	status, err := loader.GetGameStatus(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to fetch game status: %w", err)
+	}
+	claims, err := loader.FetchClaims(ctx.Context)
+	if err != nil {
+		return fmt.Errorf("failed to fetch claims: %w", err)
+	}
+	infos := newClaimInfos(claims, int(maxDepth))
+
+	out := ctx.App.Writer
+	if format == formatJSON {
+		vis := gameVisualization{
+			MaxDepth:     maxDepth,
+			GameDuration: uint64(duration.Seconds()),
+			Status:       status.String(),
+		}
+		if len(infos) > 0 {
+			vis.Root = infos[0]
+		}
+		return writeJSON(out, vis)
+	}
+	if _, err := fmt.Fprintf(out, "Game: %v Status: %v Max Depth: %d Duration: %v\n", gameAddr, status, maxDepth, duration); err != nil {
+		return err
+	}
+	if len(infos) == 0 {
+		return nil
+	}
+	return writeTree(out, infos[0], detailedLabel)
+}
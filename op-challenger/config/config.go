@@ -3,21 +3,27 @@ package config
 import (
 	"errors"
 	"fmt"
+	"math/big"
 	"runtime"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slices"
 
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
 var (
 	ErrMissingTraceType              = errors.New("missing trace type")
+	ErrInvalidTraceType              = errors.New("invalid trace type")
 	ErrMissingDatadir                = errors.New("missing datadir")
 	ErrMaxConcurrencyZero            = errors.New("max concurrency must not be 0")
+	ErrNegativeMoveBond              = errors.New("move bond must not be negative")
 	ErrMissingCannonL2               = errors.New("missing cannon L2")
 	ErrMissingCannonBin              = errors.New("missing cannon bin")
 	ErrMissingCannonServer           = errors.New("missing cannon server")
@@ -32,7 +38,9 @@ var (
 	ErrCannonNetworkAndRollupConfig  = errors.New("only specify one of network or rollup config path")
 	ErrCannonNetworkAndL2Genesis     = errors.New("only specify one of network or l2 genesis path")
 	ErrCannonNetworkUnknown          = errors.New("unknown cannon network")
+	ErrCannonDataFormatUnknown       = errors.New("unknown cannon data format")
 	ErrMissingRollupRpc              = errors.New("missing rollup rpc url")
+	ErrMissingOutputCannonSplitDepth = errors.New("missing output cannon split depth")
 )
 
 type TraceType string
@@ -45,6 +53,9 @@ const (
 	// Mainnet games
 	CannonFaultGameID = 0
 
+	// Output root games, see GameTypes.OUTPUT_CANNON in DisputeTypes.sol
+	OutputCannonFaultGameID = 3
+
 	// Devnet games
 	AlphabetFaultGameID = 255
 )
@@ -53,14 +64,29 @@ var TraceTypes = []TraceType{TraceTypeAlphabet, TraceTypeCannon, TraceTypeOutput
 
 // GameIdToString maps game IDs to their string representation.
 var GameIdToString = map[uint8]string{
-	CannonFaultGameID:   "Cannon",
-	AlphabetFaultGameID: "Alphabet",
+	CannonFaultGameID:       "Cannon",
+	OutputCannonFaultGameID: "OutputCannon",
+	AlphabetFaultGameID:     "Alphabet",
 }
 
 func (t TraceType) String() string {
 	return string(t)
 }
 
+// GameType returns the ID of the dispute game type that is played with the trace type.
+func (t TraceType) GameType() (uint8, error) {
+	switch t {
+	case TraceTypeCannon:
+		return CannonFaultGameID, nil
+	case TraceTypeOutputCannon:
+		return OutputCannonFaultGameID, nil
+	case TraceTypeAlphabet:
+		return AlphabetFaultGameID, nil
+	default:
+		return 0, fmt.Errorf("unknown trace type: %q", t)
+	}
+}
+
 // Set implements the Set method required by the [cli.Generic] interface.
 func (t *TraceType) Set(value string) error {
 	if !ValidTraceType(TraceType(value)) {
@@ -83,6 +109,11 @@ const (
 	DefaultPollInterval       = time.Second * 12
 	DefaultCannonSnapshotFreq = uint(1_000_000_000)
 	DefaultCannonInfoFreq     = uint(10_000_000)
+	// DefaultCannonCacheSize is the default maximum size of the cannon snapshot cache in MiB.
+	DefaultCannonCacheSize = uint64(10 * 1024)
+	// DefaultOutputCannonSplitDepth is the default depth at which output_cannon games
+	// switch from bisecting output roots to bisecting the cannon execution trace.
+	DefaultOutputCannonSplitDepth = uint64(14)
 	// DefaultGameWindow is the default maximum time duration in the past
 	// that the challenger will look for games to progress.
 	// The default value is 11 days, which is a 4 day resolution buffer
@@ -94,22 +125,32 @@ const (
 // This also contains config options for auxiliary services.
 // It is used to initialize the challenger.
 type Config struct {
-	L1EthRpc                string           // L1 RPC Url
-	GameFactoryAddress      common.Address   // Address of the dispute game factory
-	GameAllowlist           []common.Address // Allowlist of fault game addresses
-	GameWindow              time.Duration    // Maximum time duration to look for games to progress
-	AgreeWithProposedOutput bool             // Temporary config if we agree or disagree with the posted output
-	Datadir                 string           // Data Directory
-	MaxConcurrency          uint             // Maximum number of threads to use when progressing games
-	PollInterval            time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
+	L1EthRpc           string           // L1 RPC Url
+	GameFactoryAddress common.Address   // Address of the dispute game factory
+	GameAllowlist      []common.Address // Allowlist of fault game addresses
+	GameWindow         time.Duration    // Maximum time duration to look for games to progress
+	Datadir            string           // Data Directory
+	MaxConcurrency     uint             // Maximum number of threads to use when progressing games
+	PollInterval       time.Duration    // Polling interval for latest-block subscription when using an HTTP RPC provider
+
+	TraceTypes []TraceType // Types of traces supported
+
+	// Observer disables sending transactions. The actions the challenger would take are reported
+	// through metrics and the RPC server instead, so no funded key is required.
+	Observer bool
 
-	TraceType TraceType // Type of trace
+	// MoveBond is the bond in wei posted with each move. The bonds that are posted are reported
+	// through metrics and the RPC server.
+	MoveBond *big.Int
 
 	// Specific to the alphabet trace provider
 	AlphabetTrace string // String for the AlphabetTraceProvider
 
+	// Specific to the cannon and output cannon trace types
+	RollupRpc string // Trusted rollup node RPC Url, used to check the proposed outputs of games
+
 	// Specific to the output cannon trace type
-	RollupRpc string
+	OutputCannonSplitDepth uint64 // Depth of the last output root bisection level, cannon traces are bisected below it
 
 	// Specific to the cannon trace provider
 	CannonBin              string // Path to the cannon executable to run when generating trace data
@@ -118,44 +159,55 @@ type Config struct {
 	CannonNetwork          string
 	CannonRollupConfigPath string
 	CannonL2GenesisPath    string
-	CannonL2               string // L2 RPC Url
-	CannonSnapshotFreq     uint   // Frequency of snapshots to create when executing cannon (in VM instructions)
-	CannonInfoFreq         uint   // Frequency of cannon progress log messages (in VM instructions)
+	CannonL2               string           // L2 RPC Url
+	CannonSnapshotFreq     uint             // Frequency of snapshots to create when executing cannon (in VM instructions)
+	CannonInfoFreq         uint             // Frequency of cannon progress log messages (in VM instructions)
+	CannonCacheSize        uint64           // Maximum size of the snapshot cache shared between games (in MiB), 0 disables the cache
+	CannonDataFormat       types.DataFormat // Format op-program uses to store pre-images in the data directory
 
 	TxMgrConfig   txmgr.CLIConfig
 	MetricsConfig opmetrics.CLIConfig
 	PprofConfig   oppprof.CLIConfig
+	RPCConfig     oprpc.CLIConfig // RPC server reporting observed games in observer mode, or the bonds posted if MoveBond is set
 }
 
 func NewConfig(
 	gameFactoryAddress common.Address,
 	l1EthRpc string,
-	traceType TraceType,
-	agreeWithProposedOutput bool,
 	datadir string,
+	supportedTraceTypes ...TraceType,
 ) Config {
 	return Config{
 		L1EthRpc:           l1EthRpc,
 		GameFactoryAddress: gameFactoryAddress,
 		MaxConcurrency:     uint(runtime.NumCPU()),
 		PollInterval:       DefaultPollInterval,
+		MoveBond:           new(big.Int),
 
-		AgreeWithProposedOutput: agreeWithProposedOutput,
-
-		TraceType: traceType,
+		TraceTypes: supportedTraceTypes,
 
 		TxMgrConfig:   txmgr.NewCLIConfig(l1EthRpc),
 		MetricsConfig: opmetrics.DefaultCLIConfig(),
 		PprofConfig:   oppprof.DefaultCLIConfig(),
+		RPCConfig:     oprpc.DefaultCLIConfig(),
 
 		Datadir: datadir,
 
 		CannonSnapshotFreq: DefaultCannonSnapshotFreq,
 		CannonInfoFreq:     DefaultCannonInfoFreq,
+		CannonCacheSize:    DefaultCannonCacheSize,
+		CannonDataFormat:   types.DataFormatDirectory,
 		GameWindow:         DefaultGameWindow,
+
+		OutputCannonSplitDepth: DefaultOutputCannonSplitDepth,
 	}
 }
 
+// TraceTypeEnabled returns true if the challenger should play games of the trace type.
+func (c Config) TraceTypeEnabled(t TraceType) bool {
+	return slices.Contains(c.TraceTypes, t)
+}
+
 func (c Config) Check() error {
 	if c.L1EthRpc == "" {
 		return ErrMissingL1EthRPC
@@ -163,21 +215,29 @@ func (c Config) Check() error {
 	if c.GameFactoryAddress == (common.Address{}) {
 		return ErrMissingGameFactoryAddress
 	}
-	if c.TraceType == "" {
+	if len(c.TraceTypes) == 0 {
 		return ErrMissingTraceType
 	}
+	for _, traceType := range c.TraceTypes {
+		if !ValidTraceType(traceType) {
+			return fmt.Errorf("%w: %q", ErrInvalidTraceType, traceType)
+		}
+	}
 	if c.Datadir == "" {
 		return ErrMissingDatadir
 	}
 	if c.MaxConcurrency == 0 {
 		return ErrMaxConcurrencyZero
 	}
-	if c.TraceType == TraceTypeOutputCannon {
+	if c.TraceTypeEnabled(TraceTypeOutputCannon) {
+		if c.OutputCannonSplitDepth == 0 {
+			return ErrMissingOutputCannonSplitDepth
+		}
+	}
+	if c.TraceTypeEnabled(TraceTypeCannon) || c.TraceTypeEnabled(TraceTypeOutputCannon) {
 		if c.RollupRpc == "" {
 			return ErrMissingRollupRpc
 		}
-	}
-	if c.TraceType == TraceTypeCannon || c.TraceType == TraceTypeOutputCannon {
 		if c.CannonBin == "" {
 			return ErrMissingCannonBin
 		}
@@ -214,12 +274,20 @@ func (c Config) Check() error {
 		if c.CannonInfoFreq == 0 {
 			return ErrMissingCannonInfoFreq
 		}
+		if !c.CannonDataFormat.Valid() {
+			return fmt.Errorf("%w: %v", ErrCannonDataFormatUnknown, c.CannonDataFormat)
+		}
 	}
-	if c.TraceType == TraceTypeAlphabet && c.AlphabetTrace == "" {
+	if c.TraceTypeEnabled(TraceTypeAlphabet) && c.AlphabetTrace == "" {
 		return ErrMissingAlphabetTrace
 	}
-	if err := c.TxMgrConfig.Check(); err != nil {
-		return err
+	if c.MoveBond != nil && c.MoveBond.Sign() < 0 {
+		return ErrNegativeMoveBond
+	}
+	if !c.Observer {
+		if err := c.TxMgrConfig.Check(); err != nil {
+			return err
+		}
 	}
 	if err := c.MetricsConfig.Check(); err != nil {
 		return err
@@ -227,5 +295,8 @@ func (c Config) Check() error {
 	if err := c.PprofConfig.Check(); err != nil {
 		return err
 	}
+	if err := c.RPCConfig.Check(); err != nil {
+		return err
+	}
 	return nil
 }
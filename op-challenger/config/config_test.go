@@ -1,6 +1,7 @@
 package config
 
 import (
+	"math/big"
 	"runtime"
 	"testing"
 
@@ -21,23 +22,22 @@ var (
 	validDatadir               = "/tmp/data"
 	validCannonL2              = "http://localhost:9545"
 	validRollupRpc             = "http://localhost:8555"
-	agreeWithProposedOutput    = true
 )
 
-func validConfig(traceType TraceType) Config {
-	cfg := NewConfig(validGameFactoryAddress, validL1EthRpc, traceType, agreeWithProposedOutput, validDatadir)
-	switch traceType {
-	case TraceTypeAlphabet:
-		cfg.AlphabetTrace = validAlphabetTrace
-	case TraceTypeCannon, TraceTypeOutputCannon:
-		cfg.CannonBin = validCannonBin
-		cfg.CannonServer = validCannonOpProgramBin
-		cfg.CannonAbsolutePreState = validCannonAbsolutPreState
-		cfg.CannonL2 = validCannonL2
-		cfg.CannonNetwork = validCannonNetwork
-	}
-	if traceType == TraceTypeOutputCannon {
-		cfg.RollupRpc = validRollupRpc
+func validConfig(traceTypes ...TraceType) Config {
+	cfg := NewConfig(validGameFactoryAddress, validL1EthRpc, validDatadir, traceTypes...)
+	for _, traceType := range traceTypes {
+		switch traceType {
+		case TraceTypeAlphabet:
+			cfg.AlphabetTrace = validAlphabetTrace
+		case TraceTypeCannon, TraceTypeOutputCannon:
+			cfg.CannonBin = validCannonBin
+			cfg.CannonServer = validCannonOpProgramBin
+			cfg.CannonAbsolutePreState = validCannonAbsolutPreState
+			cfg.CannonL2 = validCannonL2
+			cfg.CannonNetwork = validCannonNetwork
+			cfg.RollupRpc = validRollupRpc
+		}
 	}
 	return cfg
 }
@@ -53,12 +53,73 @@ func TestValidConfigIsValid(t *testing.T) {
 	}
 }
 
+func TestMultipleTraceTypes(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		config := validConfig(TraceTypes...)
+		require.NoError(t, config.Check())
+		for _, traceType := range TraceTypes {
+			require.Truef(t, config.TraceTypeEnabled(traceType), "should enable %v", traceType)
+		}
+	})
+
+	t.Run("CheckConfigOfEachType", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon, TraceTypeAlphabet)
+		config.AlphabetTrace = ""
+		require.ErrorIs(t, config.Check(), ErrMissingAlphabetTrace)
+	})
+
+	t.Run("OnlyEnableConfiguredTypes", func(t *testing.T) {
+		config := validConfig(TraceTypeAlphabet)
+		require.True(t, config.TraceTypeEnabled(TraceTypeAlphabet))
+		require.False(t, config.TraceTypeEnabled(TraceTypeCannon))
+		require.False(t, config.TraceTypeEnabled(TraceTypeOutputCannon))
+	})
+}
+
+func TestTraceTypeRequired(t *testing.T) {
+	config := validConfig()
+	require.ErrorIs(t, config.Check(), ErrMissingTraceType)
+}
+
+func TestTraceTypeMustBeValid(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	config.TraceTypes = append(config.TraceTypes, "foo")
+	require.ErrorIs(t, config.Check(), ErrInvalidTraceType)
+}
+
+func TestTraceTypeGameType(t *testing.T) {
+	for traceType, expected := range map[TraceType]uint8{
+		TraceTypeCannon:       CannonFaultGameID,
+		TraceTypeOutputCannon: OutputCannonFaultGameID,
+		TraceTypeAlphabet:     AlphabetFaultGameID,
+	} {
+		gameType, err := traceType.GameType()
+		require.NoError(t, err)
+		require.Equal(t, expected, gameType)
+	}
+	_, err := TraceType("foo").GameType()
+	require.ErrorContains(t, err, "unknown trace type")
+}
+
 func TestTxMgrConfig(t *testing.T) {
 	t.Run("Invalid", func(t *testing.T) {
 		config := validConfig(TraceTypeCannon)
 		config.TxMgrConfig = txmgr.CLIConfig{}
 		require.Equal(t, config.Check().Error(), "must provide a L1 RPC url")
 	})
+
+	t.Run("NotRequiredForObserver", func(t *testing.T) {
+		config := validConfig(TraceTypeCannon)
+		config.Observer = true
+		config.TxMgrConfig = txmgr.CLIConfig{}
+		require.NoError(t, config.Check())
+	})
+}
+
+func TestRPCConfig(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	config.RPCConfig.ListenPort = -1
+	require.ErrorContains(t, config.Check(), "invalid RPC port")
 }
 
 func TestL1EthRpcRequired(t *testing.T) {
@@ -122,6 +183,12 @@ func TestMaxConcurrency(t *testing.T) {
 	})
 }
 
+func TestMoveBondMustNotBeNegative(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
+	config.MoveBond = big.NewInt(-1)
+	require.ErrorIs(t, config.Check(), ErrNegativeMoveBond)
+}
+
 func TestHttpPollInterval(t *testing.T) {
 	t.Run("Default", func(t *testing.T) {
 		config := validConfig(TraceTypeAlphabet)
@@ -130,9 +197,26 @@ func TestHttpPollInterval(t *testing.T) {
 }
 
 func TestRollupRpcRequired(t *testing.T) {
-	config := validConfig(TraceTypeOutputCannon)
+	for _, traceType := range []TraceType{TraceTypeCannon, TraceTypeOutputCannon} {
+		traceType := traceType
+		t.Run(traceType.String(), func(t *testing.T) {
+			config := validConfig(traceType)
+			config.RollupRpc = ""
+			require.ErrorIs(t, config.Check(), ErrMissingRollupRpc)
+		})
+	}
+}
+
+func TestRollupRpcNotRequiredForAlphabet(t *testing.T) {
+	config := validConfig(TraceTypeAlphabet)
 	config.RollupRpc = ""
-	require.ErrorIs(t, config.Check(), ErrMissingRollupRpc)
+	require.NoError(t, config.Check())
+}
+
+func TestOutputCannonSplitDepthRequired(t *testing.T) {
+	config := validConfig(TraceTypeOutputCannon)
+	config.OutputCannonSplitDepth = 0
+	require.ErrorIs(t, config.Check(), ErrMissingOutputCannonSplitDepth)
 }
 
 func TestCannonL2Required(t *testing.T) {
@@ -194,3 +278,9 @@ func TestNetworkMustBeValid(t *testing.T) {
 	cfg.CannonNetwork = "unknown"
 	require.ErrorIs(t, cfg.Check(), ErrCannonNetworkUnknown)
 }
+
+func TestCannonDataFormatMustBeValid(t *testing.T) {
+	cfg := validConfig(TraceTypeCannon)
+	cfg.CannonDataFormat = "unknown"
+	require.ErrorIs(t, cfg.Check(), ErrCannonDataFormatUnknown)
+}
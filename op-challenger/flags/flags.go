@@ -2,28 +2,32 @@ package flags
 
 import (
 	"fmt"
+	"math/big"
 	"runtime"
 	"strings"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/exp/slices"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 )
 
 const (
-	envVarPrefix = "OP_CHALLENGER"
+	EnvVarPrefix = "OP_CHALLENGER"
 )
 
 func prefixEnvVars(name string) []string {
-	return opservice.PrefixEnvVar(envVarPrefix, name)
+	return opservice.PrefixEnvVar(EnvVarPrefix, name)
 }
 
 var (
@@ -44,19 +48,10 @@ var (
 			"If empty, the challenger will play all games.",
 		EnvVars: prefixEnvVars("GAME_ALLOWLIST"),
 	}
-	TraceTypeFlag = &cli.GenericFlag{
+	TraceTypeFlag = &cli.StringSliceFlag{
 		Name:    "trace-type",
-		Usage:   "The trace type. Valid options: " + openum.EnumString(config.TraceTypes),
+		Usage:   "The trace types to support, each playing its own dispute game type. Valid options: " + openum.EnumString(config.TraceTypes),
 		EnvVars: prefixEnvVars("TRACE_TYPE"),
-		Value: func() *config.TraceType {
-			out := config.TraceType("") // No default value
-			return &out
-		}(),
-	}
-	AgreeWithProposedOutputFlag = &cli.BoolFlag{
-		Name:    "agree-with-proposed-output",
-		Usage:   "Temporary hardcoded flag if we agree or disagree with the proposed output.",
-		EnvVars: prefixEnvVars("AGREE_WITH_PROPOSED_OUTPUT"),
 	}
 	DatadirFlag = &cli.StringFlag{
 		Name:    "datadir",
@@ -78,9 +73,15 @@ var (
 	}
 	RollupRpcFlag = &cli.StringFlag{
 		Name:    "rollup-rpc",
-		Usage:   "HTTP provider URL for the rollup node",
+		Usage:   "HTTP provider URL for the trusted rollup node, used to check the proposed output of each game (cannon and output_cannon trace types only)",
 		EnvVars: prefixEnvVars("ROLLUP_RPC"),
 	}
+	OutputCannonSplitDepthFlag = &cli.Uint64Flag{
+		Name:    "output-cannon-split-depth",
+		Usage:   "Depth of the last output root bisection level, below which cannon traces are bisected (output_cannon trace type only)",
+		EnvVars: prefixEnvVars("OUTPUT_CANNON_SPLIT_DEPTH"),
+		Value:   config.DefaultOutputCannonSplitDepth,
+	}
 	AlphabetFlag = &cli.StringFlag{
 		Name:    "alphabet",
 		Usage:   "Correct Alphabet Trace (alphabet trace type only)",
@@ -136,6 +137,33 @@ var (
 		EnvVars: prefixEnvVars("CANNON_INFO_FREQ"),
 		Value:   config.DefaultCannonInfoFreq,
 	}
+	CannonCacheSizeFlag = &cli.Uint64Flag{
+		Name: "cannon-cache-size",
+		Usage: "Maximum size in MiB of the cache of cannon snapshots and proofs shared between games. " +
+			"The least recently used executions are removed when it is full. 0 disables the cache (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_CACHE_SIZE"),
+		Value:   config.DefaultCannonCacheSize,
+	}
+	CannonDataFormatFlag = &cli.StringFlag{
+		Name: "cannon-data-format",
+		Usage: "Format op-program uses to store pre-images in the data directory. Available formats: " +
+			openum.EnumString(types.SupportedDataFormats) + " (cannon trace type only)",
+		EnvVars: prefixEnvVars("CANNON_DATA_FORMAT"),
+		Value:   types.DataFormatDirectory.String(),
+	}
+	ObserverFlag = &cli.BoolFlag{
+		Name: "observer",
+		Usage: "Observe games without sending any transactions, so no private key is required. " +
+			"The actions that would be taken are reported through metrics and the RPC server instead.",
+		EnvVars: prefixEnvVars("OBSERVER"),
+	}
+	MoveBondFlag = &cli.StringFlag{
+		Name: "move-bond",
+		Usage: "Bond in wei to post with each move. The bonds that are posted, and whether they are owed back " +
+			"or forfeited once games resolve, are reported through metrics and the RPC server.",
+		EnvVars: prefixEnvVars("MOVE_BOND"),
+		Value:   "0",
+	}
 	GameWindowFlag = &cli.DurationFlag{
 		Name:    "game-window",
 		Usage:   "The time window which the challenger will look for games to progress.",
@@ -149,7 +177,6 @@ var requiredFlags = []cli.Flag{
 	L1EthRpcFlag,
 	FactoryAddressFlag,
 	TraceTypeFlag,
-	AgreeWithProposedOutputFlag,
 	DatadirFlag,
 }
 
@@ -158,6 +185,7 @@ var optionalFlags = []cli.Flag{
 	MaxConcurrencyFlag,
 	HTTPPollInterval,
 	RollupRpcFlag,
+	OutputCannonSplitDepthFlag,
 	AlphabetFlag,
 	GameAllowlistFlag,
 	CannonNetworkFlag,
@@ -169,14 +197,19 @@ var optionalFlags = []cli.Flag{
 	CannonL2Flag,
 	CannonSnapshotFreqFlag,
 	CannonInfoFreqFlag,
+	CannonCacheSizeFlag,
+	CannonDataFormatFlag,
 	GameWindowFlag,
+	ObserverFlag,
+	MoveBondFlag,
 }
 
 func init() {
-	optionalFlags = append(optionalFlags, oplog.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, txmgr.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(envVarPrefix)...)
-	optionalFlags = append(optionalFlags, oppprof.CLIFlags(envVarPrefix)...)
+	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, txmgr.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, opmetrics.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, oppprof.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, oprpc.CLIFlags(EnvVarPrefix)...)
 
 	Flags = append(requiredFlags, optionalFlags...)
 }
@@ -207,6 +240,9 @@ func CheckCannonFlags(ctx *cli.Context) error {
 	if !ctx.IsSet(CannonL2Flag.Name) {
 		return fmt.Errorf("flag %s is required", CannonL2Flag.Name)
 	}
+	if !ctx.IsSet(RollupRpcFlag.Name) {
+		return fmt.Errorf("flag %s is required", RollupRpcFlag.Name)
+	}
 	return nil
 }
 
@@ -216,27 +252,40 @@ func CheckRequired(ctx *cli.Context) error {
 			return fmt.Errorf("flag %s is required", f.Names()[0])
 		}
 	}
-	gameType := config.TraceType(strings.ToLower(ctx.String(TraceTypeFlag.Name)))
-	switch gameType {
-	case config.TraceTypeCannon:
-		if err := CheckCannonFlags(ctx); err != nil {
-			return err
-		}
-	case config.TraceTypeAlphabet:
-		if !ctx.IsSet(AlphabetFlag.Name) {
-			return fmt.Errorf("flag %s is required", "alphabet")
+	traceTypes, err := parseTraceTypes(ctx)
+	if err != nil {
+		return err
+	}
+	for _, traceType := range traceTypes {
+		switch traceType {
+		case config.TraceTypeCannon, config.TraceTypeOutputCannon:
+			if err := CheckCannonFlags(ctx); err != nil {
+				return err
+			}
+		case config.TraceTypeAlphabet:
+			if !ctx.IsSet(AlphabetFlag.Name) {
+				return fmt.Errorf("flag %s is required", "alphabet")
+			}
+		default:
+			return fmt.Errorf("invalid trace type. must be one of %v", config.TraceTypes)
 		}
-	case config.TraceTypeOutputCannon:
-		if err := CheckCannonFlags(ctx); err != nil {
-			return err
+	}
+	return nil
+}
+
+// parseTraceTypes parses the trace types to support, ignoring duplicates.
+func parseTraceTypes(ctx *cli.Context) ([]config.TraceType, error) {
+	var traceTypes []config.TraceType
+	for _, typeName := range ctx.StringSlice(TraceTypeFlag.Name) {
+		var traceType config.TraceType
+		if err := traceType.Set(strings.ToLower(typeName)); err != nil {
+			return nil, err
 		}
-		if !ctx.IsSet(RollupRpcFlag.Name) {
-			return fmt.Errorf("flag %s is required", RollupRpcFlag.Name)
+		if !slices.Contains(traceTypes, traceType) {
+			traceTypes = append(traceTypes, traceType)
 		}
-	default:
-		return fmt.Errorf("invalid trace type. must be one of %v", config.TraceTypes)
 	}
-	return nil
+	return traceTypes, nil
 }
 
 // NewConfigFromCLI parses the Config from the provided flags or environment variables.
@@ -262,37 +311,50 @@ func NewConfigFromCLI(ctx *cli.Context) (*config.Config, error) {
 	txMgrConfig := txmgr.ReadCLIConfig(ctx)
 	metricsConfig := opmetrics.ReadCLIConfig(ctx)
 	pprofConfig := oppprof.ReadCLIConfig(ctx)
+	rpcConfig := oprpc.ReadCLIConfig(ctx)
 
-	traceTypeFlag := config.TraceType(strings.ToLower(ctx.String(TraceTypeFlag.Name)))
+	traceTypes, err := parseTraceTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
 
 	maxConcurrency := ctx.Uint(MaxConcurrencyFlag.Name)
 	if maxConcurrency == 0 {
 		return nil, fmt.Errorf("%v must not be 0", MaxConcurrencyFlag.Name)
 	}
+	moveBond, ok := new(big.Int).SetString(ctx.String(MoveBondFlag.Name), 10)
+	if !ok {
+		return nil, fmt.Errorf("invalid %v: %v", MoveBondFlag.Name, ctx.String(MoveBondFlag.Name))
+	}
 	return &config.Config{
 		// Required Flags
-		L1EthRpc:                ctx.String(L1EthRpcFlag.Name),
-		TraceType:               traceTypeFlag,
-		GameFactoryAddress:      gameFactoryAddress,
-		GameAllowlist:           allowedGames,
-		GameWindow:              ctx.Duration(GameWindowFlag.Name),
-		MaxConcurrency:          maxConcurrency,
-		PollInterval:            ctx.Duration(HTTPPollInterval.Name),
-		RollupRpc:               ctx.String(RollupRpcFlag.Name),
-		AlphabetTrace:           ctx.String(AlphabetFlag.Name),
-		CannonNetwork:           ctx.String(CannonNetworkFlag.Name),
-		CannonRollupConfigPath:  ctx.String(CannonRollupConfigFlag.Name),
-		CannonL2GenesisPath:     ctx.String(CannonL2GenesisFlag.Name),
-		CannonBin:               ctx.String(CannonBinFlag.Name),
-		CannonServer:            ctx.String(CannonServerFlag.Name),
-		CannonAbsolutePreState:  ctx.String(CannonPreStateFlag.Name),
-		Datadir:                 ctx.String(DatadirFlag.Name),
-		CannonL2:                ctx.String(CannonL2Flag.Name),
-		CannonSnapshotFreq:      ctx.Uint(CannonSnapshotFreqFlag.Name),
-		CannonInfoFreq:          ctx.Uint(CannonInfoFreqFlag.Name),
-		AgreeWithProposedOutput: ctx.Bool(AgreeWithProposedOutputFlag.Name),
-		TxMgrConfig:             txMgrConfig,
-		MetricsConfig:           metricsConfig,
-		PprofConfig:             pprofConfig,
+		L1EthRpc:               ctx.String(L1EthRpcFlag.Name),
+		TraceTypes:             traceTypes,
+		Observer:               ctx.Bool(ObserverFlag.Name),
+		MoveBond:               moveBond,
+		GameFactoryAddress:     gameFactoryAddress,
+		GameAllowlist:          allowedGames,
+		GameWindow:             ctx.Duration(GameWindowFlag.Name),
+		MaxConcurrency:         maxConcurrency,
+		PollInterval:           ctx.Duration(HTTPPollInterval.Name),
+		RollupRpc:              ctx.String(RollupRpcFlag.Name),
+		OutputCannonSplitDepth: ctx.Uint64(OutputCannonSplitDepthFlag.Name),
+		AlphabetTrace:          ctx.String(AlphabetFlag.Name),
+		CannonNetwork:          ctx.String(CannonNetworkFlag.Name),
+		CannonRollupConfigPath: ctx.String(CannonRollupConfigFlag.Name),
+		CannonL2GenesisPath:    ctx.String(CannonL2GenesisFlag.Name),
+		CannonBin:              ctx.String(CannonBinFlag.Name),
+		CannonServer:           ctx.String(CannonServerFlag.Name),
+		CannonAbsolutePreState: ctx.String(CannonPreStateFlag.Name),
+		Datadir:                ctx.String(DatadirFlag.Name),
+		CannonL2:               ctx.String(CannonL2Flag.Name),
+		CannonSnapshotFreq:     ctx.Uint(CannonSnapshotFreqFlag.Name),
+		CannonInfoFreq:         ctx.Uint(CannonInfoFreqFlag.Name),
+		CannonCacheSize:        ctx.Uint64(CannonCacheSizeFlag.Name),
+		CannonDataFormat:       types.DataFormat(ctx.String(CannonDataFormatFlag.Name)),
+		TxMgrConfig:            txMgrConfig,
+		MetricsConfig:          metricsConfig,
+		PprofConfig:            pprofConfig,
+		RPCConfig:              rpcConfig,
 	}, nil
 }
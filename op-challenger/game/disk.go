@@ -11,15 +11,25 @@ import (
 	"golang.org/x/exp/slices"
 )
 
-const gameDirPrefix = "game-"
+const (
+	gameDirPrefix    = "game-"
+	snapshotCacheDir = "cannon-cache"
+)
+
+// diskCache is data on disk that is shared between games, so it isn't removed with the data of any one game.
+type diskCache interface {
+	// Prune removes data from the cache until it is within its size limit.
+	Prune() error
+}
 
 // diskManager coordinates the storage of game data on disk.
 type diskManager struct {
 	datadir string
+	caches  []diskCache
 }
 
-func newDiskManager(dir string) *diskManager {
-	return &diskManager{datadir: dir}
+func newDiskManager(dir string, caches ...diskCache) *diskManager {
+	return &diskManager{datadir: dir, caches: caches}
 }
 
 func (d *diskManager) DirForGame(addr common.Address) string {
@@ -51,5 +61,8 @@ func (d *diskManager) RemoveAllExcept(keep []common.Address) error {
 		}
 		errs = append(errs, os.RemoveAll(filepath.Join(d.datadir, entry.Name())))
 	}
+	for _, cache := range d.caches {
+		errs = append(errs, cache.Prune())
+	}
 	return errors.Join(errs...)
 }
@@ -1,6 +1,7 @@
 package game
 
 import (
+	"errors"
 	"os"
 	"path/filepath"
 	"testing"
@@ -55,3 +56,28 @@ func TestDiskManager_RemoveAllExcept(t *testing.T) {
 	require.DirExists(t, unexpectedDir, "should not delete unexpected dir")
 	require.DirExists(t, invalidHexDir, "should not delete dir with invalid address")
 }
+
+func TestDiskManager_RemoveAllExceptPrunesCaches(t *testing.T) {
+	baseDir := t.TempDir()
+	cache := &stubDiskCache{}
+	disk := newDiskManager(baseDir, cache)
+	cacheDir := filepath.Join(baseDir, snapshotCacheDir)
+	require.NoError(t, os.MkdirAll(cacheDir, 0777))
+
+	require.NoError(t, disk.RemoveAllExcept(nil))
+	require.Equal(t, 1, cache.pruneCalls)
+	require.DirExists(t, cacheDir, "should not delete shared cache")
+
+	cache.err = errors.New("boom")
+	require.ErrorIs(t, disk.RemoveAllExcept(nil), cache.err)
+}
+
+type stubDiskCache struct {
+	pruneCalls int
+	err        error
+}
+
+func (s *stubDiskCache) Prune() error {
+	s.pruneCalls++
+	return s.err
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -25,12 +26,6 @@ type MinimalDisputeGameFactoryCaller interface {
 	}, error)
 }
 
-type FaultDisputeGame struct {
-	GameType  uint8
-	Timestamp uint64
-	Proxy     common.Address
-}
-
 type gameLoader struct {
 	caller MinimalDisputeGameFactoryCaller
 }
@@ -43,7 +38,7 @@ func NewGameLoader(caller MinimalDisputeGameFactoryCaller) *gameLoader {
 }
 
 // FetchAllGamesAtBlock fetches all dispute games from the factory at a given block number.
-func (l *gameLoader) FetchAllGamesAtBlock(ctx context.Context, earliestTimestamp uint64, blockNumber *big.Int) ([]FaultDisputeGame, error) {
+func (l *gameLoader) FetchAllGamesAtBlock(ctx context.Context, earliestTimestamp uint64, blockNumber *big.Int) ([]types.GameMetadata, error) {
 	if blockNumber == nil {
 		return nil, ErrMissingBlockNumber
 	}
@@ -56,7 +51,7 @@ func (l *gameLoader) FetchAllGamesAtBlock(ctx context.Context, earliestTimestamp
 		return nil, fmt.Errorf("failed to fetch game count: %w", err)
 	}
 
-	games := make([]FaultDisputeGame, 0)
+	games := make([]types.GameMetadata, 0)
 	if gameCount.Uint64() == 0 {
 		return games, nil
 	}
@@ -5,11 +5,15 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/observer"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 )
@@ -24,90 +28,228 @@ type Responder interface {
 	PerformAction(ctx context.Context, action types.Action) error
 }
 
+// GameObserver receives the state of games that are played in observer mode.
+type GameObserver interface {
+	RecordGameObservation(obs observer.GameObservation)
+}
+
 type ClaimLoader interface {
 	FetchClaims(ctx context.Context) ([]types.Claim, error)
 }
 
 type Agent struct {
 	metrics                 metrics.Metricer
+	clock                   clock.Clock
 	fdgAddr                 common.Address
+	traceType               config.TraceType
 	solver                  *solver.GameSolver
 	loader                  ClaimLoader
 	responder               Responder
 	updater                 types.OracleUpdater
+	observer                GameObserver
 	maxDepth                int
+	gameDuration            time.Duration
 	agreeWithProposedOutput bool
 	log                     log.Logger
 }
 
-func NewAgent(m metrics.Metricer, addr common.Address, loader ClaimLoader, maxDepth int, trace types.TraceProvider, responder Responder, updater types.OracleUpdater, agreeWithProposedOutput bool, log log.Logger) *Agent {
+func NewAgent(m metrics.Metricer, cl clock.Clock, addr common.Address, traceType config.TraceType, loader ClaimLoader, maxDepth int, gameDuration time.Duration, trace types.TraceAccessor, responder Responder, updater types.OracleUpdater, agreeWithProposedOutput bool, log log.Logger) *Agent {
 	return &Agent{
 		metrics:                 m,
+		clock:                   cl,
 		fdgAddr:                 addr,
+		traceType:               traceType,
 		solver:                  solver.NewGameSolver(maxDepth, trace),
 		loader:                  loader,
 		responder:               responder,
 		updater:                 updater,
 		maxDepth:                maxDepth,
+		gameDuration:            gameDuration,
 		agreeWithProposedOutput: agreeWithProposedOutput,
 		log:                     log,
 	}
 }
 
+// NewObserverAgent creates an agent that calculates the actions required to play the game without performing them.
+// The actions and the status the game is trending towards are reported to the observer instead, so no
+// [Responder] or [types.OracleUpdater] is required.
+func NewObserverAgent(m metrics.Metricer, cl clock.Clock, addr common.Address, traceType config.TraceType, loader ClaimLoader, maxDepth int, gameDuration time.Duration, trace types.TraceAccessor, gameObserver GameObserver, agreeWithProposedOutput bool, log log.Logger) *Agent {
+	agent := NewAgent(m, cl, addr, traceType, loader, maxDepth, gameDuration, trace, nil, nil, agreeWithProposedOutput, log)
+	agent.observer = gameObserver
+	return agent
+}
+
 // Act iterates the game & performs all of the next actions.
 func (a *Agent) Act(ctx context.Context) error {
+	if a.observer != nil {
+		return a.observe(ctx)
+	}
 	if a.tryResolve(ctx) {
 		return nil
 	}
-	game, err := a.newGameFromContracts(ctx)
+	_, _, err := a.calculateActions(ctx, func(action prioritizedAction) {
+		a.performAction(ctx, action)
+	})
+	return err
+}
+
+// performAction updates the oracle data required by the action, if any, and sends it.
+func (a *Agent) performAction(ctx context.Context, action prioritizedAction) {
+	log := a.actionLogger(action)
+
+	if action.OracleData != nil {
+		a.log.Info("Updating oracle data", "oracleKey", action.OracleData.OracleKey, "oracleData", action.OracleData.OracleData)
+		if err := a.updater.UpdateOracle(ctx, action.OracleData); err != nil {
+			// Continue with the remaining actions so a failed step doesn't block responses to other claims
+			log.Error("Failed to load oracle data", "err", err)
+			return
+		}
+	}
+
+	switch action.Type {
+	case types.ActionTypeMove:
+		a.metrics.RecordGameMove(a.traceType.String())
+	case types.ActionTypeStep:
+		a.metrics.RecordGameStep(a.traceType.String())
+	}
+	log.Info("Performing action")
+	err := a.responder.PerformAction(ctx, action.Action)
 	if err != nil {
-		return fmt.Errorf("create game from contracts: %w", err)
+		log.Error("Action failed", "err", err)
 	}
+}
 
-	// Calculate the actions to take
-	actions, err := a.solver.CalculateNextActions(ctx, game)
+// observe calculates the actions required to progress the game and reports them to the observer
+// along with the status the game would resolve to if no further claims were made.
+func (a *Agent) observe(ctx context.Context) error {
+	actions := make([]observer.Action, 0)
+	game, expired, err := a.calculateActions(ctx, func(action prioritizedAction) {
+		a.actionLogger(action).Info("Would perform action")
+		a.metrics.RecordObservedAction(a.traceType.String())
+		actions = append(actions, newObservedAction(action))
+	})
 	if err != nil {
-		log.Error("Failed to calculate all required moves", "err", err)
+		return err
 	}
 
-	// Perform the actions
-	for _, action := range actions {
-		log := a.log.New("action", action.Type, "is_attack", action.IsAttack, "parent", action.ParentIdx)
-		if action.Type == types.ActionTypeStep {
-			log = log.New("prestate", common.Bytes2Hex(action.PreState), "proof", common.Bytes2Hex(action.ProofData))
-		} else {
-			log = log.New("value", action.Value)
-		}
+	claims := game.Claims()
+	expected := a.expectedStatus()
+	projected := projectedStatus(claims)
+	obs := observer.GameObservation{
+		Game:                    a.fdgAddr,
+		TraceType:               a.traceType.String(),
+		AgreeWithProposedOutput: a.agreeWithProposedOutput,
+		ClaimCount:              len(claims),
+		ExpectedStatus:          expected.String(),
+		ProjectedStatus:         projected.String(),
+		Actions:                 actions,
+		ExpiredActions:          expired,
+		ObservedAt:              a.clock.Now(),
+	}
+	if obs.TrendingIncorrect() {
+		a.log.Warn("Game is trending towards an incorrect resolution", "expected", expected, "projected", projected, "actions", len(actions))
+	}
+	a.metrics.RecordGameTrendingIncorrect(a.fdgAddr.String(), obs.TrendingIncorrect())
+	a.observer.RecordGameObservation(obs)
+	return nil
+}
 
-		if action.OracleData != nil {
-			a.log.Info("Updating oracle data", "oracleKey", action.OracleData.OracleKey, "oracleData", action.OracleData.OracleData)
-			if err := a.updater.UpdateOracle(ctx, action.OracleData); err != nil {
-				return fmt.Errorf("failed to load oracle data: %w", err)
-			}
-		}
+func newObservedAction(action prioritizedAction) observer.Action {
+	observed := observer.Action{
+		Type:             action.Type.String(),
+		ParentIdx:        action.ParentIdx,
+		IsAttack:         action.IsAttack,
+		RemainingSeconds: uint64(action.remaining / time.Second),
+	}
+	if action.Type == types.ActionTypeMove {
+		value := action.Value
+		observed.Value = &value
+	}
+	return observed
+}
 
-		switch action.Type {
-		case types.ActionTypeMove:
-			a.metrics.RecordGameMove()
-		case types.ActionTypeStep:
-			a.metrics.RecordGameStep()
+// calculateActions loads the game from the contract and calculates the actions to take, most urgent first.
+// Claims are ordered by the time left to respond to them before any action is calculated, and each action is passed
+// to handle as soon as it is calculated. Calculating a response to one claim, which may require running cannon,
+// then doesn't delay the responses to claims whose clock is closer to expiring.
+// Actions that can no longer be included before the clock of the claim to counter expires are dropped and the number
+// of dropped actions is returned.
+func (a *Agent) calculateActions(ctx context.Context, handle func(action prioritizedAction)) (types.Game, int, error) {
+	game, err := a.newGameFromContracts(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("create game from contracts: %w", err)
+	}
+
+	now := a.clock.Now()
+	prioritized, expired := prioritizeClaims(game, a.gameDuration, now)
+	dropped := 0
+	drop := func(action prioritizedAction) {
+		a.log.Warn("Dropping action, clock has expired", "action", action.Type, "is_attack", action.IsAttack, "parent", action.ParentIdx, "remaining", action.remaining)
+		a.metrics.RecordDroppedAction(a.traceType.String())
+		dropped++
+	}
+	for _, claim := range prioritized {
+		action := a.calculateAction(ctx, game, claim)
+		if action == nil {
+			continue
 		}
-		log.Info("Performing action")
-		err := a.responder.PerformAction(ctx, action)
-		if err != nil {
-			log.Error("Action failed", "err", err)
+		// Calculating the earlier actions may have taken long enough for this clock to expire
+		action.remaining -= a.clock.Now().Sub(now)
+		if action.remaining < minResponseTime {
+			drop(*action)
+			continue
 		}
+		handle(*action)
 	}
-	return nil
+	// Responses to claims whose clock has already expired are only calculated to report them as dropped
+	for _, claim := range expired {
+		if action := a.calculateAction(ctx, game, claim); action != nil {
+			drop(*action)
+		}
+	}
+	return game, dropped, nil
+}
+
+// calculateAction calculates the action to take in response to claim.
+// Returns nil if no response is required or it could not be calculated.
+func (a *Agent) calculateAction(ctx context.Context, game types.Game, claim prioritizedClaim) *prioritizedAction {
+	action, err := a.solver.CalculateNextAction(ctx, game, claim.Claim)
+	if err != nil {
+		a.log.Error("Failed to calculate required move", "claim", claim.ContractIndex, "err", err)
+		return nil
+	}
+	if action == nil {
+		return nil
+	}
+	return &prioritizedAction{*action, claim.remaining}
+}
+
+// actionLogger returns a logger with the details of the action, warning if the clock of the claim to counter is
+// close to expiring.
+func (a *Agent) actionLogger(action prioritizedAction) log.Logger {
+	log := a.log.New("action", action.Type, "is_attack", action.IsAttack, "parent", action.ParentIdx, "remaining", action.remaining)
+	if isClockExpiring(a.gameDuration, action.remaining) {
+		log.Warn("Clock of claim to counter is close to expiring")
+		a.metrics.RecordClockExpiring(a.traceType.String())
+	}
+	if action.Type == types.ActionTypeStep {
+		return log.New("prestate", common.Bytes2Hex(action.PreState), "proof", common.Bytes2Hex(action.ProofData))
+	}
+	return log.New("value", action.Value)
+}
+
+// expectedStatus returns the status the game resolves to if it is played correctly.
+func (a *Agent) expectedStatus() gameTypes.GameStatus {
+	if a.agreeWithProposedOutput {
+		return gameTypes.GameStatusChallengerWon
+	}
+	return gameTypes.GameStatusDefenderWon
 }
 
 // shouldResolve returns true if the agent should resolve the game.
 // This method will return false if the game is still in progress.
 func (a *Agent) shouldResolve(status gameTypes.GameStatus) bool {
-	expected := gameTypes.GameStatusDefenderWon
-	if a.agreeWithProposedOutput {
-		expected = gameTypes.GameStatusChallengerWon
-	}
+	expected := a.expectedStatus()
 	if expected != status {
 		a.log.Warn("Game will be lost", "expected", expected, "actual", status)
 	}
@@ -4,19 +4,27 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/observer"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
 
+const testGameDuration = 200 * time.Second
+
 // TestShouldResolve tests the resolution logic.
 func TestShouldResolve(t *testing.T) {
 	t.Run("AgreeWithProposedOutput", func(t *testing.T) {
@@ -108,15 +116,155 @@ func TestLoadClaimsWhenGameNotResolvable(t *testing.T) {
 	require.Zero(t, responder.resolveClaimCount, "should not send resolveClaim")
 }
 
+func TestPerformMostUrgentActionFirst(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t, false)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := 4
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcd", uint64(depth)))
+
+	// Both invalid claims must be countered, but the clock of the second is closer to expiring
+	root := claimBuilder.CreateRootClaim(true)
+	root.Clock = types.NewClock(0, 900)
+	lessUrgent := claimBuilder.AttackClaimWithValue(root, common.Hash{0x01})
+	lessUrgent.ContractIndex = 1
+	lessUrgent.Clock = types.NewClock(0, 990)
+	moreUrgent := claimBuilder.AttackClaimWithValue(root, common.Hash{0x02})
+	moreUrgent.ContractIndex = 2
+	moreUrgent.Clock = types.NewClock(0, 960)
+	claimLoader.claims = []types.Claim{root, lessUrgent, moreUrgent}
+
+	require.NoError(t, agent.Act(context.Background()))
+
+	require.Len(t, responder.actions, 2)
+	require.Equal(t, 2, responder.actions[0].ParentIdx)
+	require.Equal(t, 1, responder.actions[1].ParentIdx)
+}
+
+func TestCalculateEachActionInOrderOfUrgency(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t, false)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := 4
+	provider := alphabet.NewTraceProvider("abcd", uint64(depth))
+	claimBuilder := test.NewClaimBuilder(t, depth, provider)
+	cl := agent.clock.(*clock.DeterministicClock)
+	accessor := &slowTraceAccessor{
+		TraceAccessor: trace.NewSimpleTraceAccessor(depth, provider),
+		clock:         cl,
+		responder:     responder,
+		delays:        map[int]time.Duration{1: 80 * time.Second},
+		performed:     make(map[int]int),
+	}
+	agent.solver = solver.NewGameSolver(depth, accessor)
+
+	root := claimBuilder.CreateRootClaim(true)
+	root.Clock = types.NewClock(0, 900)
+	lessUrgent := claimBuilder.AttackClaimWithValue(root, common.Hash{0x01})
+	lessUrgent.ContractIndex = 1
+	lessUrgent.Clock = types.NewClock(0, 990)
+	moreUrgent := claimBuilder.AttackClaimWithValue(root, common.Hash{0x02})
+	moreUrgent.ContractIndex = 2
+	moreUrgent.Clock = types.NewClock(0, 960)
+	claimLoader.claims = []types.Claim{root, lessUrgent, moreUrgent}
+
+	require.NoError(t, agent.Act(context.Background()))
+
+	// The most urgent action is sent before the response to the next claim is calculated
+	require.Equal(t, 0, accessor.performed[2])
+	require.Equal(t, 1, accessor.performed[1])
+	// Calculating the response to the less urgent claim took long enough for its clock to expire
+	require.Len(t, responder.actions, 1)
+	require.Equal(t, 2, responder.actions[0].ParentIdx)
+}
+
+func TestDropExpiredActions(t *testing.T) {
+	agent, claimLoader, responder := setupTestAgent(t, false)
+	responder.callResolveErr = errors.New("game is not resolvable")
+	responder.callResolveClaimErr = errors.New("claim is not resolvable")
+	depth := 4
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcd", uint64(depth)))
+
+	root := claimBuilder.CreateRootClaim(true)
+	root.Clock = types.NewClock(0, 900)
+	invalid := claimBuilder.AttackClaim(root, false)
+	invalid.ContractIndex = 1
+	// The clock of the invalid claim has already run out
+	invalid.Clock = types.NewClock(0, 900)
+	claimLoader.claims = []types.Claim{root, invalid}
+
+	require.NoError(t, agent.Act(context.Background()))
+	require.Empty(t, responder.actions)
+}
+
+func TestObserveGame(t *testing.T) {
+	depth := 4
+	claimBuilder := test.NewClaimBuilder(t, depth, alphabet.NewTraceProvider("abcd", uint64(depth)))
+	root := claimBuilder.CreateRootClaim(true)
+	root.Clock = types.NewClock(0, 900)
+
+	t.Run("NoActionsRequired", func(t *testing.T) {
+		agent, claimLoader, store := setupObserverAgent(t, false)
+		claimLoader.claims = []types.Claim{root}
+
+		require.NoError(t, agent.Act(context.Background()))
+
+		obs, ok := store.Game(agent.fdgAddr)
+		require.True(t, ok)
+		require.Empty(t, obs.Actions)
+		require.Equal(t, 1, obs.ClaimCount)
+		require.Equal(t, gameTypes.GameStatusDefenderWon.String(), obs.ProjectedStatus)
+		require.False(t, obs.TrendingIncorrect())
+	})
+
+	t.Run("ReportActionsAndIncorrectTrend", func(t *testing.T) {
+		agent, claimLoader, store := setupObserverAgent(t, false)
+		lessUrgent := claimBuilder.AttackClaimWithValue(root, common.Hash{0x01})
+		lessUrgent.ContractIndex = 1
+		lessUrgent.Clock = types.NewClock(0, 990)
+		moreUrgent := claimBuilder.AttackClaimWithValue(root, common.Hash{0x02})
+		moreUrgent.ContractIndex = 2
+		moreUrgent.Clock = types.NewClock(0, 960)
+		claimLoader.claims = []types.Claim{root, lessUrgent, moreUrgent}
+
+		require.NoError(t, agent.Act(context.Background()))
+
+		obs, ok := store.Game(agent.fdgAddr)
+		require.True(t, ok)
+		require.Len(t, obs.Actions, 2)
+		require.Equal(t, 2, obs.Actions[0].ParentIdx)
+		require.Equal(t, 1, obs.Actions[1].ParentIdx)
+		require.Equal(t, types.ActionTypeMove.String(), obs.Actions[0].Type)
+		require.NotNil(t, obs.Actions[0].Value)
+		// The uncountered attacks on the root claim would make the challenger win
+		require.Equal(t, gameTypes.GameStatusDefenderWon.String(), obs.ExpectedStatus)
+		require.Equal(t, gameTypes.GameStatusChallengerWon.String(), obs.ProjectedStatus)
+		require.True(t, obs.TrendingIncorrect())
+	})
+}
+
+func setupObserverAgent(t *testing.T, agreeWithProposedOutput bool) (*Agent, *stubClaimLoader, *observer.Store) {
+	logger := testlog.Logger(t, log.LvlInfo)
+	claimLoader := &stubClaimLoader{}
+	addr := common.HexToAddress("0x1234")
+	depth := 4
+	provider := alphabet.NewTraceProvider("abcd", uint64(depth))
+	store := observer.NewStore()
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	agent := NewObserverAgent(metrics.NoopMetrics, cl, addr, config.TraceTypeAlphabet, claimLoader, depth, testGameDuration, trace.NewSimpleTraceAccessor(depth, provider), store, agreeWithProposedOutput, logger)
+	return agent, claimLoader, store
+}
+
 func setupTestAgent(t *testing.T, agreeWithProposedOutput bool) (*Agent, *stubClaimLoader, *stubResponder) {
 	logger := testlog.Logger(t, log.LvlInfo)
 	claimLoader := &stubClaimLoader{}
 	addr := common.HexToAddress("0x1234")
 	depth := 4
-	trace := alphabet.NewTraceProvider("abcd", uint64(depth))
+	provider := alphabet.NewTraceProvider("abcd", uint64(depth))
 	responder := &stubResponder{}
 	updater := &stubUpdater{}
-	agent := NewAgent(metrics.NoopMetrics, addr, claimLoader, depth, trace, responder, updater, agreeWithProposedOutput, logger)
+	cl := clock.NewDeterministicClock(time.Unix(1000, 0))
+	agent := NewAgent(metrics.NoopMetrics, cl, addr, config.TraceTypeAlphabet, claimLoader, depth, testGameDuration, trace.NewSimpleTraceAccessor(depth, provider), responder, updater, agreeWithProposedOutput, logger)
 	return agent, claimLoader, responder
 }
 
@@ -141,6 +289,8 @@ type stubResponder struct {
 	callResolveClaimCount int
 	callResolveClaimErr   error
 	resolveClaimCount     int
+
+	actions []types.Action
 }
 
 func (s *stubResponder) CallResolve(ctx context.Context) (gameTypes.GameStatus, error) {
@@ -164,9 +314,28 @@ func (s *stubResponder) ResolveClaim(ctx context.Context, clainIdx uint64) error
 }
 
 func (s *stubResponder) PerformAction(ctx context.Context, response types.Action) error {
+	s.actions = append(s.actions, response)
 	return nil
 }
 
+// slowTraceAccessor records how many actions were performed before the response to each claim was first
+// calculated and advances the clock to simulate slow calculations.
+type slowTraceAccessor struct {
+	types.TraceAccessor
+	clock     *clock.DeterministicClock
+	responder *stubResponder
+	delays    map[int]time.Duration
+	performed map[int]int
+}
+
+func (s *slowTraceAccessor) Get(ctx context.Context, game types.Game, ref types.Claim, pos types.Position) (common.Hash, error) {
+	if _, ok := s.performed[ref.ContractIndex]; !ok {
+		s.performed[ref.ContractIndex] = len(s.responder.actions)
+		s.clock.AdvanceTime(s.delays[ref.ContractIndex])
+	}
+	return s.TraceAccessor.Get(ctx, game, ref, pos)
+}
+
 type stubUpdater struct {
 }
 
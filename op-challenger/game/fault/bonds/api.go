@@ -0,0 +1,58 @@
+package bonds
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// RPCNamespace is the namespace the bonds API is served under.
+const RPCNamespace = "challenger"
+
+// Summary is the balance of the challenger and the totals of the bonds it posted across all games.
+type Summary struct {
+	Balance   *hexutil.Big `json:"balance"`
+	AtRisk    *hexutil.Big `json:"atRisk"`
+	Credit    *hexutil.Big `json:"credit"`
+	Forfeited *hexutil.Big `json:"forfeited"`
+	Games     []GameBonds  `json:"games"`
+}
+
+// API serves the bonds posted by the challenger over RPC.
+type API struct {
+	ledger  *Ledger
+	balance func(ctx context.Context) (*big.Int, error)
+}
+
+// NewAPI creates the bonds API. The balance function returns the balance of the challenger account.
+func NewAPI(ledger *Ledger, balance func(ctx context.Context) (*big.Int, error)) *API {
+	return &API{ledger: ledger, balance: balance}
+}
+
+// Bonds returns the balance of the challenger and the bonds it posted in every game.
+func (a *API) Bonds(ctx context.Context) (Summary, error) {
+	balance, err := a.balance(ctx)
+	if err != nil {
+		return Summary{}, fmt.Errorf("failed to fetch balance: %w", err)
+	}
+	atRisk, credit, forfeited := a.ledger.Totals()
+	return Summary{
+		Balance:   (*hexutil.Big)(balance),
+		AtRisk:    (*hexutil.Big)(atRisk),
+		Credit:    (*hexutil.Big)(credit),
+		Forfeited: (*hexutil.Big)(forfeited),
+		Games:     a.ledger.Games(),
+	}, nil
+}
+
+// GameBonds returns the bonds the challenger posted in a single game.
+func (a *API) GameBonds(_ context.Context, addr common.Address) (GameBonds, error) {
+	g, ok := a.ledger.Game(addr)
+	if !ok {
+		return GameBonds{}, fmt.Errorf("no bonds posted in game %v", addr)
+	}
+	return g, nil
+}
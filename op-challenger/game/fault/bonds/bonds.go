@@ -0,0 +1,202 @@
+package bonds
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// bondsFile is the file in the game directory that records the bonds posted in the game.
+const bondsFile = "bonds.json"
+
+var ErrClaimNotFound = errors.New("claim of bond not found")
+
+// Status is the expected outcome of a bond.
+type Status string
+
+const (
+	// StatusAtRisk is the status of a bond in a game that has not resolved yet.
+	StatusAtRisk Status = "atRisk"
+	// StatusCredit is the status of a bond whose claim was not countered when the game resolved,
+	// so the bond is owed back to the challenger.
+	StatusCredit Status = "credit"
+	// StatusForfeited is the status of a bond whose claim was countered when the game resolved,
+	// so the bond is lost to the claim that countered it.
+	StatusForfeited Status = "forfeited"
+)
+
+// Bond is a bond posted by the challenger with a move.
+type Bond struct {
+	ParentIdx int          `json:"parentIndex"`
+	IsAttack  bool         `json:"isAttack"`
+	Claim     common.Hash  `json:"claim"`
+	Amount    *hexutil.Big `json:"amount"`
+	TxHash    common.Hash  `json:"txHash"`
+	// ClaimIdx is the index of the claim the bond was posted with. It is set when the game resolves.
+	ClaimIdx *int   `json:"claimIndex,omitempty"`
+	Status   Status `json:"status"`
+}
+
+// GameBonds are the bonds posted by the challenger in a single game, with their totals by status.
+type GameBonds struct {
+	Game      common.Address `json:"game"`
+	Bonds     []Bond         `json:"bonds"`
+	AtRisk    *hexutil.Big   `json:"atRisk"`
+	Credit    *hexutil.Big   `json:"credit"`
+	Forfeited *hexutil.Big   `json:"forfeited"`
+}
+
+func newGameBonds(game common.Address, bonds []Bond) GameBonds {
+	g := GameBonds{
+		Game:      game,
+		Bonds:     bonds,
+		AtRisk:    (*hexutil.Big)(new(big.Int)),
+		Credit:    (*hexutil.Big)(new(big.Int)),
+		Forfeited: (*hexutil.Big)(new(big.Int)),
+	}
+	for _, bond := range bonds {
+		var total *big.Int
+		switch bond.Status {
+		case StatusCredit:
+			total = g.Credit.ToInt()
+		case StatusForfeited:
+			total = g.Forfeited.ToInt()
+		default:
+			total = g.AtRisk.ToInt()
+		}
+		total.Add(total, bond.Amount.ToInt())
+	}
+	return g
+}
+
+// Book records the bonds posted in a single game. The bonds are stored in the game directory,
+// so they are still accounted for when the challenger restarts.
+type Book struct {
+	lock   sync.Mutex
+	ledger *Ledger
+	game   common.Address
+	path   string
+	bonds  []Bond
+}
+
+// MoveBond returns the bond to post with each move.
+func (b *Book) MoveBond() *big.Int {
+	return b.ledger.MoveBond()
+}
+
+// RecordBond records a bond posted with a move, which is at risk until the game resolves.
+func (b *Book) RecordBond(parentIdx int, isAttack bool, claim common.Hash, amount *big.Int, txHash common.Hash) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	b.bonds = append(b.bonds, Bond{
+		ParentIdx: parentIdx,
+		IsAttack:  isAttack,
+		Claim:     claim,
+		Amount:    (*hexutil.Big)(new(big.Int).Set(amount)),
+		TxHash:    txHash,
+		Status:    StatusAtRisk,
+	})
+	return b.update()
+}
+
+// Bonds returns the bonds posted in the game, with their totals by status.
+func (b *Book) Bonds() GameBonds {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	return newGameBonds(b.game, append([]Bond(nil), b.bonds...))
+}
+
+// Settled returns true if no bonds of the game are at risk.
+func (b *Book) Settled() bool {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, bond := range b.bonds {
+		if bond.Status == StatusAtRisk {
+			return false
+		}
+	}
+	return true
+}
+
+// Settle computes the expected payout of each bond from the claims of the resolved game.
+// The FaultDisputeGame resolves the subgame of each claim, which leaves the claim countered
+// if any of its children won their own subgame. The bond of a claim that is not countered
+// is owed back to the challenger as credit, while the bond of a countered claim is forfeited.
+func (b *Book) Settle(claims []types.Claim) error {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for i, bond := range b.bonds {
+		if bond.Status != StatusAtRisk {
+			continue
+		}
+		claim, ok := findClaim(claims, bond)
+		if !ok {
+			return fmt.Errorf("%w: %v against claim %v in tx %v", ErrClaimNotFound, bond.Claim, bond.ParentIdx, bond.TxHash)
+		}
+		claimIdx := claim.ContractIndex
+		b.bonds[i].ClaimIdx = &claimIdx
+		if claim.Countered {
+			b.bonds[i].Status = StatusForfeited
+		} else {
+			b.bonds[i].Status = StatusCredit
+		}
+	}
+	return b.update()
+}
+
+// findClaim finds the claim the bond was posted with. There is only one claim with the same
+// value at the same position against the same parent.
+func findClaim(claims []types.Claim, bond Bond) (types.Claim, bool) {
+	for _, claim := range claims {
+		if claim.IsRoot() || claim.ParentContractIndex != bond.ParentIdx || claim.Value != bond.Claim {
+			continue
+		}
+		position := claim.Parent.Position.Defend()
+		if bond.IsAttack {
+			position = claim.Parent.Position.Attack()
+		}
+		if claim.Position == position {
+			return claim, true
+		}
+	}
+	return types.Claim{}, false
+}
+
+// update stores the bonds in the game directory and reports them to the ledger.
+func (b *Book) update() error {
+	b.ledger.update(newGameBonds(b.game, append([]Bond(nil), b.bonds...)))
+	data, err := json.Marshal(b.bonds)
+	if err != nil {
+		return fmt.Errorf("failed to encode bonds: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(b.path), 0755); err != nil {
+		return fmt.Errorf("failed to create game directory: %w", err)
+	}
+	if err := os.WriteFile(b.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write bonds: %w", err)
+	}
+	return nil
+}
+
+// loadBonds loads the bonds stored in the game directory, if any.
+func loadBonds(path string) ([]Bond, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read bonds: %w", err)
+	}
+	var bonds []Bond
+	if err := json.Unmarshal(data, &bonds); err != nil {
+		return nil, fmt.Errorf("failed to decode bonds: %w", err)
+	}
+	return bonds, nil
+}
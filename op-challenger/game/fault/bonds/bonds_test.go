@@ -0,0 +1,184 @@
+package bonds
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	gameAddr  = common.Address{0xaa}
+	otherGame = common.Address{0xbb}
+)
+
+func TestRecordBond(t *testing.T) {
+	m := &stubMetrics{}
+	ledger := NewLedger(m, big.NewInt(10))
+	book, err := ledger.OpenBook(gameAddr, t.TempDir())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(10), book.MoveBond())
+	require.True(t, book.Settled(), "no bonds at risk")
+
+	require.NoError(t, book.RecordBond(0, true, common.Hash{0x01}, big.NewInt(10), common.Hash{0xcc}))
+	require.NoError(t, book.RecordBond(1, false, common.Hash{0x02}, big.NewInt(10), common.Hash{0xdd}))
+	require.False(t, book.Settled())
+
+	bonds := book.Bonds()
+	require.Equal(t, gameAddr, bonds.Game)
+	require.Len(t, bonds.Bonds, 2)
+	require.Equal(t, StatusAtRisk, bonds.Bonds[0].Status)
+	require.Equal(t, big.NewInt(20), bonds.AtRisk.ToInt())
+	require.Zero(t, bonds.Credit.ToInt().Sign())
+	require.Zero(t, bonds.Forfeited.ToInt().Sign())
+
+	require.Equal(t, big.NewInt(20), m.atRisk)
+	require.Zero(t, m.credit.Sign())
+	require.Zero(t, m.forfeited.Sign())
+}
+
+func TestReloadBonds(t *testing.T) {
+	dir := t.TempDir()
+	book, err := NewLedger(&stubMetrics{}, big.NewInt(10)).OpenBook(gameAddr, dir)
+	require.NoError(t, err)
+	require.NoError(t, book.RecordBond(0, true, common.Hash{0x01}, big.NewInt(10), common.Hash{0xcc}))
+
+	m := &stubMetrics{}
+	ledger := NewLedger(m, big.NewInt(10))
+	reloaded, err := ledger.OpenBook(gameAddr, dir)
+	require.NoError(t, err)
+	require.Equal(t, book.Bonds(), reloaded.Bonds())
+	require.Equal(t, big.NewInt(10), m.atRisk, "should report reloaded bonds")
+
+	g, ok := ledger.Game(gameAddr)
+	require.True(t, ok)
+	require.Equal(t, book.Bonds(), g)
+}
+
+func TestSettle(t *testing.T) {
+	m := &stubMetrics{}
+	ledger := NewLedger(m, big.NewInt(10))
+	book, err := ledger.OpenBook(gameAddr, t.TempDir())
+	require.NoError(t, err)
+
+	root := types.Claim{ClaimData: types.ClaimData{Value: common.Hash{0x01}, Position: types.NewPositionFromGIndex(1)}}
+	attack := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0x02}, Position: root.Position.Attack()},
+		Parent:              root.ClaimData,
+		ParentContractIndex: 0,
+		ContractIndex:       1,
+	}
+	// counter has the same value against the same parent as defend but in the attack position
+	counter := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0x03}, Position: attack.Position.Attack()},
+		Parent:              attack.ClaimData,
+		ParentContractIndex: 1,
+		ContractIndex:       2,
+	}
+	defend := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0x03}, Position: attack.Position.Defend()},
+		Countered:           true,
+		Parent:              attack.ClaimData,
+		ParentContractIndex: 1,
+		ContractIndex:       3,
+	}
+	require.NoError(t, book.RecordBond(0, true, attack.Value, big.NewInt(10), common.Hash{0xcc}))
+	require.NoError(t, book.RecordBond(1, false, defend.Value, big.NewInt(15), common.Hash{0xdd}))
+
+	require.NoError(t, book.Settle([]types.Claim{root, attack, counter, defend}))
+	require.True(t, book.Settled())
+	bonds := book.Bonds()
+	require.Equal(t, StatusCredit, bonds.Bonds[0].Status)
+	require.Equal(t, 1, *bonds.Bonds[0].ClaimIdx)
+	require.Equal(t, StatusForfeited, bonds.Bonds[1].Status)
+	require.Equal(t, 3, *bonds.Bonds[1].ClaimIdx)
+	require.Zero(t, bonds.AtRisk.ToInt().Sign())
+	require.Equal(t, big.NewInt(10), bonds.Credit.ToInt())
+	require.Equal(t, big.NewInt(15), bonds.Forfeited.ToInt())
+
+	require.Zero(t, m.atRisk.Sign())
+	require.Equal(t, big.NewInt(10), m.credit)
+	require.Equal(t, big.NewInt(15), m.forfeited)
+}
+
+func TestSettleClaimNotFound(t *testing.T) {
+	book, err := NewLedger(&stubMetrics{}, big.NewInt(10)).OpenBook(gameAddr, t.TempDir())
+	require.NoError(t, err)
+	root := types.Claim{ClaimData: types.ClaimData{Value: common.Hash{0x01}, Position: types.NewPositionFromGIndex(1)}}
+	require.NoError(t, book.RecordBond(0, true, common.Hash{0x02}, big.NewInt(10), common.Hash{0xcc}))
+
+	err = book.Settle([]types.Claim{root})
+	require.ErrorIs(t, err, ErrClaimNotFound)
+	require.False(t, book.Settled())
+}
+
+func TestLedgerTotals(t *testing.T) {
+	m := &stubMetrics{}
+	ledger := NewLedger(m, big.NewInt(10))
+	book1, err := ledger.OpenBook(otherGame, t.TempDir())
+	require.NoError(t, err)
+	book2, err := ledger.OpenBook(gameAddr, t.TempDir())
+	require.NoError(t, err)
+
+	require.NoError(t, book1.RecordBond(0, true, common.Hash{0x01}, big.NewInt(10), common.Hash{0xcc}))
+	require.NoError(t, book2.RecordBond(0, true, common.Hash{0x02}, big.NewInt(7), common.Hash{0xdd}))
+
+	atRisk, credit, forfeited := ledger.Totals()
+	require.Equal(t, big.NewInt(17), atRisk)
+	require.Zero(t, credit.Sign())
+	require.Zero(t, forfeited.Sign())
+	require.Equal(t, big.NewInt(17), m.atRisk)
+
+	games := ledger.Games()
+	require.Len(t, games, 2)
+	require.Equal(t, gameAddr, games[0].Game, "should order games by address")
+	require.Equal(t, otherGame, games[1].Game)
+}
+
+func TestAPI(t *testing.T) {
+	ledger := NewLedger(&stubMetrics{}, big.NewInt(10))
+	book, err := ledger.OpenBook(gameAddr, t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, book.RecordBond(0, true, common.Hash{0x01}, big.NewInt(10), common.Hash{0xcc}))
+
+	api := NewAPI(ledger, func(ctx context.Context) (*big.Int, error) {
+		return big.NewInt(1000), nil
+	})
+	summary, err := api.Bonds(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(1000), summary.Balance.ToInt())
+	require.Equal(t, big.NewInt(10), summary.AtRisk.ToInt())
+	require.Zero(t, summary.Credit.ToInt().Sign())
+	require.Zero(t, summary.Forfeited.ToInt().Sign())
+	require.Equal(t, []GameBonds{book.Bonds()}, summary.Games)
+
+	g, err := api.GameBonds(context.Background(), gameAddr)
+	require.NoError(t, err)
+	require.Equal(t, book.Bonds(), g)
+
+	_, err = api.GameBonds(context.Background(), otherGame)
+	require.Error(t, err)
+
+	balanceErr := errors.New("boom")
+	api = NewAPI(ledger, func(ctx context.Context) (*big.Int, error) {
+		return nil, balanceErr
+	})
+	_, err = api.Bonds(context.Background())
+	require.ErrorIs(t, err, balanceErr)
+}
+
+type stubMetrics struct {
+	atRisk    *big.Int
+	credit    *big.Int
+	forfeited *big.Int
+}
+
+func (s *stubMetrics) RecordBonds(atRisk, credit, forfeited *big.Int) {
+	s.atRisk = atRisk
+	s.credit = credit
+	s.forfeited = forfeited
+}
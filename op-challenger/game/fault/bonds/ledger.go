@@ -0,0 +1,103 @@
+package bonds
+
+import (
+	"bytes"
+	"math/big"
+	"path/filepath"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slices"
+)
+
+type Metrics interface {
+	RecordBonds(atRisk, credit, forfeited *big.Int)
+}
+
+// Ledger holds the bonds posted by the challenger across all games, and reports their totals as metrics.
+// It is safe for concurrent use as games are played by multiple executors.
+type Ledger struct {
+	metrics  Metrics
+	moveBond *big.Int
+
+	lock  sync.RWMutex
+	games map[common.Address]GameBonds
+}
+
+// NewLedger creates a ledger for the bonds posted with each move, which may be zero.
+func NewLedger(m Metrics, moveBond *big.Int) *Ledger {
+	if moveBond == nil {
+		moveBond = new(big.Int)
+	}
+	return &Ledger{
+		metrics:  m,
+		moveBond: new(big.Int).Set(moveBond),
+		games:    make(map[common.Address]GameBonds),
+	}
+}
+
+// MoveBond returns the bond to post with each move.
+func (l *Ledger) MoveBond() *big.Int {
+	return new(big.Int).Set(l.moveBond)
+}
+
+// OpenBook returns the book of the bonds posted in the game, loading the bonds already stored in the game directory.
+func (l *Ledger) OpenBook(game common.Address, dir string) (*Book, error) {
+	path := filepath.Join(dir, bondsFile)
+	bonds, err := loadBonds(path)
+	if err != nil {
+		return nil, err
+	}
+	book := &Book{ledger: l, game: game, path: path, bonds: bonds}
+	if len(bonds) > 0 {
+		l.update(newGameBonds(game, bonds))
+	}
+	return book, nil
+}
+
+// Game returns the bonds posted in the game, if any.
+func (l *Ledger) Game(addr common.Address) (GameBonds, bool) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	g, ok := l.games[addr]
+	return g, ok
+}
+
+// Games returns the bonds of every game the challenger posted bonds in, ordered by game address.
+func (l *Ledger) Games() []GameBonds {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	games := make([]GameBonds, 0, len(l.games))
+	for _, g := range l.games {
+		games = append(games, g)
+	}
+	slices.SortFunc(games, func(a, b GameBonds) int {
+		return bytes.Compare(a.Game[:], b.Game[:])
+	})
+	return games
+}
+
+// Totals returns the bonds of all games that are at risk, owed back as credit and forfeited.
+func (l *Ledger) Totals() (atRisk *big.Int, credit *big.Int, forfeited *big.Int) {
+	l.lock.RLock()
+	defer l.lock.RUnlock()
+	return l.totals()
+}
+
+func (l *Ledger) totals() (atRisk *big.Int, credit *big.Int, forfeited *big.Int) {
+	atRisk, credit, forfeited = new(big.Int), new(big.Int), new(big.Int)
+	for _, g := range l.games {
+		atRisk.Add(atRisk, g.AtRisk.ToInt())
+		credit.Add(credit, g.Credit.ToInt())
+		forfeited.Add(forfeited, g.Forfeited.ToInt())
+	}
+	return atRisk, credit, forfeited
+}
+
+// update replaces the bonds of the game and records the new totals.
+func (l *Ledger) update(g GameBonds) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.games[g.Game] = g
+	l.metrics.RecordBonds(l.totals())
+}
@@ -3,6 +3,7 @@ package fault
 import (
 	"context"
 	"math/big"
+	"time"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
@@ -25,7 +26,14 @@ type MinimalFaultDisputeGameCaller interface {
 	Status(opts *bind.CallOpts) (uint8, error)
 	ClaimDataLen(opts *bind.CallOpts) (*big.Int, error)
 	MAXGAMEDEPTH(opts *bind.CallOpts) (*big.Int, error)
+	GAMEDURATION(opts *bind.CallOpts) (uint64, error)
 	ABSOLUTEPRESTATE(opts *bind.CallOpts) ([32]byte, error)
+	RootClaim(opts *bind.CallOpts) ([32]byte, error)
+	L2BlockNumber(opts *bind.CallOpts) (*big.Int, error)
+	Proposals(opts *bind.CallOpts) (struct {
+		Starting bindings.IFaultDisputeGameOutputProposal
+		Disputed bindings.IFaultDisputeGameOutputProposal
+	}, error)
 }
 
 // loader pulls in fault dispute game claim data periodically and over subscriptions.
@@ -78,6 +86,15 @@ func (l *loader) FetchGameDepth(ctx context.Context) (uint64, error) {
 	return gameDepth.Uint64(), nil
 }
 
+// FetchGameDuration fetches the total duration of the game, which is shared equally between both teams.
+func (l *loader) FetchGameDuration(ctx context.Context) (time.Duration, error) {
+	duration, err := l.caller.GAMEDURATION(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(duration) * time.Second, nil
+}
+
 // fetchClaim fetches a single [Claim] with a hydrated parent.
 func (l *loader) fetchClaim(ctx context.Context, arrIndex uint64) (types.Claim, error) {
 	callOpts := bind.CallOpts{
@@ -95,7 +112,7 @@ func (l *loader) fetchClaim(ctx context.Context, arrIndex uint64) (types.Claim,
 			Position: types.NewPositionFromGIndex(fetchedClaim.Position.Uint64()),
 		},
 		Countered:           fetchedClaim.Countered,
-		Clock:               fetchedClaim.Clock.Uint64(),
+		Clock:               types.DecodeClock(fetchedClaim.Clock),
 		ContractIndex:       int(arrIndex),
 		ParentContractIndex: int(fetchedClaim.ParentIndex),
 	}
@@ -151,3 +168,30 @@ func (l *loader) FetchAbsolutePrestateHash(ctx context.Context) (common.Hash, er
 
 	return absolutePrestate, nil
 }
+
+// FetchRootClaim fetches the value of the root claim of the fault dispute game.
+func (l *loader) FetchRootClaim(ctx context.Context) (common.Hash, error) {
+	rootClaim, err := l.caller.RootClaim(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return rootClaim, nil
+}
+
+// FetchL2BlockNumber fetches the L2 block number of the output proposal disputed by the fault dispute game.
+func (l *loader) FetchL2BlockNumber(ctx context.Context) (uint64, error) {
+	blockNum, err := l.caller.L2BlockNumber(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return 0, err
+	}
+	return blockNum.Uint64(), nil
+}
+
+// FetchDisputedOutputRoot fetches the output root of the output proposal disputed by the fault dispute game.
+func (l *loader) FetchDisputedOutputRoot(ctx context.Context) (common.Hash, error) {
+	proposals, err := l.caller.Proposals(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return proposals.Disputed.OutputRoot, nil
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"math/big"
 	"testing"
+	"time"
 
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 
@@ -18,8 +20,12 @@ var (
 	mockClaimDataError    = fmt.Errorf("claim data errored")
 	mockClaimLenError     = fmt.Errorf("claim len errored")
 	mockMaxGameDepthError = fmt.Errorf("max game depth errored")
+	mockGameDurationError = fmt.Errorf("game duration errored")
 	mockPrestateError     = fmt.Errorf("prestate errored")
 	mockStatusError       = fmt.Errorf("status errored")
+	mockRootClaimError    = fmt.Errorf("root claim errored")
+	mockL2BlockNumError   = fmt.Errorf("l2 block number errored")
+	mockProposalsError    = fmt.Errorf("proposals errored")
 )
 
 // TestLoader_GetGameStatus tests fetching the game status.
@@ -85,6 +91,27 @@ func TestLoader_FetchGameDepth(t *testing.T) {
 	})
 }
 
+// TestLoader_FetchGameDuration tests fetching the game duration.
+func TestLoader_FetchGameDuration(t *testing.T) {
+	t.Run("Succeeds", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.gameDuration = 1200
+		loader := NewLoader(mockCaller)
+		duration, err := loader.FetchGameDuration(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 20*time.Minute, duration)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.gameDurationError = true
+		loader := NewLoader(mockCaller)
+		duration, err := loader.FetchGameDuration(context.Background())
+		require.ErrorIs(t, err, mockGameDurationError)
+		require.Zero(t, duration)
+	})
+}
+
 // TestLoader_FetchAbsolutePrestateHash tests fetching the absolute prestate hash.
 func TestLoader_FetchAbsolutePrestateHash(t *testing.T) {
 	t.Run("Succeeds", func(t *testing.T) {
@@ -105,6 +132,66 @@ func TestLoader_FetchAbsolutePrestateHash(t *testing.T) {
 	})
 }
 
+// TestLoader_FetchRootClaim tests fetching the root claim.
+func TestLoader_FetchRootClaim(t *testing.T) {
+	t.Run("Succeeds", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.rootClaim = common.Hash{0xaa}
+		loader := NewLoader(mockCaller)
+		rootClaim, err := loader.FetchRootClaim(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{0xaa}, rootClaim)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.rootClaimError = true
+		loader := NewLoader(mockCaller)
+		_, err := loader.FetchRootClaim(context.Background())
+		require.ErrorIs(t, err, mockRootClaimError)
+	})
+}
+
+// TestLoader_FetchL2BlockNumber tests fetching the disputed L2 block number.
+func TestLoader_FetchL2BlockNumber(t *testing.T) {
+	t.Run("Succeeds", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.l2BlockNum = 123
+		loader := NewLoader(mockCaller)
+		blockNum, err := loader.FetchL2BlockNumber(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, uint64(123), blockNum)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.l2BlockNumError = true
+		loader := NewLoader(mockCaller)
+		_, err := loader.FetchL2BlockNumber(context.Background())
+		require.ErrorIs(t, err, mockL2BlockNumError)
+	})
+}
+
+// TestLoader_FetchDisputedOutputRoot tests fetching the disputed output root.
+func TestLoader_FetchDisputedOutputRoot(t *testing.T) {
+	t.Run("Succeeds", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.disputedOutput = common.Hash{0xbb}
+		loader := NewLoader(mockCaller)
+		outputRoot, err := loader.FetchDisputedOutputRoot(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, common.Hash{0xbb}, outputRoot)
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		mockCaller := newMockCaller()
+		mockCaller.proposalsError = true
+		loader := NewLoader(mockCaller)
+		_, err := loader.FetchDisputedOutputRoot(context.Background())
+		require.ErrorIs(t, err, mockProposalsError)
+	})
+}
+
 // TestLoader_FetchClaims tests fetching claims.
 func TestLoader_FetchClaims(t *testing.T) {
 	t.Run("Succeeds", func(t *testing.T) {
@@ -124,7 +211,7 @@ func TestLoader_FetchClaims(t *testing.T) {
 					Position: types.NewPositionFromGIndex(expectedClaims[0].Position.Uint64()),
 				},
 				Countered:     false,
-				Clock:         uint64(0),
+				Clock:         types.NewClock(0, 0),
 				ContractIndex: 0,
 			},
 			{
@@ -137,7 +224,7 @@ func TestLoader_FetchClaims(t *testing.T) {
 					Position: types.NewPositionFromGIndex(expectedClaims[1].Position.Uint64()),
 				},
 				Countered:     false,
-				Clock:         uint64(0),
+				Clock:         types.NewClock(0, 0),
 				ContractIndex: 1,
 			},
 			{
@@ -150,7 +237,7 @@ func TestLoader_FetchClaims(t *testing.T) {
 					Position: types.NewPositionFromGIndex(expectedClaims[2].Position.Uint64()),
 				},
 				Countered:     false,
-				Clock:         uint64(0),
+				Clock:         types.NewClock(0, 0),
 				ContractIndex: 2,
 			},
 		}, claims)
@@ -179,9 +266,17 @@ type mockCaller struct {
 	claimDataError    bool
 	claimLenError     bool
 	maxGameDepthError bool
+	gameDurationError bool
 	prestateError     bool
 	statusError       bool
+	rootClaimError    bool
+	l2BlockNumError   bool
+	proposalsError    bool
 	maxGameDepth      uint64
+	gameDuration      uint64
+	rootClaim         common.Hash
+	l2BlockNum        uint64
+	disputedOutput    common.Hash
 	currentIndex      uint64
 	status            uint8
 	returnClaims      []struct {
@@ -266,9 +361,45 @@ func (m *mockCaller) MAXGAMEDEPTH(opts *bind.CallOpts) (*big.Int, error) {
 	return big.NewInt(int64(m.maxGameDepth)), nil
 }
 
+func (m *mockCaller) GAMEDURATION(opts *bind.CallOpts) (uint64, error) {
+	if m.gameDurationError {
+		return 0, mockGameDurationError
+	}
+	return m.gameDuration, nil
+}
+
 func (m *mockCaller) ABSOLUTEPRESTATE(opts *bind.CallOpts) ([32]byte, error) {
 	if m.prestateError {
 		return [32]byte{}, mockPrestateError
 	}
 	return common.HexToHash("0xdEad"), nil
 }
+
+func (m *mockCaller) RootClaim(opts *bind.CallOpts) ([32]byte, error) {
+	if m.rootClaimError {
+		return [32]byte{}, mockRootClaimError
+	}
+	return m.rootClaim, nil
+}
+
+func (m *mockCaller) L2BlockNumber(opts *bind.CallOpts) (*big.Int, error) {
+	if m.l2BlockNumError {
+		return nil, mockL2BlockNumError
+	}
+	return new(big.Int).SetUint64(m.l2BlockNum), nil
+}
+
+func (m *mockCaller) Proposals(opts *bind.CallOpts) (struct {
+	Starting bindings.IFaultDisputeGameOutputProposal
+	Disputed bindings.IFaultDisputeGameOutputProposal
+}, error) {
+	var proposals struct {
+		Starting bindings.IFaultDisputeGameOutputProposal
+		Disputed bindings.IFaultDisputeGameOutputProposal
+	}
+	if m.proposalsError {
+		return proposals, mockProposalsError
+	}
+	proposals.Disputed.OutputRoot = m.disputedOutput
+	return proposals, nil
+}
@@ -3,16 +3,17 @@ package fault
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/bonds"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/responder"
-	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
-	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -21,9 +22,13 @@ import (
 
 type actor func(ctx context.Context) error
 
+// resourceCreator creates the trace type specific resources required to play a game.
+type resourceCreator func(logger log.Logger, addr common.Address, gameDepth uint64, loader *loader, dir string) (types.TraceAccessor, types.PrestateProvider, types.OracleUpdater, RootClaimValidator, error)
+
 type GameInfo interface {
 	GetGameStatus(context.Context) (gameTypes.GameStatus, error)
 	GetClaimCount(context.Context) (uint64, error)
+	FetchClaims(ctx context.Context) ([]types.Claim, error)
 }
 
 type GamePlayer struct {
@@ -32,19 +37,31 @@ type GamePlayer struct {
 	loader                  GameInfo
 	logger                  log.Logger
 	status                  gameTypes.GameStatus
+
+	// validator decides whether to defend or challenge the root claim before the game is first acted on.
+	validator   RootClaimValidator
+	createAgent func(agreeWithProposedOutput bool) actor
+
+	// bonds records the bonds posted in the game, which are settled once the game resolves.
+	// It is nil if no bonds are accounted for, e.g. in observer mode.
+	bonds *bonds.Book
 }
 
 func NewGamePlayer(
 	ctx context.Context,
 	logger log.Logger,
 	m metrics.Metricer,
-	cfg *config.Config,
+	cl clock.Clock,
+	traceType config.TraceType,
 	dir string,
 	addr common.Address,
 	txMgr txmgr.TxManager,
+	gameObserver GameObserver,
+	bondLedger *bonds.Ledger,
 	client bind.ContractCaller,
+	creator resourceCreator,
 ) (*GamePlayer, error) {
-	logger = logger.New("game", addr)
+	logger = logger.New("game", addr, "traceType", traceType)
 	contract, err := bindings.NewFaultDisputeGameCaller(addr, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to bind the fault dispute game contract: %w", err)
@@ -52,6 +69,17 @@ func NewGamePlayer(
 
 	loader := NewLoader(contract)
 
+	// Bonds are only posted when transactions are sent
+	var book *bonds.Book
+	var bondRecorder responder.BondRecorder
+	if gameObserver == nil && bondLedger != nil {
+		book, err = bondLedger.OpenBook(addr, dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load the bonds of the game: %w", err)
+		}
+		bondRecorder = book
+	}
+
 	status, err := loader.GetGameStatus(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch game status: %w", err)
@@ -60,14 +88,14 @@ func NewGamePlayer(
 		logger.Info("Game already resolved", "status", status)
 		// Game is already complete so skip creating the trace provider, loading game inputs etc.
 		return &GamePlayer{
-			logger:                  logger,
-			loader:                  loader,
-			agreeWithProposedOutput: cfg.AgreeWithProposedOutput,
-			status:                  status,
+			logger: logger,
+			loader: loader,
+			status: status,
 			// Act function does nothing because the game is already complete
 			act: func(ctx context.Context) error {
 				return nil
 			},
+			bonds: book,
 		}, nil
 	}
 
@@ -76,41 +104,47 @@ func NewGamePlayer(
 		return nil, fmt.Errorf("failed to fetch the game depth: %w", err)
 	}
 
-	var provider types.TraceProvider
-	var updater types.OracleUpdater
-	switch cfg.TraceType {
-	case config.TraceTypeCannon:
-		cannonProvider, err := cannon.NewTraceProvider(ctx, logger, m, cfg, client, dir, addr)
-		if err != nil {
-			return nil, fmt.Errorf("create cannon trace provider: %w", err)
-		}
-		provider = cannonProvider
-		updater, err = cannon.NewOracleUpdater(ctx, logger, txMgr, addr, client)
-		if err != nil {
-			return nil, fmt.Errorf("failed to create the cannon updater: %w", err)
-		}
-	case config.TraceTypeAlphabet:
-		provider = alphabet.NewTraceProvider(cfg.AlphabetTrace, gameDepth)
-		updater = alphabet.NewOracleUpdater(logger)
-	default:
-		return nil, fmt.Errorf("unsupported trace type: %v", cfg.TraceType)
+	gameDuration, err := loader.FetchGameDuration(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the game duration: %w", err)
+	}
+
+	accessor, prestateProvider, updater, validator, err := creator(logger, addr, gameDepth, loader, dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create trace accessor: %w", err)
 	}
 
-	if err := ValidateAbsolutePrestate(ctx, provider, loader); err != nil {
+	if err := ValidateAbsolutePrestate(ctx, prestateProvider, loader); err != nil {
 		return nil, fmt.Errorf("failed to validate absolute prestate: %w", err)
 	}
 
-	responder, err := responder.NewFaultResponder(logger, txMgr, addr)
+	if gameObserver != nil {
+		// Observe the game without sending any transactions
+		return &GamePlayer{
+			loader:    loader,
+			logger:    logger,
+			status:    status,
+			validator: validator,
+			createAgent: func(agreeWithProposedOutput bool) actor {
+				return NewObserverAgent(m, cl, addr, traceType, loader, int(gameDepth), gameDuration, accessor, gameObserver, agreeWithProposedOutput, logger).Act
+			},
+		}, nil
+	}
+
+	responder, err := responder.NewFaultResponder(logger, txMgr, addr, bondRecorder)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create the responder: %w", err)
 	}
 
 	return &GamePlayer{
-		act:                     NewAgent(m, addr, loader, int(gameDepth), provider, responder, updater, cfg.AgreeWithProposedOutput, logger).Act,
-		agreeWithProposedOutput: cfg.AgreeWithProposedOutput,
-		loader:                  loader,
-		logger:                  logger,
-		status:                  status,
+		loader:    loader,
+		logger:    logger,
+		status:    status,
+		validator: validator,
+		createAgent: func(agreeWithProposedOutput bool) actor {
+			return NewAgent(m, cl, addr, traceType, loader, int(gameDepth), gameDuration, accessor, responder, updater, agreeWithProposedOutput, logger).Act
+		},
+		bonds: book,
 	}, nil
 }
 
@@ -122,8 +156,18 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 	if g.status != gameTypes.GameStatusInProgress {
 		// Game is already complete so don't try to perform further actions.
 		g.logger.Trace("Skipping completed game")
+		g.settleBonds(ctx)
 		return g.status
 	}
+	if g.act == nil {
+		if err := g.checkRootClaim(ctx); errors.Is(err, ErrOutputNotAvailable) {
+			g.logger.Info("Skipping game until the trusted rollup node can provide the disputed output", "err", err)
+			return g.status
+		} else if err != nil {
+			g.logger.Error("Failed to check the root claim", "err", err)
+			return g.status
+		}
+	}
 	g.logger.Trace("Checking if actions are required")
 	if err := g.act(ctx); err != nil {
 		g.logger.Error("Error when acting on game", "err", err)
@@ -135,9 +179,44 @@ func (g *GamePlayer) ProgressGame(ctx context.Context) gameTypes.GameStatus {
 	}
 	g.logGameStatus(ctx, status)
 	g.status = status
+	if status != gameTypes.GameStatusInProgress {
+		g.settleBonds(ctx)
+	}
 	return status
 }
 
+// settleBonds computes the payouts of the bonds posted in the resolved game, unless they are settled already.
+// Settling is retried on the next update if it fails.
+func (g *GamePlayer) settleBonds(ctx context.Context) {
+	if g.bonds == nil || g.bonds.Settled() {
+		return
+	}
+	claims, err := g.loader.FetchClaims(ctx)
+	if err != nil {
+		g.logger.Error("Failed to fetch claims to settle bonds", "err", err)
+		return
+	}
+	if err := g.bonds.Settle(claims); err != nil {
+		g.logger.Error("Failed to settle bonds", "err", err)
+		return
+	}
+	settled := g.bonds.Bonds()
+	g.logger.Info("Settled bonds", "credit", settled.Credit, "forfeited", settled.Forfeited)
+}
+
+// checkRootClaim decides whether to defend or challenge the root claim and creates the agent that plays the game.
+func (g *GamePlayer) checkRootClaim(ctx context.Context) error {
+	agreeWithRootClaim, err := g.validator.AgreeWithRootClaim(ctx)
+	if err != nil {
+		return err
+	}
+	g.logger.Info("Checked root claim", "agreeWithRootClaim", agreeWithRootClaim)
+	// The game state agrees with the root claim level when it disagrees with the proposed output.
+	g.agreeWithProposedOutput = !agreeWithRootClaim
+	g.act = g.createAgent(g.agreeWithProposedOutput)
+	return nil
+}
+
 func (g *GamePlayer) logGameStatus(ctx context.Context, status gameTypes.GameStatus) {
 	if status == gameTypes.GameStatusInProgress {
 		claimCount, err := g.loader.GetClaimCount(ctx)
@@ -166,7 +245,7 @@ type PrestateLoader interface {
 }
 
 // ValidateAbsolutePrestate validates the absolute prestate of the fault game.
-func ValidateAbsolutePrestate(ctx context.Context, trace types.TraceProvider, loader PrestateLoader) error {
+func ValidateAbsolutePrestate(ctx context.Context, trace types.PrestateProvider, loader PrestateLoader) error {
 	providerPrestateHash, err := trace.AbsolutePreStateCommitment(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to get the trace provider's absolute prestate: %w", err)
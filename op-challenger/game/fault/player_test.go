@@ -4,9 +4,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math/big"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/bonds"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
@@ -115,6 +117,108 @@ func TestDoNotActOnCompleteGame(t *testing.T) {
 	}
 }
 
+func TestSettleBondsWhenGameComplete(t *testing.T) {
+	_, game, gameState := setupProgressGameTest(t, true)
+	ledger := bonds.NewLedger(&stubBondMetrics{}, big.NewInt(10))
+	book, err := ledger.OpenBook(common.Address{0xaa}, t.TempDir())
+	require.NoError(t, err)
+	game.bonds = book
+	root := types.Claim{ClaimData: types.ClaimData{Value: common.Hash{0x01}, Position: types.NewPositionFromGIndex(1)}}
+	claim := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{0x02}, Position: root.Position.Attack()},
+		Parent:              root.ClaimData,
+		ParentContractIndex: 0,
+		ContractIndex:       1,
+	}
+	gameState.claims = []types.Claim{root, claim}
+	require.NoError(t, book.RecordBond(0, true, claim.Value, big.NewInt(10), common.Hash{0xcc}))
+
+	game.ProgressGame(context.Background())
+	require.False(t, book.Settled(), "should not settle bonds while the game is in progress")
+
+	gameState.status = gameTypes.GameStatusChallengerWon
+	game.ProgressGame(context.Background())
+	require.True(t, book.Settled())
+	require.Equal(t, big.NewInt(10), book.Bonds().Credit.ToInt())
+	require.Equal(t, 1, gameState.fetchClaimsCount)
+
+	// Should not fetch the claims again once the bonds are settled
+	game.ProgressGame(context.Background())
+	require.Equal(t, 1, gameState.fetchClaimsCount)
+}
+
+func TestProgressGame_CheckRootClaim(t *testing.T) {
+	tests := []struct {
+		name                    string
+		agreeWithRootClaim      bool
+		agreeWithProposedOutput bool
+	}{
+		{"AgreeWithRootClaim", true, false},
+		{"DisagreeWithRootClaim", false, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			handler, game, gameState := setupProgressGameTest(t, false)
+			validator := &stubRootClaimValidator{agree: test.agreeWithRootClaim}
+			var agentAgreement []bool
+			game.act = nil
+			game.validator = validator
+			game.createAgent = func(agreeWithProposedOutput bool) actor {
+				agentAgreement = append(agentAgreement, agreeWithProposedOutput)
+				return gameState.Act
+			}
+
+			game.ProgressGame(context.Background())
+			require.Equal(t, 1, gameState.callCount, "should perform next actions")
+			require.Equal(t, []bool{test.agreeWithProposedOutput}, agentAgreement)
+			require.Equal(t, test.agreeWithProposedOutput, game.agreeWithProposedOutput)
+			require.NotNil(t, handler.FindLog(log.LvlInfo, "Checked root claim"))
+
+			// Should only check the root claim once
+			game.ProgressGame(context.Background())
+			require.Equal(t, 2, gameState.callCount, "should perform next actions")
+			require.Equal(t, 1, validator.callCount, "should not check root claim again")
+			require.Len(t, agentAgreement, 1, "should not create agent again")
+		})
+	}
+}
+
+func TestProgressGame_SkipWhenOutputNotAvailable(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, false)
+	validator := &stubRootClaimValidator{err: fmt.Errorf("%w: block 100 is not safe yet", ErrOutputNotAvailable)}
+	game.act = nil
+	game.validator = validator
+	game.createAgent = func(agreeWithProposedOutput bool) actor {
+		return gameState.Act
+	}
+
+	status := game.ProgressGame(context.Background())
+	require.Equal(t, gameTypes.GameStatusInProgress, status)
+	require.Zero(t, gameState.callCount, "should not act until the output is available")
+	require.NotNil(t, handler.FindLog(log.LvlInfo, "Skipping game until the trusted rollup node can provide the disputed output"))
+
+	// Should retry on the next update once the output is available
+	validator.err = nil
+	game.ProgressGame(context.Background())
+	require.Equal(t, 2, validator.callCount)
+	require.Equal(t, 1, gameState.callCount, "should act once the output is available")
+}
+
+func TestProgressGame_LogErrorFromRootClaimCheck(t *testing.T) {
+	handler, game, gameState := setupProgressGameTest(t, false)
+	validator := &stubRootClaimValidator{err: errors.New("boom")}
+	game.act = nil
+	game.validator = validator
+
+	status := game.ProgressGame(context.Background())
+	require.Equal(t, gameTypes.GameStatusInProgress, status)
+	require.Zero(t, gameState.callCount, "should not act")
+	errLog := handler.FindLog(log.LvlError, "Failed to check the root claim")
+	require.NotNil(t, errLog)
+	require.Equal(t, validator.err, errLog.GetContextValue("err"))
+}
+
 // TestValidateAbsolutePrestate tests that the absolute prestate is validated
 // correctly by the service component.
 func TestValidateAbsolutePrestate(t *testing.T) {
@@ -168,12 +272,25 @@ func setupProgressGameTest(t *testing.T, agreeWithProposedRoot bool) (*testlog.C
 	return handler, game, gameState
 }
 
+type stubRootClaimValidator struct {
+	agree     bool
+	err       error
+	callCount int
+}
+
+func (s *stubRootClaimValidator) AgreeWithRootClaim(_ context.Context) (bool, error) {
+	s.callCount++
+	return s.agree, s.err
+}
+
 type stubGameState struct {
-	status     gameTypes.GameStatus
-	claimCount uint64
-	callCount  int
-	actErr     error
-	Err        error
+	status           gameTypes.GameStatus
+	claimCount       uint64
+	claims           []types.Claim
+	fetchClaimsCount int
+	callCount        int
+	actErr           error
+	Err              error
 }
 
 func (s *stubGameState) Act(ctx context.Context) error {
@@ -189,6 +306,15 @@ func (s *stubGameState) GetClaimCount(ctx context.Context) (uint64, error) {
 	return s.claimCount, nil
 }
 
+func (s *stubGameState) FetchClaims(ctx context.Context) ([]types.Claim, error) {
+	s.fetchClaimsCount++
+	return s.claims, nil
+}
+
+type stubBondMetrics struct{}
+
+func (s *stubBondMetrics) RecordBonds(_, _, _ *big.Int) {}
+
 type mockTraceProvider struct {
 	prestateErrors bool
 	prestate       []byte
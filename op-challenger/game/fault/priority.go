@@ -0,0 +1,72 @@
+package fault
+
+import (
+	"cmp"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"golang.org/x/exp/slices"
+)
+
+const (
+	// minResponseTime is the minimum time that must remain on the clock for a response to be included on chain
+	// before the clock expires. Responses with less time remaining are dropped.
+	minResponseTime = 12 * time.Second
+
+	// clockExpiringDivisor determines when a claim that must be countered is close to expiring, which is when less
+	// than 1/clockExpiringDivisor of each team's share of the game duration remains to respond to it.
+	clockExpiringDivisor = 10
+)
+
+type prioritizedClaim struct {
+	types.Claim
+	remaining time.Duration
+}
+
+type prioritizedAction struct {
+	types.Action
+	remaining time.Duration
+}
+
+// prioritizeClaims orders the claims of the game by the time remaining on the clock to respond to them, most urgent
+// first, so that responses can be calculated and sent in that order. Claims that can no longer be responded to on
+// chain before that clock expires are returned separately.
+func prioritizeClaims(game types.Game, gameDuration time.Duration, now time.Time) (prioritized []prioritizedClaim, expired []prioritizedClaim) {
+	claims := make(map[int]types.Claim)
+	for _, claim := range game.Claims() {
+		claims[claim.ContractIndex] = claim
+	}
+	for _, claim := range game.Claims() {
+		remaining := remainingResponseTime(claims, game.MaxDepth(), gameDuration, now, claim)
+		if remaining < minResponseTime {
+			expired = append(expired, prioritizedClaim{claim, remaining})
+			continue
+		}
+		prioritized = append(prioritized, prioritizedClaim{claim, remaining})
+	}
+	slices.SortStableFunc(prioritized, func(a, b prioritizedClaim) int {
+		return cmp.Compare(a.remaining, b.remaining)
+	})
+	return prioritized, expired
+}
+
+// remainingResponseTime calculates how long is left to respond to a claim before its clock expires. This mirrors the
+// clock checks in the FaultDisputeGame contract. A move is rejected once the duration of the grandparent of the new
+// claim plus the time since the claim was posted exceeds half the game duration. Claims at the max depth are
+// countered with a step, which is too late once the claim can be resolved. That happens once the duration of the
+// claim plus the time since it was posted exceeds half the game duration.
+func remainingResponseTime(claims map[int]types.Claim, maxDepth uint64, gameDuration time.Duration, now time.Time, claim types.Claim) time.Duration {
+	var accumulated time.Duration
+	if uint64(claim.Depth()) == maxDepth {
+		accumulated = claim.Clock.Duration
+	} else if !claim.IsRoot() {
+		accumulated = claims[claim.ParentContractIndex].Clock.Duration
+	}
+	accumulated += now.Sub(claim.Clock.Timestamp)
+	return gameDuration/2 - accumulated
+}
+
+// isClockExpiring returns true if the remaining response time is close enough to expiring to raise an alert.
+func isClockExpiring(gameDuration time.Duration, remaining time.Duration) bool {
+	return remaining < gameDuration/2/clockExpiringDivisor
+}
@@ -0,0 +1,105 @@
+package fault
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const priorityGameDuration = 1000 * time.Second
+
+// priorityGame builds a game where each claim is posted with the given clock.
+type priorityGame struct {
+	t    *testing.T
+	game types.Game
+}
+
+func newPriorityGame(t *testing.T, rootTimestamp uint64) (*priorityGame, types.Claim) {
+	root := types.Claim{
+		ClaimData: types.ClaimData{Value: common.Hash{0xaa}, Position: types.NewPosition(0, 0)},
+		Clock:     types.NewClock(0, rootTimestamp),
+	}
+	return &priorityGame{t: t, game: types.NewGameState(false, root, 10)}, root
+}
+
+func (g *priorityGame) add(parent types.Claim, pos types.Position, duration uint64, timestamp uint64) types.Claim {
+	claim := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{byte(len(g.game.Claims()))}, Position: pos},
+		Clock:               types.NewClock(duration, timestamp),
+		Parent:              parent.ClaimData,
+		ContractIndex:       len(g.game.Claims()),
+		ParentContractIndex: parent.ContractIndex,
+	}
+	require.NoError(g.t, g.game.Put(claim))
+	return claim
+}
+
+func TestRemainingResponseTime(t *testing.T) {
+	builder, root := newPriorityGame(t, 1000)
+	child := builder.add(root, root.Attack(), 100, 1200)
+	grandchild := builder.add(child, child.Attack(), 150, 1250)
+	claims := make(map[int]types.Claim)
+	for _, claim := range builder.game.Claims() {
+		claims[claim.ContractIndex] = claim
+	}
+	now := time.Unix(1300, 0)
+
+	tests := []struct {
+		name     string
+		claim    types.Claim
+		maxDepth uint64
+		expected time.Duration
+	}{
+		// The root claim's clock started when the game was created, with no accumulated duration
+		{"MoveOnRoot", root, 10, 200 * time.Second},
+		// A move on the child accumulates the duration of the root claim
+		{"MoveOnChild", child, 10, 400 * time.Second},
+		// A move on the grandchild accumulates the duration of the child
+		{"MoveOnGrandchild", grandchild, 10, 350 * time.Second},
+		// A step must land before the grandchild can be resolved, accumulating the grandchild's own duration
+		{"StepOnGrandchild", grandchild, 2, 300 * time.Second},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, remainingResponseTime(claims, test.maxDepth, priorityGameDuration, now, test.claim))
+		})
+	}
+}
+
+func TestPrioritizeClaims(t *testing.T) {
+	builder, root := newPriorityGame(t, 1000)
+	child := builder.add(root, root.Attack(), 100, 1200)
+	grandchild := builder.add(child, child.Attack(), 150, 1250)
+	now := time.Unix(1300, 0)
+
+	prioritized, expired := prioritizeClaims(builder.game, priorityGameDuration, now)
+	require.Empty(t, expired)
+	require.Equal(t, []prioritizedClaim{
+		{root, 200 * time.Second},
+		{grandchild, 350 * time.Second},
+		{child, 400 * time.Second},
+	}, prioritized)
+}
+
+func TestPrioritizeClaimsDropsExpired(t *testing.T) {
+	builder, root := newPriorityGame(t, 1000)
+	child := builder.add(root, root.Attack(), 100, 1200)
+
+	// The root clock expires at 1500, so a response can't be included in time with less than minResponseTime left
+	now := time.Unix(1500, 0).Add(-minResponseTime + time.Second)
+	prioritized, expired := prioritizeClaims(builder.game, priorityGameDuration, now)
+	require.Len(t, prioritized, 1)
+	require.Equal(t, child, prioritized[0].Claim)
+	require.Len(t, expired, 1)
+	require.Equal(t, root, expired[0].Claim)
+	require.Equal(t, minResponseTime-time.Second, expired[0].remaining)
+}
+
+func TestIsClockExpiring(t *testing.T) {
+	require.False(t, isClockExpiring(priorityGameDuration, 50*time.Second))
+	require.True(t, isClockExpiring(priorityGameDuration, 49*time.Second))
+}
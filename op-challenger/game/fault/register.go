@@ -0,0 +1,125 @@
+package fault
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/bonds"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	faultTypes "github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-service/clock"
+	"github.com/ethereum-optimism/optimism/op-service/txmgr"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// RegisterGameTypes registers a player creator with the registry for the game type of each enabled trace type.
+// If gameObserver is not nil, games are only observed and txMgr may be nil.
+// Otherwise the bonds posted in each game are recorded in bondLedger.
+// Cannon executions are stored in snapshotCache and shared between games if it is not nil.
+func RegisterGameTypes(
+	r *registry.GameTypeRegistry,
+	ctx context.Context,
+	logger log.Logger,
+	m metrics.Metricer,
+	cl clock.Clock,
+	cfg *config.Config,
+	txMgr txmgr.TxManager,
+	gameObserver GameObserver,
+	bondLedger *bonds.Ledger,
+	client bind.ContractCaller,
+	rollupClient outputs.OutputRollupClient,
+	snapshotCache *cannon.SnapshotCache,
+) error {
+	if cfg.TraceTypeEnabled(config.TraceTypeCannon) {
+		resourceCreator := func(logger log.Logger, addr common.Address, gameDepth uint64, loader *loader, dir string) (faultTypes.TraceAccessor, faultTypes.PrestateProvider, faultTypes.OracleUpdater, RootClaimValidator, error) {
+			provider, err := cannon.NewTraceProvider(ctx, logger, m, cfg, client, dir, snapshotCache, addr)
+			if err != nil {
+				return nil, nil, nil, nil, fmt.Errorf("create cannon trace provider: %w", err)
+			}
+			updater, err := newCannonUpdater(ctx, logger, txMgr, addr, client, dir)
+			if err != nil {
+				return nil, nil, nil, nil, fmt.Errorf("failed to create the cannon updater: %w", err)
+			}
+			validator := NewCannonRootValidator(loader, rollupClient, provider, int(gameDepth))
+			return trace.NewSimpleTraceAccessor(int(gameDepth), provider), provider, updater, validator, nil
+		}
+		if err := registerGameType(r, ctx, logger, m, cl, config.TraceTypeCannon, txMgr, gameObserver, bondLedger, client, resourceCreator); err != nil {
+			return err
+		}
+	}
+	if cfg.TraceTypeEnabled(config.TraceTypeOutputCannon) {
+		resourceCreator := func(logger log.Logger, addr common.Address, gameDepth uint64, loader *loader, dir string) (faultTypes.TraceAccessor, faultTypes.PrestateProvider, faultTypes.OracleUpdater, RootClaimValidator, error) {
+			splitDepth := cfg.OutputCannonSplitDepth
+			if splitDepth >= gameDepth {
+				return nil, nil, nil, nil, fmt.Errorf("output cannon split depth %v must be less than the game depth %v", splitDepth, gameDepth)
+			}
+			accessor, err := outputs.NewOutputCannonTraceAccessor(ctx, logger, m, cfg, client, rollupClient, dir, snapshotCache, addr, int(splitDepth))
+			if err != nil {
+				return nil, nil, nil, nil, fmt.Errorf("create output cannon trace accessor: %w", err)
+			}
+			updater, err := newCannonUpdater(ctx, logger, txMgr, addr, client, dir)
+			if err != nil {
+				return nil, nil, nil, nil, fmt.Errorf("failed to create the cannon updater: %w", err)
+			}
+			validator := NewOutputRootValidator(loader, rollupClient)
+			return accessor, cannon.NewPrestateProvider(cfg.CannonAbsolutePreState), updater, validator, nil
+		}
+		if err := registerGameType(r, ctx, logger, m, cl, config.TraceTypeOutputCannon, txMgr, gameObserver, bondLedger, client, resourceCreator); err != nil {
+			return err
+		}
+	}
+	if cfg.TraceTypeEnabled(config.TraceTypeAlphabet) {
+		resourceCreator := func(logger log.Logger, addr common.Address, gameDepth uint64, loader *loader, dir string) (faultTypes.TraceAccessor, faultTypes.PrestateProvider, faultTypes.OracleUpdater, RootClaimValidator, error) {
+			provider := alphabet.NewTraceProvider(cfg.AlphabetTrace, gameDepth)
+			validator := NewTraceRootValidator(loader, provider, int(gameDepth))
+			return trace.NewSimpleTraceAccessor(int(gameDepth), provider), provider, alphabet.NewOracleUpdater(logger), validator, nil
+		}
+		if err := registerGameType(r, ctx, logger, m, cl, config.TraceTypeAlphabet, txMgr, gameObserver, bondLedger, client, resourceCreator); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func registerGameType(
+	r *registry.GameTypeRegistry,
+	ctx context.Context,
+	logger log.Logger,
+	m metrics.Metricer,
+	cl clock.Clock,
+	traceType config.TraceType,
+	txMgr txmgr.TxManager,
+	gameObserver GameObserver,
+	bondLedger *bonds.Ledger,
+	client bind.ContractCaller,
+	creator resourceCreator,
+) error {
+	gameType, err := traceType.GameType()
+	if err != nil {
+		return err
+	}
+	playerCreator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+		return NewGamePlayer(ctx, logger, m, cl, traceType, dir, game.Proxy, txMgr, gameObserver, bondLedger, client, creator)
+	}
+	r.RegisterGameType(gameType, playerCreator)
+	return nil
+}
+
+// newCannonUpdater creates the updater for the pre-image oracle used by the game.
+// No updater is required when there is no transaction manager because the game is only observed.
+func newCannonUpdater(ctx context.Context, logger log.Logger, txMgr txmgr.TxManager, addr common.Address, client bind.ContractCaller, dir string) (faultTypes.OracleUpdater, error) {
+	if txMgr == nil {
+		return nil, nil
+	}
+	return cannon.NewOracleUpdater(ctx, logger, txMgr, addr, client, dir)
+}
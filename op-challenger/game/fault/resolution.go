@@ -0,0 +1,36 @@
+package fault
+
+import (
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+// projectedStatus returns the status the game would resolve to if no further claims were made.
+// As in the contract, a claim with child claims is countered if any of its child claims is uncountered.
+// A claim without child claims is only countered if it was countered by a step. The contract also marks
+// claims as countered when a move is made against them, so the Countered flag of a claim with children is ignored.
+// The challenger wins if the root claim is countered.
+// Every child claim must come after its parent in claims, as in contract order.
+func projectedStatus(claims []types.Claim) gameTypes.GameStatus {
+	if len(claims) == 0 {
+		return gameTypes.GameStatusInProgress
+	}
+	hasChildren := make(map[int]bool, len(claims))
+	countered := make(map[int]bool, len(claims))
+	for i := len(claims) - 1; i >= 0; i-- {
+		claim := claims[i]
+		if !hasChildren[claim.ContractIndex] && claim.Countered {
+			countered[claim.ContractIndex] = true
+		}
+		if !claim.IsRoot() {
+			hasChildren[claim.ParentContractIndex] = true
+			if !countered[claim.ContractIndex] {
+				countered[claim.ParentContractIndex] = true
+			}
+		}
+	}
+	if countered[claims[0].ContractIndex] {
+		return gameTypes.GameStatusChallengerWon
+	}
+	return gameTypes.GameStatusDefenderWon
+}
@@ -0,0 +1,63 @@
+package fault
+
+import (
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProjectedStatus(t *testing.T) {
+	root := types.Claim{ClaimData: types.ClaimData{Position: types.NewPosition(0, 0)}}
+	counteredRoot := root
+	counteredRoot.Countered = true
+	child := func(idx int, parentIdx int, countered bool) types.Claim {
+		return types.Claim{
+			ClaimData:           types.ClaimData{Position: types.NewPosition(1, 0)},
+			Countered:           countered,
+			ContractIndex:       idx,
+			ParentContractIndex: parentIdx,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		claims   []types.Claim
+		expected gameTypes.GameStatus
+	}{
+		{"NoClaims", nil, gameTypes.GameStatusInProgress},
+		{"UncounteredRoot", []types.Claim{root}, gameTypes.GameStatusDefenderWon},
+		{"RootCounteredByChild", []types.Claim{root, child(1, 0, false)}, gameTypes.GameStatusChallengerWon},
+		{"ChildCounteredByStep", []types.Claim{root, child(1, 0, true)}, gameTypes.GameStatusDefenderWon},
+		{"ChildCounteredByGrandchild", []types.Claim{root, child(1, 0, false), child(2, 1, false)}, gameTypes.GameStatusDefenderWon},
+		{
+			// Moves mark their parent as countered in the contract, which must not count as countered by a step
+			name:     "ChildMarkedCounteredByGrandchild",
+			claims:   []types.Claim{counteredRoot, child(1, 0, true), child(2, 1, false)},
+			expected: gameTypes.GameStatusDefenderWon,
+		},
+		{
+			name:     "RootMarkedCounteredByChildCounteredByStep",
+			claims:   []types.Claim{counteredRoot, child(1, 0, true)},
+			expected: gameTypes.GameStatusDefenderWon,
+		},
+		{
+			// The root is countered as long as any one of its children is uncountered
+			name:     "OneUncounteredChild",
+			claims:   []types.Claim{root, child(1, 0, false), child(2, 0, false), child(3, 1, false)},
+			expected: gameTypes.GameStatusChallengerWon,
+		},
+		{
+			name:     "GrandchildCounteredByStep",
+			claims:   []types.Claim{root, child(1, 0, false), child(2, 1, true)},
+			expected: gameTypes.GameStatusChallengerWon,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expected, projectedStatus(test.claims))
+		})
+	}
+}
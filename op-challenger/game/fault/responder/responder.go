@@ -2,6 +2,7 @@ package responder
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
@@ -16,6 +17,12 @@ import (
 	"github.com/ethereum/go-ethereum/log"
 )
 
+// BondRecorder provides the bond to post with each move, and records the bonds that were posted.
+type BondRecorder interface {
+	MoveBond() *big.Int
+	RecordBond(parentIdx int, isAttack bool, claim common.Hash, amount *big.Int, txHash common.Hash) error
+}
+
 // FaultResponder implements the [Responder] interface to send onchain transactions.
 type FaultResponder struct {
 	log log.Logger
@@ -24,10 +31,13 @@ type FaultResponder struct {
 
 	fdgAddr common.Address
 	fdgAbi  *abi.ABI
+
+	bonds BondRecorder
 }
 
 // NewFaultResponder returns a new [FaultResponder].
-func NewFaultResponder(logger log.Logger, txManagr txmgr.TxManager, fdgAddr common.Address) (*FaultResponder, error) {
+// Moves are made without a bond if bonds is nil.
+func NewFaultResponder(logger log.Logger, txManagr txmgr.TxManager, fdgAddr common.Address, bonds BondRecorder) (*FaultResponder, error) {
 	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
 	if err != nil {
 		return nil, err
@@ -37,6 +47,7 @@ func NewFaultResponder(logger log.Logger, txManagr txmgr.TxManager, fdgAddr comm
 		txMgr:   txManagr,
 		fdgAddr: fdgAddr,
 		fdgAbi:  fdgAbi,
+		bonds:   bonds,
 	}, nil
 }
 
@@ -91,7 +102,8 @@ func (r *FaultResponder) Resolve(ctx context.Context) error {
 		return err
 	}
 
-	return r.sendTxAndWait(ctx, txData)
+	_, err = r.sendTxAndWait(ctx, txData, nil)
+	return err
 }
 
 // buildResolveClaimData creates the transaction data for the ResolveClaim function.
@@ -119,12 +131,14 @@ func (r *FaultResponder) ResolveClaim(ctx context.Context, claimIdx uint64) erro
 	if err != nil {
 		return err
 	}
-	return r.sendTxAndWait(ctx, txData)
+	_, err = r.sendTxAndWait(ctx, txData, nil)
+	return err
 }
 
 func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action) error {
 	var txData []byte
 	var err error
+	var bond *big.Int
 	switch action.Type {
 	case types.ActionTypeMove:
 		if action.IsAttack {
@@ -132,32 +146,45 @@ func (r *FaultResponder) PerformAction(ctx context.Context, action types.Action)
 		} else {
 			txData, err = r.buildFaultDefendData(action.ParentIdx, action.Value)
 		}
+		if r.bonds != nil {
+			bond = r.bonds.MoveBond()
+		}
 	case types.ActionTypeStep:
 		txData, err = r.buildStepTxData(uint64(action.ParentIdx), action.IsAttack, action.PreState, action.ProofData)
 	}
 	if err != nil {
 		return err
 	}
-	return r.sendTxAndWait(ctx, txData)
+	receipt, err := r.sendTxAndWait(ctx, txData, bond)
+	if err != nil {
+		return err
+	}
+	if bond != nil && bond.Sign() > 0 && receipt.Status == ethtypes.ReceiptStatusSuccessful {
+		if err := r.bonds.RecordBond(action.ParentIdx, action.IsAttack, action.Value, bond, receipt.TxHash); err != nil {
+			return fmt.Errorf("failed to record bond: %w", err)
+		}
+	}
+	return nil
 }
 
-// sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
+// sendTxAndWait sends a transaction with the given value, which may be nil, through the [txmgr] and waits for a receipt.
 // This sets the tx GasLimit to 0, performing gas estimation online through the [txmgr].
-func (r *FaultResponder) sendTxAndWait(ctx context.Context, txData []byte) error {
+func (r *FaultResponder) sendTxAndWait(ctx context.Context, txData []byte, value *big.Int) (*ethtypes.Receipt, error) {
 	receipt, err := r.txMgr.Send(ctx, txmgr.TxCandidate{
 		To:       &r.fdgAddr,
 		TxData:   txData,
 		GasLimit: 0,
+		Value:    value,
 	})
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if receipt.Status == ethtypes.ReceiptStatusFailed {
 		r.log.Error("Responder tx successfully published but reverted", "tx_hash", receipt.TxHash)
 	} else {
 		r.log.Debug("Responder tx successfully published", "tx_hash", receipt.TxHash)
 	}
-	return nil
+	return receipt, nil
 }
 
 // buildStepTxData creates the transaction data for the step function.
@@ -197,12 +197,62 @@ func TestPerformAction(t *testing.T) {
 		require.Len(t, mockTxMgr.sent, 1)
 		require.Equal(t, expected, mockTxMgr.sent[0].TxData)
 	})
+
+	t.Run("move posts bond", func(t *testing.T) {
+		responder, mockTxMgr := newTestFaultResponder(t)
+		bonds := &stubBondRecorder{bond: big.NewInt(100)}
+		responder.bonds = bonds
+		action := types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: 123,
+			IsAttack:  true,
+			Value:     common.Hash{0xaa},
+		}
+		err := responder.PerformAction(context.Background(), action)
+		require.NoError(t, err)
+
+		require.Len(t, mockTxMgr.sent, 1)
+		require.Equal(t, big.NewInt(100), mockTxMgr.sent[0].Value)
+		require.Len(t, bonds.recorded, 1)
+		require.Equal(t, recordedBond{parentIdx: 123, isAttack: true, claim: action.Value, amount: big.NewInt(100)}, bonds.recorded[0])
+	})
+
+	t.Run("step does not post bond", func(t *testing.T) {
+		responder, mockTxMgr := newTestFaultResponder(t)
+		bonds := &stubBondRecorder{bond: big.NewInt(100)}
+		responder.bonds = bonds
+		err := responder.PerformAction(context.Background(), types.Action{
+			Type:      types.ActionTypeStep,
+			ParentIdx: 123,
+			IsAttack:  true,
+			PreState:  []byte{1, 2, 3},
+			ProofData: []byte{4, 5, 6},
+		})
+		require.NoError(t, err)
+
+		require.Len(t, mockTxMgr.sent, 1)
+		require.Nil(t, mockTxMgr.sent[0].Value)
+		require.Empty(t, bonds.recorded)
+	})
+
+	t.Run("record bond fails", func(t *testing.T) {
+		responder, _ := newTestFaultResponder(t)
+		bonds := &stubBondRecorder{bond: big.NewInt(100), err: errors.New("boom")}
+		responder.bonds = bonds
+		err := responder.PerformAction(context.Background(), types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: 123,
+			IsAttack:  false,
+			Value:     common.Hash{0xaa},
+		})
+		require.ErrorIs(t, err, bonds.err)
+	})
 }
 
 func newTestFaultResponder(t *testing.T) (*FaultResponder, *mockTxManager) {
 	log := testlog.Logger(t, log.LvlError)
 	mockTxMgr := &mockTxManager{}
-	responder, err := NewFaultResponder(log, mockTxMgr, mockFdgAddress)
+	responder, err := NewFaultResponder(log, mockTxMgr, mockFdgAddress, nil)
 	require.NoError(t, err)
 	return responder, mockTxMgr
 }
@@ -250,3 +300,28 @@ func (m *mockTxManager) BlockNumber(ctx context.Context) (uint64, error) {
 func (m *mockTxManager) From() common.Address {
 	return m.from
 }
+
+type recordedBond struct {
+	parentIdx int
+	isAttack  bool
+	claim     common.Hash
+	amount    *big.Int
+}
+
+type stubBondRecorder struct {
+	bond     *big.Int
+	err      error
+	recorded []recordedBond
+}
+
+func (s *stubBondRecorder) MoveBond() *big.Int {
+	return s.bond
+}
+
+func (s *stubBondRecorder) RecordBond(parentIdx int, isAttack bool, claim common.Hash, amount *big.Int, _ common.Hash) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.recorded = append(s.recorded, recordedBond{parentIdx: parentIdx, isAttack: isAttack, claim: claim, amount: amount})
+	return nil
+}
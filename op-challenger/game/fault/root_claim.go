@@ -0,0 +1,165 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ErrOutputNotAvailable is returned when the trusted rollup node can't yet provide the canonical output
+// of the L2 block disputed by a game.
+var ErrOutputNotAvailable = errors.New("canonical output not available")
+
+// RootClaimValidator determines whether the challenger agrees with the root claim of a game.
+type RootClaimValidator interface {
+	AgreeWithRootClaim(ctx context.Context) (bool, error)
+}
+
+type RootClaimLoader interface {
+	FetchRootClaim(ctx context.Context) (common.Hash, error)
+	FetchL2BlockNumber(ctx context.Context) (uint64, error)
+}
+
+type DisputedOutputLoader interface {
+	RootClaimLoader
+	FetchDisputedOutputRoot(ctx context.Context) (common.Hash, error)
+}
+
+// OutputRootValidator validates games whose root claim is the output root of the disputed L2 block,
+// by comparing it with the canonical output root from the trusted rollup node.
+type OutputRootValidator struct {
+	loader       RootClaimLoader
+	rollupClient outputs.OutputRollupClient
+}
+
+func NewOutputRootValidator(loader RootClaimLoader, rollupClient outputs.OutputRollupClient) *OutputRootValidator {
+	return &OutputRootValidator{
+		loader:       loader,
+		rollupClient: rollupClient,
+	}
+}
+
+func (v *OutputRootValidator) AgreeWithRootClaim(ctx context.Context) (bool, error) {
+	rootClaim, err := v.loader.FetchRootClaim(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch root claim: %w", err)
+	}
+	blockNum, err := v.loader.FetchL2BlockNumber(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch L2 block number: %w", err)
+	}
+	canonical, err := fetchCanonicalOutputRoot(ctx, v.rollupClient, blockNum)
+	if err != nil {
+		return false, err
+	}
+	return rootClaim == canonical, nil
+}
+
+// CannonRootValidator validates games whose root claim is the final cannon state of the disputed output.
+// The VM status of the root claim asserts whether the disputed output root is valid, which is compared with
+// the canonical output root from the trusted rollup node. If the status is correct, the full root claim is
+// compared with the final state of the local cannon execution.
+type CannonRootValidator struct {
+	loader       DisputedOutputLoader
+	rollupClient outputs.OutputRollupClient
+	trace        types.TraceProvider
+	depth        int
+}
+
+func NewCannonRootValidator(loader DisputedOutputLoader, rollupClient outputs.OutputRollupClient, trace types.TraceProvider, depth int) *CannonRootValidator {
+	return &CannonRootValidator{
+		loader:       loader,
+		rollupClient: rollupClient,
+		trace:        trace,
+		depth:        depth,
+	}
+}
+
+func (v *CannonRootValidator) AgreeWithRootClaim(ctx context.Context) (bool, error) {
+	rootClaim, err := v.loader.FetchRootClaim(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch root claim: %w", err)
+	}
+	blockNum, err := v.loader.FetchL2BlockNumber(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch L2 block number: %w", err)
+	}
+	disputed, err := v.loader.FetchDisputedOutputRoot(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch disputed output root: %w", err)
+	}
+	canonical, err := fetchCanonicalOutputRoot(ctx, v.rollupClient, blockNum)
+	if err != nil {
+		return false, err
+	}
+	expectedStatus := uint8(mipsevm.VMStatusInvalid)
+	if disputed == canonical {
+		expectedStatus = mipsevm.VMStatusValid
+	}
+	if rootClaim[0] != expectedStatus {
+		// No need to run cannon, the status of an honest execution is known.
+		return false, nil
+	}
+	expected, err := v.trace.Get(ctx, types.NewPosition(0, 0).TraceIndex(v.depth))
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch the expected root claim: %w", err)
+	}
+	return rootClaim == expected, nil
+}
+
+// TraceRootValidator validates the root claim of a game against the final claim of a local trace.
+// It is used by trace types that have no canonical chain to compare with, such as the alphabet trace.
+type TraceRootValidator struct {
+	loader RootClaimLoader
+	trace  types.TraceProvider
+	depth  int
+}
+
+func NewTraceRootValidator(loader RootClaimLoader, trace types.TraceProvider, depth int) *TraceRootValidator {
+	return &TraceRootValidator{
+		loader: loader,
+		trace:  trace,
+		depth:  depth,
+	}
+}
+
+func (v *TraceRootValidator) AgreeWithRootClaim(ctx context.Context) (bool, error) {
+	rootClaim, err := v.loader.FetchRootClaim(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch root claim: %w", err)
+	}
+	expected, err := v.trace.Get(ctx, types.NewPosition(0, 0).TraceIndex(v.depth))
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch the expected root claim: %w", err)
+	}
+	return rootClaim == expected, nil
+}
+
+// fetchCanonicalOutputRoot fetches the output root of the L2 block from the trusted rollup node.
+// ErrOutputNotAvailable is returned if the node doesn't have the block yet or the block isn't safe yet.
+// Other errors, such as a failed connection to the node, are returned as is.
+func fetchCanonicalOutputRoot(ctx context.Context, rollupClient outputs.OutputRollupClient, blockNum uint64) (common.Hash, error) {
+	output, err := rollupClient.OutputAtBlock(ctx, blockNum)
+	if isNotFound(err) {
+		return common.Hash{}, fmt.Errorf("%w: failed to fetch output at block %v: %w", ErrOutputNotAvailable, blockNum, err)
+	} else if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to fetch output at block %v: %w", blockNum, err)
+	}
+	if output.Status == nil || output.Status.SafeL2.Number < blockNum {
+		return common.Hash{}, fmt.Errorf("%w: block %v is not safe yet", ErrOutputNotAvailable, blockNum)
+	}
+	return common.Hash(output.OutputRoot), nil
+}
+
+// isNotFound returns true if the error reports that the requested block is not found.
+// The rollup node only returns the message of the error over RPC, so it is matched as well.
+func isNotFound(err error) bool {
+	return err != nil && (errors.Is(err, ethereum.NotFound) || strings.Contains(err.Error(), ethereum.NotFound.Error()))
+}
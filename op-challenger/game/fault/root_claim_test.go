@@ -0,0 +1,213 @@
+package fault
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const disputedBlock = uint64(100)
+
+var (
+	canonicalOutput = common.Hash{0x01, 0xaa}
+	invalidOutput   = common.Hash{0x01, 0xbb}
+)
+
+func TestOutputRootValidator(t *testing.T) {
+	t.Run("AgreeWithCanonicalOutput", func(t *testing.T) {
+		loader := &stubRootClaimLoader{rootClaim: canonicalOutput, blockNum: disputedBlock}
+		validator := NewOutputRootValidator(loader, newStubRollupClient(disputedBlock))
+		agree, err := validator.AgreeWithRootClaim(context.Background())
+		require.NoError(t, err)
+		require.True(t, agree)
+	})
+
+	t.Run("DisagreeWithInvalidOutput", func(t *testing.T) {
+		loader := &stubRootClaimLoader{rootClaim: invalidOutput, blockNum: disputedBlock}
+		validator := NewOutputRootValidator(loader, newStubRollupClient(disputedBlock))
+		agree, err := validator.AgreeWithRootClaim(context.Background())
+		require.NoError(t, err)
+		require.False(t, agree)
+	})
+
+	t.Run("OutputNotSafe", func(t *testing.T) {
+		loader := &stubRootClaimLoader{rootClaim: canonicalOutput, blockNum: disputedBlock}
+		validator := NewOutputRootValidator(loader, newStubRollupClient(disputedBlock-1))
+		_, err := validator.AgreeWithRootClaim(context.Background())
+		require.ErrorIs(t, err, ErrOutputNotAvailable)
+	})
+
+	t.Run("OutputNotFound", func(t *testing.T) {
+		loader := &stubRootClaimLoader{rootClaim: canonicalOutput, blockNum: disputedBlock}
+		rollupClient := newStubRollupClient(disputedBlock)
+		rollupClient.err = ethereum.NotFound
+		validator := NewOutputRootValidator(loader, rollupClient)
+		_, err := validator.AgreeWithRootClaim(context.Background())
+		require.ErrorIs(t, err, ErrOutputNotAvailable)
+		require.ErrorIs(t, err, rollupClient.err)
+	})
+
+	t.Run("OutputNotFoundOverRPC", func(t *testing.T) {
+		loader := &stubRootClaimLoader{rootClaim: canonicalOutput, blockNum: disputedBlock}
+		rollupClient := newStubRollupClient(disputedBlock)
+		rollupClient.err = errors.New("failed to get L2 block ref with sync status: not found")
+		validator := NewOutputRootValidator(loader, rollupClient)
+		_, err := validator.AgreeWithRootClaim(context.Background())
+		require.ErrorIs(t, err, ErrOutputNotAvailable)
+	})
+
+	t.Run("OutputFetchFails", func(t *testing.T) {
+		loader := &stubRootClaimLoader{rootClaim: canonicalOutput, blockNum: disputedBlock}
+		rollupClient := newStubRollupClient(disputedBlock)
+		rollupClient.err = errors.New("connection refused")
+		validator := NewOutputRootValidator(loader, rollupClient)
+		_, err := validator.AgreeWithRootClaim(context.Background())
+		require.ErrorIs(t, err, rollupClient.err)
+		require.NotErrorIs(t, err, ErrOutputNotAvailable)
+	})
+
+	t.Run("RootClaimFetchFails", func(t *testing.T) {
+		loader := &stubRootClaimLoader{err: errors.New("boom")}
+		validator := NewOutputRootValidator(loader, newStubRollupClient(disputedBlock))
+		_, err := validator.AgreeWithRootClaim(context.Background())
+		require.ErrorIs(t, err, loader.err)
+		require.NotErrorIs(t, err, ErrOutputNotAvailable)
+	})
+}
+
+func TestCannonRootValidator(t *testing.T) {
+	validRoot := common.Hash{mipsevm.VMStatusValid, 0xcc}
+	invalidRoot := common.Hash{mipsevm.VMStatusInvalid, 0xcc}
+	tests := []struct {
+		name           string
+		rootClaim      common.Hash
+		disputedOutput common.Hash
+		traceRoot      common.Hash
+		expected       bool
+		runsTrace      bool
+	}{
+		{"ClaimInvalidForCanonicalOutput", invalidRoot, canonicalOutput, validRoot, false, false},
+		{"ClaimInvalidForInvalidOutput", invalidRoot, invalidOutput, invalidRoot, true, true},
+		{"ClaimValidForCanonicalOutput", validRoot, canonicalOutput, validRoot, true, true},
+		{"ClaimValidForInvalidOutput", validRoot, invalidOutput, invalidRoot, false, false},
+		{"ClaimPanic", common.Hash{mipsevm.VMStatusPanic, 0xcc}, invalidOutput, invalidRoot, false, false},
+		{"ClaimUnfinished", common.Hash{mipsevm.VMStatusUnfinished, 0xcc}, canonicalOutput, validRoot, false, false},
+		{"ClaimValidWrongState", common.Hash{mipsevm.VMStatusValid, 0xdd}, canonicalOutput, validRoot, false, true},
+		{"ClaimInvalidWrongState", common.Hash{mipsevm.VMStatusInvalid, 0xdd}, invalidOutput, invalidRoot, false, true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loader := &stubRootClaimLoader{rootClaim: test.rootClaim, blockNum: disputedBlock, disputedOutput: test.disputedOutput}
+			trace := &stubRootTraceProvider{root: test.traceRoot}
+			validator := NewCannonRootValidator(loader, newStubRollupClient(disputedBlock), trace, 4)
+			agree, err := validator.AgreeWithRootClaim(context.Background())
+			require.NoError(t, err)
+			require.Equal(t, test.expected, agree)
+			if test.runsTrace {
+				require.Equal(t, []uint64{(1 << 4) - 1}, trace.requested)
+			} else {
+				require.Empty(t, trace.requested, "no need to run the trace if the status is wrong")
+			}
+		})
+	}
+
+	t.Run("OutputNotSafe", func(t *testing.T) {
+		loader := &stubRootClaimLoader{rootClaim: invalidRoot, blockNum: disputedBlock, disputedOutput: canonicalOutput}
+		validator := NewCannonRootValidator(loader, newStubRollupClient(disputedBlock-1), &stubRootTraceProvider{}, 4)
+		_, err := validator.AgreeWithRootClaim(context.Background())
+		require.ErrorIs(t, err, ErrOutputNotAvailable)
+	})
+
+	t.Run("TraceFails", func(t *testing.T) {
+		loader := &stubRootClaimLoader{rootClaim: validRoot, blockNum: disputedBlock, disputedOutput: canonicalOutput}
+		trace := &stubRootTraceProvider{err: errors.New("boom")}
+		validator := NewCannonRootValidator(loader, newStubRollupClient(disputedBlock), trace, 4)
+		_, err := validator.AgreeWithRootClaim(context.Background())
+		require.ErrorIs(t, err, trace.err)
+	})
+}
+
+func TestTraceRootValidator(t *testing.T) {
+	depth := 4
+	provider := alphabet.NewTraceProvider("abcdefghijklmnop", uint64(depth))
+	expected, err := provider.Get(context.Background(), (1<<depth)-1)
+	require.NoError(t, err)
+
+	t.Run("Agree", func(t *testing.T) {
+		validator := NewTraceRootValidator(&stubRootClaimLoader{rootClaim: expected}, provider, depth)
+		agree, err := validator.AgreeWithRootClaim(context.Background())
+		require.NoError(t, err)
+		require.True(t, agree)
+	})
+
+	t.Run("Disagree", func(t *testing.T) {
+		validator := NewTraceRootValidator(&stubRootClaimLoader{rootClaim: common.Hash{0xdd}}, provider, depth)
+		agree, err := validator.AgreeWithRootClaim(context.Background())
+		require.NoError(t, err)
+		require.False(t, agree)
+	})
+}
+
+type stubRootClaimLoader struct {
+	rootClaim      common.Hash
+	blockNum       uint64
+	disputedOutput common.Hash
+	err            error
+}
+
+func (s *stubRootClaimLoader) FetchRootClaim(_ context.Context) (common.Hash, error) {
+	return s.rootClaim, s.err
+}
+
+func (s *stubRootClaimLoader) FetchL2BlockNumber(_ context.Context) (uint64, error) {
+	return s.blockNum, s.err
+}
+
+func (s *stubRootClaimLoader) FetchDisputedOutputRoot(_ context.Context) (common.Hash, error) {
+	return s.disputedOutput, s.err
+}
+
+type stubRootTraceProvider struct {
+	types.TraceProvider
+	root      common.Hash
+	err       error
+	requested []uint64
+}
+
+func (s *stubRootTraceProvider) Get(_ context.Context, i uint64) (common.Hash, error) {
+	s.requested = append(s.requested, i)
+	return s.root, s.err
+}
+
+type stubRollupClient struct {
+	safeHead uint64
+	err      error
+}
+
+func newStubRollupClient(safeHead uint64) *stubRollupClient {
+	return &stubRollupClient{safeHead: safeHead}
+}
+
+func (s *stubRollupClient) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	output := canonicalOutput
+	if blockNum != disputedBlock {
+		output = common.Hash{0xff}
+	}
+	return &eth.OutputResponse{
+		OutputRoot: eth.Bytes32(output),
+		BlockRef:   eth.L2BlockRef{Number: blockNum},
+		Status:     &eth.SyncStatus{SafeL2: eth.L2BlockRef{Number: s.safeHead}},
+	}, nil
+}
@@ -12,7 +12,7 @@ type GameSolver struct {
 	claimSolver *claimSolver
 }
 
-func NewGameSolver(gameDepth int, trace types.TraceProvider) *GameSolver {
+func NewGameSolver(gameDepth int, trace types.TraceAccessor) *GameSolver {
 	return &GameSolver{
 		claimSolver: newClaimSolver(gameDepth, trace),
 	}
@@ -22,13 +22,7 @@ func (s *GameSolver) CalculateNextActions(ctx context.Context, game types.Game)
 	var errs []error
 	var actions []types.Action
 	for _, claim := range game.Claims() {
-		var action *types.Action
-		var err error
-		if uint64(claim.Depth()) == game.MaxDepth() {
-			action, err = s.calculateStep(ctx, game, claim)
-		} else {
-			action, err = s.calculateMove(ctx, game, claim)
-		}
+		action, err := s.CalculateNextAction(ctx, game, claim)
 		if err != nil {
 			errs = append(errs, err)
 			continue
@@ -41,6 +35,15 @@ func (s *GameSolver) CalculateNextActions(ctx context.Context, game types.Game)
 	return actions, errors.Join(errs...)
 }
 
+// CalculateNextAction calculates the action to take in response to a single claim in the game.
+// Returns nil if no response to the claim is required.
+func (s *GameSolver) CalculateNextAction(ctx context.Context, game types.Game, claim types.Claim) (*types.Action, error) {
+	if uint64(claim.Depth()) == game.MaxDepth() {
+		return s.calculateStep(ctx, game, claim)
+	}
+	return s.calculateMove(ctx, game, claim)
+}
+
 func (s *GameSolver) calculateStep(ctx context.Context, game types.Game, claim types.Claim) (*types.Action, error) {
 	if claim.Countered {
 		return nil, nil
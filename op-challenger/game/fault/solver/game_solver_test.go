@@ -6,6 +6,8 @@ import (
 	"testing"
 
 	faulttest "github.com/ethereum-optimism/optimism/op-challenger/game/fault/test"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
 )
@@ -116,7 +118,7 @@ func TestCalculateNextActions(t *testing.T) {
 					i, claim.Position.ToGIndex(), claim.Position.TraceIndex(maxDepth), claim.ParentContractIndex, claim.Countered, claim.Value)
 			}
 
-			solver := NewGameSolver(maxDepth, claimBuilder.CorrectTraceProvider())
+			solver := NewGameSolver(maxDepth, trace.NewSimpleTraceAccessor(maxDepth, claimBuilder.CorrectTraceProvider()))
 			actions, err := solver.CalculateNextActions(context.Background(), game)
 			require.NoError(t, err)
 			for i, action := range actions {
@@ -131,6 +133,17 @@ func TestCalculateNextActions(t *testing.T) {
 				require.Containsf(t, actions, action, "Expected claim %v missing", i)
 			}
 			require.Len(t, actions, len(builder.ExpectedActions), "Incorrect number of actions")
+
+			// Calculating the response to each claim individually gives the same actions
+			var individual []types.Action
+			for _, claim := range game.Claims() {
+				action, err := solver.CalculateNextAction(context.Background(), game, claim)
+				require.NoError(t, err)
+				if action != nil {
+					individual = append(individual, *action)
+				}
+			}
+			require.Equal(t, actions, individual)
 		})
 	}
 }
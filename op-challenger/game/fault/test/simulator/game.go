@@ -0,0 +1,330 @@
+package simulator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Errors returned when an action would revert in the FaultDisputeGame contract.
+var (
+	ErrGameNotInProgress     = errors.New("game not in progress")
+	ErrCannotDefendRootClaim = errors.New("cannot defend root claim")
+	ErrGameDepthExceeded     = errors.New("game depth exceeded")
+	ErrClockTimeExceeded     = errors.New("clock time exceeded")
+	ErrClaimAlreadyExists    = errors.New("claim already exists")
+	ErrInvalidParent         = errors.New("invalid parent")
+	ErrInvalidPrestate       = errors.New("invalid prestate")
+	ErrValidStep             = errors.New("valid step")
+	ErrClockNotExpired       = errors.New("clock not expired")
+	ErrClaimAlreadyResolved  = errors.New("claim already resolved")
+	ErrOutOfOrderResolution  = errors.New("out of order resolution")
+	ErrClaimIndexOutOfBounds = errors.New("claim index out of bounds")
+	ErrTraceAncestorNotFound = errors.New("trace ancestor not found")
+)
+
+// rootParentContractIdx is the parent index of the root claim in the contract.
+const rootParentContractIdx = math.MaxUint32
+
+type claimData struct {
+	parentIndex int
+	value       common.Hash
+	position    types.Position
+	clock       types.Clock
+	countered   bool
+}
+
+type claimKey struct {
+	value       common.Hash
+	gindex      uint64
+	parentIndex int
+}
+
+// Game is an in-memory dispute game that mirrors the rules of the FaultDisputeGame contract, including
+// positions, chess clocks, steps and the resolution of subgames. Actions that would revert in the contract
+// return an error and leave the game unchanged.
+type Game struct {
+	vm               VM
+	maxDepth         int
+	duration         time.Duration
+	absolutePrestate common.Hash
+
+	now    time.Time
+	status gameTypes.GameStatus
+
+	claims                []claimData
+	subgames              map[int][]int
+	existing              map[claimKey]bool
+	subgameAtRootResolved bool
+}
+
+// NewGame creates a game with the root claim, created at start.
+func NewGame(vm VM, maxDepth int, duration time.Duration, absolutePrestate common.Hash, rootClaim common.Hash, start time.Time) *Game {
+	return &Game{
+		vm:               vm,
+		maxDepth:         maxDepth,
+		duration:         duration,
+		absolutePrestate: absolutePrestate,
+		now:              start,
+		status:           gameTypes.GameStatusInProgress,
+		claims: []claimData{{
+			parentIndex: rootParentContractIdx,
+			value:       rootClaim,
+			position:    types.NewPosition(0, 0),
+			clock:       types.Clock{Timestamp: start},
+		}},
+		subgames: make(map[int][]int),
+		existing: make(map[claimKey]bool),
+	}
+}
+
+func (g *Game) MaxDepth() int {
+	return g.maxDepth
+}
+
+func (g *Game) Duration() time.Duration {
+	return g.duration
+}
+
+func (g *Game) Now() time.Time {
+	return g.now
+}
+
+func (g *Game) Status() gameTypes.GameStatus {
+	return g.status
+}
+
+// AdvanceTime moves the current time of the game forward.
+func (g *Game) AdvanceTime(d time.Duration) {
+	g.now = g.now.Add(d)
+}
+
+// Claims returns the claims of the game in contract order, as loaded by the challenger.
+func (g *Game) Claims() []types.Claim {
+	claims := make([]types.Claim, 0, len(g.claims))
+	for i, data := range g.claims {
+		claim := types.Claim{
+			ClaimData:           types.ClaimData{Value: data.value, Position: data.position},
+			Countered:           data.countered,
+			Clock:               data.clock,
+			ContractIndex:       i,
+			ParentContractIndex: data.parentIndex,
+		}
+		if data.parentIndex != rootParentContractIdx {
+			parent := g.claims[data.parentIndex]
+			claim.Parent = types.ClaimData{Value: parent.value, Position: parent.position}
+		}
+		claims = append(claims, claim)
+	}
+	return claims
+}
+
+// State returns the game state as seen by a challenger that agrees or disagrees with the proposed output.
+func (g *Game) State(agreeWithProposedOutput bool) (types.Game, error) {
+	claims := g.Claims()
+	state := types.NewGameState(agreeWithProposedOutput, claims[0], uint64(g.maxDepth))
+	if err := state.PutAll(claims[1:]); err != nil {
+		return nil, fmt.Errorf("failed to load claims into the game state: %w", err)
+	}
+	return state, nil
+}
+
+// Perform applies an action calculated by a solver to the game.
+func (g *Game) Perform(action types.Action) error {
+	if action.Type == types.ActionTypeStep {
+		return g.Step(action.ParentIdx, action.IsAttack, action.PreState, action.ProofData)
+	}
+	return g.Move(action.ParentIdx, action.Value, action.IsAttack)
+}
+
+// Move counters the claim at parentIdx with a new claim, mirroring FaultDisputeGame.move.
+func (g *Game) Move(parentIdx int, value common.Hash, isAttack bool) error {
+	if g.status != gameTypes.GameStatusInProgress {
+		return ErrGameNotInProgress
+	}
+	if parentIdx == 0 && !isAttack {
+		return ErrCannotDefendRootClaim
+	}
+	if parentIdx < 0 || parentIdx >= len(g.claims) {
+		return ErrClaimIndexOutOfBounds
+	}
+	parent := g.claims[parentIdx]
+	position := parent.position.Attack()
+	if !isAttack {
+		position = parent.position.Defend()
+	}
+	if position.Depth() > g.maxDepth {
+		return ErrGameDepthExceeded
+	}
+
+	// The clock of the new claim continues from the clock of the grandparent
+	var grandparentDuration time.Duration
+	if parent.parentIndex != rootParentContractIdx {
+		grandparentDuration = g.claims[parent.parentIndex].clock.Duration
+	}
+	duration := grandparentDuration + g.now.Sub(parent.clock.Timestamp)
+	if duration > g.duration/2 {
+		return ErrClockTimeExceeded
+	}
+
+	key := claimKey{value: value, gindex: position.ToGIndex(), parentIndex: parentIdx}
+	if g.existing[key] {
+		return ErrClaimAlreadyExists
+	}
+	g.existing[key] = true
+	g.claims = append(g.claims, claimData{
+		parentIndex: parentIdx,
+		value:       value,
+		position:    position,
+		clock:       types.Clock{Duration: duration, Timestamp: g.now},
+	})
+	g.claims[parentIdx].countered = true
+	g.subgames[parentIdx] = append(g.subgames[parentIdx], len(g.claims)-1)
+	return nil
+}
+
+// Step counters the claim at claimIdx by executing a single instruction, mirroring FaultDisputeGame.step.
+func (g *Game) Step(claimIdx int, isAttack bool, stateData []byte, proof []byte) error {
+	if g.status != gameTypes.GameStatusInProgress {
+		return ErrGameNotInProgress
+	}
+	if claimIdx < 0 || claimIdx >= len(g.claims) {
+		return ErrClaimIndexOutOfBounds
+	}
+	parent := g.claims[claimIdx]
+	if parent.position.Depth() != g.maxDepth {
+		return ErrInvalidParent
+	}
+
+	var preStateClaim common.Hash
+	var postState claimData
+	if isAttack {
+		if parent.position.IndexAtDepth() == 0 {
+			preStateClaim = g.absolutePrestate
+		} else {
+			ancestor, err := g.findTraceAncestor(types.NewPositionFromGIndex(parent.position.ToGIndex()-1), parent.parentIndex)
+			if err != nil {
+				return err
+			}
+			preStateClaim = ancestor.value
+		}
+		postState = parent
+	} else {
+		preStateClaim = parent.value
+		ancestor, err := g.findTraceAncestor(types.NewPositionFromGIndex(parent.position.ToGIndex()+1), parent.parentIndex)
+		if err != nil {
+			return err
+		}
+		postState = ancestor
+	}
+
+	// The highest order byte of the claim is the VM status, which is ignored
+	if stateHash := crypto.Keccak256Hash(stateData); !bytes.Equal(stateHash[1:], preStateClaim[1:]) {
+		return ErrInvalidPrestate
+	}
+	post, err := g.vm.Step(stateData, proof)
+	if err != nil {
+		return err
+	}
+	validStep := post == postState.value
+	parentPostAgree := (parent.position.Depth()-postState.position.Depth())%2 == 0
+	if parentPostAgree == validStep {
+		return ErrValidStep
+	}
+	g.claims[claimIdx].countered = true
+	return nil
+}
+
+// findTraceAncestor walks up the claims from start to find the claim that commits to the same trace index as pos.
+func (g *Game) findTraceAncestor(pos types.Position, start int) (claimData, error) {
+	target := traceAncestor(pos)
+	idx := start
+	for idx != rootParentContractIdx {
+		claim := g.claims[idx]
+		if claim.position == target {
+			return claim, nil
+		}
+		idx = claim.parentIndex
+	}
+	return claimData{}, fmt.Errorf("%w: %v", ErrTraceAncestorNotFound, target.ToGIndex())
+}
+
+// traceAncestor returns the highest ancestor of pos that commits to the same trace index.
+func traceAncestor(pos types.Position) types.Position {
+	gindex := pos.ToGIndex()
+	for gindex&1 == 1 {
+		gindex >>= 1
+	}
+	return types.NewPositionFromGIndex(gindex)
+}
+
+// ResolveClaim resolves the subgame rooted at the claim, mirroring FaultDisputeGame.resolveClaim.
+func (g *Game) ResolveClaim(claimIdx int) error {
+	if g.status != gameTypes.GameStatusInProgress {
+		return ErrGameNotInProgress
+	}
+	if claimIdx < 0 || claimIdx >= len(g.claims) {
+		return ErrClaimIndexOutOfBounds
+	}
+	parent := g.claims[claimIdx]
+	if parent.clock.Duration+g.now.Sub(parent.clock.Timestamp) <= g.duration/2 {
+		return ErrClockNotExpired
+	}
+	challengeIndices := g.subgames[claimIdx]
+	if claimIdx == 0 && g.subgameAtRootResolved {
+		return ErrClaimAlreadyResolved
+	}
+	if len(challengeIndices) == 0 && claimIdx != 0 {
+		return ErrClaimAlreadyResolved
+	}
+	countered := false
+	for _, challengeIdx := range challengeIndices {
+		if len(g.subgames[challengeIdx]) != 0 {
+			return ErrOutOfOrderResolution
+		}
+		if !g.claims[challengeIdx].countered {
+			countered = true
+			break
+		}
+	}
+	g.claims[claimIdx].countered = countered
+	delete(g.subgames, claimIdx)
+	if claimIdx == 0 {
+		g.subgameAtRootResolved = true
+	}
+	return nil
+}
+
+// Resolve resolves the game once the subgame of the root claim is resolved, mirroring FaultDisputeGame.resolve.
+func (g *Game) Resolve() (gameTypes.GameStatus, error) {
+	if g.status != gameTypes.GameStatusInProgress {
+		return g.status, ErrGameNotInProgress
+	}
+	if !g.subgameAtRootResolved {
+		return g.status, ErrOutOfOrderResolution
+	}
+	if g.claims[0].countered {
+		g.status = gameTypes.GameStatusChallengerWon
+	} else {
+		g.status = gameTypes.GameStatusDefenderWon
+	}
+	return g.status, nil
+}
+
+// ResolveAll waits until every clock has expired, then resolves every subgame bottom up and resolves the game.
+func (g *Game) ResolveAll() (gameTypes.GameStatus, error) {
+	g.AdvanceTime(g.duration + time.Second)
+	for i := len(g.claims) - 1; i >= 0; i-- {
+		if err := g.ResolveClaim(i); err != nil && !errors.Is(err, ErrClaimAlreadyResolved) {
+			return gameTypes.GameStatusInProgress, fmt.Errorf("failed to resolve claim %v: %w", i, err)
+		}
+	}
+	return g.Resolve()
+}
@@ -0,0 +1,189 @@
+package simulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMaxDepth = 4
+	testDuration = 1000 * time.Second
+)
+
+var (
+	testStart  = time.Unix(1000, 0)
+	wrongClaim = common.Hash{0xbb}
+)
+
+func TestMove(t *testing.T) {
+	t.Run("CannotDefendRoot", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		require.ErrorIs(t, game.Move(0, common.Hash{0x01}, false), ErrCannotDefendRootClaim)
+	})
+
+	t.Run("InvalidParent", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		require.ErrorIs(t, game.Move(1, common.Hash{0x01}, true), ErrClaimIndexOutOfBounds)
+	})
+
+	t.Run("MarksParentCountered", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		require.NoError(t, game.Move(0, common.Hash{0x01}, true))
+		claims := game.Claims()
+		require.Len(t, claims, 2)
+		require.True(t, claims[0].Countered)
+		require.False(t, claims[1].Countered)
+		require.Equal(t, 0, claims[1].ParentContractIndex)
+		require.Equal(t, claims[0].ClaimData, claims[1].Parent)
+		require.Equal(t, types.NewPosition(1, 0), claims[1].Position)
+	})
+
+	t.Run("DuplicateClaim", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		require.NoError(t, game.Move(0, common.Hash{0x01}, true))
+		require.ErrorIs(t, game.Move(0, common.Hash{0x01}, true), ErrClaimAlreadyExists)
+		require.NoError(t, game.Move(0, common.Hash{0x02}, true))
+	})
+
+	t.Run("DepthExceeded", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		for i := 0; i < testMaxDepth; i++ {
+			require.NoError(t, game.Move(i, common.Hash{0x01}, true))
+		}
+		require.ErrorIs(t, game.Move(testMaxDepth, common.Hash{0x01}, true), ErrGameDepthExceeded)
+	})
+
+	t.Run("ClockTimeExceeded", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		game.AdvanceTime(testDuration/2 + time.Second)
+		require.ErrorIs(t, game.Move(0, common.Hash{0x01}, true), ErrClockTimeExceeded)
+	})
+
+	t.Run("ClockIncludesGrandparentDuration", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		game.AdvanceTime(400 * time.Second)
+		require.NoError(t, game.Move(0, common.Hash{0x01}, true))
+		require.NoError(t, game.Move(1, common.Hash{0x02}, true))
+		game.AdvanceTime(101 * time.Second)
+		require.ErrorIs(t, game.Move(2, common.Hash{0x03}, true), ErrClockTimeExceeded)
+		require.Equal(t, 400*time.Second, game.Claims()[1].Clock.Duration)
+	})
+}
+
+func TestStep(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("NotAtMaxDepth", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		require.ErrorIs(t, game.Step(0, true, nil, nil), ErrInvalidParent)
+	})
+
+	t.Run("AttackFromAbsolutePrestate", func(t *testing.T) {
+		game, provider := setupGame(t, wrongClaim)
+		// Dishonest claims all the way down the left hand side of the game
+		for i := 0; i < testMaxDepth; i++ {
+			require.NoError(t, game.Move(i, common.Hash{byte(i + 1)}, true))
+		}
+		preState, proof, _, err := provider.GetStepData(ctx, 0)
+		require.NoError(t, err)
+		require.NoError(t, game.Step(testMaxDepth, true, preState, proof))
+		require.True(t, game.Claims()[testMaxDepth].Countered)
+	})
+
+	t.Run("InvalidPrestate", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		for i := 0; i < testMaxDepth; i++ {
+			require.NoError(t, game.Move(i, common.Hash{byte(i + 1)}, true))
+		}
+		wrongPreState, proof, _, err := NewWrongPrestateTraceProvider(testMaxDepth).GetStepData(ctx, 0)
+		require.NoError(t, err)
+		require.ErrorIs(t, game.Step(testMaxDepth, true, wrongPreState, proof), ErrInvalidPrestate)
+	})
+
+	t.Run("ValidStep", func(t *testing.T) {
+		game, provider := setupGame(t, wrongClaim)
+		for i := 0; i < testMaxDepth-1; i++ {
+			require.NoError(t, game.Move(i, common.Hash{byte(i + 1)}, true))
+		}
+		// The leaf claim is correct so it can't be countered
+		correct, err := provider.Get(ctx, 0)
+		require.NoError(t, err)
+		require.NoError(t, game.Move(testMaxDepth-1, correct, true))
+		preState, proof, _, err := provider.GetStepData(ctx, 0)
+		require.NoError(t, err)
+		require.ErrorIs(t, game.Step(testMaxDepth, true, preState, proof), ErrValidStep)
+	})
+
+	t.Run("DefendFromLeafClaim", func(t *testing.T) {
+		game, provider := setupGame(t, wrongClaim)
+		for i := 0; i < testMaxDepth-1; i++ {
+			require.NoError(t, game.Move(i, common.Hash{byte(i + 1)}, true))
+		}
+		// Defends the claim at trace index 1 with the correct claim for trace index 2.
+		// The post-state of the step is then trace index 3, committed to by the claim at depth 2.
+		correct, err := provider.Get(ctx, 2)
+		require.NoError(t, err)
+		require.NoError(t, game.Move(testMaxDepth-1, correct, false))
+		require.Equal(t, uint64(2), game.Claims()[testMaxDepth].TraceIndex(testMaxDepth))
+		preState, proof, _, err := provider.GetStepData(ctx, 3)
+		require.NoError(t, err)
+		require.ErrorIs(t, game.Step(testMaxDepth, true, preState, proof), ErrInvalidPrestate)
+		require.NoError(t, game.Step(testMaxDepth, false, preState, proof))
+		require.True(t, game.Claims()[testMaxDepth].Countered)
+	})
+}
+
+func TestResolve(t *testing.T) {
+	t.Run("UncounteredRoot", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		status, err := game.ResolveAll()
+		require.NoError(t, err)
+		require.Equal(t, gameTypes.GameStatusDefenderWon, status)
+		require.Equal(t, gameTypes.GameStatusDefenderWon, game.Status())
+		require.ErrorIs(t, game.Move(0, common.Hash{0x01}, true), ErrGameNotInProgress)
+	})
+
+	t.Run("ClockNotExpired", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		require.ErrorIs(t, game.ResolveClaim(0), ErrClockNotExpired)
+		_, err := game.Resolve()
+		require.ErrorIs(t, err, ErrOutOfOrderResolution)
+	})
+
+	t.Run("OutOfOrderResolution", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		require.NoError(t, game.Move(0, common.Hash{0x01}, true))
+		require.NoError(t, game.Move(1, common.Hash{0x02}, true))
+		game.AdvanceTime(testDuration)
+		require.ErrorIs(t, game.ResolveClaim(0), ErrOutOfOrderResolution)
+		require.NoError(t, game.ResolveClaim(1))
+		require.ErrorIs(t, game.ResolveClaim(1), ErrClaimAlreadyResolved)
+		require.NoError(t, game.ResolveClaim(0))
+		status, err := game.Resolve()
+		require.NoError(t, err)
+		require.Equal(t, gameTypes.GameStatusDefenderWon, status)
+	})
+
+	t.Run("UncounteredChild", func(t *testing.T) {
+		game, _ := setupGame(t, wrongClaim)
+		require.NoError(t, game.Move(0, common.Hash{0x01}, true))
+		require.NoError(t, game.Move(1, common.Hash{0x02}, true))
+		require.NoError(t, game.Move(0, common.Hash{0x03}, true))
+		status, err := game.ResolveAll()
+		require.NoError(t, err)
+		require.Equal(t, gameTypes.GameStatusChallengerWon, status)
+	})
+}
+
+func setupGame(t *testing.T, rootClaim common.Hash) (*Game, types.TraceProvider) {
+	provider := NewAlphabetTraceProvider(testMaxDepth)
+	prestate, err := provider.AbsolutePreStateCommitment(context.Background())
+	require.NoError(t, err)
+	return NewGame(NewAlphabetVM(prestate), testMaxDepth, testDuration, prestate, rootClaim, testStart), provider
+}
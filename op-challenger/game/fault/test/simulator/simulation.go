@@ -0,0 +1,69 @@
+package simulator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+)
+
+var ErrTooManyRounds = errors.New("game did not finish within the maximum number of rounds")
+
+// Simulation plays a game between an honest player and any number of dishonest players.
+// Each round, the dishonest players act first followed by the honest player, then time advances by RoundTime.
+// The game is resolved once a round passes where none of the players make a successful action.
+type Simulation struct {
+	Game      *Game
+	Honest    Strategy
+	Dishonest []Strategy
+	RoundTime time.Duration
+	MaxRounds int
+}
+
+// Result is the outcome of a simulated game.
+type Result struct {
+	Status   gameTypes.GameStatus
+	Rounds   int
+	Reverted int
+	Claims   []types.Claim
+}
+
+func (s *Simulation) Run(ctx context.Context) (Result, error) {
+	var result Result
+	players := append([]Strategy{}, s.Dishonest...)
+	players = append(players, s.Honest)
+	for {
+		if result.Rounds >= s.MaxRounds {
+			return result, fmt.Errorf("%w: %v", ErrTooManyRounds, s.MaxRounds)
+		}
+		result.Rounds++
+		performed := 0
+		for _, player := range players {
+			actions, err := player.Actions(ctx, s.Game)
+			if err != nil {
+				return result, fmt.Errorf("failed to calculate actions in round %v: %w", result.Rounds, err)
+			}
+			for _, action := range actions {
+				if err := s.Game.Perform(action); err != nil {
+					result.Reverted++
+					continue
+				}
+				performed++
+			}
+		}
+		s.Game.AdvanceTime(s.RoundTime)
+		if performed == 0 {
+			break
+		}
+	}
+	result.Claims = s.Game.Claims()
+	status, err := s.Game.ResolveAll()
+	if err != nil {
+		return result, fmt.Errorf("failed to resolve game: %w", err)
+	}
+	result.Status = status
+	return result, nil
+}
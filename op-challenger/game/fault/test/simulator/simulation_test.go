@@ -0,0 +1,96 @@
+package simulator
+
+import (
+	"context"
+	"math/rand"
+	"testing"
+	"time"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRoundTime = 10 * time.Second
+	testMaxRounds = 1000
+)
+
+func TestHonestAlwaysWins(t *testing.T) {
+	strategies := []struct {
+		name   string
+		create func(agreeWithProposedOutput bool) Strategy
+	}{
+		{"NoOpponent", nil},
+		{"AlwaysAttack", func(_ bool) Strategy { return NewAlwaysAttackStrategy() }},
+		{"WrongPrestate", func(agree bool) Strategy { return NewWrongPrestateStrategy(testMaxDepth, !agree) }},
+		{"Freeloader", func(_ bool) Strategy {
+			return NewFreeloaderStrategy(testMaxDepth, NewAlphabetTraceProvider(testMaxDepth))
+		}},
+		{"Random", func(_ bool) Strategy {
+			return NewRandomStrategy(rand.New(rand.NewSource(1)), 5, NewAlphabetTraceProvider(testMaxDepth))
+		}},
+	}
+	for _, strategy := range strategies {
+		strategy := strategy
+		t.Run(strategy.name, func(t *testing.T) {
+			var dishonest []Strategy
+			if strategy.create != nil {
+				dishonest = []Strategy{strategy.create(true), strategy.create(false)}
+			}
+			for _, correctRoot := range []bool{true, false} {
+				correctRoot := correctRoot
+				result := runSimulation(t, correctRoot, dishonest...)
+				require.Equal(t, expectedStatus(correctRoot), result.Status, "correct root: %v", correctRoot)
+			}
+		})
+	}
+}
+
+func FuzzHonestAlwaysWins(f *testing.F) {
+	for i := int64(0); i < 10; i++ {
+		f.Add(i, i%2 == 0)
+	}
+	f.Fuzz(func(t *testing.T, seed int64, correctRoot bool) {
+		rng := rand.New(rand.NewSource(seed))
+		correct := NewAlphabetTraceProvider(testMaxDepth)
+		dishonest := []Strategy{
+			NewRandomStrategy(rng, 5, correct),
+			NewRandomStrategy(rng, 2, correct),
+			NewWrongPrestateStrategy(testMaxDepth, rng.Intn(2) == 0),
+		}
+		result := runSimulation(t, correctRoot, dishonest...)
+		require.Equal(t, expectedStatus(correctRoot), result.Status, "correct root: %v", correctRoot)
+	})
+}
+
+// runSimulation plays a game against the dishonest players where the honest player posts the correct alphabet trace.
+// The root claim is either the correct claim or an incorrect claim.
+func runSimulation(t *testing.T, correctRoot bool, dishonest ...Strategy) Result {
+	ctx := context.Background()
+	provider := NewAlphabetTraceProvider(testMaxDepth)
+	rootClaim := wrongClaim
+	if correctRoot {
+		correct, err := provider.Get(ctx, (1<<testMaxDepth)-1)
+		require.NoError(t, err)
+		rootClaim = correct
+	}
+	game, _ := setupGame(t, rootClaim)
+	sim := &Simulation{
+		Game: game,
+		// Agreeing with the proposed output means disputing the root claim
+		Honest:    NewSolverStrategy(testMaxDepth, provider, !correctRoot),
+		Dishonest: dishonest,
+		RoundTime: testRoundTime,
+		MaxRounds: testMaxRounds,
+	}
+	result, err := sim.Run(ctx)
+	require.NoError(t, err)
+	return result
+}
+
+func expectedStatus(correctRoot bool) gameTypes.GameStatus {
+	if correctRoot {
+		return gameTypes.GameStatusDefenderWon
+	}
+	return gameTypes.GameStatusChallengerWon
+}
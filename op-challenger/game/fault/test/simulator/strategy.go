@@ -0,0 +1,237 @@
+package simulator
+
+import (
+	"context"
+	"math/rand"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/solver"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Strategy decides the actions a player takes each time it is their turn.
+// Actions that revert are ignored, as a failed transaction would be.
+type Strategy interface {
+	Actions(ctx context.Context, game *Game) ([]types.Action, error)
+}
+
+// SolverStrategy plays the game with a [solver.GameSolver], as the challenger does.
+// It is honest when the trace is the correct trace.
+type SolverStrategy struct {
+	agreeWithProposedOutput bool
+	solver                  *solver.GameSolver
+}
+
+func NewSolverStrategy(maxDepth int, provider types.TraceProvider, agreeWithProposedOutput bool) *SolverStrategy {
+	return &SolverStrategy{
+		agreeWithProposedOutput: agreeWithProposedOutput,
+		solver:                  solver.NewGameSolver(maxDepth, trace.NewSimpleTraceAccessor(maxDepth, provider)),
+	}
+}
+
+func (s *SolverStrategy) Actions(ctx context.Context, game *Game) ([]types.Action, error) {
+	state, err := game.State(s.agreeWithProposedOutput)
+	if err != nil {
+		return nil, err
+	}
+	return s.solver.CalculateNextActions(ctx, state)
+}
+
+// NewWrongPrestateStrategy creates a solver that plays with a trace that starts from the wrong absolute prestate.
+// Every claim it makes is incorrect, and any step it makes from the absolute prestate reverts.
+func NewWrongPrestateStrategy(maxDepth int, agreeWithProposedOutput bool) *SolverStrategy {
+	return NewSolverStrategy(maxDepth, NewWrongPrestateTraceProvider(maxDepth), agreeWithProposedOutput)
+}
+
+// AlwaysAttackStrategy attacks every claim made by other players with an incorrect value.
+type AlwaysAttackStrategy struct {
+	attacked map[int]bool
+}
+
+func NewAlwaysAttackStrategy() *AlwaysAttackStrategy {
+	return &AlwaysAttackStrategy{attacked: make(map[int]bool)}
+}
+
+func (s *AlwaysAttackStrategy) Actions(_ context.Context, game *Game) ([]types.Action, error) {
+	var actions []types.Action
+	for _, claim := range game.Claims() {
+		if s.attacked[claim.ContractIndex] || claim.Depth() >= game.MaxDepth() {
+			continue
+		}
+		// Don't attack our own claims
+		if claim.Value == incorrectValue("always-attack", claim.Position) {
+			continue
+		}
+		s.attacked[claim.ContractIndex] = true
+		actions = append(actions, types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: claim.ContractIndex,
+			IsAttack:  true,
+			Value:     incorrectValue("always-attack", claim.Position.Attack()),
+		})
+	}
+	return actions, nil
+}
+
+// FreeloaderStrategy counters every claim with the correct value for the resulting position, whether or not
+// the claim should be countered. Its claims commit to the correct trace but are on paths that dispute it,
+// so they must not be mistaken for claims that support the honest player.
+type FreeloaderStrategy struct {
+	maxDepth  int
+	correct   types.TraceProvider
+	responded map[int]bool
+}
+
+func NewFreeloaderStrategy(maxDepth int, correct types.TraceProvider) *FreeloaderStrategy {
+	return &FreeloaderStrategy{
+		maxDepth:  maxDepth,
+		correct:   correct,
+		responded: make(map[int]bool),
+	}
+}
+
+func (s *FreeloaderStrategy) Actions(ctx context.Context, game *Game) ([]types.Action, error) {
+	var actions []types.Action
+	for _, claim := range game.Claims() {
+		if s.responded[claim.ContractIndex] || claim.Depth() >= s.maxDepth {
+			continue
+		}
+		s.responded[claim.ContractIndex] = true
+		for _, isAttack := range []bool{true, false} {
+			if claim.IsRoot() && !isAttack {
+				continue
+			}
+			pos := claim.Position.Attack()
+			if !isAttack {
+				pos = claim.Position.Defend()
+			}
+			value, err := s.correct.Get(ctx, pos.TraceIndex(s.maxDepth))
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, types.Action{
+				Type:      types.ActionTypeMove,
+				ParentIdx: claim.ContractIndex,
+				IsAttack:  isAttack,
+				Value:     value,
+			})
+		}
+	}
+	return actions, nil
+}
+
+// RandomStrategy makes random moves on random claims, using either random or correct values,
+// and randomly steps on leaf claims using the correct step data. Each leaf claim is only stepped on once.
+type RandomStrategy struct {
+	rng        *rand.Rand
+	maxActions int
+	correct    types.TraceProvider
+	stepped    map[int]bool
+}
+
+func NewRandomStrategy(rng *rand.Rand, maxActions int, correct types.TraceProvider) *RandomStrategy {
+	return &RandomStrategy{
+		rng:        rng,
+		maxActions: maxActions,
+		correct:    correct,
+		stepped:    make(map[int]bool),
+	}
+}
+
+func (s *RandomStrategy) Actions(ctx context.Context, game *Game) ([]types.Action, error) {
+	claims := game.Claims()
+	count := s.rng.Intn(s.maxActions + 1)
+	actions := make([]types.Action, 0, count)
+	for i := 0; i < count; i++ {
+		claim := claims[s.rng.Intn(len(claims))]
+		isAttack := claim.IsRoot() || s.rng.Intn(2) == 0
+		if claim.Depth() == game.MaxDepth() {
+			if s.stepped[claim.ContractIndex] {
+				continue
+			}
+			s.stepped[claim.ContractIndex] = true
+			traceIdx := claim.TraceIndex(game.MaxDepth())
+			if !isAttack {
+				traceIdx++
+			}
+			preState, proof, _, err := s.correct.GetStepData(ctx, traceIdx)
+			if err != nil {
+				return nil, err
+			}
+			actions = append(actions, types.Action{
+				Type:      types.ActionTypeStep,
+				ParentIdx: claim.ContractIndex,
+				IsAttack:  isAttack,
+				PreState:  preState,
+				ProofData: proof,
+			})
+			continue
+		}
+		pos := claim.Position.Attack()
+		if !isAttack {
+			pos = claim.Position.Defend()
+		}
+		var value common.Hash
+		if s.rng.Intn(2) == 0 {
+			correct, err := s.correct.Get(ctx, pos.TraceIndex(game.MaxDepth()))
+			if err != nil {
+				return nil, err
+			}
+			value = correct
+		} else {
+			s.rng.Read(value[:])
+		}
+		actions = append(actions, types.Action{
+			Type:      types.ActionTypeMove,
+			ParentIdx: claim.ContractIndex,
+			IsAttack:  isAttack,
+			Value:     value,
+		})
+	}
+	return actions, nil
+}
+
+// incorrectValue returns a value for the position that has no known pre-image.
+func incorrectValue(player string, pos types.Position) common.Hash {
+	return crypto.Keccak256Hash([]byte(player), common.Big0.SetUint64(pos.ToGIndex()).Bytes())
+}
+
+// NewAlphabetTraceProvider creates the correct alphabet trace for a game of the given depth.
+func NewAlphabetTraceProvider(maxDepth int) *alphabet.AlphabetTraceProvider {
+	return alphabet.NewTraceProvider(alphabetTrace(maxDepth, 'a'), uint64(maxDepth))
+}
+
+// alphabetTrace returns an alphabet trace with a state for every trace index of a game of the given depth.
+// Letters wrap around as the AlphabetVM only increments them.
+func alphabetTrace(maxDepth int, start byte) string {
+	letters := make([]byte, 1<<maxDepth)
+	for i := range letters {
+		letters[i] = start + byte(i)
+	}
+	return string(letters)
+}
+
+// wrongPrestateTraceProvider is an alphabet trace that starts one letter ahead of the correct trace,
+// as if it had executed from a different absolute prestate.
+type wrongPrestateTraceProvider struct {
+	*alphabet.AlphabetTraceProvider
+}
+
+func NewWrongPrestateTraceProvider(maxDepth int) types.TraceProvider {
+	return &wrongPrestateTraceProvider{alphabet.NewTraceProvider(alphabetTrace(maxDepth, 'b'), uint64(maxDepth))}
+}
+
+func (p *wrongPrestateTraceProvider) AbsolutePreState(_ context.Context) ([]byte, error) {
+	return common.Hex2Bytes("0000000000000000000000000000000000000000000000000000000000000061"), nil
+}
+
+func (p *wrongPrestateTraceProvider) GetStepData(ctx context.Context, i uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	if i == 0 {
+		prestate, err := p.AbsolutePreState(ctx)
+		return prestate, []byte{}, nil, err
+	}
+	return p.AlphabetTraceProvider.GetStepData(ctx, i)
+}
@@ -0,0 +1,48 @@
+package simulator
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// VM executes a single instruction for a step, returning the claim of the post-state.
+type VM interface {
+	Step(stateData []byte, proof []byte) (common.Hash, error)
+}
+
+// AlphabetVM mirrors the AlphabetVM contract used to play alphabet games.
+// Each state is a trace index and a letter, and each instruction moves to the next index and letter.
+type AlphabetVM struct {
+	absolutePrestate common.Hash
+}
+
+func NewAlphabetVM(absolutePrestate common.Hash) *AlphabetVM {
+	return &AlphabetVM{absolutePrestate: absolutePrestate}
+}
+
+func (v *AlphabetVM) Step(stateData []byte, _ []byte) (common.Hash, error) {
+	traceIndex := new(big.Int)
+	var claim *big.Int
+	if stateHash := crypto.Keccak256Hash(stateData); bytes.Equal(stateHash[1:], v.absolutePrestate[1:]) {
+		if len(stateData) < 32 {
+			return common.Hash{}, fmt.Errorf("invalid absolute prestate length: %v", len(stateData))
+		}
+		claim = new(big.Int).SetBytes(stateData[:32])
+	} else {
+		if len(stateData) < 64 {
+			return common.Hash{}, fmt.Errorf("invalid state length: %v", len(stateData))
+		}
+		traceIndex.SetBytes(stateData[:32])
+		traceIndex.Add(traceIndex, big.NewInt(1))
+		claim = new(big.Int).SetBytes(stateData[32:64])
+	}
+	claim.Add(claim, big.NewInt(1))
+	post := crypto.Keccak256Hash(common.BigToHash(traceIndex).Bytes(), common.BigToHash(claim).Bytes())
+	post[0] = mipsevm.VMStatusInvalid
+	return post, nil
+}
@@ -0,0 +1,47 @@
+package trace
+
+import (
+	"context"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ProviderSelector selects the [types.TraceProvider] that backs the given position when responding
+// to ref, and returns the index of the position in the trace of that provider.
+type ProviderSelector func(ctx context.Context, game types.Game, ref types.Claim, pos types.Position) (types.TraceProvider, uint64, error)
+
+// Accessor is a [types.TraceAccessor] that reads the claim values from the selected [types.TraceProvider].
+type Accessor struct {
+	selector ProviderSelector
+}
+
+var _ types.TraceAccessor = (*Accessor)(nil)
+
+// NewAccessor creates a new [Accessor] that uses the selector to pick the trace for each position.
+func NewAccessor(selector ProviderSelector) *Accessor {
+	return &Accessor{selector: selector}
+}
+
+// NewSimpleTraceAccessor creates a new [Accessor] for a game that is backed by a single trace of the given depth.
+func NewSimpleTraceAccessor(depth int, trace types.TraceProvider) *Accessor {
+	return NewAccessor(func(_ context.Context, _ types.Game, _ types.Claim, pos types.Position) (types.TraceProvider, uint64, error) {
+		return trace, pos.TraceIndex(depth), nil
+	})
+}
+
+func (t *Accessor) Get(ctx context.Context, game types.Game, ref types.Claim, pos types.Position) (common.Hash, error) {
+	provider, i, err := t.selector(ctx, game, ref, pos)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return provider.Get(ctx, i)
+}
+
+func (t *Accessor) GetStepData(ctx context.Context, game types.Game, ref types.Claim, pos types.Position) ([]byte, []byte, *types.PreimageOracleData, error) {
+	provider, i, err := t.selector(ctx, game, ref, pos)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return provider.GetStepData(ctx, i)
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/cannon/mipsevm"
+	"github.com/ethereum-optimism/optimism/cannon/serialize"
 	"github.com/stretchr/testify/require"
 )
 
@@ -47,4 +48,18 @@ func TestLoadState(t *testing.T) {
 		require.NoError(t, json.Unmarshal(testState, &expected))
 		require.Equal(t, &expected, state)
 	})
+
+	for _, name := range []string{"state.bin", "state.bin.gz"} {
+		name := name
+		t.Run("Binary-"+name, func(t *testing.T) {
+			var expected mipsevm.State
+			require.NoError(t, json.Unmarshal(testState, &expected))
+			path := filepath.Join(t.TempDir(), name)
+			require.NoError(t, serialize.WriteState(path, &expected))
+
+			state, err := parseState(path)
+			require.NoError(t, err)
+			require.Equal(t, &expected, state)
+		})
+	}
 }
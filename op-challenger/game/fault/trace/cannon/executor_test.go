@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
@@ -24,7 +25,7 @@ func TestGenerateProof(t *testing.T) {
 	input := "starting.json"
 	tempDir := t.TempDir()
 	dir := filepath.Join(tempDir, "gameDir")
-	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8888", config.TraceTypeCannon, true, tempDir)
+	cfg := config.NewConfig(common.Address{0xbb}, "http://localhost:8888", tempDir, config.TraceTypeCannon)
 	cfg.CannonAbsolutePreState = "pre.json"
 	cfg.CannonBin = "./bin/cannon"
 	cfg.CannonServer = "./bin/op-program"
@@ -94,10 +95,11 @@ func TestGenerateProof(t *testing.T) {
 		require.Equal(t, cfg.CannonL2, args["--l2"])
 		require.Equal(t, filepath.Join(dir, preimagesDir), args["--datadir"])
 		require.Equal(t, filepath.Join(dir, proofsDir, "%d.json.gz"), args["--proof-fmt"])
-		require.Equal(t, filepath.Join(dir, snapsDir, "%d.json.gz"), args["--snapshot-fmt"])
+		require.Equal(t, filepath.Join(dir, snapsDir, "%d.bin.gz"), args["--snapshot-fmt"])
 		require.Equal(t, cfg.CannonNetwork, args["--network"])
 		require.NotContains(t, args, "--rollup.config")
 		require.NotContains(t, args, "--l2.genesis")
+		require.NotContains(t, args, "--data.format")
 
 		// Local game inputs
 		require.Equal(t, inputs.L1Head.Hex(), args["--l1.head"])
@@ -117,6 +119,14 @@ func TestGenerateProof(t *testing.T) {
 		require.Equal(t, cfg.CannonL2GenesisPath, args["--l2.genesis"])
 	})
 
+	t.Run("DataFormat", func(t *testing.T) {
+		cfg := cfg
+		cfg.CannonDataFormat = types.DataFormatPebble
+		_, _, args := captureExec(t, cfg, 150_000_000)
+		require.Equal(t, "pebble", args["--data.format"])
+		require.Equal(t, filepath.Join(dir, preimagesDir), args["--datadir"])
+	})
+
 	t.Run("NoStopAtWhenProofIsMaxUInt", func(t *testing.T) {
 		cfg.CannonNetwork = "mainnet"
 		cfg.CannonRollupConfigPath = "rollup.json"
@@ -198,6 +208,18 @@ func TestFindStartingSnapshot(t *testing.T) {
 		require.Equal(t, filepath.Join(dir, "250.json.gz"), snapshot)
 	})
 
+	t.Run("UseBinaryAndJSONSnapshots", func(t *testing.T) {
+		dir := withSnapshots(t, "100.json.gz", "200.bin.gz", "300.json.gz")
+
+		snapshot, err := findStartingSnapshot(logger, dir, execTestCannonPrestate, 250)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "200.bin.gz"), snapshot)
+
+		snapshot, err = findStartingSnapshot(logger, dir, execTestCannonPrestate, 350)
+		require.NoError(t, err)
+		require.Equal(t, filepath.Join(dir, "300.json.gz"), snapshot)
+	})
+
 	t.Run("IgnoreDirectories", func(t *testing.T) {
 		dir := withSnapshots(t, "100.json.gz")
 		require.NoError(t, os.Mkdir(filepath.Join(dir, "120.json.gz"), 0o777))
@@ -47,15 +47,23 @@ type ProofGenerator interface {
 type CannonTraceProvider struct {
 	logger    log.Logger
 	dir       string
+	cache     *SnapshotCache
+	inputs    LocalGameInputs
 	prestate  string
 	generator ProofGenerator
 
 	// lastStep stores the last step in the actual trace if known. 0 indicates unknown.
 	// Cached as an optimisation to avoid repeatedly attempting to execute beyond the end of the trace.
 	lastStep uint64
+
+	// executionKey identifies the execution in the snapshot cache. nil until first calculated.
+	// Cached to avoid loading and hashing the absolute prestate for every proof.
+	executionKey *common.Hash
 }
 
-func NewTraceProvider(ctx context.Context, logger log.Logger, m CannonMetricer, cfg *config.Config, l1Client bind.ContractCaller, dir string, gameAddr common.Address) (*CannonTraceProvider, error) {
+// NewTraceProvider creates a provider for the cannon trace of the game at gameAddr.
+// Executions are stored in cache if it is not nil, and otherwise in dir.
+func NewTraceProvider(ctx context.Context, logger log.Logger, m CannonMetricer, cfg *config.Config, l1Client bind.ContractCaller, dir string, cache *SnapshotCache, gameAddr common.Address) (*CannonTraceProvider, error) {
 	l2Client, err := ethclient.DialContext(ctx, cfg.CannonL2)
 	if err != nil {
 		return nil, fmt.Errorf("dial l2 client %v: %w", cfg.CannonL2, err)
@@ -69,13 +77,15 @@ func NewTraceProvider(ctx context.Context, logger log.Logger, m CannonMetricer,
 	if err != nil {
 		return nil, fmt.Errorf("fetch local game inputs: %w", err)
 	}
-	return NewTraceProviderFromInputs(logger, m, cfg, localInputs, dir), nil
+	return NewTraceProviderFromInputs(logger, m, cfg, localInputs, dir, cache), nil
 }
 
-func NewTraceProviderFromInputs(logger log.Logger, m CannonMetricer, cfg *config.Config, localInputs LocalGameInputs, dir string) *CannonTraceProvider {
+func NewTraceProviderFromInputs(logger log.Logger, m CannonMetricer, cfg *config.Config, localInputs LocalGameInputs, dir string, cache *SnapshotCache) *CannonTraceProvider {
 	return &CannonTraceProvider{
 		logger:    logger,
 		dir:       dir,
+		cache:     cache,
+		inputs:    localInputs,
 		prestate:  cfg.CannonAbsolutePreState,
 		generator: NewExecutor(logger, m, cfg, localInputs),
 	}
@@ -115,31 +125,25 @@ func (p *CannonTraceProvider) GetStepData(ctx context.Context, i uint64) ([]byte
 }
 
 func (p *CannonTraceProvider) AbsolutePreState(ctx context.Context) ([]byte, error) {
-	state, err := parseState(p.prestate)
-	if err != nil {
-		return nil, fmt.Errorf("cannot load absolute pre-state: %w", err)
-	}
-	return state.EncodeWitness(), nil
+	return NewPrestateProvider(p.prestate).AbsolutePreState(ctx)
 }
 
 func (p *CannonTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
-	state, err := p.AbsolutePreState(ctx)
-	if err != nil {
-		return common.Hash{}, fmt.Errorf("cannot load absolute pre-state: %w", err)
-	}
-	hash, err := mipsevm.StateWitness(state).StateHash()
-	if err != nil {
-		return common.Hash{}, fmt.Errorf("cannot hash absolute pre-state: %w", err)
-	}
-	return hash, nil
+	return NewPrestateProvider(p.prestate).AbsolutePreStateCommitment(ctx)
 }
 
 // loadProof will attempt to load or generate the proof data at the specified index
 // If the requested index is beyond the end of the actual trace it is extended with no-op instructions.
 func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*proofData, error) {
+	dir, release, err := p.executionDir(ctx)
+	if err != nil {
+		return nil, err
+	}
+	generated := false
+	defer func() { release(generated) }()
 	// Attempt to read the last step from disk cache
 	if p.lastStep == 0 {
-		step, err := readLastStep(p.dir)
+		step, err := readLastStep(dir)
 		if err != nil {
 			p.logger.Warn("Failed to read last step from disk cache", "err", err)
 		} else {
@@ -150,17 +154,18 @@ func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*proofDa
 	if p.lastStep != 0 && i > p.lastStep {
 		i = p.lastStep
 	}
-	path := filepath.Join(p.dir, proofsDir, fmt.Sprintf("%d.json.gz", i))
+	path := filepath.Join(dir, proofsDir, fmt.Sprintf("%d.json.gz", i))
 	file, err := ioutil.OpenDecompressed(path)
 	if errors.Is(err, os.ErrNotExist) {
-		if err := p.generator.GenerateProof(ctx, p.dir, i); err != nil {
+		generated = true
+		if err := p.generator.GenerateProof(ctx, dir, i); err != nil {
 			return nil, fmt.Errorf("generate cannon trace with proof at %v: %w", i, err)
 		}
 		// Try opening the file again now and it should exist.
 		file, err = ioutil.OpenDecompressed(path)
 		if errors.Is(err, os.ErrNotExist) {
 			// Expected proof wasn't generated, check if we reached the end of execution
-			state, err := parseState(filepath.Join(p.dir, finalState))
+			state, err := parseState(filepath.Join(dir, finalState))
 			if err != nil {
 				return nil, fmt.Errorf("cannot read final state: %w", err)
 			}
@@ -184,7 +189,7 @@ func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*proofDa
 					OracleValue:  nil,
 					OracleOffset: 0,
 				}
-				if err := writeLastStep(p.dir, proof, p.lastStep); err != nil {
+				if err := writeLastStep(dir, proof, p.lastStep); err != nil {
 					p.logger.Warn("Failed to write last step to disk cache", "step", p.lastStep)
 				}
 				return proof, nil
@@ -205,6 +210,24 @@ func (p *CannonTraceProvider) loadProof(ctx context.Context, i uint64) (*proofDa
 	return &proof, nil
 }
 
+// executionDir returns the directory to load and generate proofs in, which is in the shared snapshot cache if there
+// is one. The returned release function must be called once the directory is no longer in use, reporting whether
+// cannon was executed in it.
+func (p *CannonTraceProvider) executionDir(ctx context.Context) (string, func(generated bool), error) {
+	if p.cache == nil {
+		return p.dir, func(bool) {}, nil
+	}
+	if p.executionKey == nil {
+		prestate, err := p.AbsolutePreStateCommitment(ctx)
+		if err != nil {
+			return "", nil, fmt.Errorf("cannot load absolute pre-state: %w", err)
+		}
+		key := ExecutionKey(prestate, p.inputs)
+		p.executionKey = &key
+	}
+	return p.cache.Acquire(*p.executionKey)
+}
+
 type diskStateCacheObj struct {
 	Step uint64 `json:"step"`
 }
@@ -6,6 +6,7 @@ import (
 	_ "embed"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"os"
 	"path/filepath"
 	"testing"
@@ -193,6 +194,52 @@ func TestGetStepData(t *testing.T) {
 	})
 }
 
+func TestSharedSnapshotCache(t *testing.T) {
+	dataDir := t.TempDir()
+	setupPreState(t, dataDir, "state.json")
+	cache, err := NewSnapshotCache(testlog.Logger(t, log.LvlInfo), filepath.Join(dataDir, "cache"), 1024*1024)
+	require.NoError(t, err)
+	inputs := LocalGameInputs{L1Head: common.Hash{0x11}, L2BlockNumber: big.NewInt(10)}
+	setupWithCache := func(inputs LocalGameInputs) (*CannonTraceProvider, *stubGenerator) {
+		provider, generator := setupWithTestData(t, t.TempDir(), "state.json")
+		provider.prestate = filepath.Join(dataDir, "state.json")
+		provider.cache = cache
+		provider.inputs = inputs
+		generator.proof = &proofData{
+			ClaimValue: common.Hash{0xaa},
+			StateData:  []byte{0xbb},
+			ProofData:  []byte{0xcc},
+		}
+		return provider, generator
+	}
+
+	provider1, generator1 := setupWithCache(inputs)
+	value, err := provider1.Get(context.Background(), 4)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{0xaa}, value)
+	require.Contains(t, generator1.generated, 4, "should have generated the proof")
+	require.NoDirExists(t, filepath.Join(provider1.dir, proofsDir), "should not use the game directory")
+
+	provider2, generator2 := setupWithCache(inputs)
+	value, err = provider2.Get(context.Background(), 4)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{0xaa}, value)
+	require.Empty(t, generator2.generated, "should reuse the proof generated for the other game")
+
+	otherInputs := inputs
+	otherInputs.L2Claim = common.Hash{0x22}
+	provider3, generator3 := setupWithCache(otherInputs)
+	_, err = provider3.Get(context.Background(), 4)
+	require.NoError(t, err)
+	require.Contains(t, generator3.generated, 4, "should generate the proof for a different execution")
+
+	// The execution key is calculated once, so the prestate isn't loaded again for later proofs
+	require.NoError(t, os.Remove(filepath.Join(dataDir, "state.json")))
+	value, err = provider1.Get(context.Background(), 4)
+	require.NoError(t, err)
+	require.Equal(t, common.Hash{0xaa}, value)
+}
+
 func TestAbsolutePreState(t *testing.T) {
 	dataDir := t.TempDir()
 
@@ -286,6 +333,9 @@ func (e *stubGenerator) GenerateProof(ctx context.Context, dir string, i uint64)
 		return writeGzip(filepath.Join(dir, finalState), data)
 	}
 	if e.proof != nil {
+		if err := os.MkdirAll(filepath.Join(dir, proofsDir), 0o755); err != nil {
+			return err
+		}
 		proofFile := filepath.Join(dir, proofsDir, fmt.Sprintf("%d.json.gz", i))
 		data, err := json.Marshal(e.proof)
 		if err != nil {
@@ -0,0 +1,200 @@
+package cannon
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"golang.org/x/exp/slices"
+)
+
+// SnapshotCache stores the snapshots, proofs and preimages of cannon executions in directories shared by all games.
+// Executions are content-addressed by the absolute prestate and the local inputs, so games that dispute the same
+// execution reuse the snapshots and proofs already generated instead of executing cannon from the start again.
+// Once the total size of the cache exceeds its limit, the least recently used executions that are not in use are removed.
+type SnapshotCache struct {
+	logger  log.Logger
+	dir     string
+	maxSize int64
+
+	mu      sync.Mutex
+	entries map[common.Hash]*cacheEntry
+}
+
+type cacheEntry struct {
+	// lock is held while the execution is in use so only one game runs cannon in the directory at a time
+	lock     sync.Mutex
+	users    int
+	size     int64
+	lastUsed time.Time
+}
+
+// NewSnapshotCache creates a cache in dir with a size limit of maxSize bytes.
+// Executions already in dir are loaded, using their modification time as the time they were last used.
+func NewSnapshotCache(logger log.Logger, dir string, maxSize int64) (*SnapshotCache, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("could not create snapshot cache directory %v: %w", dir, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("list snapshot cache directory %v: %w", dir, err)
+	}
+	c := &SnapshotCache{
+		logger:  logger,
+		dir:     dir,
+		maxSize: maxSize,
+		entries: make(map[common.Hash]*cacheEntry),
+	}
+	for _, entry := range entries {
+		key, ok := parseExecutionKey(entry.Name())
+		if !entry.IsDir() || !ok {
+			logger.Warn("Unexpected file in snapshot cache dir", "parent", dir, "child", entry.Name())
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("read snapshot cache entry %v: %w", entry.Name(), err)
+		}
+		size, err := dirSize(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("calculate size of snapshot cache entry %v: %w", entry.Name(), err)
+		}
+		c.entries[key] = &cacheEntry{size: size, lastUsed: info.ModTime()}
+	}
+	return c, nil
+}
+
+// ExecutionKey returns the key that identifies the cannon execution from the absolute prestate with the local inputs.
+func ExecutionKey(prestate common.Hash, inputs LocalGameInputs) common.Hash {
+	return crypto.Keccak256Hash(
+		prestate[:],
+		inputs.L1Head[:],
+		inputs.L2Head[:],
+		inputs.L2OutputRoot[:],
+		inputs.L2Claim[:],
+		common.BigToHash(inputs.L2BlockNumber).Bytes())
+}
+
+// Acquire returns the directory for the execution with the specified key, waiting until it isn't in use by any
+// other game. The returned release function must be called once the execution is no longer in use, reporting whether
+// cannon was executed in the directory. The size of the execution is only recalculated when it may have changed.
+func (c *SnapshotCache) Acquire(key common.Hash) (string, func(generated bool), error) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	if !ok {
+		entry = &cacheEntry{}
+		c.entries[key] = entry
+	}
+	entry.users++
+	entry.lastUsed = time.Now()
+	c.mu.Unlock()
+
+	dir := filepath.Join(c.dir, key.Hex())
+	entry.lock.Lock()
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		c.release(key, entry, dir, false)
+		return "", nil, fmt.Errorf("could not create snapshot cache entry %v: %w", dir, err)
+	}
+	return dir, func(generated bool) { c.release(key, entry, dir, generated) }, nil
+}
+
+// release marks the execution as no longer in use. Loading existing proofs doesn't change the execution on disk,
+// so its size is only recalculated, and the cache pruned, if cannon was executed.
+func (c *SnapshotCache) release(key common.Hash, entry *cacheEntry, dir string, generated bool) {
+	if !generated {
+		entry.lock.Unlock()
+		c.mu.Lock()
+		entry.users--
+		c.mu.Unlock()
+		return
+	}
+
+	size, err := dirSize(dir)
+	if err != nil {
+		c.logger.Warn("Failed to calculate size of snapshot cache entry", "key", key, "err", err)
+	}
+	// Record the time the execution was last used on disk so it is used for eviction after a restart
+	now := time.Now()
+	if err := os.Chtimes(dir, now, now); err != nil && !errors.Is(err, os.ErrNotExist) {
+		c.logger.Warn("Failed to update modification time of snapshot cache entry", "key", key, "err", err)
+	}
+	entry.lock.Unlock()
+
+	c.mu.Lock()
+	entry.size = size
+	entry.users--
+	c.mu.Unlock()
+	if err := c.Prune(); err != nil {
+		c.logger.Error("Failed to prune snapshot cache", "err", err)
+	}
+}
+
+// Prune removes the least recently used executions that are not in use until the size of the cache is within its limit.
+func (c *SnapshotCache) Prune() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var total int64
+	keys := make([]common.Hash, 0, len(c.entries))
+	for key, entry := range c.entries {
+		total += entry.size
+		keys = append(keys, key)
+	}
+	if total <= c.maxSize {
+		return nil
+	}
+	slices.SortFunc(keys, func(a, b common.Hash) int {
+		return c.entries[a].lastUsed.Compare(c.entries[b].lastUsed)
+	})
+	var errs []error
+	for _, key := range keys {
+		if total <= c.maxSize {
+			break
+		}
+		entry := c.entries[key]
+		if entry.users > 0 {
+			continue
+		}
+		if err := os.RemoveAll(filepath.Join(c.dir, key.Hex())); err != nil {
+			errs = append(errs, fmt.Errorf("remove snapshot cache entry %v: %w", key, err))
+			continue
+		}
+		c.logger.Info("Removed cannon execution from snapshot cache", "key", key, "size", entry.size, "lastUsed", entry.lastUsed)
+		delete(c.entries, key)
+		total -= entry.size
+	}
+	return errors.Join(errs...)
+}
+
+func parseExecutionKey(name string) (common.Hash, bool) {
+	key := common.HexToHash(name)
+	return key, key.Hex() == name
+}
+
+func dirSize(dir string) (int64, error) {
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		size += info.Size()
+		return nil
+	})
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	}
+	return size, err
+}
@@ -0,0 +1,162 @@
+package cannon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotCache_Acquire(t *testing.T) {
+	cache, dir := setupSnapshotCache(t, 1000)
+	key := common.Hash{0xaa}
+	entryDir, release, err := cache.Acquire(key)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, key.Hex()), entryDir)
+	require.DirExists(t, entryDir)
+
+	acquired := make(chan struct{})
+	go func() {
+		_, release, err := cache.Acquire(key)
+		require.NoError(t, err)
+		release(false)
+		close(acquired)
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("should not acquire an execution that is in use")
+	case <-time.After(50 * time.Millisecond):
+	}
+	release(false)
+	select {
+	case <-acquired:
+	case <-time.After(10 * time.Second):
+		t.Fatal("should acquire the execution once it is released")
+	}
+}
+
+func TestSnapshotCache_Prune(t *testing.T) {
+	t.Run("RemoveLeastRecentlyUsed", func(t *testing.T) {
+		cache, dir := setupSnapshotCache(t, 250)
+		oldest := common.Hash{0x01}
+		middle := common.Hash{0x02}
+		newest := common.Hash{0x03}
+		useExecution(t, cache, oldest, 100)
+		useExecution(t, cache, middle, 100)
+		useExecution(t, cache, newest, 100)
+		require.NoDirExists(t, filepath.Join(dir, oldest.Hex()))
+		require.DirExists(t, filepath.Join(dir, middle.Hex()))
+		require.DirExists(t, filepath.Join(dir, newest.Hex()))
+	})
+
+	t.Run("UsingExecutionKeepsIt", func(t *testing.T) {
+		cache, dir := setupSnapshotCache(t, 250)
+		first := common.Hash{0x01}
+		second := common.Hash{0x02}
+		third := common.Hash{0x03}
+		useExecution(t, cache, first, 100)
+		useExecution(t, cache, second, 100)
+		useExecution(t, cache, first, 100)
+		useExecution(t, cache, third, 100)
+		require.DirExists(t, filepath.Join(dir, first.Hex()))
+		require.NoDirExists(t, filepath.Join(dir, second.Hex()))
+		require.DirExists(t, filepath.Join(dir, third.Hex()))
+	})
+
+	t.Run("KeepExecutionsInUse", func(t *testing.T) {
+		cache, dir := setupSnapshotCache(t, 150)
+		inUse := common.Hash{0x01}
+		useExecution(t, cache, inUse, 100)
+		_, release, err := cache.Acquire(inUse)
+		require.NoError(t, err)
+
+		other := common.Hash{0x02}
+		useExecution(t, cache, other, 100)
+		require.DirExists(t, filepath.Join(dir, inUse.Hex()), "should not remove execution in use")
+		require.NoDirExists(t, filepath.Join(dir, other.Hex()))
+		release(false)
+	})
+
+	t.Run("OnlyUpdateSizeAfterGenerating", func(t *testing.T) {
+		cache, dir := setupSnapshotCache(t, 150)
+		first := common.Hash{0x01}
+		second := common.Hash{0x02}
+		useExecution(t, cache, first, 100)
+
+		// The size isn't recalculated if cannon wasn't executed
+		entryDir, release, err := cache.Acquire(second)
+		require.NoError(t, err)
+		writeFile(t, entryDir, 100)
+		release(false)
+		require.DirExists(t, filepath.Join(dir, first.Hex()))
+
+		entryDir, release, err = cache.Acquire(second)
+		require.NoError(t, err)
+		release(true)
+		require.NoDirExists(t, filepath.Join(dir, first.Hex()))
+		require.DirExists(t, entryDir)
+	})
+
+	t.Run("LoadExistingExecutions", func(t *testing.T) {
+		cache, dir := setupSnapshotCache(t, 250)
+		oldest := common.Hash{0x01}
+		newest := common.Hash{0x02}
+		useExecution(t, cache, oldest, 100)
+		useExecution(t, cache, newest, 100)
+		lastHour := time.Now().Add(-time.Hour)
+		require.NoError(t, os.Chtimes(filepath.Join(dir, oldest.Hex()), lastHour, lastHour))
+		unexpectedFile := filepath.Join(dir, "file.txt")
+		require.NoError(t, os.WriteFile(unexpectedFile, []byte("test"), 0o644))
+
+		reloaded, err := NewSnapshotCache(testlog.Logger(t, log.LvlInfo), dir, 150)
+		require.NoError(t, err)
+		require.NoError(t, reloaded.Prune())
+		require.NoDirExists(t, filepath.Join(dir, oldest.Hex()))
+		require.DirExists(t, filepath.Join(dir, newest.Hex()))
+		require.FileExists(t, unexpectedFile, "should not remove unexpected files")
+	})
+}
+
+func TestExecutionKey(t *testing.T) {
+	inputs := LocalGameInputs{
+		L1Head:        common.Hash{0x01},
+		L2Head:        common.Hash{0x02},
+		L2OutputRoot:  common.Hash{0x03},
+		L2Claim:       common.Hash{0x04},
+		L2BlockNumber: common.Big1,
+	}
+	prestate := common.Hash{0xaa}
+	key := ExecutionKey(prestate, inputs)
+	require.Equal(t, key, ExecutionKey(prestate, inputs))
+	require.NotEqual(t, key, ExecutionKey(common.Hash{0xbb}, inputs))
+	modified := inputs
+	modified.L2Claim = common.Hash{0x05}
+	require.NotEqual(t, key, ExecutionKey(prestate, modified))
+	modified = inputs
+	modified.L2BlockNumber = common.Big2
+	require.NotEqual(t, key, ExecutionKey(prestate, modified))
+}
+
+func setupSnapshotCache(t *testing.T, maxSize int64) (*SnapshotCache, string) {
+	dir := filepath.Join(t.TempDir(), "cache")
+	cache, err := NewSnapshotCache(testlog.Logger(t, log.LvlInfo), dir, maxSize)
+	require.NoError(t, err)
+	return cache, dir
+}
+
+// useExecution acquires the execution and writes size bytes of data to it before releasing it.
+func useExecution(t *testing.T, cache *SnapshotCache, key common.Hash, size int) {
+	dir, release, err := cache.Acquire(key)
+	require.NoError(t, err)
+	writeFile(t, dir, size)
+	release(true)
+}
+
+func writeFile(t *testing.T, dir string, size int) {
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "snapshot"), make([]byte, size), 0o644))
+}
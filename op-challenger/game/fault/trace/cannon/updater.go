@@ -1,9 +1,15 @@
 package cannon
 
 import (
+	"bytes"
 	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
+	"os"
+	"path/filepath"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
@@ -12,10 +18,39 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 )
 
+const (
+	// txMaxSize is the maximum size of a transaction accepted by the L1 transaction pool.
+	txMaxSize = 128 * 1024
+	// maxGlobalPreimageSize is the largest preimage that is loaded into the PreimageOracle in a single transaction.
+	// This leaves space for the ABI encoding of the call, the signature and the rest of the transaction envelope.
+	// Larger preimages are loaded in chunks across multiple transactions.
+	maxGlobalPreimageSize = txMaxSize - 1024
+	// keccakBlockSize is the number of bytes the PreimageOracle absorbs into its keccak256 state at a time.
+	keccakBlockSize = 136
+	// largePreimageChunkSize is the number of preimage bytes sent in each transaction of a large preimage upload.
+	// The PreimageOracle hashes each chunk as it is added, which keeps the gas used well below the block gas limit.
+	largePreimageChunkSize = 64 * keccakBlockSize
+	// largePreimageProgressFile is the file in the game directory recording the progress of a large preimage upload.
+	largePreimageProgressFile = "large_preimage.json"
+)
+
+// errTxReverted is returned when a transaction that must succeed for an upload to continue is reverted.
+var errTxReverted = errors.New("transaction reverted")
+
+// largePreimageProgress records how much of a large preimage has been loaded into the PreimageOracle, so an upload
+// that is interrupted resumes from the last chunk that was included rather than starting again.
+type largePreimageProgress struct {
+	Key       hexutil.Bytes `json:"key"`
+	Offset    uint32        `json:"offset"`
+	BytesSent uint64        `json:"bytesSent"`
+}
+
 // cannonUpdater is a [types.OracleUpdater] that exposes a method
 // to update onchain cannon oracles with required data.
 type cannonUpdater struct {
@@ -27,15 +62,20 @@ type cannonUpdater struct {
 
 	preimageOracleAbi  abi.ABI
 	preimageOracleAddr common.Address
+
+	// dir is the game directory, which stores the progress of large preimage uploads.
+	dir string
 }
 
 // NewOracleUpdater returns a new updater. The pre-image oracle address is loaded from the fault dispute game.
+// The progress of large pre-image uploads is stored in dir.
 func NewOracleUpdater(
 	ctx context.Context,
 	logger log.Logger,
 	txMgr txmgr.TxManager,
 	fdgAddr common.Address,
 	client bind.ContractCaller,
+	dir string,
 ) (*cannonUpdater, error) {
 	gameCaller, err := bindings.NewFaultDisputeGameCaller(fdgAddr, client)
 	if err != nil {
@@ -54,7 +94,7 @@ func NewOracleUpdater(
 	if err != nil {
 		return nil, fmt.Errorf("failed to load pre-image oracle address from game %v: %w", fdgAddr, err)
 	}
-	return NewOracleUpdaterWithOracle(logger, txMgr, fdgAddr, oracleAddr)
+	return NewOracleUpdaterWithOracle(logger, txMgr, fdgAddr, oracleAddr, dir)
 }
 
 // NewOracleUpdaterWithOracle returns a new updater using a specified pre-image oracle address.
@@ -63,6 +103,7 @@ func NewOracleUpdaterWithOracle(
 	txMgr txmgr.TxManager,
 	fdgAddr common.Address,
 	preimageOracleAddr common.Address,
+	dir string,
 ) (*cannonUpdater, error) {
 	fdgAbi, err := bindings.FaultDisputeGameMetaData.GetAbi()
 	if err != nil {
@@ -82,6 +123,8 @@ func NewOracleUpdaterWithOracle(
 
 		preimageOracleAbi:  *preimageOracleAbi,
 		preimageOracleAddr: preimageOracleAddr,
+
+		dir: dir,
 	}, nil
 }
 
@@ -103,12 +146,124 @@ func (u *cannonUpdater) sendLocalOracleData(ctx context.Context, data *types.Pre
 }
 
 // sendGlobalOracleData sends the global oracle data to the [txmgr].
+// Preimages too large for a single transaction are loaded in chunks with sendLargeGlobalOracleData.
 func (u *cannonUpdater) sendGlobalOracleData(ctx context.Context, data *types.PreimageOracleData) error {
+	if len(data.GetPreimageWithoutSize()) > maxGlobalPreimageSize {
+		return u.sendLargeGlobalOracleData(ctx, data)
+	}
 	txData, err := u.BuildGlobalOracleData(data)
 	if err != nil {
 		return fmt.Errorf("global oracle tx data build: %w", err)
 	}
-	return u.sendTxAndWait(ctx, u.fdgAddr, txData)
+	return u.sendTxAndWait(ctx, u.preimageOracleAddr, txData)
+}
+
+// sendLargeGlobalOracleData loads a preimage into the PreimageOracle across multiple transactions. The progress is
+// stored in the game directory after each transaction, so an upload interrupted by an error or restart resumes
+// with the next chunk. If a chunk is reverted, the progress no longer matches the PreimageOracle and the upload is
+// started again from the beginning on the next attempt.
+func (u *cannonUpdater) sendLargeGlobalOracleData(ctx context.Context, data *types.PreimageOracleData) error {
+	preimage := data.GetPreimageWithoutSize()
+	uuid := u.largePreimageUUID(data)
+	progress, err := u.loadLargePreimageProgress(data)
+	if err != nil {
+		return err
+	}
+	if progress == nil {
+		u.log.Info("Starting large preimage upload", "oracleKey", data.OracleKey, "size", len(preimage))
+		txData, err := u.preimageOracleAbi.Pack("initLargeKeccak256Preimage", uuid, uint64(data.OracleOffset), uint64(len(preimage)))
+		if err != nil {
+			return fmt.Errorf("large preimage init tx data build: %w", err)
+		}
+		if err := u.sendTxAndCheck(ctx, u.preimageOracleAddr, txData); err != nil {
+			return fmt.Errorf("failed to start large preimage upload: %w", err)
+		}
+		progress = &largePreimageProgress{Key: data.OracleKey, Offset: data.OracleOffset}
+		if err := u.saveLargePreimageProgress(progress); err != nil {
+			return err
+		}
+	} else {
+		u.log.Info("Resuming large preimage upload", "oracleKey", data.OracleKey, "size", len(preimage), "sent", progress.BytesSent)
+	}
+
+	size := uint64(len(preimage))
+	for progress.BytesSent < size {
+		end := min(progress.BytesSent+largePreimageChunkSize, size)
+		finalize := end == size
+		txData, err := u.preimageOracleAbi.Pack("addLargeKeccak256PreimageData", uuid, new(big.Int).SetUint64(progress.BytesSent), preimage[progress.BytesSent:end], finalize)
+		if err != nil {
+			return fmt.Errorf("large preimage data tx data build: %w", err)
+		}
+		if err := u.sendTxAndCheck(ctx, u.preimageOracleAddr, txData); err != nil {
+			if errors.Is(err, errTxReverted) {
+				if err := u.clearLargePreimageProgress(); err != nil {
+					u.log.Error("Failed to clear large preimage progress", "err", err)
+				}
+			}
+			return fmt.Errorf("failed to load large preimage data at offset %v: %w", progress.BytesSent, err)
+		}
+		progress.BytesSent = end
+		u.log.Debug("Loaded large preimage data", "oracleKey", data.OracleKey, "sent", end, "size", size)
+		if err := u.saveLargePreimageProgress(progress); err != nil {
+			return err
+		}
+	}
+	return u.clearLargePreimageProgress()
+}
+
+// largePreimageUUID returns the id of the PreimageOracle proposal used to load a large preimage.
+// The id is derived from the game, key and offset so that the same proposal is used when an upload is resumed.
+func (u *cannonUpdater) largePreimageUUID(data *types.PreimageOracleData) *big.Int {
+	offset := binary.BigEndian.AppendUint32(nil, data.OracleOffset)
+	return new(big.Int).SetBytes(crypto.Keccak256(u.fdgAddr.Bytes(), data.OracleKey, offset))
+}
+
+// loadLargePreimageProgress returns the progress of the upload of the preimage in data.
+// Returns nil if the upload hasn't been started.
+func (u *cannonUpdater) loadLargePreimageProgress(data *types.PreimageOracleData) (*largePreimageProgress, error) {
+	file, err := os.ReadFile(filepath.Join(u.dir, largePreimageProgressFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to read large preimage progress: %w", err)
+	}
+	var progress largePreimageProgress
+	if err := json.Unmarshal(file, &progress); err != nil {
+		return nil, fmt.Errorf("failed to parse large preimage progress: %w", err)
+	}
+	if !bytes.Equal(progress.Key, data.OracleKey) || progress.Offset != data.OracleOffset {
+		// The progress is for a different preimage, which is no longer being loaded
+		return nil, nil
+	}
+	return &progress, nil
+}
+
+// saveLargePreimageProgress stores the progress of a large preimage upload.
+// The file is replaced atomically so an interrupted write doesn't lose the existing progress.
+func (u *cannonUpdater) saveLargePreimageProgress(progress *largePreimageProgress) error {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		return fmt.Errorf("failed to encode large preimage progress: %w", err)
+	}
+	if err := os.MkdirAll(u.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create game dir: %w", err)
+	}
+	path := filepath.Join(u.dir, largePreimageProgressFile)
+	if err := os.WriteFile(path+".tmp", data, 0o644); err != nil {
+		return fmt.Errorf("failed to write large preimage progress: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		return fmt.Errorf("failed to write large preimage progress: %w", err)
+	}
+	return nil
+}
+
+// clearLargePreimageProgress removes the progress of a completed or abandoned large preimage upload.
+func (u *cannonUpdater) clearLargePreimageProgress() error {
+	if err := os.Remove(filepath.Join(u.dir, largePreimageProgressFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to remove large preimage progress: %w", err)
+	}
+	return nil
 }
 
 // BuildLocalOracleData takes the local preimage key and data
@@ -133,6 +288,23 @@ func (u *cannonUpdater) BuildGlobalOracleData(data *types.PreimageOracleData) ([
 	)
 }
 
+// sendTxAndCheck sends a transaction through the [txmgr] and waits for a receipt,
+// returning errTxReverted if the transaction was reverted.
+func (u *cannonUpdater) sendTxAndCheck(ctx context.Context, addr common.Address, txData []byte) error {
+	receipt, err := u.txMgr.Send(ctx, txmgr.TxCandidate{
+		To:       &addr,
+		TxData:   txData,
+		GasLimit: 0,
+	})
+	if err != nil {
+		return err
+	}
+	if receipt.Status == ethtypes.ReceiptStatusFailed {
+		return fmt.Errorf("%w: %v", errTxReverted, receipt.TxHash)
+	}
+	return nil
+}
+
 // sendTxAndWait sends a transaction through the [txmgr] and waits for a receipt.
 // This sets the tx GasLimit to 0, performing gas estimation online through the [txmgr].
 func (u *cannonUpdater) sendTxAndWait(ctx context.Context, addr common.Address, txData []byte) error {
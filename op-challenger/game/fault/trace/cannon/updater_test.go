@@ -2,8 +2,10 @@ package cannon
 
 import (
 	"context"
+	"encoding/binary"
 	"errors"
 	"math/big"
+	"path/filepath"
 	"testing"
 
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
@@ -28,20 +30,28 @@ var (
 
 type mockTxManager struct {
 	from        common.Address
+	sentTo      []common.Address
+	sentData    [][]byte
 	sends       int
 	failedSends int
 	sendFails   bool
+	// failAfter and revertAfter make sends after the given number of successful sends fail or revert, if non-zero.
+	failAfter   int
+	revertAfter int
 }
 
 func (m *mockTxManager) Send(ctx context.Context, candidate txmgr.TxCandidate) (*ethtypes.Receipt, error) {
-	if m.sendFails {
+	if m.sendFails || (m.failAfter != 0 && m.sends >= m.failAfter) {
 		m.failedSends++
 		return nil, mockSendError
 	}
+	reverted := m.revertAfter != 0 && m.sends >= m.revertAfter
 	m.sends++
+	m.sentTo = append(m.sentTo, *candidate.To)
+	m.sentData = append(m.sentData, candidate.TxData)
 	return ethtypes.NewReceipt(
 		[]byte{},
-		false,
+		reverted,
 		0,
 	), nil
 }
@@ -59,12 +69,13 @@ func (m *mockTxManager) From() common.Address {
 }
 
 func newTestCannonUpdater(t *testing.T, sendFails bool) (*cannonUpdater, *mockTxManager) {
+	return newTestCannonUpdaterWithDir(t, &mockTxManager{sendFails: sendFails}, t.TempDir())
+}
+
+func newTestCannonUpdaterWithDir(t *testing.T, txMgr *mockTxManager, dir string) (*cannonUpdater, *mockTxManager) {
 	logger := testlog.Logger(t, log.LvlInfo)
-	txMgr := &mockTxManager{
-		from:      mockFdgAddress,
-		sendFails: sendFails,
-	}
-	updater, err := NewOracleUpdaterWithOracle(logger, txMgr, mockFdgAddress, mockPreimageOracleAddress)
+	txMgr.from = mockFdgAddress
+	updater, err := NewOracleUpdaterWithOracle(logger, txMgr, mockFdgAddress, mockPreimageOracleAddress, dir)
 	require.NoError(t, err)
 	return updater, txMgr
 }
@@ -79,6 +90,36 @@ func TestCannonUpdater_UpdateOracle(t *testing.T) {
 			OracleData: common.Hex2Bytes("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"),
 		}))
 		require.Equal(t, 1, mockTxMgr.sends)
+		require.Equal(t, []common.Address{mockPreimageOracleAddress}, mockTxMgr.sentTo)
+	})
+
+	t.Run("local data sent to game", func(t *testing.T) {
+		updater, mockTxMgr := newTestCannonUpdater(t, false)
+		require.NoError(t, updater.UpdateOracle(context.Background(), &types.PreimageOracleData{
+			IsLocal:    true,
+			OracleKey:  common.Hash{0x01}.Bytes(),
+			OracleData: common.Hex2Bytes("cccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccccc"),
+		}))
+		require.Equal(t, []common.Address{mockFdgAddress}, mockTxMgr.sentTo)
+	})
+
+	t.Run("large preimage", func(t *testing.T) {
+		updater, mockTxMgr := newTestCannonUpdater(t, false)
+		data := largePreimageData(maxGlobalPreimageSize + 1)
+		require.NoError(t, updater.UpdateOracle(context.Background(), data))
+		uploaded := unpackLargePreimageUpload(t, updater, mockTxMgr.sentData)
+		require.Equal(t, data.GetPreimageWithoutSize(), uploaded)
+		require.Equal(t, mockPreimageOracleAddress, mockTxMgr.sentTo[len(mockTxMgr.sentTo)-1])
+		require.NoFileExists(t, filepath.Join(updater.dir, largePreimageProgressFile))
+	})
+
+	t.Run("largest preimage", func(t *testing.T) {
+		updater, mockTxMgr := newTestCannonUpdater(t, false)
+		require.NoError(t, updater.UpdateOracle(context.Background(), &types.PreimageOracleData{
+			OracleKey:  common.Hash{0x02}.Bytes(),
+			OracleData: make([]byte, 8+maxGlobalPreimageSize),
+		}))
+		require.Equal(t, 1, mockTxMgr.sends)
 	})
 
 	t.Run("send fails", func(t *testing.T) {
@@ -140,3 +181,125 @@ func TestCannonUpdater_BuildGlobalOracleData(t *testing.T) {
 
 	require.Equal(t, expected, txData)
 }
+
+// TestCannonUpdater_ResumeLargePreimage tests that an interrupted large preimage upload
+// resumes from the progress stored in the game directory.
+func TestCannonUpdater_ResumeLargePreimage(t *testing.T) {
+	dir := t.TempDir()
+	size := maxGlobalPreimageSize + 100
+	chunks := (size + largePreimageChunkSize - 1) / largePreimageChunkSize
+	data := largePreimageData(size)
+
+	// Fail after starting the upload and loading two chunks
+	updater, mockTxMgr := newTestCannonUpdaterWithDir(t, &mockTxManager{failAfter: 3}, dir)
+	require.ErrorIs(t, updater.UpdateOracle(context.Background(), data), mockSendError)
+	require.Equal(t, 3, mockTxMgr.sends)
+	progress, err := updater.loadLargePreimageProgress(data)
+	require.NoError(t, err)
+	require.EqualValues(t, 2*largePreimageChunkSize, progress.BytesSent)
+
+	// A new updater for the same game continues with the third chunk
+	updater, mockTxMgr = newTestCannonUpdaterWithDir(t, &mockTxManager{}, dir)
+	require.NoError(t, updater.UpdateOracle(context.Background(), data))
+	require.Equal(t, chunks-2, mockTxMgr.sends)
+	method, args := unpackLargePreimageTx(t, updater, mockTxMgr.sentData[0])
+	require.Equal(t, "addLargeKeccak256PreimageData", method)
+	require.Equal(t, updater.largePreimageUUID(data), args[0])
+	require.EqualValues(t, 2*largePreimageChunkSize, args[1].(*big.Int).Uint64())
+	require.NoFileExists(t, filepath.Join(dir, largePreimageProgressFile))
+}
+
+// TestCannonUpdater_RestartRevertedLargePreimage tests that a large preimage upload is started again
+// if a chunk is reverted, because the stored progress no longer matches the PreimageOracle.
+func TestCannonUpdater_RestartRevertedLargePreimage(t *testing.T) {
+	dir := t.TempDir()
+	data := largePreimageData(maxGlobalPreimageSize + 100)
+
+	updater, _ := newTestCannonUpdaterWithDir(t, &mockTxManager{revertAfter: 2}, dir)
+	require.ErrorIs(t, updater.UpdateOracle(context.Background(), data), errTxReverted)
+	require.NoFileExists(t, filepath.Join(dir, largePreimageProgressFile))
+
+	updater, mockTxMgr := newTestCannonUpdaterWithDir(t, &mockTxManager{}, dir)
+	require.NoError(t, updater.UpdateOracle(context.Background(), data))
+	require.Equal(t, data.GetPreimageWithoutSize(), unpackLargePreimageUpload(t, updater, mockTxMgr.sentData))
+}
+
+// TestCannonUpdater_IgnoreProgressOfOtherPreimage tests that progress stored for a different preimage
+// doesn't affect the upload of the requested preimage.
+func TestCannonUpdater_IgnoreProgressOfOtherPreimage(t *testing.T) {
+	dir := t.TempDir()
+	updater, _ := newTestCannonUpdaterWithDir(t, &mockTxManager{}, dir)
+	require.NoError(t, updater.saveLargePreimageProgress(&largePreimageProgress{
+		Key:       common.Hash{0xbb}.Bytes(),
+		BytesSent: largePreimageChunkSize,
+	}))
+
+	data := largePreimageData(2 * maxGlobalPreimageSize)
+	progress, err := updater.loadLargePreimageProgress(data)
+	require.NoError(t, err)
+	require.Nil(t, progress)
+}
+
+// largePreimageData returns oracle data for a keccak256 preimage of the given size.
+func largePreimageData(size int) *types.PreimageOracleData {
+	preimage := make([]byte, size)
+	for i := range preimage {
+		preimage[i] = byte(i)
+	}
+	// Keccak256 keys are the hash of the preimage with the first byte replaced by the key type
+	key := crypto.Keccak256(preimage)
+	key[0] = 2
+	return &types.PreimageOracleData{
+		OracleKey:    key,
+		OracleData:   append(binary.BigEndian.AppendUint64(nil, uint64(size)), preimage...),
+		OracleOffset: 16,
+	}
+}
+
+// unpackLargePreimageTx decodes the method and arguments of a large preimage upload transaction.
+func unpackLargePreimageTx(t *testing.T, updater *cannonUpdater, txData []byte) (string, []interface{}) {
+	method, err := updater.preimageOracleAbi.MethodById(txData[:4])
+	require.NoError(t, err)
+	args, err := method.Inputs.Unpack(txData[4:])
+	require.NoError(t, err)
+	return method.Name, args
+}
+
+// unpackLargePreimageUpload checks that the transactions form a complete large preimage upload
+// and returns the uploaded preimage.
+func unpackLargePreimageUpload(t *testing.T, updater *cannonUpdater, txs [][]byte) []byte {
+	require.GreaterOrEqual(t, len(txs), 2)
+	method, args := unpackLargePreimageTx(t, updater, txs[0])
+	require.Equal(t, "initLargeKeccak256Preimage", method)
+	uuid := args[0].(*big.Int)
+	claimedSize := args[2].(uint64)
+
+	var uploaded []byte
+	for i, tx := range txs[1:] {
+		method, args := unpackLargePreimageTx(t, updater, tx)
+		require.Equal(t, "addLargeKeccak256PreimageData", method)
+		require.Equal(t, uuid, args[0])
+		require.EqualValues(t, len(uploaded), args[1].(*big.Int).Uint64(), "chunks must be sent in order")
+		input := args[2].([]byte)
+		last := i == len(txs)-2
+		require.Equal(t, last, args[3].(bool), "only the last chunk finalizes the upload")
+		if !last {
+			require.Zero(t, len(input)%keccakBlockSize, "chunks must be whole keccak blocks")
+		}
+		uploaded = append(uploaded, input...)
+	}
+	require.EqualValues(t, claimedSize, len(uploaded))
+	return uploaded
+}
+
+// TestCannonUpdater_LargePreimageOracleABI tests that the large preimage methods
+// match the signatures of the PreimageOracle contract.
+func TestCannonUpdater_LargePreimageOracleABI(t *testing.T) {
+	updater, _ := newTestCannonUpdater(t, false)
+	for name, signature := range map[string]string{
+		"initLargeKeccak256Preimage":    "initLargeKeccak256Preimage(uint256,uint64,uint64)",
+		"addLargeKeccak256PreimageData": "addLargeKeccak256PreimageData(uint256,uint256,bytes,bool)",
+	} {
+		require.Equal(t, crypto.Keccak256([]byte(signature))[:4], updater.preimageOracleAbi.Methods[name].ID)
+	}
+}
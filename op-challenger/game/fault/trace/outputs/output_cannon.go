@@ -0,0 +1,108 @@
+package outputs
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"path/filepath"
+
+	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/split"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+// NewOutputCannonTraceAccessor creates the [trace.Accessor] of an output_cannon game. The game bisects over the
+// output roots of the L2 blocks between the starting and disputed proposals of the game up to the split depth,
+// and over the cannon execution trace of the disputed pair of output roots below it.
+func NewOutputCannonTraceAccessor(ctx context.Context, logger log.Logger, m cannon.CannonMetricer, cfg *config.Config, l1Client bind.ContractCaller, rollupClient OutputRollupClient, dir string, cache *cannon.SnapshotCache, gameAddr common.Address, splitDepth int) (*trace.Accessor, error) {
+	gameCaller, err := bindings.NewFaultDisputeGameCaller(gameAddr, l1Client)
+	if err != nil {
+		return nil, fmt.Errorf("create caller for game %v: %w", gameAddr, err)
+	}
+	opts := &bind.CallOpts{Context: ctx}
+	l1Head, err := gameCaller.L1Head(opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetch L1 head for game %v: %w", gameAddr, err)
+	}
+	proposals, err := gameCaller.Proposals(opts)
+	if err != nil {
+		return nil, fmt.Errorf("fetch proposals: %w", err)
+	}
+	prestateBlock := proposals.Starting.L2BlockNumber.Uint64()
+	poststateBlock := proposals.Disputed.L2BlockNumber.Uint64()
+	outputProvider := NewTraceProvider(logger, rollupClient, prestateBlock, poststateBlock)
+	cannonCreator := NewCannonProviderCreator(logger, m, cfg, rollupClient, outputProvider, l1Head, dir, cache, splitDepth)
+	return trace.NewAccessor(split.NewSplitProviderSelector(outputProvider, splitDepth, cannonCreator)), nil
+}
+
+// NewCannonProviderCreator creates a [split.ProviderCreator] that creates a [cannon.CannonTraceProvider]
+// for each disputed pair of output roots, with the local inputs derived from the pair.
+// Providers are cached, and each provider stores executions in the shared snapshot cache if it is not nil,
+// or otherwise in its own subdirectory of dir.
+func NewCannonProviderCreator(logger log.Logger, m cannon.CannonMetricer, cfg *config.Config, rollupClient OutputRollupClient, outputProvider *OutputTraceProvider, l1Head common.Hash, dir string, snapshotCache *cannon.SnapshotCache, splitDepth int) split.ProviderCreator {
+	cache := make(map[common.Hash]*cannon.CannonTraceProvider)
+	return func(ctx context.Context, _ int, pre types.Claim, post types.Claim) (types.TraceProvider, error) {
+		localInputs, err := fetchLocalInputs(ctx, rollupClient, outputProvider, l1Head, splitDepth, pre, post)
+		if err != nil {
+			return nil, err
+		}
+		key := localInputsKey(localInputs)
+		if provider, ok := cache[key]; ok {
+			return provider, nil
+		}
+		subdir := filepath.Join(dir, key.Hex())
+		provider := cannon.NewTraceProviderFromInputs(logger.New("l2BlockNumber", localInputs.L2BlockNumber), m, cfg, localInputs, subdir, snapshotCache)
+		cache[key] = provider
+		return provider, nil
+	}
+}
+
+// fetchLocalInputs derives the cannon local inputs from the agreed pre and disputed post output root claims.
+// An empty pre claim refers to the output root of the starting proposal of the game.
+func fetchLocalInputs(ctx context.Context, rollupClient OutputRollupClient, outputProvider *OutputTraceProvider, l1Head common.Hash, splitDepth int, pre types.Claim, post types.Claim) (cannon.LocalGameInputs, error) {
+	agreedBlock := outputProvider.prestateBlock
+	var agreedOutputRoot common.Hash
+	if pre == (types.Claim{}) {
+		root, err := outputProvider.AbsolutePreStateCommitment(ctx)
+		if err != nil {
+			return cannon.LocalGameInputs{}, fmt.Errorf("failed to fetch starting output root: %w", err)
+		}
+		agreedOutputRoot = root
+	} else {
+		block, err := outputProvider.BlockNumber(pre.TraceIndex(splitDepth))
+		if err != nil {
+			return cannon.LocalGameInputs{}, err
+		}
+		agreedBlock = block
+		agreedOutputRoot = pre.Value
+	}
+	claimedBlock, err := outputProvider.BlockNumber(post.TraceIndex(splitDepth))
+	if err != nil {
+		return cannon.LocalGameInputs{}, err
+	}
+	agreedOutput, err := rollupClient.OutputAtBlock(ctx, agreedBlock)
+	if err != nil {
+		return cannon.LocalGameInputs{}, fmt.Errorf("failed to fetch L2 block at %v: %w", agreedBlock, err)
+	}
+	return cannon.LocalGameInputs{
+		L1Head:        l1Head,
+		L2Head:        agreedOutput.BlockRef.Hash,
+		L2OutputRoot:  agreedOutputRoot,
+		L2Claim:       post.Value,
+		L2BlockNumber: new(big.Int).SetUint64(claimedBlock),
+	}, nil
+}
+
+func localInputsKey(inputs cannon.LocalGameInputs) common.Hash {
+	var blockNum [8]byte
+	binary.BigEndian.PutUint64(blockNum[:], inputs.L2BlockNumber.Uint64())
+	return crypto.Keccak256Hash(inputs.L1Head[:], inputs.L2Head[:], inputs.L2OutputRoot[:], inputs.L2Claim[:], blockNum[:])
+}
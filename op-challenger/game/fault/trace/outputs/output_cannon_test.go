@@ -0,0 +1,67 @@
+package outputs
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/config"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const testSplitDepth = 3
+
+func leafClaim(traceIdx int, value common.Hash) types.Claim {
+	return types.Claim{ClaimData: types.ClaimData{Value: value, Position: types.NewPosition(testSplitDepth, traceIdx)}}
+}
+
+func TestFetchLocalInputs(t *testing.T) {
+	l1Head := common.Hash{0x11}
+
+	t.Run("FromAbsolutePrestate", func(t *testing.T) {
+		provider, client := setupWithTestData(t)
+		post := leafClaim(0, common.Hash{0x22})
+		inputs, err := fetchLocalInputs(context.Background(), client, provider, l1Head, testSplitDepth, types.Claim{}, post)
+		require.NoError(t, err)
+		require.Equal(t, l1Head, inputs.L1Head)
+		require.Equal(t, blockHash(prestateBlock), inputs.L2Head)
+		require.Equal(t, outputRoot(prestateBlock), inputs.L2OutputRoot)
+		require.Equal(t, post.Value, inputs.L2Claim)
+		require.Equal(t, new(big.Int).SetUint64(prestateBlock+1), inputs.L2BlockNumber)
+	})
+
+	t.Run("FromPreClaim", func(t *testing.T) {
+		provider, client := setupWithTestData(t)
+		pre := leafClaim(3, common.Hash{0x33})
+		post := leafClaim(4, common.Hash{0x44})
+		inputs, err := fetchLocalInputs(context.Background(), client, provider, l1Head, testSplitDepth, pre, post)
+		require.NoError(t, err)
+		require.Equal(t, blockHash(prestateBlock+4), inputs.L2Head)
+		require.Equal(t, pre.Value, inputs.L2OutputRoot, "should use the claimed output root")
+		require.Equal(t, post.Value, inputs.L2Claim)
+		require.Equal(t, new(big.Int).SetUint64(prestateBlock+5), inputs.L2BlockNumber)
+	})
+}
+
+func TestCannonProviderCreatorCachesProviders(t *testing.T) {
+	provider, client := setupWithTestData(t)
+	cfg := config.NewConfig(common.Address{0xaa}, "http://localhost:8545", t.TempDir(), config.TraceTypeOutputCannon)
+	creator := NewCannonProviderCreator(testlog.Logger(t, log.LvlInfo), metrics.NoopMetrics, &cfg, client, provider, common.Hash{0x11}, t.TempDir(), nil, testSplitDepth)
+
+	pre := leafClaim(3, common.Hash{0x33})
+	post := leafClaim(4, common.Hash{0x44})
+	first, err := creator(context.Background(), 4, pre, post)
+	require.NoError(t, err)
+	second, err := creator(context.Background(), 4, pre, post)
+	require.NoError(t, err)
+	require.Same(t, first, second)
+
+	other, err := creator(context.Background(), 4, pre, leafClaim(4, common.Hash{0x55}))
+	require.NoError(t, err)
+	require.NotSame(t, first, other)
+}
@@ -0,0 +1,88 @@
+package outputs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	ErrGetStepData = errors.New("GetStepData not supported")
+	ErrIndexTooBig = errors.New("trace index is greater than max uint64")
+)
+
+var _ types.TraceProvider = (*OutputTraceProvider)(nil)
+
+type OutputRollupClient interface {
+	OutputAtBlock(ctx context.Context, blockNum uint64) (*eth.OutputResponse, error)
+}
+
+// OutputTraceProvider is a [types.TraceProvider] implementation that uses
+// output roots for given L2 Blocks as a trace.
+// The trace index i is the output root of L2 block prestateBlock+i+1, capped at poststateBlock.
+type OutputTraceProvider struct {
+	logger         log.Logger
+	rollupClient   OutputRollupClient
+	prestateBlock  uint64
+	poststateBlock uint64
+}
+
+func NewTraceProvider(logger log.Logger, rollupClient OutputRollupClient, prestateBlock, poststateBlock uint64) *OutputTraceProvider {
+	return &OutputTraceProvider{
+		logger:         logger,
+		rollupClient:   rollupClient,
+		prestateBlock:  prestateBlock,
+		poststateBlock: poststateBlock,
+	}
+}
+
+// BlockNumber returns the L2 block number of the output root at the trace index.
+func (o *OutputTraceProvider) BlockNumber(i uint64) (uint64, error) {
+	// Block number is prestate block + trace index + 1
+	if i >= math.MaxUint64-o.prestateBlock {
+		return 0, ErrIndexTooBig
+	}
+	blockNum := o.prestateBlock + i + 1
+	if blockNum > o.poststateBlock {
+		blockNum = o.poststateBlock
+	}
+	return blockNum, nil
+}
+
+func (o *OutputTraceProvider) Get(ctx context.Context, i uint64) (common.Hash, error) {
+	blockNum, err := o.BlockNumber(i)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return o.outputAtBlock(ctx, blockNum)
+}
+
+// AbsolutePreState returns the absolute prestate, which is the output root of the prestate block.
+// The output root is committed to directly, so there is no preimage.
+func (o *OutputTraceProvider) AbsolutePreState(ctx context.Context) ([]byte, error) {
+	return nil, fmt.Errorf("output root preimage: %w", ErrGetStepData)
+}
+
+// AbsolutePreStateCommitment returns the output root of the prestate block.
+func (o *OutputTraceProvider) AbsolutePreStateCommitment(ctx context.Context) (common.Hash, error) {
+	return o.outputAtBlock(ctx, o.prestateBlock)
+}
+
+// GetStepData is not supported, as the output root bisection is resolved by the trace below the split depth.
+func (o *OutputTraceProvider) GetStepData(_ context.Context, _ uint64) ([]byte, []byte, *types.PreimageOracleData, error) {
+	return nil, nil, nil, ErrGetStepData
+}
+
+func (o *OutputTraceProvider) outputAtBlock(ctx context.Context, block uint64) (common.Hash, error) {
+	output, err := o.rollupClient.OutputAtBlock(ctx, block)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to fetch output at block %v: %w", block, err)
+	}
+	return common.Hash(output.OutputRoot), nil
+}
@@ -0,0 +1,96 @@
+package outputs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	prestateBlock  = uint64(100)
+	poststateBlock = uint64(200)
+)
+
+func TestGet(t *testing.T) {
+	t.Run("PrestateBlock", func(t *testing.T) {
+		provider, _ := setupWithTestData(t)
+		_, err := provider.Get(context.Background(), 0)
+		require.NoError(t, err)
+	})
+
+	t.Run("IndexToBlockNumber", func(t *testing.T) {
+		provider, _ := setupWithTestData(t)
+		value, err := provider.Get(context.Background(), 5)
+		require.NoError(t, err)
+		require.Equal(t, outputRoot(prestateBlock+6), value)
+	})
+
+	t.Run("CappedAtPoststateBlock", func(t *testing.T) {
+		provider, _ := setupWithTestData(t)
+		value, err := provider.Get(context.Background(), 1000)
+		require.NoError(t, err)
+		require.Equal(t, outputRoot(poststateBlock), value)
+	})
+
+	t.Run("IndexTooBig", func(t *testing.T) {
+		provider, _ := setupWithTestData(t)
+		_, err := provider.Get(context.Background(), math.MaxUint64)
+		require.ErrorIs(t, err, ErrIndexTooBig)
+	})
+
+	t.Run("RollupClientError", func(t *testing.T) {
+		provider, client := setupWithTestData(t)
+		client.err = errors.New("boom")
+		_, err := provider.Get(context.Background(), 0)
+		require.ErrorIs(t, err, client.err)
+	})
+}
+
+func TestAbsolutePreStateCommitment(t *testing.T) {
+	provider, _ := setupWithTestData(t)
+	value, err := provider.AbsolutePreStateCommitment(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, outputRoot(prestateBlock), value)
+}
+
+func TestGetStepDataReturnsError(t *testing.T) {
+	provider, _ := setupWithTestData(t)
+	_, _, _, err := provider.GetStepData(context.Background(), 0)
+	require.ErrorIs(t, err, ErrGetStepData)
+}
+
+func setupWithTestData(t *testing.T) (*OutputTraceProvider, *stubRollupClient) {
+	client := &stubRollupClient{}
+	return NewTraceProvider(testlog.Logger(t, log.LvlInfo), client, prestateBlock, poststateBlock), client
+}
+
+func outputRoot(block uint64) common.Hash {
+	return common.HexToHash(fmt.Sprintf("0x%x", block))
+}
+
+// blockHash returns the fake hash of the L2 block with the given number.
+func blockHash(block uint64) common.Hash {
+	return common.HexToHash(fmt.Sprintf("0xbb%x", block))
+}
+
+type stubRollupClient struct {
+	err error
+}
+
+func (s *stubRollupClient) OutputAtBlock(_ context.Context, blockNum uint64) (*eth.OutputResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return &eth.OutputResponse{
+		OutputRoot: eth.Bytes32(outputRoot(blockNum)),
+		BlockRef:   eth.L2BlockRef{Hash: blockHash(blockNum), Number: blockNum},
+	}, nil
+}
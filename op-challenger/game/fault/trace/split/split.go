@@ -0,0 +1,96 @@
+package split
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+)
+
+var errRefClaimNotDeepEnough = errors.New("reference claim is not deep enough")
+
+// ProviderCreator creates the [types.TraceProvider] of the bottom game that disputes the transition
+// from the pre claim to the post claim, both at the split depth.
+// The pre claim is empty if the transition starts from the absolute prestate of the top game.
+type ProviderCreator func(ctx context.Context, depth int, pre types.Claim, post types.Claim) (types.TraceProvider, error)
+
+// NewSplitProviderSelector creates a [trace.ProviderSelector] for a game that is split in two:
+// positions up to and including topDepth are backed by topProvider, positions below it are
+// backed by a bottom game that is created for the pair of top game leaves that is disputed.
+func NewSplitProviderSelector(topProvider types.TraceProvider, topDepth int, bottomProviderCreator ProviderCreator) trace.ProviderSelector {
+	return func(ctx context.Context, game types.Game, ref types.Claim, pos types.Position) (types.TraceProvider, uint64, error) {
+		if pos.Depth() <= topDepth {
+			return topProvider, pos.TraceIndex(topDepth), nil
+		}
+		if ref.Depth() < topDepth {
+			return nil, 0, fmt.Errorf("%w, claim depth: %v, depth required: %v", errRefClaimNotDeepEnough, ref.Depth(), topDepth)
+		}
+
+		// Find the ancestor claim at the leaf level of the top game.
+		topLeaf, err := findAncestorAtDepth(game, ref, topDepth)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		var pre, post types.Claim
+		leafIdx := topLeaf.TraceIndex(topDepth)
+		// If pos is to the right of the top game leaf, the leaf is defended and is the agreed pre claim.
+		// Otherwise, the leaf is attacked and is the disputed post claim.
+		if pos.TraceIndex(pos.Depth()) > topLeaf.TraceIndex(pos.Depth()) {
+			pre = topLeaf
+			post, err = findAncestorWithTraceIndex(game, topLeaf, topDepth, leafIdx+1)
+			if err != nil {
+				return nil, 0, fmt.Errorf("failed to find post claim: %w", err)
+			}
+		} else {
+			post = topLeaf
+			if leafIdx > 0 {
+				pre, err = findAncestorWithTraceIndex(game, topLeaf, topDepth, leafIdx-1)
+				if err != nil {
+					return nil, 0, fmt.Errorf("failed to find pre claim: %w", err)
+				}
+			}
+		}
+
+		// The top game runs from depth 0 to the split depth inclusive, so the root of the bottom game
+		// is the level below the top game leaves.
+		bottomDepth := int(game.MaxDepth()) - topDepth - 1
+		provider, err := bottomProviderCreator(ctx, bottomDepth, pre, post)
+		if err != nil {
+			return nil, 0, err
+		}
+		relativePos, err := pos.RelativeToAncestorAtDepth(topDepth + 1)
+		if err != nil {
+			return nil, 0, err
+		}
+		return provider, relativePos.TraceIndex(bottomDepth), nil
+	}
+}
+
+func findAncestorAtDepth(game types.Game, claim types.Claim, depth int) (types.Claim, error) {
+	for claim.Depth() > depth {
+		parent, err := game.GetParent(claim)
+		if err != nil {
+			return types.Claim{}, fmt.Errorf("failed to find ancestor at depth %v: %w", depth, err)
+		}
+		claim = parent
+	}
+	return claim, nil
+}
+
+func findAncestorWithTraceIndex(game types.Game, ref types.Claim, depth int, traceIdx uint64) (types.Claim, error) {
+	candidate := ref
+	for candidate.TraceIndex(depth) != traceIdx {
+		if candidate.IsRoot() {
+			return types.Claim{}, fmt.Errorf("no ancestor with trace index %v: %w", traceIdx, types.ErrClaimNotFound)
+		}
+		parent, err := game.GetParent(candidate)
+		if err != nil {
+			return types.Claim{}, fmt.Errorf("failed to find ancestor with trace index %v: %w", traceIdx, err)
+		}
+		candidate = parent
+	}
+	return candidate, nil
+}
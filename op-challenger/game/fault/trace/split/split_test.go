@@ -0,0 +1,150 @@
+package split
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/alphabet"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	gameDepth  = 6
+	splitDepth = 3
+)
+
+type bottomCall struct {
+	depth int
+	pre   types.Claim
+	post  types.Claim
+}
+
+func setupSelector(t *testing.T) (*alphabet.AlphabetTraceProvider, *alphabet.AlphabetTraceProvider, *bottomCall, func(types.Game, types.Claim, types.Position) (types.TraceProvider, uint64, error)) {
+	top := alphabet.NewTraceProvider("abcdefgh", splitDepth)
+	bottom := alphabet.NewTraceProvider("ijklmnop", gameDepth-splitDepth-1)
+	call := &bottomCall{}
+	selector := NewSplitProviderSelector(top, splitDepth, func(ctx context.Context, depth int, pre types.Claim, post types.Claim) (types.TraceProvider, error) {
+		*call = bottomCall{depth: depth, pre: pre, post: post}
+		return bottom, nil
+	})
+	return top, bottom, call, func(game types.Game, ref types.Claim, pos types.Position) (types.TraceProvider, uint64, error) {
+		return selector(context.Background(), game, ref, pos)
+	}
+}
+
+// gameBuilder adds claims to a game, responding to the given parent claims.
+type gameBuilder struct {
+	t    *testing.T
+	game types.Game
+}
+
+func newGameBuilder(t *testing.T) (*gameBuilder, types.Claim) {
+	root := types.Claim{ClaimData: types.ClaimData{Value: common.Hash{0xaa}, Position: types.NewPosition(0, 0)}}
+	return &gameBuilder{t: t, game: types.NewGameState(false, root, gameDepth)}, root
+}
+
+func (b *gameBuilder) add(parent types.Claim, pos types.Position) types.Claim {
+	claim := types.Claim{
+		ClaimData:           types.ClaimData{Value: common.Hash{byte(len(b.game.Claims()))}, Position: pos},
+		Parent:              parent.ClaimData,
+		ContractIndex:       len(b.game.Claims()),
+		ParentContractIndex: parent.ContractIndex,
+	}
+	require.NoError(b.t, b.game.Put(claim))
+	return claim
+}
+
+func TestUseTopProvider(t *testing.T) {
+	top, _, _, selector := setupSelector(t)
+	builder, root := newGameBuilder(t)
+	ref := builder.add(root, root.Attack())
+
+	pos := ref.Attack()
+	provider, idx, err := selector(builder.game, ref, pos)
+	require.NoError(t, err)
+	require.Same(t, top, provider)
+	require.Equal(t, pos.TraceIndex(splitDepth), idx)
+
+	pos = types.NewPosition(splitDepth, 5)
+	provider, idx, err = selector(builder.game, ref, pos)
+	require.NoError(t, err)
+	require.Same(t, top, provider)
+	require.Equal(t, uint64(5), idx)
+}
+
+func TestErrorWhenRefAboveTopGameLeafs(t *testing.T) {
+	_, _, _, selector := setupSelector(t)
+	builder, root := newGameBuilder(t)
+	ref := builder.add(root, root.Attack())
+	_, _, err := selector(builder.game, ref, types.NewPosition(splitDepth+1, 0))
+	require.ErrorIs(t, err, errRefClaimNotDeepEnough)
+}
+
+func TestAttackFirstTopGameLeaf(t *testing.T) {
+	_, bottom, call, selector := setupSelector(t)
+	builder, root := newGameBuilder(t)
+	c1 := builder.add(root, root.Attack())
+	c2 := builder.add(c1, c1.Attack())
+	leaf := builder.add(c2, c2.Attack())
+	require.Equal(t, uint64(0), leaf.TraceIndex(splitDepth))
+
+	provider, idx, err := selector(builder.game, leaf, leaf.Attack())
+	require.NoError(t, err)
+	require.Same(t, bottom, provider)
+	require.Equal(t, gameDepth-splitDepth-1, call.depth)
+	require.Equal(t, types.Claim{}, call.pre, "should use the absolute prestate")
+	require.Equal(t, leaf, call.post)
+	// The first level of the bottom game is its root, which claims the last trace index
+	require.Equal(t, uint64(3), idx)
+}
+
+func TestAttackTopGameLeaf(t *testing.T) {
+	_, _, call, selector := setupSelector(t)
+	builder, root := newGameBuilder(t)
+	c1 := builder.add(root, root.Attack())
+	c2 := builder.add(c1, c1.Attack())
+	leaf := builder.add(c2, c2.Defend())
+	require.Equal(t, uint64(2), leaf.TraceIndex(splitDepth))
+
+	_, _, err := selector(builder.game, leaf, leaf.Attack())
+	require.NoError(t, err)
+	require.Equal(t, c2, call.pre)
+	require.Equal(t, leaf, call.post)
+}
+
+func TestDefendTopGameLeaf(t *testing.T) {
+	_, _, call, selector := setupSelector(t)
+	builder, root := newGameBuilder(t)
+	c1 := builder.add(root, root.Attack())
+	c2 := builder.add(c1, c1.Attack())
+	leaf := builder.add(c2, c2.Attack())
+
+	_, _, err := selector(builder.game, leaf, leaf.Defend())
+	require.NoError(t, err)
+	require.Equal(t, leaf, call.pre)
+	require.Equal(t, c2, call.post)
+}
+
+func TestBottomGamePositions(t *testing.T) {
+	_, bottom, call, selector := setupSelector(t)
+	builder, root := newGameBuilder(t)
+	c1 := builder.add(root, root.Attack())
+	c2 := builder.add(c1, c1.Defend())
+	leaf := builder.add(c2, c2.Attack())
+	bottomRoot := builder.add(leaf, leaf.Attack())
+	bottomClaim := builder.add(bottomRoot, bottomRoot.Attack())
+
+	provider, idx, err := selector(builder.game, bottomClaim, bottomClaim.Defend())
+	require.NoError(t, err)
+	require.Same(t, bottom, provider)
+	require.Equal(t, c1, call.pre)
+	require.Equal(t, leaf, call.post)
+	require.Equal(t, uint64(2), idx)
+
+	provider, idx, err = selector(builder.game, bottomClaim, bottomClaim.Position)
+	require.NoError(t, err)
+	require.Same(t, bottom, provider)
+	require.Equal(t, uint64(1), idx)
+}
@@ -0,0 +1,29 @@
+package types
+
+import (
+	"math/big"
+	"time"
+)
+
+// Clock tracks the chess clock of a claim. Duration is the total time accumulated by the team that made the claim
+// when it was posted and Timestamp is when the claim was posted, which starts the clock of the opposing team.
+type Clock struct {
+	Duration  time.Duration
+	Timestamp time.Time
+}
+
+// NewClock creates a new Clock from a duration and unix timestamp, both in seconds.
+func NewClock(duration uint64, timestamp uint64) Clock {
+	return Clock{
+		Duration:  time.Duration(duration) * time.Second,
+		Timestamp: time.Unix(int64(timestamp), 0),
+	}
+}
+
+// DecodeClock decodes a Clock packed by the FaultDisputeGame contract, which stores the duration in the
+// high-order 64 bits of a uint128 and the timestamp in the low-order 64 bits.
+func DecodeClock(packed *big.Int) Clock {
+	duration := new(big.Int).Rsh(packed, 64).Uint64()
+	timestamp := new(big.Int).And(packed, new(big.Int).SetUint64(^uint64(0))).Uint64()
+	return NewClock(duration, timestamp)
+}
@@ -0,0 +1,24 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeClock(t *testing.T) {
+	t.Run("Zero", func(t *testing.T) {
+		require.Equal(t, NewClock(0, 0), DecodeClock(big.NewInt(0)))
+	})
+
+	t.Run("DurationAndTimestamp", func(t *testing.T) {
+		packed := new(big.Int).Lsh(big.NewInt(300), 64)
+		packed.Or(packed, big.NewInt(1_700_000_000))
+		clock := DecodeClock(packed)
+		require.Equal(t, 300*time.Second, clock.Duration)
+		require.Equal(t, time.Unix(1_700_000_000, 0), clock.Timestamp)
+	})
+
+}
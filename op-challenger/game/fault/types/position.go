@@ -73,6 +73,27 @@ func (p Position) Defend() Position {
 	return p.parent().move(true).move(false)
 }
 
+// MoveRight returns a new position at the same depth, with the next trace index.
+func (p Position) MoveRight() Position {
+	return Position{
+		depth:        p.depth,
+		indexAtDepth: p.indexAtDepth + 1,
+	}
+}
+
+// RelativeToAncestorAtDepth returns the position within the subtree rooted at the ancestor
+// of this position at the given depth.
+func (p Position) RelativeToAncestorAtDepth(ancestor int) (Position, error) {
+	if ancestor > p.depth {
+		return Position{}, fmt.Errorf("ancestor depth %v is below position depth %v", ancestor, p.depth)
+	}
+	depth := p.depth - ancestor
+	return Position{
+		depth:        depth,
+		indexAtDepth: p.indexAtDepth % (1 << depth),
+	}, nil
+}
+
 func (p Position) Print(maxDepth int) {
 	fmt.Printf("GIN: %4b\tTrace Position is %4b\tTrace Depth is: %d\tTrace Index is: %d\n", p.ToGIndex(), p.indexAtDepth, p.depth, p.TraceIndex(maxDepth))
 }
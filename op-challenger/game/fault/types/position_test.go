@@ -119,3 +119,37 @@ func TestDefend(t *testing.T) {
 		require.Equalf(t, test.DefendGIndex, result.ToGIndex(), "Defend from GIndex %v", pos.ToGIndex())
 	}
 }
+
+func TestMoveRight(t *testing.T) {
+	for _, test := range treeNodesMaxDepth4 {
+		pos := NewPosition(test.Depth, test.IndexAtDepth)
+		result := pos.MoveRight()
+		require.Equal(t, pos.Depth(), result.Depth())
+		require.Equal(t, pos.TraceIndex(4)+uint64(1<<(4-test.Depth)), result.TraceIndex(4))
+	}
+}
+
+func TestRelativeToAncestorAtDepth(t *testing.T) {
+	t.Run("InvalidAncestor", func(t *testing.T) {
+		_, err := NewPosition(2, 1).RelativeToAncestorAtDepth(3)
+		require.Error(t, err)
+	})
+
+	tests := []struct {
+		pos      Position
+		ancestor int
+		expected Position
+	}{
+		{NewPosition(0, 0), 0, NewPosition(0, 0)},
+		{NewPosition(3, 5), 0, NewPosition(3, 5)},
+		{NewPosition(3, 5), 3, NewPosition(0, 0)},
+		{NewPosition(3, 5), 1, NewPosition(2, 1)},
+		{NewPosition(4, 7), 2, NewPosition(2, 3)},
+		{NewPosition(4, 8), 2, NewPosition(2, 0)},
+	}
+	for _, test := range tests {
+		result, err := test.pos.RelativeToAncestorAtDepth(test.ancestor)
+		require.NoError(t, err)
+		require.Equalf(t, test.expected, result, "position %v relative to depth %v", test.pos, test.ancestor)
+	}
+}
@@ -79,6 +79,24 @@ type TraceProvider interface {
 	AbsolutePreStateCommitment(ctx context.Context) (hash common.Hash, err error)
 }
 
+// PrestateProvider provides the commitment to the absolute pre-state of a trace.
+type PrestateProvider interface {
+	// AbsolutePreStateCommitment is the commitment of the pre-image value of the trace that transitions to the trace value at index 0
+	AbsolutePreStateCommitment(ctx context.Context) (hash common.Hash, err error)
+}
+
+// TraceAccessor provides the claim values and step data at positions in a game.
+// The claim that is responded to is passed along, so that different parts of the game
+// can be backed by different traces.
+type TraceAccessor interface {
+	// Get returns the claim value at the requested position, when responding to ref.
+	Get(ctx context.Context, game Game, ref Claim, pos Position) (common.Hash, error)
+
+	// GetStepData returns the data required to execute the step that results in the claim
+	// at the requested position, when stepping on ref.
+	GetStepData(ctx context.Context, game Game, ref Claim, pos Position) (prestate []byte, proofData []byte, preimageData *PreimageOracleData, err error)
+}
+
 // ClaimData is the core of a claim. It must be unique inside a specific game.
 type ClaimData struct {
 	Value common.Hash
@@ -103,7 +121,7 @@ type Claim struct {
 	//       When caching is implemented for the Challenger, this will need
 	//       to be changed/removed to avoid invalid/stale contract state.
 	Countered bool
-	Clock     uint64
+	Clock     Clock
 	Parent    ClaimData
 	// Location of the claim & it's parent inside the contract. Does not exist
 	// for claims that have not made it to the contract.
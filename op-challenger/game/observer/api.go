@@ -0,0 +1,34 @@
+package observer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// RPCNamespace is the namespace the observer API is served under.
+const RPCNamespace = "challenger"
+
+// API serves the observations of games over RPC.
+type API struct {
+	store *Store
+}
+
+func NewAPI(store *Store) *API {
+	return &API{store: store}
+}
+
+// ObservedGames returns the latest observation of every observed game.
+func (a *API) ObservedGames(_ context.Context) ([]GameObservation, error) {
+	return a.store.Games(), nil
+}
+
+// ObservedGame returns the latest observation of a single game.
+func (a *API) ObservedGame(_ context.Context, addr common.Address) (GameObservation, error) {
+	obs, ok := a.store.Game(addr)
+	if !ok {
+		return GameObservation{}, fmt.Errorf("game %v has not been observed", addr)
+	}
+	return obs, nil
+}
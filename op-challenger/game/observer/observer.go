@@ -0,0 +1,83 @@
+package observer
+
+import (
+	"bytes"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slices"
+)
+
+// Action is an action the challenger would have performed on a game if it wasn't running in observer mode.
+type Action struct {
+	Type             string       `json:"type"`
+	ParentIdx        int          `json:"parentIndex"`
+	IsAttack         bool         `json:"isAttack"`
+	Value            *common.Hash `json:"value,omitempty"`
+	RemainingSeconds uint64       `json:"remainingSeconds"`
+}
+
+// GameObservation is the latest state of an observed game.
+type GameObservation struct {
+	Game                    common.Address `json:"game"`
+	TraceType               string         `json:"traceType"`
+	AgreeWithProposedOutput bool           `json:"agreeWithProposedOutput"`
+	ClaimCount              int            `json:"claimCount"`
+	// ExpectedStatus is the status the game resolves to if it is played correctly.
+	ExpectedStatus string `json:"expectedStatus"`
+	// ProjectedStatus is the status the game would resolve to if no further claims were made.
+	ProjectedStatus string `json:"projectedStatus"`
+	// Actions are the actions that would have been performed to progress the game towards the expected status.
+	Actions []Action `json:"actions"`
+	// ExpiredActions is the number of required actions that can no longer be performed because the clock expired.
+	ExpiredActions int       `json:"expiredActions"`
+	ObservedAt     time.Time `json:"observedAt"`
+}
+
+// TrendingIncorrect returns true if the game would resolve incorrectly if no further claims were made.
+func (o GameObservation) TrendingIncorrect() bool {
+	return o.ProjectedStatus != o.ExpectedStatus
+}
+
+// Store holds the latest observation of each game.
+// It is safe for concurrent use as games are observed by multiple executors.
+type Store struct {
+	lock  sync.RWMutex
+	games map[common.Address]GameObservation
+}
+
+func NewStore() *Store {
+	return &Store{
+		games: make(map[common.Address]GameObservation),
+	}
+}
+
+// RecordGameObservation replaces the previous observation of the game.
+func (s *Store) RecordGameObservation(obs GameObservation) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.games[obs.Game] = obs
+}
+
+// Game returns the latest observation of the game, if it has been observed.
+func (s *Store) Game(addr common.Address) (GameObservation, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	obs, ok := s.games[addr]
+	return obs, ok
+}
+
+// Games returns the latest observation of every observed game, ordered by game address.
+func (s *Store) Games() []GameObservation {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	games := make([]GameObservation, 0, len(s.games))
+	for _, obs := range s.games {
+		games = append(games, obs)
+	}
+	slices.SortFunc(games, func(a, b GameObservation) int {
+		return bytes.Compare(a.Game[:], b.Game[:])
+	})
+	return games
+}
@@ -0,0 +1,68 @@
+package observer
+
+import (
+	"context"
+	"testing"
+
+	gameTypes "github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStore(t *testing.T) {
+	t.Run("UnknownGame", func(t *testing.T) {
+		store := NewStore()
+		_, ok := store.Game(common.Address{0xaa})
+		require.False(t, ok)
+		require.Empty(t, store.Games())
+	})
+
+	t.Run("ReplacePreviousObservation", func(t *testing.T) {
+		store := NewStore()
+		store.RecordGameObservation(GameObservation{Game: common.Address{0xaa}, ClaimCount: 1})
+		store.RecordGameObservation(GameObservation{Game: common.Address{0xaa}, ClaimCount: 2})
+		obs, ok := store.Game(common.Address{0xaa})
+		require.True(t, ok)
+		require.Equal(t, 2, obs.ClaimCount)
+		require.Len(t, store.Games(), 1)
+	})
+
+	t.Run("OrderGamesByAddress", func(t *testing.T) {
+		store := NewStore()
+		store.RecordGameObservation(GameObservation{Game: common.Address{0xcc}})
+		store.RecordGameObservation(GameObservation{Game: common.Address{0xaa}})
+		store.RecordGameObservation(GameObservation{Game: common.Address{0xbb}})
+		games := store.Games()
+		require.Len(t, games, 3)
+		require.Equal(t, common.Address{0xaa}, games[0].Game)
+		require.Equal(t, common.Address{0xbb}, games[1].Game)
+		require.Equal(t, common.Address{0xcc}, games[2].Game)
+	})
+}
+
+func TestTrendingIncorrect(t *testing.T) {
+	obs := GameObservation{
+		ExpectedStatus:  gameTypes.GameStatusChallengerWon.String(),
+		ProjectedStatus: gameTypes.GameStatusChallengerWon.String(),
+	}
+	require.False(t, obs.TrendingIncorrect())
+	obs.ProjectedStatus = gameTypes.GameStatusDefenderWon.String()
+	require.True(t, obs.TrendingIncorrect())
+}
+
+func TestAPI(t *testing.T) {
+	store := NewStore()
+	api := NewAPI(store)
+	store.RecordGameObservation(GameObservation{Game: common.Address{0xaa}, ClaimCount: 3})
+
+	games, err := api.ObservedGames(context.Background())
+	require.NoError(t, err)
+	require.Len(t, games, 1)
+
+	obs, err := api.ObservedGame(context.Background(), common.Address{0xaa})
+	require.NoError(t, err)
+	require.Equal(t, 3, obs.ClaimCount)
+
+	_, err = api.ObservedGame(context.Background(), common.Address{0xbb})
+	require.ErrorContains(t, err, "has not been observed")
+}
@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/types"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnknownGameType(t *testing.T) {
+	registry := NewGameTypeRegistry()
+	player, err := registry.CreatePlayer(types.GameMetadata{GameType: 0}, "")
+	require.ErrorIs(t, err, ErrUnsupportedGameType)
+	require.Nil(t, player)
+}
+
+func TestKnownGameType(t *testing.T) {
+	registry := NewGameTypeRegistry()
+	expectedPlayer := &stubPlayer{}
+	var created types.GameMetadata
+	creator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+		created = game
+		return expectedPlayer, nil
+	}
+	registry.RegisterGameType(0, creator)
+	game := types.GameMetadata{GameType: 0, Proxy: common.Address{0xaa}}
+	player, err := registry.CreatePlayer(game, "")
+	require.NoError(t, err)
+	require.Same(t, expectedPlayer, player)
+	require.Equal(t, game, created)
+}
+
+func TestPanicsOnDuplicateGameType(t *testing.T) {
+	registry := NewGameTypeRegistry()
+	creator := func(game types.GameMetadata, dir string) (scheduler.GamePlayer, error) {
+		return nil, nil
+	}
+	registry.RegisterGameType(0, creator)
+	require.Panics(t, func() {
+		registry.RegisterGameType(0, creator)
+	})
+}
+
+type stubPlayer struct {
+	status types.GameStatus
+}
+
+func (s *stubPlayer) ProgressGame(_ context.Context) types.GameStatus {
+	return s.status
+}
+
+func (s *stubPlayer) Status() types.GameStatus {
+	return s.status
+}
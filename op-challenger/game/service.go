@@ -3,10 +3,17 @@ package game
 import (
 	"context"
 	"fmt"
+	"math/big"
+	"path/filepath"
 
 	"github.com/ethereum-optimism/optimism/op-bindings/bindings"
 	"github.com/ethereum-optimism/optimism/op-challenger/config"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/fault"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/bonds"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/cannon"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/fault/trace/outputs"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/observer"
+	"github.com/ethereum-optimism/optimism/op-challenger/game/registry"
 	"github.com/ethereum-optimism/optimism/op-challenger/game/scheduler"
 	"github.com/ethereum-optimism/optimism/op-challenger/metrics"
 	"github.com/ethereum-optimism/optimism/op-challenger/version"
@@ -14,9 +21,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/client"
 	"github.com/ethereum-optimism/optimism/op-service/clock"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
+	oprpc "github.com/ethereum-optimism/optimism/op-service/rpc"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 )
 
 type Service struct {
@@ -24,15 +32,31 @@ type Service struct {
 	metrics metrics.Metricer
 	monitor *gameMonitor
 	sched   *scheduler.Scheduler
+
+	rpcServer *oprpc.Server
 }
 
 // NewService creates a new Service.
 func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Service, error) {
 	cl := clock.SystemClock
 	m := metrics.NewMetrics()
-	txMgr, err := txmgr.NewSimpleTxManager("challenger", logger, &m.TxMetrics, cfg.TxMgrConfig)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create the transaction manager: %w", err)
+
+	// In observer mode games are only observed so no transaction manager, or private key, is required
+	var txMgr txmgr.TxManager
+	var gameObserver fault.GameObserver
+	var observations *observer.Store
+	var bondLedger *bonds.Ledger
+	if cfg.Observer {
+		logger.Info("Running in observer mode, no transactions will be sent")
+		observations = observer.NewStore()
+		gameObserver = observations
+	} else {
+		var err error
+		txMgr, err = txmgr.NewSimpleTxManager("challenger", logger, &m.TxMetrics, cfg.TxMgrConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create the transaction manager: %w", err)
+		}
+		bondLedger = bonds.NewLedger(m, cfg.MoveBond)
 	}
 
 	l1Client, err := client.DialEthClientWithTimeout(client.DefaultDialTimeout, logger, cfg.L1EthRpc)
@@ -58,7 +82,9 @@ func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Se
 				logger.Error("error starting metrics server", "err", err)
 			}
 		}()
-		m.StartBalanceMetrics(ctx, logger, l1Client, txMgr.From())
+		if txMgr != nil {
+			m.StartBalanceMetrics(ctx, logger, l1Client, txMgr.From())
+		}
 	}
 
 	factory, err := bindings.NewDisputeGameFactory(cfg.GameFactoryAddress, l1Client)
@@ -67,15 +93,37 @@ func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Se
 	}
 	loader := NewGameLoader(factory)
 
-	disk := newDiskManager(cfg.Datadir)
+	var rollupClient outputs.OutputRollupClient
+	if cfg.RollupRpc != "" {
+		rollupClient, err = client.DialRollupClientWithTimeout(client.DefaultDialTimeout, logger, cfg.RollupRpc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to dial rollup node: %w", err)
+		}
+	}
+
+	// Cannon executions are shared between games so they are stored outside the directory of each game
+	var snapshotCache *cannon.SnapshotCache
+	var caches []diskCache
+	if cfg.CannonCacheSize > 0 && (cfg.TraceTypeEnabled(config.TraceTypeCannon) || cfg.TraceTypeEnabled(config.TraceTypeOutputCannon)) {
+		snapshotCache, err = cannon.NewSnapshotCache(logger, filepath.Join(cfg.Datadir, snapshotCacheDir), int64(cfg.CannonCacheSize)*1024*1024)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create cannon snapshot cache: %w", err)
+		}
+		caches = append(caches, snapshotCache)
+	}
+
+	gameTypeRegistry := registry.NewGameTypeRegistry()
+	if err := fault.RegisterGameTypes(gameTypeRegistry, ctx, logger, m, cl, cfg, txMgr, gameObserver, bondLedger, l1Client, rollupClient, snapshotCache); err != nil {
+		return nil, fmt.Errorf("failed to register game types: %w", err)
+	}
+
+	disk := newDiskManager(cfg.Datadir, caches...)
 	sched := scheduler.NewScheduler(
 		logger,
 		m,
 		disk,
 		cfg.MaxConcurrency,
-		func(addr common.Address, dir string) (scheduler.GamePlayer, error) {
-			return fault.NewGamePlayer(ctx, logger, m, cfg, dir, addr, txMgr, l1Client)
-		})
+		gameTypeRegistry.CreatePlayer)
 
 	pollClient, err := opClient.NewRPCWithClient(ctx, logger, cfg.L1EthRpc, opClient.NewBaseRPCClient(l1Client.Client()), cfg.PollInterval)
 	if err != nil {
@@ -83,14 +131,40 @@ func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Se
 	}
 	monitor := newGameMonitor(logger, cl, loader, sched, cfg.GameWindow, l1Client.BlockNumber, cfg.GameAllowlist, pollClient)
 
+	// The RPC server reports the observed games in observer mode, or the bonds posted with moves
+	var rpcServer *oprpc.Server
+	if cfg.Observer || (bondLedger != nil && bondLedger.MoveBond().Sign() > 0) {
+		rpcCfg := cfg.RPCConfig
+		rpcServer = oprpc.NewServer(rpcCfg.ListenAddr, rpcCfg.ListenPort, version.SimpleWithMeta, oprpc.WithLogger(logger))
+		if cfg.Observer {
+			rpcServer.AddAPI(gethrpc.API{
+				Namespace: observer.RPCNamespace,
+				Service:   observer.NewAPI(observations),
+			})
+		} else {
+			account := txMgr.From()
+			rpcServer.AddAPI(gethrpc.API{
+				Namespace: bonds.RPCNamespace,
+				Service: bonds.NewAPI(bondLedger, func(ctx context.Context) (*big.Int, error) {
+					return l1Client.BalanceAt(ctx, account, nil)
+				}),
+			})
+		}
+		logger.Info("starting RPC server", "addr", rpcCfg.ListenAddr, "port", rpcCfg.ListenPort)
+		if err := rpcServer.Start(); err != nil {
+			return nil, fmt.Errorf("error starting RPC server: %w", err)
+		}
+	}
+
 	m.RecordInfo(version.SimpleWithMeta)
 	m.RecordUp()
 
 	return &Service{
-		logger:  logger,
-		metrics: m,
-		monitor: monitor,
-		sched:   sched,
+		logger:    logger,
+		metrics:   m,
+		monitor:   monitor,
+		sched:     sched,
+		rpcServer: rpcServer,
 	}, nil
 }
 
@@ -98,5 +172,12 @@ func NewService(ctx context.Context, logger log.Logger, cfg *config.Config) (*Se
 func (s *Service) MonitorGame(ctx context.Context) error {
 	s.sched.Start(ctx)
 	defer s.sched.Close()
+	if s.rpcServer != nil {
+		defer func() {
+			if err := s.rpcServer.Stop(); err != nil {
+				s.logger.Error("Error shutting down RPC server", "err", err)
+			}
+		}()
+	}
 	return s.monitor.MonitorGames(ctx)
 }
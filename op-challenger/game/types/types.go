@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
 )
 
 type GameStatus uint8
@@ -33,3 +35,10 @@ func GameStatusFromUint8(i uint8) (GameStatus, error) {
 	}
 	return GameStatus(i), nil
 }
+
+// GameMetadata is the information about a dispute game that is recorded by the dispute game factory.
+type GameMetadata struct {
+	GameType  uint8
+	Timestamp uint64
+	Proxy     common.Address
+}
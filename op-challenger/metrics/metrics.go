@@ -2,10 +2,12 @@ package metrics
 
 import (
 	"context"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
 	"github.com/prometheus/client_golang/prometheus"
 
 	opmetrics "github.com/ethereum-optimism/optimism/op-service/metrics"
@@ -21,14 +23,20 @@ type Metricer interface {
 	// Record Tx metrics
 	txmetrics.TxMetricer
 
-	RecordGameStep()
-	RecordGameMove()
+	RecordGameStep(traceType string)
+	RecordGameMove(traceType string)
+	RecordClockExpiring(traceType string)
+	RecordDroppedAction(traceType string)
+	RecordObservedAction(traceType string)
 	RecordCannonExecutionTime(t float64)
 
 	RecordGameClaimCount(addr string, count int)
+	RecordGameTrendingIncorrect(addr string, incorrect bool)
 
 	RecordGamesStatus(inProgress, defenderWon, challengerWon int)
 
+	RecordBonds(atRisk, credit, forfeited *big.Int)
+
 	RecordGameUpdateScheduled()
 	RecordGameUpdateCompleted()
 
@@ -50,15 +58,22 @@ type Metrics struct {
 
 	executors prometheus.GaugeVec
 
-	moves prometheus.Counter
-	steps prometheus.Counter
+	moves prometheus.CounterVec
+	steps prometheus.CounterVec
+
+	clockExpiring   prometheus.CounterVec
+	droppedActions  prometheus.CounterVec
+	observedActions prometheus.CounterVec
 
 	cannonExecutionTime prometheus.Histogram
 
-	gameClaimCount prometheus.GaugeVec
+	gameClaimCount        prometheus.GaugeVec
+	gameTrendingIncorrect prometheus.GaugeVec
 
 	trackedGames  prometheus.GaugeVec
 	inflightGames prometheus.Gauge
+
+	bonds prometheus.GaugeVec
 }
 
 var _ Metricer = (*Metrics)(nil)
@@ -93,15 +108,40 @@ func NewMetrics() *Metrics {
 		}, []string{
 			"status",
 		}),
-		moves: factory.NewCounter(prometheus.CounterOpts{
+		moves: *factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "moves",
 			Help:      "Number of game moves made by the challenge agent",
+		}, []string{
+			"trace_type",
 		}),
-		steps: factory.NewCounter(prometheus.CounterOpts{
+		steps: *factory.NewCounterVec(prometheus.CounterOpts{
 			Namespace: Namespace,
 			Name:      "steps",
 			Help:      "Number of game steps made by the challenge agent",
+		}, []string{
+			"trace_type",
+		}),
+		clockExpiring: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "clock_expiring",
+			Help:      "Number of responses made to claims with a clock that is close to expiring",
+		}, []string{
+			"trace_type",
+		}),
+		droppedActions: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "dropped_actions",
+			Help:      "Number of actions dropped because the clock of the claim to counter expired",
+		}, []string{
+			"trace_type",
+		}),
+		observedActions: *factory.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Name:      "observed_actions",
+			Help:      "Number of actions that would have been performed when running in observer mode",
+		}, []string{
+			"trace_type",
 		}),
 		cannonExecutionTime: factory.NewHistogram(prometheus.HistogramOpts{
 			Namespace: Namespace,
@@ -118,6 +158,13 @@ func NewMetrics() *Metrics {
 		}, []string{
 			"game_address",
 		}),
+		gameTrendingIncorrect: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "game_trending_incorrect",
+			Help:      "1 if the observed game would resolve incorrectly if no further claims were made",
+		}, []string{
+			"game_address",
+		}),
 		trackedGames: *factory.NewGaugeVec(prometheus.GaugeOpts{
 			Namespace: Namespace,
 			Name:      "tracked_games",
@@ -130,6 +177,13 @@ func NewMetrics() *Metrics {
 			Name:      "inflight_games",
 			Help:      "Number of games being tracked by the challenger",
 		}),
+		bonds: *factory.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Name:      "bonds",
+			Help:      "Total bonds (in ether) posted by the challenger that are at risk, owed back as credit or forfeited",
+		}, []string{
+			"status",
+		}),
 	}
 }
 
@@ -162,12 +216,24 @@ func (m *Metrics) Document() []opmetrics.DocumentedMetric {
 	return m.factory.Document()
 }
 
-func (m *Metrics) RecordGameMove() {
-	m.moves.Add(1)
+func (m *Metrics) RecordGameMove(traceType string) {
+	m.moves.WithLabelValues(traceType).Add(1)
 }
 
-func (m *Metrics) RecordGameStep() {
-	m.steps.Add(1)
+func (m *Metrics) RecordGameStep(traceType string) {
+	m.steps.WithLabelValues(traceType).Add(1)
+}
+
+func (m *Metrics) RecordClockExpiring(traceType string) {
+	m.clockExpiring.WithLabelValues(traceType).Add(1)
+}
+
+func (m *Metrics) RecordDroppedAction(traceType string) {
+	m.droppedActions.WithLabelValues(traceType).Add(1)
+}
+
+func (m *Metrics) RecordObservedAction(traceType string) {
+	m.observedActions.WithLabelValues(traceType).Add(1)
 }
 
 func (m *Metrics) RecordCannonExecutionTime(t float64) {
@@ -194,12 +260,34 @@ func (m *Metrics) RecordGameClaimCount(addr string, count int) {
 	m.gameClaimCount.With(prometheus.Labels{"game_address": addr}).Set(float64(count))
 }
 
+func (m *Metrics) RecordGameTrendingIncorrect(addr string, incorrect bool) {
+	value := 0.0
+	if incorrect {
+		value = 1
+	}
+	m.gameTrendingIncorrect.With(prometheus.Labels{"game_address": addr}).Set(value)
+}
+
 func (m *Metrics) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {
 	m.trackedGames.WithLabelValues("in_progress").Set(float64(inProgress))
 	m.trackedGames.WithLabelValues("defender_won").Set(float64(defenderWon))
 	m.trackedGames.WithLabelValues("challenger_won").Set(float64(challengerWon))
 }
 
+func (m *Metrics) RecordBonds(atRisk, credit, forfeited *big.Int) {
+	m.bonds.WithLabelValues("at_risk").Set(weiToEther(atRisk))
+	m.bonds.WithLabelValues("credit").Set(weiToEther(credit))
+	m.bonds.WithLabelValues("forfeited").Set(weiToEther(forfeited))
+}
+
+// weiToEther divides the wei value by 10^18 to get a number in ether as a float64
+func weiToEther(wei *big.Int) float64 {
+	num := new(big.Rat).SetInt(wei)
+	num = num.Quo(num, big.NewRat(params.Ether, 1))
+	f, _ := num.Float64()
+	return f
+}
+
 func (m *Metrics) RecordGameUpdateScheduled() {
 	m.inflightGames.Add(1)
 }
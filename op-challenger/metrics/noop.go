@@ -1,6 +1,8 @@
 package metrics
 
 import (
+	"math/big"
+
 	txmetrics "github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 )
 
@@ -13,13 +15,19 @@ var NoopMetrics Metricer = new(NoopMetricsImpl)
 func (*NoopMetricsImpl) RecordInfo(version string) {}
 func (*NoopMetricsImpl) RecordUp()                 {}
 
-func (*NoopMetricsImpl) RecordGameMove() {}
-func (*NoopMetricsImpl) RecordGameStep() {}
+func (*NoopMetricsImpl) RecordGameMove(traceType string) {}
+func (*NoopMetricsImpl) RecordGameStep(traceType string) {}
+
+func (*NoopMetricsImpl) RecordClockExpiring(traceType string)  {}
+func (*NoopMetricsImpl) RecordDroppedAction(traceType string)  {}
+func (*NoopMetricsImpl) RecordObservedAction(traceType string) {}
 
 func (*NoopMetricsImpl) RecordCannonExecutionTime(t float64) {}
 
 func (*NoopMetricsImpl) RecordGamesStatus(inProgress, defenderWon, challengerWon int) {}
 
+func (*NoopMetricsImpl) RecordBonds(atRisk, credit, forfeited *big.Int) {}
+
 func (*NoopMetricsImpl) RecordGameUpdateScheduled() {}
 func (*NoopMetricsImpl) RecordGameUpdateCompleted() {}
 
@@ -28,4 +36,5 @@ func (*NoopMetricsImpl) DecActiveExecutors() {}
 func (*NoopMetricsImpl) IncIdleExecutors()   {}
 func (*NoopMetricsImpl) DecIdleExecutors()   {}
 
-func (*NoopMetricsImpl) RecordGameClaimCount(addr string, count int) {}
+func (*NoopMetricsImpl) RecordGameClaimCount(addr string, count int)             {}
+func (*NoopMetricsImpl) RecordGameTrendingIncorrect(addr string, incorrect bool) {}
@@ -35,5 +35,4 @@ $CHALLENGER_DIR/bin/op-challenger \
   --private-key $CHARLIE_KEY \
   --num-confirmations 1 \
   --metrics.enabled --metrics.port=7304 \
-  --pprof.enabled --pprof.port=6064 \
-  --agree-with-proposed-output=true
+  --pprof.enabled --pprof.port=6064
@@ -57,9 +57,9 @@ type L2API interface {
 	OutputV0AtBlock(ctx context.Context, blockHash common.Hash) (*eth.OutputV0, error)
 }
 
-func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, eng L2API, cfg *rollup.Config, syncCfg *sync.Config) *L2Verifier {
+func NewL2Verifier(t Testing, log log.Logger, l1 derive.L1Fetcher, blobsSrc derive.L1BlobsFetcher, eng L2API, cfg *rollup.Config, syncCfg *sync.Config) *L2Verifier {
 	metrics := &testutils.TestDerivationMetrics{}
-	pipeline := derive.NewDerivationPipeline(log, cfg, l1, eng, metrics, syncCfg)
+	pipeline := derive.NewDerivationPipeline(log, cfg, l1, blobsSrc, nil, eng, metrics, syncCfg)
 	pipeline.Reset()
 
 	rollupNode := &L2Verifier{
@@ -110,6 +110,10 @@ func (s *l2VerifierBackend) SyncStatus(ctx context.Context) (*eth.SyncStatus, er
 	return s.verifier.SyncStatus(), nil
 }
 
+func (s *l2VerifierBackend) DerivationStatus(ctx context.Context) (*derive.DerivationStatus, error) {
+	return s.verifier.derivation.Status(), nil
+}
+
 func (s *l2VerifierBackend) ResetDerivationPipeline(ctx context.Context) error {
 	s.verifier.derivation.Reset()
 	return nil
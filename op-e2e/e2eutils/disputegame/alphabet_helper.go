@@ -20,11 +20,10 @@ func (g *AlphabetGameHelper) StartChallenger(ctx context.Context, l1Endpoint str
 		func(c *config.Config) {
 			c.GameFactoryAddress = g.factoryAddr
 			c.GameAllowlist = []common.Address{g.addr}
-			c.TraceType = config.TraceTypeAlphabet
-			// By default the challenger agrees with the root claim (thus disagrees with the proposed output)
+			c.TraceTypes = []config.TraceType{config.TraceTypeAlphabet}
+			// By default the challenger uses the alphabet of the root claim, so agrees with it
 			// This can be overridden by passing in options
 			c.AlphabetTrace = g.claimedAlphabet
-			c.AgreeWithProposedOutput = false
 		},
 	}
 	opts = append(opts, options...)
@@ -0,0 +1,163 @@
+package fakebeacon
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// FakeBeacon is a testing-only utility that serves the subset of the beacon-node API
+// that the op-node uses to retrieve blobs. Blobs are kept in memory, by slot.
+type FakeBeacon struct {
+	log log.Logger
+
+	// in-memory blob store, by slot
+	blobsLock sync.Mutex
+	blobs     map[uint64][]*eth.BlobSidecar
+
+	genesisTime uint64
+	blockTime   uint64
+
+	srv    *http.Server
+	srvMux *http.ServeMux
+	addr   net.Addr
+}
+
+func NewBeacon(log log.Logger, genesisTime uint64, blockTime uint64) *FakeBeacon {
+	return &FakeBeacon{
+		log:         log,
+		blobs:       make(map[uint64][]*eth.BlobSidecar),
+		genesisTime: genesisTime,
+		blockTime:   blockTime,
+	}
+}
+
+func (f *FakeBeacon) Start(addr string) error {
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to open tcp listener for http beacon api server: %w", err)
+	}
+	f.addr = listener.Addr()
+	f.srvMux = new(http.ServeMux)
+	f.srvMux.HandleFunc("/eth/v1/node/version", func(w http.ResponseWriter, r *http.Request) {
+		f.writeJSON(w, map[string]any{"data": map[string]string{"version": "fakebeacon/v0.0.0"}})
+	})
+	f.srvMux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		f.writeJSON(w, &eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: eth.Uint64String(f.genesisTime)}})
+	})
+	f.srvMux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		f.writeJSON(w, &eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: eth.Uint64String(f.blockTime)}})
+	})
+	f.srvMux.HandleFunc("/eth/v1/beacon/blob_sidecars/", func(w http.ResponseWriter, r *http.Request) {
+		slot, err := strconv.ParseUint(strings.TrimPrefix(r.URL.Path, "/eth/v1/beacon/blob_sidecars/"), 10, 64)
+		if err != nil {
+			f.log.Error("could not parse slot from blob sidecars request", "path", r.URL.Path, "err", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.blobsLock.Lock()
+		sidecars, ok := f.blobs[slot]
+		f.blobsLock.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		query := r.URL.Query()
+		out := eth.APIGetBlobSidecarsResponse{Data: []*eth.BlobSidecar{}}
+		if indices := query["indices"]; len(indices) > 0 {
+			for _, s := range indices {
+				ix, err := strconv.ParseUint(s, 10, 64)
+				if err != nil {
+					f.log.Error("could not parse index from blob sidecars request", "index", s, "err", err)
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+				if ix >= uint64(len(sidecars)) {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				out.Data = append(out.Data, sidecars[ix])
+			}
+		} else {
+			out.Data = append(out.Data, sidecars...)
+		}
+		f.writeJSON(w, &out)
+	})
+	f.srv = &http.Server{
+		Handler:           f.srvMux,
+		ReadTimeout:       time.Second * 20,
+		ReadHeaderTimeout: time.Second * 20,
+		WriteTimeout:      time.Second * 20,
+		IdleTimeout:       time.Second * 20,
+	}
+	go func() {
+		if err := f.srv.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			f.log.Error("failed to start fake-pos beacon server for blobs testing", "err", err)
+		}
+	}()
+	return nil
+}
+
+func (f *FakeBeacon) writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		f.log.Error("failed to encode beacon API response", "err", err)
+	}
+}
+
+// StoreBlobs stores the blobs of the L1 block at the given timestamp, in order of inclusion,
+// and computes the commitments and proofs of the blobs to serve them as sidecars.
+func (f *FakeBeacon) StoreBlobs(timestamp uint64, blobs []*eth.Blob) error {
+	if timestamp < f.genesisTime {
+		return fmt.Errorf("timestamp %d precedes beacon genesis time %d", timestamp, f.genesisTime)
+	}
+	slot := (timestamp - f.genesisTime) / f.blockTime
+	sidecars := make([]*eth.BlobSidecar, 0, len(blobs))
+	for i, b := range blobs {
+		commitment, err := kzg4844.BlobToCommitment(*b.KZGBlob())
+		if err != nil {
+			return fmt.Errorf("failed to compute commitment of blob %d: %w", i, err)
+		}
+		proof, err := kzg4844.ComputeBlobProof(*b.KZGBlob(), commitment)
+		if err != nil {
+			return fmt.Errorf("failed to compute proof of blob %d: %w", i, err)
+		}
+		sidecars = append(sidecars, &eth.BlobSidecar{
+			Slot:          eth.Uint64String(slot),
+			Blob:          *b,
+			Index:         eth.Uint64String(i),
+			KZGCommitment: eth.Bytes48(commitment),
+			KZGProof:      eth.Bytes48(proof),
+		})
+	}
+	f.blobsLock.Lock()
+	defer f.blobsLock.Unlock()
+	f.blobs[slot] = sidecars
+	return nil
+}
+
+func (f *FakeBeacon) Close() error {
+	var lastErr error
+	if f.srv != nil {
+		if err := f.srv.Close(); err != nil {
+			lastErr = fmt.Errorf("failed to close server: %w", err)
+		}
+	}
+	return lastErr
+}
+
+func (f *FakeBeacon) BeaconAddr() string {
+	return "http://" + f.addr.String()
+}
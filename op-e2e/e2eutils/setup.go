@@ -155,8 +155,8 @@ func Setup(t require.TestingT, deployParams *DeployParams, alloc *AllocParams) *
 		BatchInboxAddress:      deployConf.BatchInboxAddress,
 		DepositContractAddress: deployConf.OptimismPortalProxy,
 		L1SystemConfigAddress:  deployConf.SystemConfigProxy,
-		RegolithTime:           deployConf.RegolithTime(uint64(deployConf.L1GenesisBlockTimestamp)),
 	}
+	deployConf.SetForkTimes(rollupCfg, uint64(deployConf.L1GenesisBlockTimestamp))
 
 	require.NoError(t, rollupCfg.Check())
 
@@ -25,9 +25,8 @@ func TestMultipleCannonGames(t *testing.T) {
 	gameFactory := disputegame.NewFactoryHelper(t, ctx, sys.cfg.L1Deployments, l1Client)
 	// Start a challenger with the correct alphabet trace
 	challenger := gameFactory.StartChallenger(ctx, sys.NodeEndpoint("l1"), "TowerDefense",
-		challenger.WithCannon(t, sys.RollupConfig, sys.L2GenesisCfg, sys.NodeEndpoint("sequencer")),
+		challenger.WithCannon(t, sys.RollupConfig, sys.L2GenesisCfg, sys.RollupNodes["sequencer"].HTTPEndpoint(), sys.NodeEndpoint("sequencer")),
 		challenger.WithPrivKey(sys.cfg.Secrets.Alice),
-		challenger.WithAgreeProposedOutput(true),
 	)
 
 	game1 := gameFactory.StartCannonGame(ctx, common.Hash{0x01, 0xaa})
@@ -157,13 +156,10 @@ func TestChallengerCompleteDisputeGame(t *testing.T) {
 			gameDuration := game.GameDuration(ctx)
 
 			game.StartChallenger(ctx, sys.NodeEndpoint("l1"), "Defender",
-				challenger.WithAgreeProposedOutput(false),
 				challenger.WithPrivKey(sys.cfg.Secrets.Mallory),
 			)
 
 			game.StartChallenger(ctx, sys.NodeEndpoint("l1"), "Challenger",
-				// Agree with the proposed output, so disagree with the root claim
-				challenger.WithAgreeProposedOutput(true),
 				challenger.WithAlphabet(test.otherAlphabet),
 				challenger.WithPrivKey(sys.cfg.Secrets.Alice),
 			)
@@ -200,7 +196,6 @@ func TestChallengerCompleteExhaustiveDisputeGame(t *testing.T) {
 
 		// Start honest challenger
 		game.StartChallenger(ctx, sys.NodeEndpoint("l1"), "Challenger",
-			challenger.WithAgreeProposedOutput(!isRootCorrect),
 			challenger.WithAlphabet(disputegame.CorrectAlphabet),
 			challenger.WithPrivKey(sys.cfg.Secrets.Alice),
 			// Ensures the challenger responds to all claims before test timeout
@@ -265,9 +260,7 @@ func TestCannonDisputeGame(t *testing.T) {
 			require.NotNil(t, game)
 			game.LogGameData(ctx)
 
-			game.StartChallenger(ctx, sys.RollupConfig, sys.L2GenesisCfg, sys.NodeEndpoint("l1"), sys.NodeEndpoint("sequencer"), "Challenger",
-				// Agree with the proposed output, so disagree with the root claim
-				challenger.WithAgreeProposedOutput(true),
+			game.StartChallenger(ctx, sys.RollupConfig, sys.L2GenesisCfg, sys.NodeEndpoint("l1"), sys.RollupNodes["sequencer"].HTTPEndpoint(), sys.NodeEndpoint("sequencer"), "Challenger",
 				challenger.WithPrivKey(sys.cfg.Secrets.Alice),
 			)
 
@@ -304,14 +297,13 @@ func TestCannonDefendStep(t *testing.T) {
 	game.LogGameData(ctx)
 
 	l1Endpoint := sys.NodeEndpoint("l1")
+	rollupEndpoint := sys.RollupNodes["sequencer"].HTTPEndpoint()
 	l2Endpoint := sys.NodeEndpoint("sequencer")
-	game.StartChallenger(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, l2Endpoint, "Challenger",
-		// Agree with the proposed output, so disagree with the root claim
-		challenger.WithAgreeProposedOutput(true),
+	game.StartChallenger(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, rollupEndpoint, l2Endpoint, "Challenger",
 		challenger.WithPrivKey(sys.cfg.Secrets.Alice),
 	)
 
-	correctTrace := game.CreateHonestActor(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Client, l1Endpoint, l2Endpoint,
+	correctTrace := game.CreateHonestActor(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Client, l1Endpoint, rollupEndpoint, l2Endpoint,
 		challenger.WithPrivKey(sys.cfg.Secrets.Mallory),
 	)
 
@@ -419,10 +411,11 @@ func TestCannonPoisonedPostState(t *testing.T) {
 	t.Cleanup(sys.Close)
 
 	l1Endpoint := sys.NodeEndpoint("l1")
+	rollupEndpoint := sys.RollupNodes["sequencer"].HTTPEndpoint()
 	l2Endpoint := sys.NodeEndpoint("sequencer")
 
 	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys.cfg.L1Deployments, l1Client)
-	game, correctTrace := disputeGameFactory.StartCannonGameWithCorrectRoot(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, l2Endpoint,
+	game, correctTrace := disputeGameFactory.StartCannonGameWithCorrectRoot(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, rollupEndpoint, l2Endpoint,
 		challenger.WithPrivKey(sys.cfg.Secrets.Mallory),
 	)
 	require.NotNil(t, game)
@@ -441,9 +434,7 @@ func TestCannonPoisonedPostState(t *testing.T) {
 	correctTrace.Attack(ctx, 3)
 
 	// Start the honest challenger
-	game.StartChallenger(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, l2Endpoint, "Honest",
-		// Agree with the proposed output, so disagree with the root claim
-		challenger.WithAgreeProposedOutput(true),
+	game.StartChallenger(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, rollupEndpoint, l2Endpoint, "Honest",
 		challenger.WithPrivKey(sys.cfg.Secrets.Bob),
 	)
 
@@ -504,19 +495,18 @@ func setupDisputeGameForInvalidOutputRoot(t *testing.T, outputRoot common.Hash)
 	l2oo.PublishNextOutput(ctx, outputRoot)
 
 	l1Endpoint := sys.NodeEndpoint("l1")
+	rollupEndpoint := sys.RollupNodes["sequencer"].HTTPEndpoint()
 	l2Endpoint := sys.NodeEndpoint("sequencer")
 
 	// Dispute the new output root by creating a new game with the correct cannon trace.
 	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys.cfg.L1Deployments, l1Client)
-	game, correctTrace := disputeGameFactory.StartCannonGameWithCorrectRoot(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, l2Endpoint,
+	game, correctTrace := disputeGameFactory.StartCannonGameWithCorrectRoot(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, rollupEndpoint, l2Endpoint,
 		challenger.WithPrivKey(sys.cfg.Secrets.Mallory),
 	)
 	require.NotNil(t, game)
 
 	// Start the honest challenger
-	game.StartChallenger(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, l2Endpoint, "Defender",
-		// Disagree with the proposed output, so agree with the (correct) root claim
-		challenger.WithAgreeProposedOutput(false),
+	game.StartChallenger(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, rollupEndpoint, l2Endpoint, "Defender",
 		challenger.WithPrivKey(sys.cfg.Secrets.Mallory),
 	)
 	return sys, l1Client, game, correctTrace
@@ -530,18 +520,17 @@ func TestCannonChallengeWithCorrectRoot(t *testing.T) {
 	t.Cleanup(sys.Close)
 
 	l1Endpoint := sys.NodeEndpoint("l1")
+	rollupEndpoint := sys.RollupNodes["sequencer"].HTTPEndpoint()
 	l2Endpoint := sys.NodeEndpoint("sequencer")
 
 	disputeGameFactory := disputegame.NewFactoryHelper(t, ctx, sys.cfg.L1Deployments, l1Client)
-	game, correctTrace := disputeGameFactory.StartCannonGameWithCorrectRoot(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, l2Endpoint,
+	game, correctTrace := disputeGameFactory.StartCannonGameWithCorrectRoot(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, rollupEndpoint, l2Endpoint,
 		challenger.WithPrivKey(sys.cfg.Secrets.Mallory),
 	)
 	require.NotNil(t, game)
 	game.LogGameData(ctx)
 
-	game.StartChallenger(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, l2Endpoint, "Challenger",
-		// Agree with the proposed output, so disagree with the root claim
-		challenger.WithAgreeProposedOutput(true),
+	game.StartChallenger(ctx, sys.RollupConfig, sys.L2GenesisCfg, l1Endpoint, rollupEndpoint, l2Endpoint, "Challenger",
 		challenger.WithPrivKey(sys.cfg.Secrets.Alice),
 	)
 
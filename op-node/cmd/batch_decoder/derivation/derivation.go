@@ -0,0 +1,348 @@
+package derivation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/metrics"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// maxTemporaryErrors is the number of consecutive temporary errors after which derivation is aborted,
+// as the offline data does not change when retrying.
+const maxTemporaryErrors = 100
+
+type Config struct {
+	// InDirectory is the directory with the fetched batcher transactions.
+	InDirectory string
+	// BlocksDirectory is the directory with the fetched L1 block headers.
+	BlocksDirectory string
+	OutFile         string
+	RollupConfig    *rollup.Config
+	// Start is the L2 safe head to derive on top of. The L2 genesis is used if nil.
+	Start *eth.L2BlockRef
+	// BatchSender overrides the batcher address of the genesis system config, if set.
+	BatchSender common.Address
+	// L2 is used to fetch the hashes of the derived blocks, if set.
+	L2 L2Client
+}
+
+// L2Client fetches the headers of the canonical L2 chain.
+type L2Client interface {
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// DerivedBlock is an L2 block that was derived from the batches.
+type DerivedBlock struct {
+	Number uint64 `json:"number"`
+	// Hash is the hash of the block, as fetched from the L2 client. Without an L2 client it is
+	// the hash claimed by the next batch that builds on top of it, or zero if no such batch was found.
+	Hash           common.Hash `json:"hash"`
+	Time           uint64      `json:"time"`
+	L1Origin       eth.BlockID `json:"l1_origin"`
+	SequenceNumber uint64      `json:"sequence_number"`
+	// DerivedFrom is the L1 block the pipeline was at when the block was derived.
+	DerivedFrom eth.L1BlockRef         `json:"derived_from"`
+	Attributes  *eth.PayloadAttributes `json:"attributes"`
+}
+
+// Warning is a warning or error that was logged by the derivation pipeline,
+// e.g. for invalid frames or channels.
+type Warning struct {
+	Level  string            `json:"level"`
+	Origin eth.L1BlockRef    `json:"origin"`
+	Msg    string            `json:"msg"`
+	Ctx    map[string]string `json:"ctx,omitempty"`
+}
+
+type Result struct {
+	Start    eth.L2BlockRef `json:"start"`
+	SafeHead eth.L2BlockRef `json:"safe_head"`
+	// L1Head is the last L1 block that was traversed.
+	L1Head         eth.L1BlockRef       `json:"l1_head"`
+	Blocks         []DerivedBlock       `json:"blocks"`
+	DroppedBatches []derive.BatchStatus `json:"dropped_batches"`
+	// ParentHashesVerified is whether the block hashes were fetched from L2, so that batches
+	// with a mismatching parent hash are known to be invalid.
+	ParentHashesVerified bool `json:"parent_hashes_verified"`
+	// UnverifiedBatches are the batches that were dropped for a mismatching parent hash while the
+	// block hashes were only inferred from the batches, so they may be valid.
+	UnverifiedBatches []derive.BatchStatus `json:"unverified_batches,omitempty"`
+	Warnings          []Warning            `json:"warnings"`
+	// Error is the error that stopped derivation before all L1 blocks were traversed, if any.
+	Error string `json:"error,omitempty"`
+}
+
+// Blocks runs the derivation pipeline over the fetched L1 data, from the L1 traversal up to the
+// payload attributes, and writes the derived blocks and dropped batches to the out file.
+//
+// The hashes of the derived L2 blocks are fetched from the L2 client. Without one, they are taken
+// from the parent references of the batches, with the first batch for a block taken as canonical, and
+// batches dropped for a mismatching parent hash are reported as unverified. L1 receipts
+// are not fetched, so the derived blocks do not include user deposits, and system config updates
+// on L1 are not applied.
+func Blocks(config Config) *Result {
+	cfg := config.RollupConfig
+	l1, err := loadL1Source(config.BlocksDirectory, config.InDirectory, cfg.BatchInboxAddress)
+	if err != nil {
+		log.Fatal(err)
+	}
+	sysCfg := cfg.Genesis.SystemConfig
+	if config.BatchSender != (common.Address{}) {
+		sysCfg.BatcherAddr = config.BatchSender
+	}
+	start := eth.L2BlockRef{
+		Hash:     cfg.Genesis.L2.Hash,
+		Number:   cfg.Genesis.L2.Number,
+		Time:     cfg.Genesis.L2Time,
+		L1Origin: cfg.Genesis.L1,
+	}
+	if config.Start != nil {
+		start = *config.Start
+	}
+
+	result := &Result{Start: start, SafeHead: start, ParentHashesVerified: config.L2 != nil}
+	if err := derivePayloads(context.Background(), cfg, l1, config.L2, sysCfg, result); err != nil {
+		result.Error = err.Error()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(config.OutFile), 0750); err != nil {
+		log.Fatal(err)
+	}
+	file, err := os.Create(config.OutFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	enc := json.NewEncoder(file)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(result); err != nil {
+		log.Fatal(err)
+	}
+	return result
+}
+
+// channelStages are the stages of the pipeline up to the channel reader.
+type channelStages struct {
+	traversal *derive.L1Traversal
+	reader    *derive.ChannelInReader
+	stages    []derive.ResettableStage
+}
+
+func newChannelStages(logger gethlog.Logger, cfg *rollup.Config, l1 *l1Source) *channelStages {
+	traversal := derive.NewL1Traversal(logger, cfg, l1)
+	dataSrc := derive.NewDataSourceFactory(logger, cfg, l1, nil)
+	l1Src := derive.NewL1Retrieval(logger, dataSrc, nil, traversal)
+	frameQueue := derive.NewFrameQueue(logger, l1Src)
+	bank := derive.NewChannelBank(logger, cfg, frameQueue, l1, metrics.NoopMetrics)
+	reader := derive.NewChannelInReader(logger, cfg, bank, metrics.NoopMetrics)
+	return &channelStages{
+		traversal: traversal,
+		reader:    reader,
+		stages:    []derive.ResettableStage{traversal, l1Src, frameQueue, bank, reader},
+	}
+}
+
+// pipelineOrigin returns the L1 block to start traversal from, which is a channel timeout
+// before the L1 origin of the start, so that channels that were opened before are read too.
+func pipelineOrigin(cfg *rollup.Config, l1 *l1Source, start eth.L2BlockRef) (eth.L1BlockRef, error) {
+	if _, ok := l1.byNumber[start.L1Origin.Number]; !ok {
+		return eth.L1BlockRef{}, fmt.Errorf("L1 origin %s of the start %s was not fetched", start.L1Origin, start)
+	}
+	num := l1.first
+	if start.L1Origin.Number > cfg.ChannelTimeout && start.L1Origin.Number-cfg.ChannelTimeout > num {
+		num = start.L1Origin.Number - cfg.ChannelTimeout
+	}
+	return l1.L1BlockRefByNumber(context.Background(), num)
+}
+
+func resetStages(ctx context.Context, stages []derive.ResettableStage, base eth.L1BlockRef, sysCfg eth.SystemConfig) error {
+	for i, stage := range stages {
+		if err := stage.Reset(ctx, base, sysCfg); err != io.EOF {
+			return fmt.Errorf("failed to reset stage %d: %w", i, err)
+		}
+	}
+	return nil
+}
+
+// step calls fn until it returns a result, or io.EOF if there is no more data.
+// Temporary errors are retried, up to maxTemporaryErrors times in a row.
+func step(fn func() error) error {
+	temporary := 0
+	for {
+		err := fn()
+		if errors.Is(err, derive.NotEnoughData) {
+			continue
+		} else if errors.Is(err, derive.ErrTemporary) {
+			if temporary++; temporary >= maxTemporaryErrors {
+				return err
+			}
+			continue
+		}
+		return err
+	}
+}
+
+// scanParentHashes reads all batches from the L1 data, and returns the parent hashes they claim,
+// by L2 timestamp of the parent. Span batches only commit to the first 20 bytes of the parent hash,
+// which is all the batch validity rules check.
+func scanParentHashes(ctx context.Context, cfg *rollup.Config, l1 *l1Source, origin eth.L1BlockRef, sysCfg eth.SystemConfig) (map[uint64]common.Hash, error) {
+	logger := gethlog.New()
+	logger.SetHandler(gethlog.DiscardHandler())
+	s := newChannelStages(logger, cfg, l1)
+	if err := resetStages(ctx, s.stages, origin, sysCfg); err != nil {
+		return nil, err
+	}
+	hashes := make(map[uint64]common.Hash)
+	for {
+		var batch derive.Batch
+		err := step(func() (err error) {
+			batch, err = s.reader.NextBatch(ctx)
+			return err
+		})
+		if err == io.EOF {
+			if err := s.traversal.AdvanceL1Block(ctx); err == io.EOF {
+				return hashes, nil
+			} else if err != nil {
+				return nil, err
+			}
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		var parentTime uint64
+		var hash common.Hash
+		switch b := batch.(type) {
+		case *derive.BatchV1:
+			parentTime, hash = b.Timestamp-cfg.BlockTime, b.ParentHash
+		case *derive.SpanBatch:
+			parentTime = b.GetTimestamp() - cfg.BlockTime
+			copy(hash[:20], b.ParentCheck[:])
+		default:
+			continue
+		}
+		existing, ok := hashes[parentTime]
+		// a full parent hash replaces a span batch parent check with the same prefix
+		if !ok || (bytes.Equal(existing[20:], make([]byte, 12)) && bytes.Equal(existing[:20], hash[:20])) {
+			hashes[parentTime] = hash
+		}
+	}
+}
+
+func derivePayloads(ctx context.Context, cfg *rollup.Config, l1 *l1Source, l2Client L2Client, sysCfg eth.SystemConfig, result *Result) error {
+	origin, err := pipelineOrigin(cfg, l1, result.Start)
+	if err != nil {
+		return err
+	}
+	result.L1Head = origin
+	var hashes map[uint64]common.Hash
+	if l2Client == nil {
+		hashes, err = scanParentHashes(ctx, cfg, l1, origin, sysCfg)
+		if err != nil {
+			return fmt.Errorf("failed to scan batches: %w", err)
+		}
+	}
+
+	s := newChannelStages(recordingLogger(result, func() eth.L1BlockRef { return result.L1Head }), cfg, l1)
+	l2 := &l2Source{sysCfg: sysCfg}
+	// the batch queue logs why batches are dropped, which is captured by its drop handler instead
+	bqLogger := gethlog.New()
+	bqLogger.SetHandler(gethlog.DiscardHandler())
+	batchQueue := derive.NewBatchQueue(bqLogger, cfg, s.reader, l2)
+	batchQueue.OnDroppedBatch(func(status derive.BatchStatus) {
+		if !result.ParentHashesVerified && status.Reason == derive.ReasonParentHashMismatch {
+			result.UnverifiedBatches = append(result.UnverifiedBatches, status)
+			return
+		}
+		result.DroppedBatches = append(result.DroppedBatches, status)
+	})
+	attrBuilder := derive.NewFetchingAttributesBuilder(cfg, l1, l2)
+	attributesQueue := derive.NewAttributesQueue(bqLogger, cfg, attrBuilder, batchQueue)
+	if err := resetStages(ctx, append(s.stages, batchQueue, attributesQueue), origin, sysCfg); err != nil {
+		return err
+	}
+
+	for {
+		var attrs *eth.PayloadAttributes
+		err := step(func() (err error) {
+			attrs, err = attributesQueue.NextAttributes(ctx, result.SafeHead)
+			return err
+		})
+		if err == io.EOF {
+			if err := s.traversal.AdvanceL1Block(ctx); err == io.EOF {
+				return nil
+			} else if err != nil {
+				return err
+			}
+			result.L1Head = s.traversal.Origin()
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		number := result.SafeHead.Number + 1
+		hash := hashes[uint64(attrs.Timestamp)]
+		if l2Client != nil {
+			header, err := l2Client.HeaderByNumber(ctx, new(big.Int).SetUint64(number))
+			if err != nil {
+				return fmt.Errorf("failed to fetch L2 block %d: %w", number, err)
+			}
+			hash = header.Hash()
+		}
+		safeHead, err := derive.PayloadToBlockRef(&eth.ExecutionPayload{
+			ParentHash:   result.SafeHead.Hash,
+			BlockNumber:  hexutil.Uint64(number),
+			BlockHash:    hash,
+			Timestamp:    attrs.Timestamp,
+			Transactions: attrs.Transactions,
+		}, &cfg.Genesis)
+		if err != nil {
+			return fmt.Errorf("invalid payload attributes: %w", err)
+		}
+		result.Blocks = append(result.Blocks, DerivedBlock{
+			Number:         safeHead.Number,
+			Hash:           safeHead.Hash,
+			Time:           safeHead.Time,
+			L1Origin:       safeHead.L1Origin,
+			SequenceNumber: safeHead.SequenceNumber,
+			DerivedFrom:    s.traversal.Origin(),
+			Attributes:     attrs,
+		})
+		result.SafeHead = safeHead
+	}
+}
+
+// recordingLogger returns a logger that records all warnings and errors in the result.
+func recordingLogger(result *Result, origin func() eth.L1BlockRef) gethlog.Logger {
+	logger := gethlog.New()
+	logger.SetHandler(gethlog.FuncHandler(func(r *gethlog.Record) error {
+		if r.Lvl > gethlog.LvlWarn {
+			return nil
+		}
+		w := Warning{Level: r.Lvl.String(), Origin: origin(), Msg: r.Msg}
+		if len(r.Ctx) > 0 {
+			w.Ctx = make(map[string]string, len(r.Ctx)/2)
+			for i := 0; i+1 < len(r.Ctx); i += 2 {
+				w.Ctx[fmt.Sprint(r.Ctx[i])] = fmt.Sprint(r.Ctx[i+1])
+			}
+		}
+		result.Warnings = append(result.Warnings, w)
+		return nil
+	}))
+	return logger
+}
@@ -0,0 +1,147 @@
+package derivation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/reassemble"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+var errNotOffline = errors.New("not available offline")
+
+// l1Source serves the fetched L1 headers and batcher transactions to the derivation pipeline.
+// Receipts are not fetched, so the L1 blocks appear to have no deposits or system config updates.
+type l1Source struct {
+	byNumber map[uint64]*types.Header
+	byHash   map[common.Hash]*types.Header
+	txs      map[common.Hash]types.Transactions
+	first    uint64
+}
+
+var _ derive.L1Fetcher = (*l1Source)(nil)
+
+// loadL1Source loads the L1 headers from the blocks directory, and the transactions sent
+// to the inbox from the transactions directory.
+func loadL1Source(blocksDir, txDir string, inbox common.Address) (*l1Source, error) {
+	files, err := os.ReadDir(blocksDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read L1 blocks directory: %w", err)
+	}
+	src := &l1Source{
+		byNumber: make(map[uint64]*types.Header),
+		byHash:   make(map[common.Hash]*types.Header),
+		txs:      make(map[common.Hash]types.Transactions),
+	}
+	for _, file := range files {
+		header, err := loadHeader(path.Join(blocksDir, file.Name()))
+		if err != nil {
+			return nil, err
+		}
+		num := header.Number.Uint64()
+		if len(src.byNumber) == 0 || num < src.first {
+			src.first = num
+		}
+		src.byNumber[num] = header
+		src.byHash[header.Hash()] = header
+	}
+	if len(src.byNumber) == 0 {
+		return nil, fmt.Errorf("no L1 blocks found in %v", blocksDir)
+	}
+
+	txns := reassemble.LoadTransactions(txDir, inbox)
+	sort.Slice(txns, func(i, j int) bool {
+		if txns[i].BlockNumber == txns[j].BlockNumber {
+			return txns[i].TxIndex < txns[j].TxIndex
+		}
+		return txns[i].BlockNumber < txns[j].BlockNumber
+	})
+	for _, tx := range txns {
+		src.txs[tx.BlockHash] = append(src.txs[tx.BlockHash], tx.Tx)
+	}
+	return src, nil
+}
+
+func loadHeader(file string) (*types.Header, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var header types.Header
+	if err := json.NewDecoder(f).Decode(&header); err != nil {
+		return nil, fmt.Errorf("failed to decode L1 header %v: %w", file, err)
+	}
+	return &header, nil
+}
+
+func (s *l1Source) info(hash common.Hash) (eth.BlockInfo, error) {
+	header, ok := s.byHash[hash]
+	if !ok {
+		return nil, fmt.Errorf("L1 block %s was not fetched: %w", hash, ethereum.NotFound)
+	}
+	return eth.HeaderBlockInfo(header), nil
+}
+
+func (s *l1Source) L1BlockRefByLabel(ctx context.Context, label eth.BlockLabel) (eth.L1BlockRef, error) {
+	return eth.L1BlockRef{}, fmt.Errorf("L1 block by label %s: %w", label, errNotOffline)
+}
+
+func (s *l1Source) L1BlockRefByNumber(ctx context.Context, num uint64) (eth.L1BlockRef, error) {
+	header, ok := s.byNumber[num]
+	if !ok {
+		return eth.L1BlockRef{}, ethereum.NotFound
+	}
+	return eth.InfoToL1BlockRef(eth.HeaderBlockInfo(header)), nil
+}
+
+func (s *l1Source) L1BlockRefByHash(ctx context.Context, hash common.Hash) (eth.L1BlockRef, error) {
+	info, err := s.info(hash)
+	if err != nil {
+		return eth.L1BlockRef{}, err
+	}
+	return eth.InfoToL1BlockRef(info), nil
+}
+
+func (s *l1Source) InfoByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, error) {
+	return s.info(hash)
+}
+
+func (s *l1Source) FetchReceipts(ctx context.Context, blockHash common.Hash) (eth.BlockInfo, types.Receipts, error) {
+	info, err := s.info(blockHash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, types.Receipts{}, nil
+}
+
+func (s *l1Source) InfoAndTxsByHash(ctx context.Context, hash common.Hash) (eth.BlockInfo, types.Transactions, error) {
+	info, err := s.info(hash)
+	if err != nil {
+		return nil, nil, err
+	}
+	return info, s.txs[hash], nil
+}
+
+// l2Source serves a fixed system config, as the L2 chain is not available offline.
+type l2Source struct {
+	sysCfg eth.SystemConfig
+}
+
+func (s *l2Source) SystemConfigByL2Hash(ctx context.Context, hash common.Hash) (eth.SystemConfig, error) {
+	return s.sysCfg, nil
+}
+
+func (s *l2Source) PayloadByNumber(ctx context.Context, num uint64) (*eth.ExecutionPayload, error) {
+	return nil, fmt.Errorf("L2 block %d: %w", num, errNotOffline)
+}
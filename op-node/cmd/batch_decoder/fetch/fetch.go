@@ -40,6 +40,10 @@ type Config struct {
 	BatchSenders       map[common.Address]struct{}
 	OutDirectory       string
 	ConcurrentRequests uint64
+
+	// BlocksOutDirectory is the directory the headers of all fetched L1 blocks are written to.
+	// The headers are not stored if it is empty.
+	BlocksOutDirectory string
 }
 
 // Batches fetches & stores all transactions sent to the batch inbox address in
@@ -49,6 +53,11 @@ func Batches(client *ethclient.Client, config Config) (totalValid, totalInvalid
 	if err := os.MkdirAll(config.OutDirectory, 0750); err != nil {
 		log.Fatal(err)
 	}
+	if config.BlocksOutDirectory != "" {
+		if err := os.MkdirAll(config.BlocksOutDirectory, 0750); err != nil {
+			log.Fatal(err)
+		}
+	}
 	signer := types.LatestSignerForChainID(config.ChainID)
 	concurrentRequests := int(config.ConcurrentRequests)
 
@@ -87,6 +96,11 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, number
 		return 0, 0, err
 	}
 	fmt.Println("Fetched block: ", number)
+	if config.BlocksOutDirectory != "" {
+		if err := writeHeader(block.Header(), config.BlocksOutDirectory); err != nil {
+			return 0, 0, err
+		}
+	}
 	for i, tx := range block.Transactions() {
 		if tx.To() != nil && *tx.To() == config.BatchInbox {
 			sender, err := signer.Sender(tx)
@@ -143,3 +157,14 @@ func fetchBatchesPerBlock(ctx context.Context, client *ethclient.Client, number
 	}
 	return validBatchCount, invalidBatchCount, nil
 }
+
+// writeHeader stores the header of a fetched L1 block, so derivation can traverse the L1 chain offline.
+func writeHeader(header *types.Header, directory string) error {
+	filename := path.Join(directory, fmt.Sprintf("%d.json", header.Number.Uint64()))
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	return json.NewEncoder(file).Encode(header)
+}
@@ -2,14 +2,18 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"log"
 	"os"
 	"time"
 
+	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/derivation"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/fetch"
 	"github.com/ethereum-optimism/optimism/op-node/cmd/batch_decoder/reassemble"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/urfave/cli/v2"
@@ -49,6 +53,11 @@ func main() {
 					Value: "/tmp/batch_decoder/transactions_cache",
 					Usage: "Cache directory for the found transactions",
 				},
+				&cli.StringFlag{
+					Name:  "blocks-out",
+					Value: "/tmp/batch_decoder/l1_blocks_cache",
+					Usage: "Cache directory for the headers of all fetched L1 blocks, used by derive. Disabled if empty.",
+				},
 				&cli.StringFlag{
 					Name:     "l1",
 					Required: true,
@@ -81,6 +90,7 @@ func main() {
 					},
 					BatchInbox:         common.HexToAddress(cliCtx.String("inbox")),
 					OutDirectory:       cliCtx.String("out"),
+					BlocksOutDirectory: cliCtx.String("blocks-out"),
 					ConcurrentRequests: uint64(cliCtx.Int("concurrent-requests")),
 				}
 				totalValid, totalInvalid := fetch.Batches(client, config)
@@ -120,6 +130,86 @@ func main() {
 				return nil
 			},
 		},
+		{
+			Name:  "derive",
+			Usage: "Derives L2 payload attributes from fetched batches, and reports dropped batches",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:     "rollup-config",
+					Required: true,
+					Usage:    "Path of the rollup config JSON file",
+				},
+				&cli.StringFlag{
+					Name:  "in",
+					Value: "/tmp/batch_decoder/transactions_cache",
+					Usage: "Cache directory for the found transactions",
+				},
+				&cli.StringFlag{
+					Name:  "blocks",
+					Value: "/tmp/batch_decoder/l1_blocks_cache",
+					Usage: "Cache directory for the fetched L1 block headers",
+				},
+				&cli.StringFlag{
+					Name:  "sender",
+					Usage: "(Optional) Batch Sender Address, if it changed since genesis",
+				},
+				&cli.StringFlag{
+					Name:  "l2-start",
+					Usage: "(Optional) L2 safe head to derive on top of, as JSON L2 block reference like the safe_l2 of optimism_syncStatus. Defaults to the L2 genesis.",
+				},
+				&cli.StringFlag{
+					Name:  "l2",
+					Usage: "(Optional) L2 RPC URL, to fetch the hashes of the derived blocks. Without it, the hashes are inferred from the batches and parent hashes are not verified.",
+				},
+				&cli.StringFlag{
+					Name:  "out",
+					Value: "/tmp/batch_decoder/derived.json",
+					Usage: "Output file for the derived blocks and dropped batches",
+				},
+			},
+			Action: func(cliCtx *cli.Context) error {
+				file, err := os.Open(cliCtx.String("rollup-config"))
+				if err != nil {
+					log.Fatal(err)
+				}
+				defer file.Close()
+				var rollupCfg rollup.Config
+				if err := json.NewDecoder(file).Decode(&rollupCfg); err != nil {
+					log.Fatalf("Failed to decode rollup config: %v", err)
+				}
+				config := derivation.Config{
+					InDirectory:     cliCtx.String("in"),
+					BlocksDirectory: cliCtx.String("blocks"),
+					OutFile:         cliCtx.String("out"),
+					RollupConfig:    &rollupCfg,
+					BatchSender:     common.HexToAddress(cliCtx.String("sender")),
+				}
+				if start := cliCtx.String("l2-start"); start != "" {
+					var ref eth.L2BlockRef
+					if err := json.Unmarshal([]byte(start), &ref); err != nil {
+						log.Fatalf("Invalid L2 start block: %v", err)
+					}
+					config.Start = &ref
+				}
+				if l2URL := cliCtx.String("l2"); l2URL != "" {
+					client, err := ethclient.Dial(l2URL)
+					if err != nil {
+						log.Fatal(err)
+					}
+					config.L2 = client
+				}
+				result := derivation.Blocks(config)
+				fmt.Printf("Derived %v L2 blocks on top of %v, up to L1 block %v. Dropped %v batches.\n", len(result.Blocks), result.Start, result.L1Head, len(result.DroppedBatches))
+				if !result.ParentHashesVerified {
+					fmt.Printf("Parent hashes were not verified without an L2 RPC. %v batches with a mismatching parent hash are unverified.\n", len(result.UnverifiedBatches))
+				}
+				if result.Error != "" {
+					fmt.Printf("Derivation stopped early: %v\n", result.Error)
+				}
+				fmt.Printf("Wrote derived blocks to %v\n", config.OutFile)
+				return nil
+			},
+		},
 		{
 			Name:  "force-close",
 			Usage: "Create the tx data which will force close a channel",
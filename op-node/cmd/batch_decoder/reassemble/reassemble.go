@@ -16,12 +16,13 @@ import (
 )
 
 type ChannelWithMetadata struct {
-	ID             derive.ChannelID    `json:"id"`
-	IsReady        bool                `json:"is_ready"`
-	InvalidFrames  bool                `json:"invalid_frames"`
-	InvalidBatches bool                `json:"invalid_batches"`
-	Frames         []FrameWithMetadata `json:"frames"`
-	Batches        []derive.BatchV1    `json:"batches"`
+	ID             derive.ChannelID      `json:"id"`
+	IsReady        bool                  `json:"is_ready"`
+	InvalidFrames  bool                  `json:"invalid_frames"`
+	InvalidBatches bool                  `json:"invalid_batches"`
+	Frames         []FrameWithMetadata   `json:"frames"`
+	Batches        []derive.BatchV1      `json:"batches"`
+	SpanBatches    []derive.RawSpanBatch `json:"span_batches,omitempty"`
 }
 
 type FrameWithMetadata struct {
@@ -39,7 +40,7 @@ type Config struct {
 }
 
 func LoadFrames(directory string, inbox common.Address) []FrameWithMetadata {
-	txns := loadTransactions(directory, inbox)
+	txns := LoadTransactions(directory, inbox)
 	// Sort first by block number then by transaction index inside the block number range.
 	// This is to match the order they are processed in derivation.
 	sort.Slice(txns, func(i, j int) bool {
@@ -101,16 +102,20 @@ func processFrames(id derive.ChannelID, frames []FrameWithMetadata) ChannelWithM
 	}
 
 	var batches []derive.BatchV1
+	var spanBatches []derive.RawSpanBatch
 	invalidBatches := false
 	if ch.IsReady() {
-		br, err := derive.BatchReader(ch.Reader(), eth.L1BlockRef{})
+		// the rollup config is not known here, so accept all compression algorithms
+		br, err := derive.BatchReader(ch.Reader(), true)
 		if err == nil {
 			for batch, err := br(); err != io.EOF; batch, err = br() {
 				if err != nil {
 					fmt.Printf("Error reading batch for channel %v. Err: %v\n", id.String(), err)
 					invalidBatches = true
+				} else if batch.BatchType == derive.SpanBatchType {
+					spanBatches = append(spanBatches, batch.RawSpanBatch)
 				} else {
-					batches = append(batches, batch.Batch.BatchV1)
+					batches = append(batches, batch.BatchV1)
 				}
 			}
 		} else {
@@ -127,6 +132,7 @@ func processFrames(id derive.ChannelID, frames []FrameWithMetadata) ChannelWithM
 		InvalidFrames:  invalidFrame,
 		InvalidBatches: invalidBatches,
 		Batches:        batches,
+		SpanBatches:    spanBatches,
 	}
 }
 
@@ -147,8 +153,9 @@ func transactionsToFrames(txns []fetch.TransactionWithMetadata) []FrameWithMetad
 	return out
 }
 
-// if inbox is the zero address, it will load all frames
-func loadTransactions(dir string, inbox common.Address) []fetch.TransactionWithMetadata {
+// LoadTransactions loads the fetched transactions of valid senders from the given directory.
+// If inbox is the zero address, it will load all transactions.
+func LoadTransactions(dir string, inbox common.Address) []fetch.TransactionWithMetadata {
 	files, err := os.ReadDir(dir)
 	if err != nil {
 		log.Fatal(err)
@@ -5,7 +5,9 @@ import (
 	"strings"
 	"time"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 	openum "github.com/ethereum-optimism/optimism/op-service/enum"
 	oplog "github.com/ethereum-optimism/optimism/op-service/log"
@@ -67,6 +69,11 @@ var (
 		Usage:   "File path used to persist state changes made via the admin API so they persist across restarts. Disabled if not set.",
 		EnvVars: prefixEnvVars("RPC_ADMIN_STATE"),
 	}
+	BeaconAddr = &cli.StringFlag{
+		Name:    "l1.beacon",
+		Usage:   "Address of L1 Beacon-node HTTP endpoint to use. Required to read batches from blobs.",
+		EnvVars: prefixEnvVars("L1_BEACON"),
+	}
 	L1TrustRPC = &cli.BoolFlag{
 		Name:    "l1.trustrpc",
 		Usage:   "Trust the L1 RPC, sync faster at risk of malicious/buggy RPC providing bad or inconsistent L1 data",
@@ -256,13 +263,29 @@ var (
 		EnvVars: prefixEnvVars("BETA_ROLLUP_LOAD_PROTOCOL_VERSIONS"),
 		Hidden:  true,
 	}
-	CanyonOverrideFlag = &cli.Uint64Flag{
-		Name:   "override.canyon",
-		Usage:  "Manually specify the Canyon fork timestamp, overriding the bundled setting",
-		Hidden: true,
-	}
 )
 
+// OverrideFlags are the flags to manually override the activation time of the network upgrades,
+// one for each fork of the rollup.Forks schedule.
+var OverrideFlags = overrideFlags()
+
+// OverrideFlagName returns the name of the flag that overrides the activation time of the given fork.
+func OverrideFlagName(fork rollup.ForkName) string {
+	return "override." + string(fork)
+}
+
+func overrideFlags() []cli.Flag {
+	out := make([]cli.Flag, 0, len(rollup.Forks))
+	for _, fork := range rollup.Forks {
+		out = append(out, &cli.Uint64Flag{
+			Name:   OverrideFlagName(fork.Name),
+			Usage:  fmt.Sprintf("Manually specify the %s fork timestamp, overriding the bundled setting", fork.Name.Title()),
+			Hidden: true,
+		})
+	}
+	return out
+}
+
 var requiredFlags = []cli.Flag{
 	L1NodeAddr,
 	L2EngineAddr,
@@ -273,6 +296,7 @@ var optionalFlags = []cli.Flag{
 	RPCListenPort,
 	RollupConfig,
 	Network,
+	BeaconAddr,
 	L1TrustRPC,
 	L1RPCProviderKind,
 	L1RPCRateLimit,
@@ -305,15 +329,16 @@ var optionalFlags = []cli.Flag{
 	BetaExtraNetworks,
 	BetaRollupHalt,
 	BetaRollupLoadProtocolVersions,
-	CanyonOverrideFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
 var Flags []cli.Flag
 
 func init() {
+	optionalFlags = append(optionalFlags, OverrideFlags...)
 	optionalFlags = append(optionalFlags, P2pFlags...)
 	optionalFlags = append(optionalFlags, oplog.CLIFlags(EnvVarPrefix)...)
+	optionalFlags = append(optionalFlags, altda.CLIFlags(EnvVarPrefix)...)
 	Flags = append(requiredFlags, optionalFlags...)
 }
 
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/ethereum-optimism/optimism/op-node/client"
@@ -36,6 +37,12 @@ type L1EndpointSetup interface {
 	Check() error
 }
 
+type L1BeaconEndpointSetup interface {
+	// Setup a client to a L1 beacon node to fetch blobs from.
+	Setup(ctx context.Context, log log.Logger) (cl *sources.L1BeaconClient, err error)
+	Check() error
+}
+
 type L2EndpointConfig struct {
 	L2EngineAddr string // Address of L2 Engine JSON-RPC endpoint to use (engine and eth namespace required)
 
@@ -208,3 +215,23 @@ func (cfg *PreparedL1Endpoint) Check() error {
 
 	return nil
 }
+
+type L1BeaconEndpointConfig struct {
+	BeaconAddr string // Address of L1 beacon-node HTTP endpoint to use
+}
+
+var _ L1BeaconEndpointSetup = (*L1BeaconEndpointConfig)(nil)
+
+func (cfg *L1BeaconEndpointConfig) Setup(ctx context.Context, log log.Logger) (*sources.L1BeaconClient, error) {
+	if err := cfg.Check(); err != nil {
+		return nil, err
+	}
+	return sources.NewL1BeaconClient(&http.Client{Timeout: 30 * time.Second}, cfg.BeaconAddr), nil
+}
+
+func (cfg *L1BeaconEndpointConfig) Check() error {
+	if cfg.BeaconAddr == "" {
+		return errors.New("expected beacon address, but got none")
+	}
+	return nil
+}
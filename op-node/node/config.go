@@ -6,6 +6,7 @@ import (
 	"math"
 	"time"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/flags"
 	"github.com/ethereum-optimism/optimism/op-node/p2p"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
@@ -20,6 +21,14 @@ type Config struct {
 	L2     L2EndpointSetup
 	L2Sync L2SyncEndpointSetup
 
+	// Beacon is the L1 beacon-node endpoint to fetch blobs from. Optional,
+	// but required if the rollup config enables batches in blobs.
+	Beacon L1BeaconEndpointSetup
+
+	// AltDA configures the DA server to resolve alt-DA commitments with.
+	// Required if the rollup config enables the alt-DA mode.
+	AltDA altda.CLIConfig
+
 	Driver driver.Config
 
 	Rollup rollup.Config
@@ -118,6 +127,19 @@ func (cfg *Config) Check() error {
 	if err := cfg.L2Sync.Check(); err != nil {
 		return fmt.Errorf("sync config error: %w", err)
 	}
+	if cfg.Beacon != nil {
+		if err := cfg.Beacon.Check(); err != nil {
+			return fmt.Errorf("beacon endpoint config error: %w", err)
+		}
+	} else if cfg.Rollup.BlobsEnabledL1Timestamp != nil {
+		return errors.New("the rollup config reads batches from blobs, but no L1 beacon endpoint is configured")
+	}
+	if err := cfg.AltDA.Check(); err != nil {
+		return fmt.Errorf("alt-DA config error: %w", err)
+	}
+	if cfg.Rollup.UseAltDA && !cfg.AltDA.Enabled() {
+		return errors.New("the rollup config uses alt-DA, but no alt-DA server is configured")
+	}
 	if err := cfg.Rollup.Check(); err != nil {
 		return fmt.Errorf("rollup config error: %w", err)
 	}
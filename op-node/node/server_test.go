@@ -18,6 +18,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/metrics"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
 	"github.com/ethereum-optimism/optimism/op-node/version"
@@ -192,6 +193,52 @@ func TestSyncStatus(t *testing.T) {
 	assert.Equal(t, status, out)
 }
 
+func TestDerivationStatus(t *testing.T) {
+	log := testlog.Logger(t, log.LvlError)
+	l2Client := &testutils.MockL2Client{}
+	drClient := &mockDriverClient{}
+	rng := rand.New(rand.NewSource(1234))
+	status := &derive.DerivationStatus{
+		Stages: []derive.StageStatus{{Name: "L1Traversal", Origin: testutils.RandomBlockRef(rng)}},
+		Channels: []derive.ChannelStatus{{
+			ID:        derive.ChannelID{0x01},
+			OpenBlock: testutils.RandomBlockRef(rng),
+			Timeout:   1234,
+			Frames:    2,
+		}},
+		Batches: []derive.BatchStatus{{
+			Timestamp:        100,
+			L1InclusionBlock: testutils.RandomBlockRef(rng),
+			Validity:         "future",
+			Reason:           "batch is for future epoch",
+		}},
+		PendingSafeBlock: &derive.PendingSafeBlockStatus{Parent: testutils.RandomL2BlockRef(rng), Timestamp: 100},
+		SafeL2:           testutils.RandomL2BlockRef(rng),
+		UnsafeL2:         testutils.RandomL2BlockRef(rng),
+	}
+	drClient.On("DerivationStatus").Return(status)
+
+	rpcCfg := &RPCConfig{
+		ListenAddr: "localhost",
+		ListenPort: 0,
+	}
+	rollupCfg := &rollup.Config{
+		// ignore other rollup config info in this test
+	}
+	server, err := newRPCServer(context.Background(), rpcCfg, rollupCfg, l2Client, drClient, log, "0.0", metrics.NoopMetrics)
+	assert.NoError(t, err)
+	assert.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpcclient.NewRPC(context.Background(), log, "http://"+server.Addr().String(), rpcclient.WithDialBackoff(3))
+	assert.NoError(t, err)
+
+	var out *derive.DerivationStatus
+	err = client.CallContext(context.Background(), &out, "optimism_derivationStatus")
+	assert.NoError(t, err)
+	assert.Equal(t, status, out)
+}
+
 type mockDriverClient struct {
 	mock.Mock
 }
@@ -209,6 +256,10 @@ func (c *mockDriverClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, err
 	return c.Mock.MethodCalled("SyncStatus").Get(0).(*eth.SyncStatus), nil
 }
 
+func (c *mockDriverClient) DerivationStatus(ctx context.Context) (*derive.DerivationStatus, error) {
+	return c.Mock.MethodCalled("DerivationStatus").Get(0).(*derive.DerivationStatus), nil
+}
+
 func (c *mockDriverClient) ResetDerivationPipeline(ctx context.Context) error {
 	return c.Mock.MethodCalled("ResetDerivationPipeline").Get(0).(error)
 }
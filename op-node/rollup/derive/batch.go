@@ -11,6 +11,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
 )
 
@@ -20,6 +21,10 @@ import (
 // BatchV1Type := 0
 // batchV1 := BatchV1Type ++ RLP([epoch, timestamp, transaction_list]
 //
+// SpanBatchType := 1
+// spanBatch := SpanBatchType ++ prefix ++ payload
+// (see span_batch.go for the encoding of the prefix and payload)
+//
 // An empty input is not a valid batch.
 //
 // Note: the type system is based on L1 typed transactions.
@@ -31,8 +36,18 @@ var encodeBufferPool = sync.Pool{
 
 const (
 	BatchV1Type = iota
+	SpanBatchType
 )
 
+// Batch contains information to build one or more L2 blocks.
+// The batcher converts L2 blocks into a Batch and writes the encoded bytes to the channel.
+// The derivation pipeline decodes a Batch from the channel, and converts it into payload attributes.
+type Batch interface {
+	GetBatchType() int
+	GetTimestamp() uint64
+	LogContext(log.Logger) log.Logger
+}
+
 type BatchV1 struct {
 	ParentHash common.Hash  // parent L2 block hash
 	EpochNum   rollup.Epoch // aka l1 num
@@ -42,15 +57,40 @@ type BatchV1 struct {
 	Transactions []hexutil.Bytes
 }
 
+// BatchData is the wire-representation of a batch, as it is RLP-encoded into a channel.
 type BatchData struct {
+	// BatchType selects which of the embedded batches is encoded.
+	// The zero value is BatchV1Type, for backwards-compatibility.
+	BatchType int
 	BatchV1
-	// batches may contain additional data with new upgrades
+	RawSpanBatch
 }
 
 func (b *BatchV1) Epoch() eth.BlockID {
 	return eth.BlockID{Hash: b.EpochHash, Number: uint64(b.EpochNum)}
 }
 
+// GetBatchType returns BatchV1Type, the type of a singular batch.
+func (b *BatchV1) GetBatchType() int {
+	return BatchV1Type
+}
+
+// GetTimestamp returns the timestamp of the L2 block that the batch builds.
+func (b *BatchV1) GetTimestamp() uint64 {
+	return b.Timestamp
+}
+
+// LogContext creates a new log context that contains information of the batch.
+func (b *BatchV1) LogContext(log log.Logger) log.Logger {
+	return log.New(
+		"batch_type", "BatchV1",
+		"batch_timestamp", b.Timestamp,
+		"parent_hash", b.ParentHash,
+		"batch_epoch", b.Epoch(),
+		"txs", len(b.Transactions),
+	)
+}
+
 // EncodeRLP implements rlp.Encoder
 func (b *BatchData) EncodeRLP(w io.Writer) error {
 	buf := encodeBufferPool.Get().(*bytes.Buffer)
@@ -70,8 +110,16 @@ func (b *BatchData) MarshalBinary() ([]byte, error) {
 }
 
 func (b *BatchData) encodeTyped(buf *bytes.Buffer) error {
-	buf.WriteByte(BatchV1Type)
-	return rlp.Encode(buf, &b.BatchV1)
+	switch b.BatchType {
+	case BatchV1Type:
+		buf.WriteByte(BatchV1Type)
+		return rlp.Encode(buf, &b.BatchV1)
+	case SpanBatchType:
+		buf.WriteByte(SpanBatchType)
+		return b.RawSpanBatch.encode(buf)
+	default:
+		return fmt.Errorf("unrecognized batch type: %d", b.BatchType)
+	}
 }
 
 // DecodeRLP implements rlp.Decoder
@@ -100,7 +148,11 @@ func (b *BatchData) decodeTyped(data []byte) error {
 	}
 	switch data[0] {
 	case BatchV1Type:
+		b.BatchType = BatchV1Type
 		return rlp.DecodeBytes(data[1:], &b.BatchV1)
+	case SpanBatchType:
+		b.BatchType = SpanBatchType
+		return b.RawSpanBatch.decode(bytes.NewReader(data[1:]))
 	default:
 		return fmt.Errorf("unrecognized batch type: %d", data[0])
 	}
@@ -29,7 +29,13 @@ import (
 
 type NextBatchProvider interface {
 	Origin() eth.L1BlockRef
-	NextBatch(ctx context.Context) (*BatchData, error)
+	NextBatch(ctx context.Context) (Batch, error)
+}
+
+// SafeBlockFetcher fetches L2 blocks of the safe chain,
+// to check span batches that overlap with already derived L2 blocks.
+type SafeBlockFetcher interface {
+	PayloadByNumber(context.Context, uint64) (*eth.ExecutionPayload, error)
 }
 
 // BatchQueue contains a set of batches for every L1 block.
@@ -42,16 +48,30 @@ type BatchQueue struct {
 
 	l1Blocks []eth.L1BlockRef
 
-	// batches in order of when we've first seen them, grouped by L2 timestamp
-	batches map[uint64][]*BatchWithL1InclusionBlock
+	// batches in order of when we've first seen them
+	batches []*BatchWithL1InclusionBlock
+
+	// nextSpan is cached singular batches derived from the last accepted span batch
+	nextSpan []*BatchV1
+
+	// checks holds the outcome of the last validity check of each buffered batch
+	checks map[*BatchWithL1InclusionBlock]batchCheck
+	// dropped are the most recently dropped batches, at most maxDroppedBatches, oldest first
+	dropped []BatchStatus
+	// onDropped is called with every dropped batch, if set
+	onDropped func(BatchStatus)
+
+	l2 SafeBlockFetcher
 }
 
 // NewBatchQueue creates a BatchQueue, which should be Reset(origin) before use.
-func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider) *BatchQueue {
+func NewBatchQueue(log log.Logger, cfg *rollup.Config, prev NextBatchProvider, l2 SafeBlockFetcher) *BatchQueue {
 	return &BatchQueue{
 		log:    log,
 		config: cfg,
 		prev:   prev,
+		l2:     l2,
+		checks: make(map[*BatchWithL1InclusionBlock]batchCheck),
 	}
 }
 
@@ -59,7 +79,43 @@ func (bq *BatchQueue) Origin() eth.L1BlockRef {
 	return bq.prev.Origin()
 }
 
-func (bq *BatchQueue) NextBatch(ctx context.Context, safeL2Head eth.L2BlockRef) (*BatchData, error) {
+// popNextBatch pops the next cached singular batch of the last accepted span batch.
+func (bq *BatchQueue) popNextBatch(safeL2Head eth.L2BlockRef) *BatchV1 {
+	nextBatch := bq.nextSpan[0]
+	bq.nextSpan = bq.nextSpan[1:]
+	// The parent hash must be set before returning the batch.
+	// The safe head can be used, since the parent check of the span was verified in CheckBatch.
+	nextBatch.ParentHash = safeL2Head.Hash
+	return nextBatch
+}
+
+// maybeAdvanceEpoch drops the current epoch from the tracked L1 blocks if the given batch starts the next epoch.
+func (bq *BatchQueue) maybeAdvanceEpoch(nextBatch *BatchV1) {
+	if len(bq.l1Blocks) == 0 {
+		return
+	}
+	if nextBatch.EpochNum == rollup.Epoch(bq.l1Blocks[0].Number)+1 {
+		bq.l1Blocks = bq.l1Blocks[1:]
+	}
+}
+
+func (bq *BatchQueue) NextBatch(ctx context.Context, safeL2Head eth.L2BlockRef) (*BatchV1, error) {
+	// Check if the next batch in the span batch is ready
+	if len(bq.nextSpan) > 0 {
+		// There are cached singular batches derived from the span batch.
+		// Check if the next cached batch matches the given parent block.
+		if bq.nextSpan[0].Timestamp == safeL2Head.Time+bq.config.BlockTime {
+			nextBatch := bq.popNextBatch(safeL2Head)
+			bq.maybeAdvanceEpoch(nextBatch)
+			return nextBatch, nil
+		} else {
+			// Given parent block does not match the next batch. It means the previously returned batch is invalid.
+			// Drop cached batches and find another batch.
+			bq.log.Warn("parent block does not match the next batch, dropped cached batches", "parent", safeL2Head.ID(), "next_batch_time", bq.nextSpan[0].Timestamp)
+			bq.nextSpan = bq.nextSpan[:0]
+		}
+	}
+
 	// Note: We use the origin that we will have to determine if it's behind. This is important
 	// because it's the future origin that gets saved into the l1Blocks array.
 	// We always update the origin of this stage if it is not the same so after the update code
@@ -89,7 +145,7 @@ func (bq *BatchQueue) NextBatch(ctx context.Context, safeL2Head eth.L2BlockRef)
 	} else if err != nil {
 		return nil, err
 	} else if !originBehind {
-		bq.AddBatch(batch, safeL2Head)
+		bq.AddBatch(ctx, batch, safeL2Head)
 	}
 
 	// Skip adding data unless we are up to date with the origin, but do fully
@@ -111,43 +167,71 @@ func (bq *BatchQueue) NextBatch(ctx context.Context, safeL2Head eth.L2BlockRef)
 	} else if err != nil {
 		return nil, err
 	}
-	return batch, nil
+
+	var nextBatch *BatchV1
+	switch typ := batch.GetBatchType(); typ {
+	case BatchV1Type:
+		singularBatch, ok := batch.(*BatchV1)
+		if !ok {
+			return nil, NewCriticalError(errors.New("failed type assertion to BatchV1"))
+		}
+		nextBatch = singularBatch
+	case SpanBatchType:
+		spanBatch, ok := batch.(*SpanBatch)
+		if !ok {
+			return nil, NewCriticalError(errors.New("failed type assertion to SpanBatch"))
+		}
+		// If the next batch is a span batch, convert it into singular batches.
+		singularBatches, err := spanBatch.GetSingularBatches(bq.l1Blocks, safeL2Head)
+		if err != nil {
+			return nil, NewCriticalError(err)
+		}
+		bq.nextSpan = singularBatches
+		// span batches are checked to contain at least one new block, so the below pop is safe.
+		nextBatch = bq.popNextBatch(safeL2Head)
+	default:
+		return nil, NewCriticalError(fmt.Errorf("unrecognized batch type: %d", typ))
+	}
+	bq.maybeAdvanceEpoch(nextBatch)
+	return nextBatch, nil
 }
 
 func (bq *BatchQueue) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.SystemConfig) error {
 	// Copy over the Origin from the next stage
 	// It is set in the engine queue (two stages away) such that the L2 Safe Head origin is the progress
 	bq.origin = base
-	bq.batches = make(map[uint64][]*BatchWithL1InclusionBlock)
+	bq.batches = []*BatchWithL1InclusionBlock{}
+	bq.checks = make(map[*BatchWithL1InclusionBlock]batchCheck)
 	// Include the new origin as an origin to build on
 	// Note: This is only for the initialization case. During normal resets we will later
 	// throw out this block.
+	bq.nextSpan = bq.nextSpan[:0]
 	bq.l1Blocks = bq.l1Blocks[:0]
 	bq.l1Blocks = append(bq.l1Blocks, base)
 	return io.EOF
 }
 
-func (bq *BatchQueue) AddBatch(batch *BatchData, l2SafeHead eth.L2BlockRef) {
+func (bq *BatchQueue) AddBatch(ctx context.Context, batch Batch, l2SafeHead eth.L2BlockRef) {
 	if len(bq.l1Blocks) == 0 {
-		panic(fmt.Errorf("cannot add batch with timestamp %d, no origin was prepared", batch.Timestamp))
+		panic(fmt.Errorf("cannot add batch with timestamp %d, no origin was prepared", batch.GetTimestamp()))
 	}
 	data := BatchWithL1InclusionBlock{
 		L1InclusionBlock: bq.origin,
 		Batch:            batch,
 	}
-	validity := CheckBatch(bq.config, bq.log, bq.l1Blocks, l2SafeHead, &data)
+	validity := bq.checkBatch(ctx, bq.log, l2SafeHead, &data)
 	if validity == BatchDrop {
 		return // if we do drop the batch, CheckBatch will log the drop reason with WARN level.
 	}
-	bq.log.Debug("Adding batch", "batch_timestamp", batch.Timestamp, "parent_hash", batch.ParentHash, "batch_epoch", batch.Epoch(), "txs", len(batch.Transactions))
-	bq.batches[batch.Timestamp] = append(bq.batches[batch.Timestamp], &data)
+	batch.LogContext(bq.log).Debug("Adding batch")
+	bq.batches = append(bq.batches, &data)
 }
 
 // deriveNextBatch derives the next batch to apply on top of the current L2 safe head,
 // following the validity rules imposed on consecutive batches,
 // based on currently available buffered batch and L1 origin information.
 // If no batch can be derived yet, then (nil, io.EOF) is returned.
-func (bq *BatchQueue) deriveNextBatch(ctx context.Context, outOfData bool, l2SafeHead eth.L2BlockRef) (*BatchData, error) {
+func (bq *BatchQueue) deriveNextBatch(ctx context.Context, outOfData bool, l2SafeHead eth.L2BlockRef) (Batch, error) {
 	if len(bq.l1Blocks) == 0 {
 		return nil, NewCriticalError(errors.New("cannot derive next batch, no origin was prepared"))
 	}
@@ -170,50 +254,38 @@ func (bq *BatchQueue) deriveNextBatch(ctx context.Context, outOfData bool, l2Saf
 	// Go over all batches, in order of inclusion, and find the first batch we can accept.
 	// We filter in-place by only remembering the batches that may be processed in the future, or those we are undecided on.
 	var remaining []*BatchWithL1InclusionBlock
-	candidates := bq.batches[nextTimestamp]
 batchLoop:
-	for i, batch := range candidates {
-		validity := CheckBatch(bq.config, bq.log.New("batch_index", i), bq.l1Blocks, l2SafeHead, batch)
+	for i, batch := range bq.batches {
+		validity := bq.checkBatch(ctx, bq.log.New("batch_index", i), l2SafeHead, batch)
 		switch validity {
 		case BatchFuture:
-			return nil, NewCriticalError(fmt.Errorf("found batch with timestamp %d marked as future batch, but expected timestamp %d", batch.Batch.Timestamp, nextTimestamp))
+			remaining = append(remaining, batch)
+			continue
 		case BatchDrop:
-			bq.log.Warn("dropping batch",
-				"batch_timestamp", batch.Batch.Timestamp,
-				"parent_hash", batch.Batch.ParentHash,
-				"batch_epoch", batch.Batch.Epoch(),
-				"txs", len(batch.Batch.Transactions),
+			batch.Batch.LogContext(bq.log).Warn("dropping batch",
 				"l2_safe_head", l2SafeHead.ID(),
 				"l2_safe_head_time", l2SafeHead.Time,
 			)
 			continue
 		case BatchAccept:
+			delete(bq.checks, batch)
 			nextBatch = batch
 			// don't keep the current batch in the remaining items since we are processing it now,
 			// but retain every batch we didn't get to yet.
-			remaining = append(remaining, candidates[i+1:]...)
+			remaining = append(remaining, bq.batches[i+1:]...)
 			break batchLoop
 		case BatchUndecided:
-			remaining = append(remaining, batch)
-			bq.batches[nextTimestamp] = remaining
+			remaining = append(remaining, bq.batches[i:]...)
+			bq.batches = remaining
 			return nil, io.EOF
 		default:
 			return nil, NewCriticalError(fmt.Errorf("unknown batch validity type: %d", validity))
 		}
 	}
-	// clean up if we remove the final batch for this timestamp
-	if len(remaining) == 0 {
-		delete(bq.batches, nextTimestamp)
-	} else {
-		bq.batches[nextTimestamp] = remaining
-	}
+	bq.batches = remaining
 
 	if nextBatch != nil {
-		// advance epoch if necessary
-		if nextBatch.Batch.EpochNum == rollup.Epoch(epoch.Number)+1 {
-			bq.l1Blocks = bq.l1Blocks[1:]
-		}
-		bq.log.Info("Found next batch", "epoch", epoch, "batch_epoch", nextBatch.Batch.EpochNum, "batch_timestamp", nextBatch.Batch.Timestamp)
+		nextBatch.Batch.LogContext(bq.log).Info("Found next batch")
 		return nextBatch.Batch, nil
 	}
 
@@ -243,14 +315,12 @@ batchLoop:
 	// batch to ensure that we at least have one batch per epoch.
 	if nextTimestamp < nextEpoch.Time || firstOfEpoch {
 		bq.log.Info("Generating next batch", "epoch", epoch, "timestamp", nextTimestamp)
-		return &BatchData{
-			BatchV1{
-				ParentHash:   l2SafeHead.Hash,
-				EpochNum:     rollup.Epoch(epoch.Number),
-				EpochHash:    epoch.Hash,
-				Timestamp:    nextTimestamp,
-				Transactions: nil,
-			},
+		return &BatchV1{
+			ParentHash:   l2SafeHead.Hash,
+			EpochNum:     rollup.Epoch(epoch.Number),
+			EpochHash:    epoch.Hash,
+			Timestamp:    nextTimestamp,
+			Transactions: nil,
 		}, nil
 	}
 
@@ -260,3 +330,69 @@ batchLoop:
 	bq.l1Blocks = bq.l1Blocks[1:]
 	return nil, io.EOF
 }
+
+// maxDroppedBatches is the number of dropped batches that are kept for inspection.
+const maxDroppedBatches = 16
+
+// batchCheck is the outcome of a batch validity check.
+type batchCheck struct {
+	validity BatchValidity
+	reason   string
+}
+
+// checkBatch checks the validity of the batch, and records the outcome and its reason for inspection.
+func (bq *BatchQueue) checkBatch(ctx context.Context, lgr log.Logger, l2SafeHead eth.L2BlockRef, batch *BatchWithL1InclusionBlock) BatchValidity {
+	validity, reason := CheckBatch(ctx, bq.config, lgr, bq.l1Blocks, l2SafeHead, batch, bq.l2)
+	check := batchCheck{validity: validity, reason: reason}
+	if validity == BatchDrop {
+		delete(bq.checks, batch)
+		status := batchStatus(batch, &check)
+		bq.dropped = append(bq.dropped, status)
+		if len(bq.dropped) > maxDroppedBatches {
+			bq.dropped = bq.dropped[len(bq.dropped)-maxDroppedBatches:]
+		}
+		if bq.onDropped != nil {
+			bq.onDropped(status)
+		}
+	} else {
+		if bq.checks == nil {
+			bq.checks = make(map[*BatchWithL1InclusionBlock]batchCheck)
+		}
+		bq.checks[batch] = check
+	}
+	return validity
+}
+
+func batchStatus(batch *BatchWithL1InclusionBlock, check *batchCheck) BatchStatus {
+	status := BatchStatus{
+		Type:             batch.Batch.GetBatchType(),
+		Timestamp:        batch.Batch.GetTimestamp(),
+		L1InclusionBlock: batch.L1InclusionBlock,
+	}
+	if check != nil {
+		status.Validity = check.validity.String()
+		status.Reason = check.reason
+	}
+	return status
+}
+
+// OnDroppedBatch sets a function that is called with the status of every batch that is dropped,
+// for tooling that inspects the derivation of batches.
+func (bq *BatchQueue) OnDroppedBatch(fn func(BatchStatus)) {
+	bq.onDropped = fn
+}
+
+// fillStatus adds the state of the batch queue to the given pipeline status.
+func (bq *BatchQueue) fillStatus(status *DerivationStatus) {
+	status.Epochs = append([]eth.L1BlockRef(nil), bq.l1Blocks...)
+	status.Batches = make([]BatchStatus, 0, len(bq.batches))
+	for _, batch := range bq.batches {
+		var check *batchCheck
+		if c, ok := bq.checks[batch]; ok {
+			check = &c
+		}
+		status.Batches = append(status.Batches, batchStatus(batch, check))
+	}
+	status.DroppedBatches = append([]BatchStatus(nil), bq.dropped...)
+	status.SpanBatchRemaining = len(bq.nextSpan)
+}
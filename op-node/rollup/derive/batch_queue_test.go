@@ -20,7 +20,7 @@ import (
 
 type fakeBatchQueueInput struct {
 	i       int
-	batches []*BatchData
+	batches []*BatchV1
 	errors  []error
 	origin  eth.L1BlockRef
 }
@@ -29,7 +29,7 @@ func (f *fakeBatchQueueInput) Origin() eth.L1BlockRef {
 	return f.origin
 }
 
-func (f *fakeBatchQueueInput) NextBatch(ctx context.Context) (*BatchData, error) {
+func (f *fakeBatchQueueInput) NextBatch(ctx context.Context) (Batch, error) {
 	if f.i >= len(f.batches) {
 		return nil, io.EOF
 	}
@@ -45,16 +45,16 @@ func mockHash(time uint64, layer uint8) common.Hash {
 	return hash
 }
 
-func b(timestamp uint64, epoch eth.L1BlockRef) *BatchData {
+func b(timestamp uint64, epoch eth.L1BlockRef) *BatchV1 {
 	rng := rand.New(rand.NewSource(int64(timestamp)))
 	data := testutils.RandomData(rng, 20)
-	return &BatchData{BatchV1{
+	return &BatchV1{
 		ParentHash:   mockHash(timestamp-2, 2),
 		Timestamp:    timestamp,
 		EpochNum:     rollup.Epoch(epoch.Number),
 		EpochHash:    epoch.Hash,
 		Transactions: []hexutil.Bytes{data},
-	}}
+	}
 }
 
 func L1Chain(l1Times []uint64) []eth.L1BlockRef {
@@ -97,12 +97,12 @@ func TestBatchQueueNewOrigin(t *testing.T) {
 	}
 
 	input := &fakeBatchQueueInput{
-		batches: []*BatchData{nil},
+		batches: []*BatchV1{nil},
 		errors:  []error{io.EOF},
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	require.Equal(t, []eth.L1BlockRef{l1[0]}, bq.l1Blocks)
 
@@ -155,7 +155,7 @@ func TestBatchQueueEager(t *testing.T) {
 		SeqWindowSize:     30,
 	}
 
-	batches := []*BatchData{b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, l1[0]), b(20, l1[0]), b(22, l1[0]), b(24, l1[1]), nil}
+	batches := []*BatchV1{b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, l1[0]), b(20, l1[0]), b(22, l1[0]), b(24, l1[1]), nil}
 	errors := []error{nil, nil, nil, nil, nil, nil, nil, io.EOF}
 
 	input := &fakeBatchQueueInput{
@@ -164,7 +164,7 @@ func TestBatchQueueEager(t *testing.T) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 	// Advance the origin
 	input.origin = l1[1]
@@ -183,6 +183,76 @@ func TestBatchQueueEager(t *testing.T) {
 	}
 }
 
+// TestBatchQueueStatus tests that the status of the batch queue reports the buffered
+// and dropped batches, along with the reason they are pending or dropped.
+func TestBatchQueueStatus(t *testing.T) {
+	log := testlog.Logger(t, log.LvlCrit)
+	l1 := L1Chain([]uint64{10, 20, 30})
+	safeHead := eth.L2BlockRef{
+		Hash:           mockHash(10, 2),
+		Number:         0,
+		ParentHash:     common.Hash{},
+		Time:           10,
+		L1Origin:       l1[0].ID(),
+		SequenceNumber: 0,
+	}
+	cfg := &rollup.Config{
+		Genesis: rollup.Genesis{
+			L2Time: 10,
+		},
+		BlockTime:         2,
+		MaxSequencerDrift: 600,
+		SeqWindowSize:     30,
+	}
+
+	input := &fakeBatchQueueInput{
+		batches: []*BatchV1{b(10, l1[0]), b(14, l1[0]), b(12, l1[0])},
+		errors:  []error{nil, nil, nil},
+		origin:  l1[0],
+	}
+
+	bq := NewBatchQueue(log, cfg, input, nil)
+	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
+	input.origin = l1[1]
+
+	status := func() *DerivationStatus {
+		var out DerivationStatus
+		bq.fillStatus(&out)
+		return &out
+	}
+
+	// The batch with an old timestamp is dropped
+	_, err := bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, err, NotEnoughData)
+	s := status()
+	require.Empty(t, s.Batches)
+	require.Len(t, s.DroppedBatches, 1)
+	require.Equal(t, uint64(10), s.DroppedBatches[0].Timestamp)
+	require.Equal(t, "drop", s.DroppedBatches[0].Validity)
+	require.Equal(t, "dropping batch with old timestamp", s.DroppedBatches[0].Reason)
+	require.Equal(t, []eth.L1BlockRef{l1[0], l1[1]}, s.Epochs)
+
+	// The batch ahead of the safe head is kept for future processing
+	_, err = bq.NextBatch(context.Background(), safeHead)
+	require.ErrorIs(t, err, NotEnoughData)
+	s = status()
+	require.Len(t, s.Batches, 1)
+	require.Equal(t, uint64(14), s.Batches[0].Timestamp)
+	require.Equal(t, l1[1], s.Batches[0].L1InclusionBlock)
+	require.Equal(t, "future", s.Batches[0].Validity)
+	require.Equal(t, "received out-of-order batch for future processing after next batch", s.Batches[0].Reason)
+
+	// The next batch is accepted, and no longer reported
+	next, err := bq.NextBatch(context.Background(), safeHead)
+	require.NoError(t, err)
+	require.Equal(t, uint64(12), next.Timestamp)
+	s = status()
+	require.Len(t, s.Batches, 1)
+	require.Equal(t, uint64(14), s.Batches[0].Timestamp)
+	require.Len(t, s.DroppedBatches, 1)
+	require.Len(t, bq.checks, 1)
+}
+
 // TestBatchQueueInvalidInternalAdvance asserts that we do not miss an epoch when generating batches.
 // This is a regression test for CLI-3378.
 func TestBatchQueueInvalidInternalAdvance(t *testing.T) {
@@ -205,7 +275,7 @@ func TestBatchQueueInvalidInternalAdvance(t *testing.T) {
 		SeqWindowSize:     2,
 	}
 
-	batches := []*BatchData{b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, l1[0]), b(20, l1[0]), b(22, l1[0]), nil}
+	batches := []*BatchV1{b(12, l1[0]), b(14, l1[0]), b(16, l1[0]), b(18, l1[0]), b(20, l1[0]), b(22, l1[0]), nil}
 	errors := []error{nil, nil, nil, nil, nil, nil, io.EOF}
 
 	input := &fakeBatchQueueInput{
@@ -214,7 +284,7 @@ func TestBatchQueueInvalidInternalAdvance(t *testing.T) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	// Load continuous batches for epoch 0
@@ -299,7 +369,7 @@ func TestBatchQueueMissing(t *testing.T) {
 	// The batches at 18 and 20 are skipped to stop 22 from being eagerly processed.
 	// This test checks that batch timestamp 12 & 14 are created, 16 is used, and 18 is advancing the epoch.
 	// Due to the large sequencer time drift 16 is perfectly valid to have epoch 0 as origin.
-	batches := []*BatchData{b(16, l1[0]), b(22, l1[1])}
+	batches := []*BatchV1{b(16, l1[0]), b(22, l1[1])}
 	errors := []error{nil, nil}
 
 	input := &fakeBatchQueueInput{
@@ -308,7 +378,7 @@ func TestBatchQueueMissing(t *testing.T) {
 		origin:  l1[0],
 	}
 
-	bq := NewBatchQueue(log, cfg, input)
+	bq := NewBatchQueue(log, cfg, input, nil)
 	_ = bq.Reset(context.Background(), l1[0], eth.SystemConfig{})
 
 	for i := 0; i < len(batches); i++ {
@@ -331,7 +401,7 @@ func TestBatchQueueMissing(t *testing.T) {
 	b, e = bq.NextBatch(context.Background(), safeHead)
 	require.Nil(t, e)
 	require.Equal(t, b.Timestamp, uint64(12))
-	require.Empty(t, b.BatchV1.Transactions)
+	require.Empty(t, b.Transactions)
 	require.Equal(t, rollup.Epoch(0), b.EpochNum)
 	safeHead.Number += 1
 	safeHead.Time += 2
@@ -341,7 +411,7 @@ func TestBatchQueueMissing(t *testing.T) {
 	b, e = bq.NextBatch(context.Background(), safeHead)
 	require.Nil(t, e)
 	require.Equal(t, b.Timestamp, uint64(14))
-	require.Empty(t, b.BatchV1.Transactions)
+	require.Empty(t, b.Transactions)
 	require.Equal(t, rollup.Epoch(0), b.EpochNum)
 	safeHead.Number += 1
 	safeHead.Time += 2
@@ -367,6 +437,6 @@ func TestBatchQueueMissing(t *testing.T) {
 	b, e = bq.NextBatch(context.Background(), safeHead)
 	require.Nil(t, e)
 	require.Equal(t, b.Timestamp, uint64(18))
-	require.Empty(t, b.BatchV1.Transactions)
+	require.Empty(t, b.Transactions)
 	require.Equal(t, rollup.Epoch(1), b.EpochNum)
 }
@@ -1,6 +1,10 @@
 package derive
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/core/types"
@@ -9,7 +13,7 @@ import (
 
 type BatchWithL1InclusionBlock struct {
 	L1InclusionBlock eth.L1BlockRef
-	Batch            *BatchData
+	Batch            Batch
 }
 
 type BatchValidity uint8
@@ -25,56 +29,95 @@ const (
 	BatchFuture
 )
 
+func (v BatchValidity) String() string {
+	switch v {
+	case BatchDrop:
+		return "drop"
+	case BatchAccept:
+		return "accept"
+	case BatchUndecided:
+		return "undecided"
+	case BatchFuture:
+		return "future"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(v))
+	}
+}
+
+// ReasonParentHashMismatch is the reason of a batch that is dropped because it does not build on the expected parent block.
+const ReasonParentHashMismatch = "ignoring batch with mismatching parent hash"
+
 // CheckBatch checks if the given batch can be applied on top of the given l2SafeHead, given the contextual L1 blocks the batch was included in.
 // The first entry of the l1Blocks should match the origin of the l2SafeHead. One or more consecutive l1Blocks should be provided.
 // In case of only a single L1 block, the decision whether a batch is valid may have to stay undecided.
-func CheckBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef, batch *BatchWithL1InclusionBlock) BatchValidity {
+// The L2 fetcher is only used by span batches, to check the parts of the span that overlap with the safe chain.
+// Along with the validity, it returns the reason the batch is not accepted, or an empty string if it is.
+func CheckBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef,
+	batch *BatchWithL1InclusionBlock, l2Fetcher SafeBlockFetcher) (BatchValidity, string) {
+	switch typ := batch.Batch.GetBatchType(); typ {
+	case BatchV1Type:
+		singularBatch, ok := batch.Batch.(*BatchV1)
+		if !ok {
+			log.Error("failed type assertion to BatchV1")
+			return BatchDrop, "failed type assertion to BatchV1"
+		}
+		return checkSingularBatch(cfg, log, l1Blocks, l2SafeHead, singularBatch, batch.L1InclusionBlock)
+	case SpanBatchType:
+		spanBatch, ok := batch.Batch.(*SpanBatch)
+		if !ok {
+			log.Error("failed type assertion to SpanBatch")
+			return BatchDrop, "failed type assertion to SpanBatch"
+		}
+		return checkSpanBatch(ctx, cfg, log, l1Blocks, l2SafeHead, spanBatch, batch.L1InclusionBlock, l2Fetcher)
+	default:
+		log.Warn("unrecognized batch type", "type", typ)
+		return BatchDrop, "unrecognized batch type"
+	}
+}
+
+// checkSingularBatch implements the batch validity rules of a BatchV1.
+func checkSingularBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef, batch *BatchV1, l1InclusionBlock eth.L1BlockRef) (BatchValidity, string) {
 	// add details to the log
-	log = log.New(
-		"batch_timestamp", batch.Batch.Timestamp,
-		"parent_hash", batch.Batch.ParentHash,
-		"batch_epoch", batch.Batch.Epoch(),
-		"txs", len(batch.Batch.Transactions),
-	)
+	log = batch.LogContext(log)
 
 	// sanity check we have consistent inputs
 	if len(l1Blocks) == 0 {
 		log.Warn("missing L1 block input, cannot proceed with batch checking")
-		return BatchUndecided
+		return BatchUndecided, "missing L1 block input, cannot proceed with batch checking"
 	}
 	epoch := l1Blocks[0]
 
 	nextTimestamp := l2SafeHead.Time + cfg.BlockTime
-	if batch.Batch.Timestamp > nextTimestamp {
+	if batch.Timestamp > nextTimestamp {
 		log.Trace("received out-of-order batch for future processing after next batch", "next_timestamp", nextTimestamp)
-		return BatchFuture
+		return BatchFuture, "received out-of-order batch for future processing after next batch"
 	}
-	if batch.Batch.Timestamp < nextTimestamp {
+	if batch.Timestamp < nextTimestamp {
 		log.Warn("dropping batch with old timestamp", "min_timestamp", nextTimestamp)
-		return BatchDrop
+		return BatchDrop, "dropping batch with old timestamp"
 	}
 
 	// dependent on above timestamp check. If the timestamp is correct, then it must build on top of the safe head.
-	if batch.Batch.ParentHash != l2SafeHead.Hash {
-		log.Warn("ignoring batch with mismatching parent hash", "current_safe_head", l2SafeHead.Hash)
-		return BatchDrop
+	if batch.ParentHash != l2SafeHead.Hash {
+		log.Warn(ReasonParentHashMismatch, "current_safe_head", l2SafeHead.Hash)
+		return BatchDrop, ReasonParentHashMismatch
 	}
 
 	// Filter out batches that were included too late.
-	if uint64(batch.Batch.EpochNum)+cfg.SeqWindowSize < batch.L1InclusionBlock.Number {
+	if uint64(batch.EpochNum)+cfg.SeqWindowSize < l1InclusionBlock.Number {
 		log.Warn("batch was included too late, sequence window expired")
-		return BatchDrop
+		return BatchDrop, "batch was included too late, sequence window expired"
 	}
 
 	// Check the L1 origin of the batch
 	batchOrigin := epoch
-	if uint64(batch.Batch.EpochNum) < epoch.Number {
+	if uint64(batch.EpochNum) < epoch.Number {
 		log.Warn("dropped batch, epoch is too old", "minimum", epoch.ID())
 		// batch epoch too old
-		return BatchDrop
-	} else if uint64(batch.Batch.EpochNum) == epoch.Number {
+		return BatchDrop, "dropped batch, epoch is too old"
+	} else if uint64(batch.EpochNum) == epoch.Number {
 		// Batch is sticking to the current epoch, continue.
-	} else if uint64(batch.Batch.EpochNum) == epoch.Number+1 {
+	} else if uint64(batch.EpochNum) == epoch.Number+1 {
 		// With only 1 l1Block we cannot look at the next L1 Origin.
 		// Note: This means that we are unable to determine validity of a batch
 		// without more information. In this case we should bail out until we have
@@ -82,39 +125,39 @@ func CheckBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l
 		// algorithm.
 		if len(l1Blocks) < 2 {
 			log.Info("eager batch wants to advance epoch, but could not without more L1 blocks", "current_epoch", epoch.ID())
-			return BatchUndecided
+			return BatchUndecided, "eager batch wants to advance epoch, but could not without more L1 blocks"
 		}
 		batchOrigin = l1Blocks[1]
 	} else {
 		log.Warn("batch is for future epoch too far ahead, while it has the next timestamp, so it must be invalid", "current_epoch", epoch.ID())
-		return BatchDrop
+		return BatchDrop, "batch is for future epoch too far ahead, while it has the next timestamp, so it must be invalid"
 	}
 
-	if batch.Batch.EpochHash != batchOrigin.Hash {
+	if batch.EpochHash != batchOrigin.Hash {
 		log.Warn("batch is for different L1 chain, epoch hash does not match", "expected", batchOrigin.ID())
-		return BatchDrop
+		return BatchDrop, "batch is for different L1 chain, epoch hash does not match"
 	}
 
-	if batch.Batch.Timestamp < batchOrigin.Time {
-		log.Warn("batch timestamp is less than L1 origin timestamp", "l2_timestamp", batch.Batch.Timestamp, "l1_timestamp", batchOrigin.Time, "origin", batchOrigin.ID())
-		return BatchDrop
+	if batch.Timestamp < batchOrigin.Time {
+		log.Warn("batch timestamp is less than L1 origin timestamp", "l2_timestamp", batch.Timestamp, "l1_timestamp", batchOrigin.Time, "origin", batchOrigin.ID())
+		return BatchDrop, "batch timestamp is less than L1 origin timestamp"
 	}
 
 	// Check if we ran out of sequencer time drift
-	if max := batchOrigin.Time + cfg.MaxSequencerDrift; batch.Batch.Timestamp > max {
-		if len(batch.Batch.Transactions) == 0 {
+	if max := batchOrigin.Time + cfg.MaxSequencerDrift; batch.Timestamp > max {
+		if len(batch.Transactions) == 0 {
 			// If the sequencer is co-operating by producing an empty batch,
 			// then allow the batch if it was the right thing to do to maintain the L2 time >= L1 time invariant.
 			// We only check batches that do not advance the epoch, to ensure epoch advancement regardless of time drift is allowed.
 			if epoch.Number == batchOrigin.Number {
 				if len(l1Blocks) < 2 {
 					log.Info("without the next L1 origin we cannot determine yet if this empty batch that exceeds the time drift is still valid")
-					return BatchUndecided
+					return BatchUndecided, "without the next L1 origin we cannot determine yet if this empty batch that exceeds the time drift is still valid"
 				}
 				nextOrigin := l1Blocks[1]
-				if batch.Batch.Timestamp >= nextOrigin.Time { // check if the next L1 origin could have been adopted
+				if batch.Timestamp >= nextOrigin.Time { // check if the next L1 origin could have been adopted
 					log.Info("batch exceeded sequencer time drift without adopting next origin, and next L1 origin would have been valid")
-					return BatchDrop
+					return BatchDrop, "batch exceeded sequencer time drift without adopting next origin, and next L1 origin would have been valid"
 				} else {
 					log.Info("continuing with empty batch before late L1 block to preserve L2 time invariant")
 				}
@@ -123,21 +166,252 @@ func CheckBatch(cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l
 			// If the sequencer is ignoring the time drift rule, then drop the batch and force an empty batch instead,
 			// as the sequencer is not allowed to include anything past this point without moving to the next epoch.
 			log.Warn("batch exceeded sequencer time drift, sequencer must adopt new L1 origin to include transactions again", "max_time", max)
-			return BatchDrop
+			return BatchDrop, "batch exceeded sequencer time drift, sequencer must adopt new L1 origin to include transactions again"
 		}
 	}
 
 	// We can do this check earlier, but it's a more intensive one, so we do this last.
-	for i, txBytes := range batch.Batch.Transactions {
+	for i, txBytes := range batch.Transactions {
 		if len(txBytes) == 0 {
 			log.Warn("transaction data must not be empty, but found empty tx", "tx_index", i)
-			return BatchDrop
+			return BatchDrop, "transaction data must not be empty, but found empty tx"
 		}
 		if txBytes[0] == types.DepositTxType {
 			log.Warn("sequencers may not embed any deposits into batch data, but found tx that has one", "tx_index", i)
-			return BatchDrop
+			return BatchDrop, "sequencers may not embed any deposits into batch data, but found tx that has one"
+		}
+	}
+
+	return BatchAccept, ""
+}
+
+// checkSpanBatch implements the batch validity rules of a SpanBatch.
+// A span batch may overlap with the safe chain: the overlapping blocks must then match the existing safe blocks,
+// which are fetched with the given L2 fetcher.
+func checkSpanBatch(ctx context.Context, cfg *rollup.Config, log log.Logger, l1Blocks []eth.L1BlockRef, l2SafeHead eth.L2BlockRef,
+	batch *SpanBatch, l1InclusionBlock eth.L1BlockRef, l2Fetcher SafeBlockFetcher) (BatchValidity, string) {
+	// add details to the log
+	log = batch.LogContext(log)
+
+	// sanity check we have consistent inputs
+	if len(l1Blocks) == 0 {
+		log.Warn("missing L1 block input, cannot proceed with batch checking")
+		return BatchUndecided, "missing L1 block input, cannot proceed with batch checking"
+	}
+	if batch.GetBlockCount() == 0 {
+		log.Warn("empty span batch, cannot proceed with batch checking")
+		return BatchDrop, "empty span batch, cannot proceed with batch checking"
+	}
+	epoch := l1Blocks[0]
+
+	startEpochNum := uint64(batch.GetStartEpochNum())
+	batchOrigin := epoch
+	if startEpochNum == batchOrigin.Number+1 {
+		if len(l1Blocks) < 2 {
+			log.Info("eager batch wants to advance epoch, but could not without more L1 blocks", "current_epoch", epoch.ID())
+			return BatchUndecided, "eager batch wants to advance epoch, but could not without more L1 blocks"
+		}
+		batchOrigin = l1Blocks[1]
+	}
+	if !cfg.IsDelta(batchOrigin.Time) {
+		log.Warn("received SpanBatch with L1 origin before Delta hard fork", "l1_origin", batchOrigin.ID(), "l1_origin_time", batchOrigin.Time)
+		return BatchDrop, "received SpanBatch with L1 origin before Delta hard fork"
+	}
+
+	nextTimestamp := l2SafeHead.Time + cfg.BlockTime
+	if batch.GetTimestamp() > nextTimestamp {
+		log.Trace("received out-of-order batch for future processing after next batch", "next_timestamp", nextTimestamp)
+		return BatchFuture, "received out-of-order batch for future processing after next batch"
+	}
+	if batch.GetBlockTimestamp(batch.GetBlockCount()-1) < nextTimestamp {
+		log.Warn("span batch has no new blocks after safe head")
+		return BatchDrop, "span batch has no new blocks after safe head"
+	}
+
+	// Find the parent block of the span batch.
+	// If the span batch does not overlap the current safe chain, the parent block is the L2 safe head.
+	parentNum := l2SafeHead.Number
+	parentBlock := l2SafeHead
+	if batch.GetTimestamp() < nextTimestamp {
+		if batch.GetTimestamp() > l2SafeHead.Time {
+			// batch timestamp cannot be between safe head and next timestamp
+			log.Warn("batch has misaligned timestamp, block time is too short")
+			return BatchDrop, "batch has misaligned timestamp, block time is too short"
+		}
+		if (l2SafeHead.Time-batch.GetTimestamp())%cfg.BlockTime != 0 {
+			log.Warn("batch has misaligned timestamp, not overlapped exactly")
+			return BatchDrop, "batch has misaligned timestamp, not overlapped exactly"
+		}
+		overlap := (l2SafeHead.Time-batch.GetTimestamp())/cfg.BlockTime + 1
+		if overlap > l2SafeHead.Number-cfg.Genesis.L2.Number {
+			log.Warn("span batch starts before the L2 genesis block", "genesis", cfg.Genesis.L2)
+			return BatchDrop, "span batch starts before the L2 genesis block"
+		}
+		parentNum = l2SafeHead.Number - overlap
+		if l2Fetcher == nil {
+			log.Warn("no L2 fetcher to check the overlapped part of the span batch")
+			return BatchDrop, "no L2 fetcher to check the overlapped part of the span batch"
+		}
+		payload, err := l2Fetcher.PayloadByNumber(ctx, parentNum)
+		if err != nil {
+			log.Warn("failed to fetch L2 block", "number", parentNum, "err", err)
+			// unable to validate the batch for now. retry later.
+			return BatchUndecided, "failed to fetch L2 block"
+		}
+		parentBlock, err = PayloadToBlockRef(payload, &cfg.Genesis)
+		if err != nil {
+			log.Warn("failed to extract L2BlockRef from execution payload", "hash", payload.BlockHash, "err", err)
+			return BatchDrop, "failed to extract L2BlockRef from execution payload"
+		}
+	}
+	if !batch.CheckParentHash(parentBlock.Hash) {
+		log.Warn(ReasonParentHashMismatch, "parent_block", parentBlock.Hash)
+		return BatchDrop, ReasonParentHashMismatch
+	}
+
+	// Filter out batches that were included too late.
+	if startEpochNum+cfg.SeqWindowSize < l1InclusionBlock.Number {
+		log.Warn("batch was included too late, sequence window expired")
+		return BatchDrop, "batch was included too late, sequence window expired"
+	}
+
+	// Check the L1 origin of the batch
+	if startEpochNum > parentBlock.L1Origin.Number+1 {
+		log.Warn("batch is for future epoch too far ahead, while it has the next timestamp, so it must be invalid", "current_epoch", epoch.ID())
+		return BatchDrop, "batch is for future epoch too far ahead, while it has the next timestamp, so it must be invalid"
+	}
+
+	endEpochNum := batch.GetBlockEpochNum(batch.GetBlockCount() - 1)
+	originChecked := false
+	// l1Blocks is supplied from the batch queue, and its length is limited to the sequencing window.
+	for _, l1Block := range l1Blocks {
+		if l1Block.Number == endEpochNum {
+			if !batch.CheckOriginHash(l1Block.Hash) {
+				log.Warn("batch is for different L1 chain, epoch hash does not match", "expected", l1Block.ID())
+				return BatchDrop, "batch is for different L1 chain, epoch hash does not match"
+			}
+			originChecked = true
+			break
+		}
+	}
+	if !originChecked {
+		log.Info("need more l1 blocks to check entire origins of span batch")
+		return BatchUndecided, "need more l1 blocks to check entire origins of span batch"
+	}
+
+	if startEpochNum < parentBlock.L1Origin.Number {
+		log.Warn("dropped batch, epoch is too old", "minimum", parentBlock.ID())
+		return BatchDrop, "dropped batch, epoch is too old"
+	}
+
+	originIdx := 0
+	originAdvanced := startEpochNum == parentBlock.L1Origin.Number+1
+
+	for i := 0; i < batch.GetBlockCount(); i++ {
+		if batch.GetBlockTimestamp(i) <= l2SafeHead.Time {
+			continue
+		}
+		var l1Origin eth.L1BlockRef
+		found := false
+		for j := originIdx; j < len(l1Blocks); j++ {
+			if batch.GetBlockEpochNum(i) == l1Blocks[j].Number {
+				l1Origin = l1Blocks[j]
+				originIdx = j
+				found = true
+				break
+			}
+		}
+		if !found {
+			log.Warn("unable to find L1 origin of block in span batch", "block_index", i, "epoch", batch.GetBlockEpochNum(i))
+			return BatchDrop, "unable to find L1 origin of block in span batch"
+		}
+		if i > 0 {
+			originAdvanced = batch.GetBlockEpochNum(i) > batch.GetBlockEpochNum(i-1)
+		}
+		blockTimestamp := batch.GetBlockTimestamp(i)
+		if blockTimestamp < l1Origin.Time {
+			log.Warn("block timestamp is less than L1 origin timestamp", "l2_timestamp", blockTimestamp, "l1_timestamp", l1Origin.Time, "origin", l1Origin.ID())
+			return BatchDrop, "block timestamp is less than L1 origin timestamp"
+		}
+
+		// Check if we ran out of sequencer time drift
+		if max := l1Origin.Time + cfg.MaxSequencerDrift; blockTimestamp > max {
+			if len(batch.GetBlockTransactions(i)) == 0 {
+				// If the sequencer is co-operating by producing an empty batch,
+				// then allow the batch if it was the right thing to do to maintain the L2 time >= L1 time invariant.
+				// We only check batches that do not advance the epoch, to ensure epoch advancement regardless of time drift is allowed.
+				if !originAdvanced {
+					if originIdx+1 >= len(l1Blocks) {
+						log.Info("without the next L1 origin we cannot determine yet if this empty batch that exceeds the time drift is still valid")
+						return BatchUndecided, "without the next L1 origin we cannot determine yet if this empty batch that exceeds the time drift is still valid"
+					}
+					if blockTimestamp >= l1Blocks[originIdx+1].Time { // check if the next L1 origin could have been adopted
+						log.Info("batch exceeded sequencer time drift without adopting next origin, and next L1 origin would have been valid")
+						return BatchDrop, "batch exceeded sequencer time drift without adopting next origin, and next L1 origin would have been valid"
+					} else {
+						log.Info("continuing with empty batch before late L1 block to preserve L2 time invariant")
+					}
+				}
+			} else {
+				// If the sequencer is ignoring the time drift rule, then drop the batch and force an empty batch instead,
+				// as the sequencer is not allowed to include anything past this point without moving to the next epoch.
+				log.Warn("batch exceeded sequencer time drift, sequencer must adopt new L1 origin to include transactions again", "max_time", max)
+				return BatchDrop, "batch exceeded sequencer time drift, sequencer must adopt new L1 origin to include transactions again"
+			}
+		}
+
+		for j, txBytes := range batch.GetBlockTransactions(i) {
+			if len(txBytes) == 0 {
+				log.Warn("transaction data must not be empty, but found empty tx", "tx_index", j)
+				return BatchDrop, "transaction data must not be empty, but found empty tx"
+			}
+			if txBytes[0] == types.DepositTxType {
+				log.Warn("sequencers may not embed any deposits into batch data, but found tx that has one", "tx_index", j)
+				return BatchDrop, "sequencers may not embed any deposits into batch data, but found tx that has one"
+			}
+		}
+	}
+
+	// Check the overlapped blocks: they must match the existing safe blocks.
+	if batch.GetTimestamp() < nextTimestamp {
+		for i := uint64(0); i < l2SafeHead.Number-parentNum; i++ {
+			safeBlockNum := parentNum + i + 1
+			safeBlockPayload, err := l2Fetcher.PayloadByNumber(ctx, safeBlockNum)
+			if err != nil {
+				log.Warn("failed to fetch L2 block payload", "number", safeBlockNum, "err", err)
+				// unable to validate the batch for now. retry later.
+				return BatchUndecided, "failed to fetch L2 block payload"
+			}
+			safeBlockTxs := safeBlockPayload.Transactions
+			batchTxs := batch.GetBlockTransactions(int(i))
+			// execution payload has deposit TXs, but batch does not.
+			depositCount := 0
+			for _, tx := range safeBlockTxs {
+				if len(tx) > 0 && tx[0] == types.DepositTxType {
+					depositCount++
+				}
+			}
+			if len(safeBlockTxs)-depositCount != len(batchTxs) {
+				log.Warn("overlapped block's tx count does not match", "safe_block_txs", len(safeBlockTxs)-depositCount, "batch_txs", len(batchTxs))
+				return BatchDrop, "overlapped block's tx count does not match"
+			}
+			for j := 0; j < len(batchTxs); j++ {
+				if !bytes.Equal(safeBlockTxs[j+depositCount], batchTxs[j]) {
+					log.Warn("overlapped block's transaction does not match", "tx_index", j)
+					return BatchDrop, "overlapped block's transaction does not match"
+				}
+			}
+			safeBlockRef, err := PayloadToBlockRef(safeBlockPayload, &cfg.Genesis)
+			if err != nil {
+				log.Warn("failed to extract L2BlockRef from execution payload", "hash", safeBlockPayload.BlockHash, "err", err)
+				return BatchDrop, "failed to extract L2BlockRef from execution payload"
+			}
+			if safeBlockRef.L1Origin.Number != batch.GetBlockEpochNum(int(i)) {
+				log.Warn("overlapped block's L1 origin number does not match", "safe_block_origin", safeBlockRef.L1Origin.Number, "batch_origin", batch.GetBlockEpochNum(int(i)))
+				return BatchDrop, "overlapped block's L1 origin number does not match"
+			}
 		}
 	}
 
-	return BatchAccept
+	return BatchAccept, ""
 }
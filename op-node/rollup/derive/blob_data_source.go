@@ -0,0 +1,149 @@
+package derive
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// blobOrCalldata holds a single piece of batcher data, which is either read
+// from the calldata of a regular transaction, or from a blob of a blob transaction.
+type blobOrCalldata struct {
+	// calldata is set if the data is read from calldata
+	calldata *eth.Data
+	// blobIndex is the index of the blob in the list of fetched blobs, if the data is read from a blob
+	blobIndex int
+}
+
+// BlobDataSource fetches both call-data (backwards compatible) and blobs and
+// returns the data of batcher transactions in the order they were included.
+// Like the CalldataSource, the constructor never fails: errors are retried on the next call to Next.
+type BlobDataSource struct {
+	data         []eth.Data
+	ref          eth.L1BlockRef
+	batcherAddr  common.Address
+	cfg          *rollup.Config
+	fetcher      L1TransactionFetcher
+	blobsFetcher L1BlobsFetcher
+	log          log.Logger
+}
+
+// NewBlobDataSource creates a new blob data source.
+func NewBlobDataSource(ctx context.Context, log log.Logger, cfg *rollup.Config, fetcher L1TransactionFetcher, blobsFetcher L1BlobsFetcher, ref eth.L1BlockRef, batcherAddr common.Address) DataIter {
+	return &BlobDataSource{
+		ref:          ref,
+		cfg:          cfg,
+		fetcher:      fetcher,
+		log:          log.New("origin", ref),
+		batcherAddr:  batcherAddr,
+		blobsFetcher: blobsFetcher,
+	}
+}
+
+// Next returns the next piece of batcher data, or an io.EOF error if no data remains. It returns
+// ResetError if it cannot find the referenced block or a referenced blob, or TemporaryError for
+// any other failure to fetch a block or blob.
+func (ds *BlobDataSource) Next(ctx context.Context) (eth.Data, error) {
+	if ds.data == nil {
+		var err error
+		if ds.data, err = ds.open(ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(ds.data) == 0 {
+		return nil, io.EOF
+	} else {
+		data := ds.data[0]
+		ds.data = ds.data[1:]
+		return data, nil
+	}
+}
+
+// open fetches and returns the blob or calldata of the batcher transactions in the L1 block.
+// The returned list is never nil if there is no error.
+func (ds *BlobDataSource) open(ctx context.Context) ([]eth.Data, error) {
+	_, txs, err := ds.fetcher.InfoAndTxsByHash(ctx, ds.ref.Hash)
+	if err != nil {
+		if errors.Is(err, ethereum.NotFound) {
+			return nil, NewResetError(fmt.Errorf("failed to open blob data source: %w", err))
+		}
+		return nil, NewTemporaryError(fmt.Errorf("failed to open blob data source: %w", err))
+	}
+
+	data, hashes := dataAndHashesFromTxs(txs, ds.cfg.L1Signer(), ds.cfg.BatchInboxAddress, ds.batcherAddr, ds.log)
+
+	var blobs []*eth.Blob
+	if len(hashes) > 0 {
+		blobs, err = ds.blobsFetcher.GetBlobs(ctx, ds.ref, hashes)
+		if errors.Is(err, ethereum.NotFound) {
+			// If the L1 block was available, then the blobs should be available too. The only
+			// exception is if the blob retention window has expired, which we will ultimately handle
+			// by failing over to a blob archival service.
+			return nil, NewResetError(fmt.Errorf("failed to fetch blobs: %w", err))
+		} else if err != nil {
+			return nil, NewTemporaryError(fmt.Errorf("failed to fetch blobs: %w", err))
+		}
+		if len(blobs) != len(hashes) {
+			return nil, NewTemporaryError(fmt.Errorf("expected %d blobs, but got %d", len(hashes), len(blobs)))
+		}
+	}
+
+	out := make([]eth.Data, 0, len(data))
+	for _, d := range data {
+		if d.calldata != nil {
+			out = append(out, *d.calldata)
+			continue
+		}
+		blobData, err := blobs[d.blobIndex].ToData()
+		if err != nil {
+			// invalid blob encodings are ignored, like any other invalid batcher data
+			ds.log.Warn("ignoring blob due to parse failure", "blobHash", hashes[d.blobIndex].Hash, "err", err)
+			continue
+		}
+		out = append(out, blobData)
+	}
+	return out, nil
+}
+
+// dataAndHashesFromTxs extracts calldata and datahashes from the input transactions and returns them. It
+// creates a placeholder blobOrCalldata element for each returned blob hash that must be populated
+// by the blobs that are fetched for the returned hashes.
+func dataAndHashesFromTxs(txs types.Transactions, l1Signer types.Signer, batchInboxAddr, batcherAddr common.Address, log log.Logger) ([]blobOrCalldata, []eth.IndexedBlobHash) {
+	var data []blobOrCalldata
+	var hashes []eth.IndexedBlobHash
+	// the blob index is the position of the blob within all the blobs of the block
+	blobIndex := 0
+	for _, tx := range txs {
+		// skip any non-batcher transactions
+		if !isValidBatchTx(tx, l1Signer, batchInboxAddr, batcherAddr, log) {
+			blobIndex += len(tx.BlobHashes())
+			continue
+		}
+		// handle non-blob batcher transactions by extracting their calldata
+		if tx.Type() != types.BlobTxType {
+			calldata := eth.Data(tx.Data())
+			data = append(data, blobOrCalldata{calldata: &calldata})
+			continue
+		}
+		// handle blob batcher transactions by extracting their blob hashes, ignoring any calldata.
+		if len(tx.Data()) > 0 {
+			log.Warn("blob tx has calldata, which will be ignored", "txhash", tx.Hash())
+		}
+		for _, h := range tx.BlobHashes() {
+			data = append(data, blobOrCalldata{blobIndex: len(hashes)})
+			hashes = append(hashes, eth.IndexedBlobHash{Index: uint64(blobIndex), Hash: h})
+			blobIndex++
+		}
+	}
+	return data, hashes
+}
@@ -0,0 +1,146 @@
+package derive
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"io"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/holiman/uint256"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func createBlobTx(t *testing.T, signer types.Signer, author *ecdsa.PrivateKey, nonce uint64, to common.Address, blobHashes []common.Hash) *types.Transaction {
+	t.Helper()
+	out, err := types.SignNewTx(author, signer, &types.BlobTx{
+		ChainID:    uint256.MustFromBig(signer.ChainID()),
+		Nonce:      nonce,
+		GasTipCap:  uint256.NewInt(2 * params.GWei),
+		GasFeeCap:  uint256.NewInt(30 * params.GWei),
+		Gas:        100_000,
+		To:         &to,
+		Value:      uint256.NewInt(0),
+		BlobFeeCap: uint256.NewInt(params.GWei),
+		BlobHashes: blobHashes,
+	})
+	require.NoError(t, err)
+	return out
+}
+
+func TestDataAndHashesFromTxs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	batcherPriv := testutils.RandomKey()
+	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	altAuthor := testutils.RandomKey()
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+	}
+	signer := cfg.L1Signer()
+	logger := testlog.Logger(t, log.LvlCrit)
+
+	calldataTx := (&testTx{to: &cfg.BatchInboxAddress, dataLen: 100, author: batcherPriv}).Create(t, signer, rng)
+	batcherBlobTx := createBlobTx(t, signer, batcherPriv, 1, cfg.BatchInboxAddress,
+		[]common.Hash{testutils.RandomHash(rng), testutils.RandomHash(rng)})
+	otherBlobTx := createBlobTx(t, signer, altAuthor, 0, cfg.BatchInboxAddress,
+		[]common.Hash{testutils.RandomHash(rng)})
+	lastBlobTx := createBlobTx(t, signer, batcherPriv, 2, cfg.BatchInboxAddress,
+		[]common.Hash{testutils.RandomHash(rng)})
+
+	txs := types.Transactions{calldataTx, otherBlobTx, batcherBlobTx, lastBlobTx}
+	data, hashes := dataAndHashesFromTxs(txs, signer, cfg.BatchInboxAddress, batcherAddr, logger)
+	require.Len(t, data, 4)
+	require.NotNil(t, data[0].calldata)
+	require.Equal(t, eth.Data(calldataTx.Data()), *data[0].calldata)
+	require.Equal(t, []eth.IndexedBlobHash{
+		// the blob of the non-batcher tx occupies index 0 of the block
+		{Index: 1, Hash: batcherBlobTx.BlobHashes()[0]},
+		{Index: 2, Hash: batcherBlobTx.BlobHashes()[1]},
+		{Index: 3, Hash: lastBlobTx.BlobHashes()[0]},
+	}, hashes)
+	for i := 1; i < 4; i++ {
+		require.Nil(t, data[i].calldata)
+		require.Equal(t, i-1, data[i].blobIndex)
+	}
+}
+
+func TestBlobDataSource(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	batcherPriv := testutils.RandomKey()
+	batcherAddr := crypto.PubkeyToAddress(batcherPriv.PublicKey)
+	cfg := &rollup.Config{
+		L1ChainID:         big.NewInt(100),
+		BatchInboxAddress: testutils.RandomAddress(rng),
+	}
+	signer := cfg.L1Signer()
+	logger := testlog.Logger(t, log.LvlCrit)
+	ref := testutils.RandomBlockRef(rng)
+
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data("blob frame data")))
+	var invalidBlob eth.Blob
+	invalidBlob[1] = 0xff // invalid encoding version
+
+	calldataTx := (&testTx{to: &cfg.BatchInboxAddress, dataLen: 100, author: batcherPriv}).Create(t, signer, rng)
+	blobTx := createBlobTx(t, signer, batcherPriv, 1, cfg.BatchInboxAddress,
+		[]common.Hash{testutils.RandomHash(rng), testutils.RandomHash(rng)})
+	hashes := []eth.IndexedBlobHash{
+		{Index: 0, Hash: blobTx.BlobHashes()[0]},
+		{Index: 1, Hash: blobTx.BlobHashes()[1]},
+	}
+
+	t.Run("mixed calldata and blobs", func(t *testing.T) {
+		l1F := &testutils.MockL1Source{}
+		blobsF := &testutils.MockBlobsFetcher{}
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), types.Transactions{calldataTx, blobTx}, nil)
+		blobsF.ExpectGetBlobs(ref, hashes, []*eth.Blob{&blob, &invalidBlob}, nil)
+
+		src := NewBlobDataSource(context.Background(), logger, cfg, l1F, blobsF, ref, batcherAddr)
+		data, err := src.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, eth.Data(calldataTx.Data()), data)
+		data, err = src.Next(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, eth.Data("blob frame data"), data)
+		// the invalid blob is skipped
+		_, err = src.Next(context.Background())
+		require.ErrorIs(t, err, io.EOF)
+		l1F.AssertExpectations(t)
+		blobsF.AssertExpectations(t)
+	})
+
+	t.Run("missing blobs", func(t *testing.T) {
+		l1F := &testutils.MockL1Source{}
+		blobsF := &testutils.MockBlobsFetcher{}
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), types.Transactions{blobTx}, nil)
+		blobsF.ExpectGetBlobs(ref, hashes, nil, ethereum.NotFound)
+
+		src := NewBlobDataSource(context.Background(), logger, cfg, l1F, blobsF, ref, batcherAddr)
+		_, err := src.Next(context.Background())
+		require.ErrorIs(t, err, ErrReset)
+	})
+
+	t.Run("blob count mismatch", func(t *testing.T) {
+		l1F := &testutils.MockL1Source{}
+		blobsF := &testutils.MockBlobsFetcher{}
+		l1F.ExpectInfoAndTxsByHash(ref.Hash, testutils.RandomBlockInfo(rng), types.Transactions{blobTx}, nil)
+		blobsF.ExpectGetBlobs(ref, hashes, []*eth.Blob{&blob}, nil)
+
+		src := NewBlobDataSource(context.Background(), logger, cfg, l1F, blobsF, ref, batcherAddr)
+		_, err := src.Next(context.Background())
+		require.ErrorIs(t, err, ErrTemporary)
+	})
+}
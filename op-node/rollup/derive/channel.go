@@ -1,11 +1,14 @@
 package derive
 
 import (
+	"bufio"
 	"bytes"
 	"compress/zlib"
 	"fmt"
 	"io"
 
+	"github.com/andybalholm/brotli"
+
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum/go-ethereum/rlp"
 )
@@ -107,6 +110,22 @@ func (ch *Channel) Size() uint64 {
 	return ch.size
 }
 
+// Status returns the status of the channel, which times out after the given L1 block number.
+func (ch *Channel) Status(timeout uint64) ChannelStatus {
+	return ChannelStatus{
+		ID:                      ch.id,
+		OpenBlock:               ch.openBlock,
+		HighestL1InclusionBlock: ch.highestL1InclusionBlock,
+		Timeout:                 timeout,
+		Frames:                  len(ch.inputs),
+		HighestFrameNumber:      ch.highestFrameNumber,
+		Closed:                  ch.closed,
+		EndFrameNumber:          ch.endFrameNumber,
+		Ready:                   ch.IsReady(),
+		Size:                    ch.size,
+	}
+}
+
 // IsReady returns true iff the channel is ready to be read.
 func (ch *Channel) IsReady() bool {
 	// Must see the last frame before the channel is ready to be read
@@ -143,20 +162,45 @@ func (ch *Channel) Reader() io.Reader {
 }
 
 // BatchReader provides a function that iteratively consumes batches from the reader.
-// The L1Inclusion block is also provided at creation time.
-func BatchReader(r io.Reader, l1InclusionBlock eth.L1BlockRef) (func() (BatchWithL1InclusionBlock, error), error) {
-	// Setup decompressor stage + RLP reader
-	zr, err := zlib.NewReader(r)
+// The compression algorithm is determined by the first byte of the channel data:
+// zlib streams carry no version byte, brotli channels are prefixed with ChannelVersionBrotli.
+// Brotli channels are only accepted if isFjord is true.
+func BatchReader(r io.Reader, isFjord bool) (func() (*BatchData, error), error) {
+	// Peek at the first byte to determine the compression algorithm
+	bufReader := bufio.NewReader(r)
+	compressionType, err := bufReader.Peek(1)
 	if err != nil {
 		return nil, err
 	}
+
+	// Setup decompressor stage + RLP reader
+	var zr io.Reader
+	switch {
+	case compressionType[0]&0x0F == ZlibCM8 || compressionType[0]&0x0F == ZlibCM15:
+		zr, err = zlib.NewReader(bufReader)
+		if err != nil {
+			return nil, err
+		}
+	case compressionType[0] == ChannelVersionBrotli:
+		if !isFjord {
+			return nil, fmt.Errorf("cannot use brotli compression before Fjord")
+		}
+		// discard the version byte
+		if _, err := bufReader.Discard(1); err != nil {
+			return nil, err
+		}
+		zr = brotli.NewReader(bufReader)
+	default:
+		return nil, fmt.Errorf("cannot distinguish the compression algo used given type byte %v", compressionType[0])
+	}
+
 	rlpReader := rlp.NewStream(zr, MaxRLPBytesPerChannel)
 	// Read each batch iteratively
-	return func() (BatchWithL1InclusionBlock, error) {
-		ret := BatchWithL1InclusionBlock{
-			L1InclusionBlock: l1InclusionBlock,
+	return func() (*BatchData, error) {
+		var batchData BatchData
+		if err := rlpReader.Decode(&batchData); err != nil {
+			return nil, err
 		}
-		err := rlpReader.Decode(&ret.Batch)
-		return ret, err
+		return &batchData, nil
 	}, nil
 }
@@ -177,6 +177,16 @@ func (cb *ChannelBank) NextData(ctx context.Context) ([]byte, error) {
 	}
 }
 
+// Status returns the status of the buffered channels, in FIFO order.
+func (cb *ChannelBank) Status() []ChannelStatus {
+	out := make([]ChannelStatus, 0, len(cb.channelQueue))
+	for _, id := range cb.channelQueue {
+		ch := cb.channels[id]
+		out = append(out, ch.Status(ch.OpenBlockNumber()+cb.cfg.ChannelTimeout))
+	}
+	return out
+}
+
 func (cb *ChannelBank) Reset(ctx context.Context, base eth.L1BlockRef, _ eth.SystemConfig) error {
 	cb.channels = make(map[ChannelID]*Channel)
 	cb.channelQueue = make([]ChannelID, 0, 10)
@@ -0,0 +1,163 @@
+package derive
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"io"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	// ZlibCM8 and ZlibCM15 are the compression methods of a zlib stream, which are encoded in the
+	// lower 4 bits of its first byte. Channels without a version byte always start with a zlib header.
+	ZlibCM8  = 8
+	ZlibCM15 = 15
+
+	// ChannelVersionBrotli is the version byte that prefixes brotli-compressed channel data.
+	ChannelVersionBrotli byte = 0x01
+)
+
+type CompressionAlgo string
+
+const (
+	// compression algorithms
+	Zlib     CompressionAlgo = "zlib"
+	Brotli   CompressionAlgo = "brotli" // default brotli 10
+	Brotli9  CompressionAlgo = "brotli-9"
+	Brotli10 CompressionAlgo = "brotli-10"
+	Brotli11 CompressionAlgo = "brotli-11"
+)
+
+var CompressionAlgos = []CompressionAlgo{
+	Zlib,
+	Brotli,
+	Brotli9,
+	Brotli10,
+	Brotli11,
+}
+
+func (algo CompressionAlgo) String() string {
+	return string(algo)
+}
+
+func (algo *CompressionAlgo) Set(value string) error {
+	if !ValidCompressionAlgo(CompressionAlgo(value)) {
+		return fmt.Errorf("unknown compression algo: %s", value)
+	}
+	*algo = CompressionAlgo(value)
+	return nil
+}
+
+func (algo *CompressionAlgo) Clone() any {
+	cpy := *algo
+	return &cpy
+}
+
+// IsBrotli returns true if the algorithm compresses channels with brotli,
+// which is only allowed once the Fjord upgrade is active.
+func (algo CompressionAlgo) IsBrotli() bool {
+	return brotliLevels[algo] != 0
+}
+
+func ValidCompressionAlgo(value CompressionAlgo) bool {
+	for _, k := range CompressionAlgos {
+		if k == value {
+			return true
+		}
+	}
+	return false
+}
+
+var brotliLevels = map[CompressionAlgo]int{
+	Brotli:   10,
+	Brotli9:  9,
+	Brotli10: 10,
+	Brotli11: 11,
+}
+
+// ChannelCompressor compresses channel data into an internal buffer,
+// from which the compressed data can be read.
+type ChannelCompressor interface {
+	Write([]byte) (int, error)
+	Flush() error
+	Close() error
+	Reset()
+	Len() int
+	Read([]byte) (int, error)
+	GetCompressed() *bytes.Buffer
+}
+
+// CompressorWriter is the common interface of the zlib and brotli writers.
+type CompressorWriter interface {
+	Write([]byte) (int, error)
+	Flush() error
+	Close() error
+	Reset(io.Writer)
+}
+
+type baseChannelCompressor struct {
+	compressed *bytes.Buffer
+	CompressorWriter
+}
+
+func (bcc *baseChannelCompressor) Len() int {
+	return bcc.compressed.Len()
+}
+
+func (bcc *baseChannelCompressor) Read(p []byte) (int, error) {
+	return bcc.compressed.Read(p)
+}
+
+func (bcc *baseChannelCompressor) GetCompressed() *bytes.Buffer {
+	return bcc.compressed
+}
+
+type zlibCompressor struct {
+	baseChannelCompressor
+}
+
+func (zc *zlibCompressor) Reset() {
+	zc.compressed.Reset()
+	zc.CompressorWriter.Reset(zc.compressed)
+}
+
+type brotliCompressor struct {
+	baseChannelCompressor
+}
+
+func (bc *brotliCompressor) Reset() {
+	bc.compressed.Reset()
+	// brotli channels are prefixed with a version byte, zlib channels are not
+	bc.compressed.WriteByte(ChannelVersionBrotli)
+	bc.CompressorWriter.Reset(bc.compressed)
+}
+
+// NewChannelCompressor creates a ChannelCompressor for the given compression algorithm.
+func NewChannelCompressor(algo CompressionAlgo) (ChannelCompressor, error) {
+	compressed := &bytes.Buffer{}
+	if algo == Zlib {
+		writer, err := zlib.NewWriterLevel(compressed, zlib.BestCompression)
+		if err != nil {
+			return nil, err
+		}
+		return &zlibCompressor{
+			baseChannelCompressor{
+				CompressorWriter: writer,
+				compressed:       compressed,
+			},
+		}, nil
+	} else if level, ok := brotliLevels[algo]; ok {
+		compressed.WriteByte(ChannelVersionBrotli)
+		writer := brotli.NewWriterLevel(compressed, level)
+		return &brotliCompressor{
+			baseChannelCompressor{
+				CompressorWriter: writer,
+				compressed:       compressed,
+			},
+		}, nil
+	} else {
+		return nil, fmt.Errorf("unsupported compression algorithm: %s", algo)
+	}
+}
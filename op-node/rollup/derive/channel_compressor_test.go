@@ -0,0 +1,69 @@
+package derive
+
+import (
+	"bytes"
+	"io"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+)
+
+func TestChannelCompressorRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(0xc0ffee))
+	origins := L1Chain([]uint64{10, 20})
+	batches, _ := randomSingularBatches(rng, 10, 12, origins)
+
+	for _, algo := range CompressionAlgos {
+		algo := algo
+		t.Run(algo.String(), func(t *testing.T) {
+			c, err := NewChannelCompressor(algo)
+			require.NoError(t, err)
+			// write some garbage first, to check that Reset restores the version byte
+			_, err = c.Write(testutils.RandomData(rng, 100))
+			require.NoError(t, err)
+			c.Reset()
+
+			cout, err := NewChannelOut(&testCompressor{c})
+			require.NoError(t, err)
+			for _, batch := range batches {
+				_, err := cout.AddBatch(&BatchData{BatchV1: *batch}, 0)
+				require.NoError(t, err)
+			}
+			require.NoError(t, cout.Close())
+			data, err := io.ReadAll(c)
+			require.NoError(t, err)
+			if algo.IsBrotli() {
+				require.Equal(t, ChannelVersionBrotli, data[0])
+				_, err := BatchReader(bytes.NewReader(data), false)
+				require.ErrorContains(t, err, "before Fjord")
+			} else {
+				require.Contains(t, []byte{ZlibCM8, ZlibCM15}, data[0]&0x0F)
+			}
+
+			next, err := BatchReader(bytes.NewReader(data), true)
+			require.NoError(t, err)
+			for _, batch := range batches {
+				batchData, err := next()
+				require.NoError(t, err)
+				require.Equal(t, batch.ParentHash, batchData.ParentHash)
+				require.Equal(t, batch.Timestamp, batchData.Timestamp)
+				require.Equal(t, len(batch.Transactions), len(batchData.Transactions))
+			}
+			_, err = next()
+			require.ErrorIs(t, err, io.EOF)
+		})
+	}
+}
+
+func TestBatchReaderUnknownVersion(t *testing.T) {
+	_, err := BatchReader(bytes.NewReader([]byte{0x02, 0x00}), true)
+	require.ErrorContains(t, err, "cannot distinguish the compression algo")
+}
+
+func TestNewChannelCompressorInvalid(t *testing.T) {
+	_, err := NewChannelCompressor("lz4")
+	require.ErrorContains(t, err, "unsupported compression algorithm")
+}
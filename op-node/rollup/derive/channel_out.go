@@ -59,12 +59,26 @@ type ChannelOut struct {
 	compress Compressor
 
 	closed bool
+
+	// spanBatch accumulates all batches of the channel into a single span batch,
+	// if the channel was created with NewSpanChannelOut. It is nil for channels of singular batches.
+	spanBatch *SpanBatch
+	// spanTxsLength is the encoded size of the block tx counts and transactions of the span batch
+	spanTxsLength int
+	// genesisTimestamp is the L2 genesis time, to encode the relative timestamp of the span batch
+	genesisTimestamp uint64
 }
 
 func (co *ChannelOut) ID() ChannelID {
 	return co.id
 }
 
+// SetID overrides the randomly generated channel ID. It must be called before any data
+// is added to the channel, e.g. to rebuild a channel that was persisted before.
+func (co *ChannelOut) SetID(id ChannelID) {
+	co.id = id
+}
+
 func NewChannelOut(compress Compressor) (*ChannelOut, error) {
 	c := &ChannelOut{
 		id:        ChannelID{}, // TODO: use GUID here instead of fully random data
@@ -80,12 +94,30 @@ func NewChannelOut(compress Compressor) (*ChannelOut, error) {
 	return c, nil
 }
 
+// NewSpanChannelOut creates a ChannelOut that encodes all added batches into a single span batch.
+// Since the span batch is only encoded as a whole, no frames can be output before the channel is closed.
+// Until then, only the transactions of the added batches are written to the compressor, so that it
+// can estimate when the channel is full.
+func NewSpanChannelOut(compress Compressor, genesisTimestamp uint64) (*ChannelOut, error) {
+	c, err := NewChannelOut(compress)
+	if err != nil {
+		return nil, err
+	}
+	c.spanBatch = &SpanBatch{}
+	c.genesisTimestamp = genesisTimestamp
+	return c, nil
+}
+
 // TODO: reuse ChannelOut for performance
 func (co *ChannelOut) Reset() error {
 	co.frame = 0
 	co.rlpLength = 0
 	co.compress.Reset()
 	co.closed = false
+	if co.spanBatch != nil {
+		co.spanBatch = &SpanBatch{}
+		co.spanTxsLength = 0
+	}
 	_, err := rand.Read(co.id[:])
 	return err
 }
@@ -99,11 +131,11 @@ func (co *ChannelOut) AddBlock(block *types.Block) (uint64, error) {
 		return 0, errors.New("already closed")
 	}
 
-	batch, _, err := BlockToBatch(block)
+	batch, l1Info, err := BlockToBatch(block)
 	if err != nil {
 		return 0, err
 	}
-	return co.AddBatch(batch)
+	return co.AddBatch(batch, l1Info.SequenceNumber)
 }
 
 // AddBatch adds a batch to the channel. It returns the RLP encoded byte size
@@ -113,11 +145,15 @@ func (co *ChannelOut) AddBlock(block *types.Block) (uint64, error) {
 //
 // AddBatch should be used together with BlockToBatch if you need to access the
 // BatchData before adding a block to the channel. It isn't possible to access
-// the batch data with AddBlock.
-func (co *ChannelOut) AddBatch(batch *BatchData) (uint64, error) {
+// the batch data with AddBlock. The sequence number of the block within its epoch
+// is only used by span batch channels.
+func (co *ChannelOut) AddBatch(batch *BatchData, seqNum uint64) (uint64, error) {
 	if co.closed {
 		return 0, errors.New("already closed")
 	}
+	if co.spanBatch != nil {
+		return co.addToSpanBatch(&batch.BatchV1, seqNum)
+	}
 
 	// We encode to a temporary buffer to determine the encoded length to
 	// ensure that the total size of all RLP elements is less than or equal to MAX_RLP_BYTES_PER_CHANNEL
@@ -136,6 +172,58 @@ func (co *ChannelOut) AddBatch(batch *BatchData) (uint64, error) {
 	return uint64(written), err
 }
 
+// addToSpanBatch appends the batch to the span batch of the channel.
+// The span batch is only encoded as a whole when the channel is closed. Adding a batch only
+// writes its transactions to the compressor, so the cost of adding a batch doesn't depend on
+// the number of batches already in the channel.
+func (co *ChannelOut) addToSpanBatch(batch *BatchV1, seqNum uint64) (uint64, error) {
+	blocks := co.spanBatch.Batches
+	if len(blocks) == 0 {
+		if batch.Timestamp < co.genesisTimestamp {
+			return 0, fmt.Errorf("span batch starts at %d, before genesis time %d", batch.Timestamp, co.genesisTimestamp)
+		}
+	} else if prev := blocks[len(blocks)-1].EpochNum; batch.EpochNum != prev && batch.EpochNum != prev+1 {
+		return 0, fmt.Errorf("batch with L1 origin %d does not follow L1 origin %d of the span batch", batch.EpochNum, prev)
+	}
+
+	var buf bytes.Buffer
+	writeSpanBatchTxs(&buf, batch.Transactions)
+	firstTimestamp := batch.Timestamp
+	if len(blocks) > 0 {
+		firstTimestamp = blocks[0].Timestamp
+	}
+	rlpLength := spanBatchRLPLength(firstTimestamp-co.genesisTimestamp, uint64(batch.EpochNum),
+		uint64(len(blocks)+1), co.spanTxsLength+buf.Len())
+	if rlpLength > MaxRLPBytesPerChannel {
+		return 0, fmt.Errorf("could not add %d bytes to channel of %d bytes, max is %d. err: %w",
+			rlpLength-co.rlpLength, co.rlpLength, MaxRLPBytesPerChannel, ErrTooManyRLPBytes)
+	}
+
+	written, err := co.compress.Write(buf.Bytes())
+	if err != nil {
+		return uint64(written), err
+	}
+	co.spanBatch.AppendBatch(batch, seqNum)
+	co.spanTxsLength += buf.Len()
+	co.rlpLength = rlpLength
+	return uint64(written), nil
+}
+
+// writeSpanBatch replaces the contents of the compressor with the complete encoded span batch.
+func (co *ChannelOut) writeSpanBatch() error {
+	rawSpanBatch, err := co.spanBatch.ToRawSpanBatch(co.genesisTimestamp)
+	if err != nil {
+		return fmt.Errorf("could not convert span batch: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := rlp.Encode(&buf, &BatchData{BatchType: SpanBatchType, RawSpanBatch: *rawSpanBatch}); err != nil {
+		return err
+	}
+	co.compress.Reset()
+	_, err = co.compress.Write(buf.Bytes())
+	return err
+}
+
 // InputBytes returns the total amount of RLP-encoded input bytes.
 func (co *ChannelOut) InputBytes() int {
 	return co.rlpLength
@@ -144,7 +232,12 @@ func (co *ChannelOut) InputBytes() int {
 // ReadyBytes returns the number of bytes that the channel out can immediately output into a frame.
 // Use `Flush` or `Close` to move data from the compression buffer into the ready buffer if more bytes
 // are needed. Add blocks may add to the ready buffer, but it is not guaranteed due to the compression stage.
+//
+// Span batch channels have no ready bytes until they are closed.
 func (co *ChannelOut) ReadyBytes() int {
+	if co.spanBatch != nil && !co.closed {
+		return 0
+	}
 	return co.compress.Len()
 }
 
@@ -163,6 +256,11 @@ func (co *ChannelOut) Close() error {
 		return errors.New("already closed")
 	}
 	co.closed = true
+	if co.spanBatch != nil && len(co.spanBatch.Batches) > 0 {
+		if err := co.writeSpanBatch(); err != nil {
+			return err
+		}
+	}
 	return co.compress.Close()
 }
 
@@ -184,6 +282,10 @@ func (co *ChannelOut) OutputFrame(w *bytes.Buffer, maxSize uint64) (uint16, erro
 		return 0, ErrMaxFrameSizeTooSmall
 	}
 
+	if co.spanBatch != nil && !co.closed {
+		return 0, errors.New("span batch channel must be closed before outputting frames")
+	}
+
 	// Copy data from the local buffer into the frame data buffer
 	maxDataSize := maxSize - FrameV0OverHeadSize
 	if maxDataSize > uint64(co.compress.Len()) {
@@ -239,7 +341,7 @@ func BlockToBatch(block *types.Block) (*BatchData, L1BlockInfo, error) {
 	}
 
 	return &BatchData{
-		BatchV1{
+		BatchV1: BatchV1{
 			ParentHash:   block.ParentHash(),
 			EpochNum:     rollup.Epoch(l1Info.Number),
 			EpochHash:    l1Info.BlockHash,
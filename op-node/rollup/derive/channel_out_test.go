@@ -2,7 +2,9 @@ package derive
 
 import (
 	"bytes"
+	"io"
 	"math/big"
+	"math/rand"
 	"testing"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -28,6 +30,22 @@ func (s *nonCompressor) FullErr() error {
 	return nil
 }
 
+// testCompressor wraps a ChannelCompressor into a Compressor that is never full,
+// so that the output can be read back with BatchReader.
+type testCompressor struct {
+	ChannelCompressor
+}
+
+func (c *testCompressor) FullErr() error {
+	return nil
+}
+
+func newTestCompressor(t *testing.T, algo CompressionAlgo) *testCompressor {
+	c, err := NewChannelCompressor(algo)
+	require.NoError(t, err)
+	return &testCompressor{c}
+}
+
 func TestChannelOutAddBlock(t *testing.T) {
 	cout, err := NewChannelOut(&nonCompressor{})
 	require.NoError(t, err)
@@ -155,3 +173,117 @@ func TestBlockToBatchValidity(t *testing.T) {
 	_, _, err := BlockToBatch(block)
 	require.ErrorContains(t, err, "has no transactions")
 }
+
+func TestSpanChannelOut(t *testing.T) {
+	rng := rand.New(rand.NewSource(0x5ba7c7))
+	origins := L1Chain([]uint64{10, 20, 30})
+	batches, seqNums := randomSingularBatches(rng, 8, 12, origins)
+
+	cout, err := NewSpanChannelOut(newTestCompressor(t, Zlib), 10)
+	require.NoError(t, err)
+	for i, batch := range batches {
+		_, err := cout.AddBatch(&BatchData{BatchV1: *batch}, seqNums[i])
+		require.NoError(t, err)
+		require.Zero(t, cout.ReadyBytes(), "open span channel must not have ready bytes")
+
+		// The tracked input size must match the encoding of the span batch so far
+		rawSpanBatch, err := cout.spanBatch.ToRawSpanBatch(10)
+		require.NoError(t, err)
+		encoded, err := rlp.EncodeToBytes(&BatchData{BatchType: SpanBatchType, RawSpanBatch: *rawSpanBatch})
+		require.NoError(t, err)
+		require.Equal(t, len(encoded), cout.InputBytes())
+	}
+
+	var buf bytes.Buffer
+	_, err = cout.OutputFrame(&buf, 1000)
+	require.Error(t, err, "cannot output frames of open span channel")
+
+	require.NoError(t, cout.Close())
+	require.NotZero(t, cout.ReadyBytes())
+
+	var data bytes.Buffer
+	for {
+		buf.Reset()
+		_, err := cout.OutputFrame(&buf, 1000)
+		var f Frame
+		require.NoError(t, f.UnmarshalBinary(&buf))
+		data.Write(f.Data)
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+	}
+
+	next, err := BatchReader(&data, false)
+	require.NoError(t, err)
+	batchData, err := next()
+	require.NoError(t, err)
+	require.Equal(t, SpanBatchType, batchData.BatchType)
+	spanBatch, err := batchData.RawSpanBatch.Derive(2, 10)
+	require.NoError(t, err)
+	require.Equal(t, len(batches), spanBatch.GetBlockCount())
+	for i, batch := range batches {
+		require.Equal(t, batch.Timestamp, spanBatch.GetBlockTimestamp(i))
+		require.Equal(t, len(batch.Transactions), len(spanBatch.GetBlockTransactions(i)))
+	}
+	_, err = next()
+	require.ErrorIs(t, err, io.EOF)
+}
+
+// fullCompressor is a nonCompressor that reports being full once it holds more than limit bytes.
+type fullCompressor struct {
+	nonCompressor
+	limit int
+}
+
+func (c *fullCompressor) Write(p []byte) (int, error) {
+	if err := c.FullErr(); err != nil {
+		return 0, err
+	}
+	return c.nonCompressor.Write(p)
+}
+
+func (c *fullCompressor) FullErr() error {
+	if c.Len() > c.limit {
+		return CompressorFullErr
+	}
+	return nil
+}
+
+func TestSpanChannelOutIncremental(t *testing.T) {
+	rng := rand.New(rand.NewSource(0x5ba7c8))
+	origins := L1Chain([]uint64{10, 20})
+	batches, seqNums := randomSingularBatches(rng, 5, 12, origins)
+	last := len(batches) - 1
+
+	compressor := &fullCompressor{}
+	compressor.limit = 1 << 30
+	cout, err := NewSpanChannelOut(compressor, 10)
+	require.NoError(t, err)
+	for i, batch := range batches[:last] {
+		before := compressor.Len()
+		_, err := cout.AddBatch(&BatchData{BatchV1: *batch}, seqNums[i])
+		require.NoError(t, err)
+		// Only the new block is written to the compressor
+		var buf bytes.Buffer
+		writeSpanBatchTxs(&buf, batch.Transactions)
+		require.Equal(t, before+buf.Len(), compressor.Len())
+	}
+
+	// Once the compressor is full, further batches are rejected and not added to the span batch
+	compressor.limit = compressor.Len() - 1
+	blockCount := cout.spanBatch.GetBlockCount()
+	inputBytes := cout.InputBytes()
+	_, err = cout.AddBatch(&BatchData{BatchV1: *batches[last]}, seqNums[last])
+	require.ErrorIs(t, err, CompressorFullErr)
+	require.Equal(t, blockCount, cout.spanBatch.GetBlockCount())
+	require.Equal(t, inputBytes, cout.InputBytes())
+
+	// Closing the channel replaces the compressor contents with the complete span batch
+	require.NoError(t, cout.Close())
+	rawSpanBatch, err := cout.spanBatch.ToRawSpanBatch(10)
+	require.NoError(t, err)
+	encoded, err := rlp.EncodeToBytes(&BatchData{BatchType: SpanBatchType, RawSpanBatch: *rawSpanBatch})
+	require.NoError(t, err)
+	require.Equal(t, encoded, compressor.Bytes())
+}
@@ -293,6 +293,19 @@ func (eq *EngineQueue) Step(ctx context.Context) error {
 	}
 }
 
+// PendingSafeBlock returns the status of the safe attributes that are queued up
+// to be processed on top of the safe head, if any.
+func (eq *EngineQueue) PendingSafeBlock() *PendingSafeBlockStatus {
+	if eq.safeAttributes == nil {
+		return nil
+	}
+	return &PendingSafeBlockStatus{
+		Parent:    eq.safeAttributes.parent,
+		Timestamp: uint64(eq.safeAttributes.attributes.Timestamp),
+		TxCount:   len(eq.safeAttributes.attributes.Transactions),
+	}
+}
+
 // verifyNewL1Origin checks that the L2 unsafe head still has a L1 origin that is on the canonical chain.
 // If the unsafe head origin is after the new L1 origin it is assumed to still be canonical.
 // The check is only required when moving to a new L1 origin.
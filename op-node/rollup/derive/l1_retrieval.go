@@ -2,16 +2,29 @@ package derive
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"io"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
+// errNoAltDA is returned when alt-DA data is read without an alt-DA input fetcher.
+var errNoAltDA = errors.New("rollup uses alt-DA, but no alt-DA input fetcher is configured")
+
+// noAltDAFetcher is used when the rollup uses alt-DA, but no DA server is available.
+type noAltDAFetcher struct{}
+
+func (noAltDAFetcher) GetInput(context.Context, altda.Keccak256Commitment) ([]byte, error) {
+	return nil, errNoAltDA
+}
+
 type DataAvailabilitySource interface {
-	OpenData(ctx context.Context, id eth.BlockID, batcherAddr common.Address) DataIter
+	OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) DataIter
 }
 
 type NextBlockProvider interface {
@@ -20,20 +33,34 @@ type NextBlockProvider interface {
 	SystemConfig() eth.SystemConfig
 }
 
+// AltDAInputFetcher retrieves the frame data of alt-DA commitments.
+type AltDAInputFetcher interface {
+	// GetInput returns the input of the commitment, verified against the commitment.
+	// It returns altda.ErrNotFound if the input is not available, then the commitment is skipped.
+	GetInput(ctx context.Context, comm altda.Keccak256Commitment) ([]byte, error)
+}
+
 type L1Retrieval struct {
 	log     log.Logger
 	dataSrc DataAvailabilitySource
-	prev    NextBlockProvider
+	// altDA resolves alt-DA commitments, nil if the alt-DA mode is disabled
+	altDA AltDAInputFetcher
+	prev  NextBlockProvider
 
 	datas DataIter
+	// pendingComm is the alt-DA commitment that is being resolved, if any
+	pendingComm *altda.Keccak256Commitment
 }
 
 var _ ResettableStage = (*L1Retrieval)(nil)
 
-func NewL1Retrieval(log log.Logger, dataSrc DataAvailabilitySource, prev NextBlockProvider) *L1Retrieval {
+// NewL1Retrieval creates a new L1 retrieval stage. If altDA is not nil, batcher data that holds
+// an alt-DA commitment is resolved to the committed frame data.
+func NewL1Retrieval(log log.Logger, dataSrc DataAvailabilitySource, altDA AltDAInputFetcher, prev NextBlockProvider) *L1Retrieval {
 	return &L1Retrieval{
 		log:     log,
 		dataSrc: dataSrc,
+		altDA:   altDA,
 		prev:    prev,
 	}
 }
@@ -46,6 +73,9 @@ func (l1r *L1Retrieval) Origin() eth.L1BlockRef {
 // If there is data, it pushes it to the next stage.
 // If there is no more data open ourselves if we are closed or close ourselves if we are open
 func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
+	if l1r.pendingComm != nil {
+		return l1r.resolveCommitment(ctx)
+	}
 	if l1r.datas == nil {
 		next, err := l1r.prev.NextL1Block(ctx)
 		if err == io.EOF {
@@ -53,7 +83,7 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 		} else if err != nil {
 			return nil, err
 		}
-		l1r.datas = l1r.dataSrc.OpenData(ctx, next.ID(), l1r.prev.SystemConfig().BatcherAddr)
+		l1r.datas = l1r.dataSrc.OpenData(ctx, next, l1r.prev.SystemConfig().BatcherAddr)
 	}
 
 	l1r.log.Debug("fetching next piece of data")
@@ -64,16 +94,42 @@ func (l1r *L1Retrieval) NextData(ctx context.Context) ([]byte, error) {
 	} else if err != nil {
 		// CalldataSource appropriately wraps the error so avoid double wrapping errors here.
 		return nil, err
+	} else if l1r.altDA != nil && len(data) > 0 && data[0] == altda.TxDataVersion1 {
+		comm, err := altda.DecodeKeccak256(data[1:])
+		if err != nil {
+			// the data is passed on as is, and dropped like any other invalid frame data
+			l1r.log.Warn("invalid alt-DA commitment", "err", err)
+			return data, nil
+		}
+		l1r.pendingComm = &comm
+		return l1r.resolveCommitment(ctx)
 	} else {
 		return data, nil
 	}
 }
 
+// resolveCommitment fetches the frame data of the pending alt-DA commitment. The commitment
+// stays pending if the data cannot be fetched, so it is retried on the next call to NextData.
+// This includes commitments the DA server does not have: skipping them would make the derived
+// chain depend on the contents of the local DA server, and the fault proof program cannot skip them either.
+func (l1r *L1Retrieval) resolveCommitment(ctx context.Context) ([]byte, error) {
+	input, err := l1r.altDA.GetInput(ctx, *l1r.pendingComm)
+	if errors.Is(err, altda.ErrNotFound) {
+		return nil, NewTemporaryError(fmt.Errorf("alt-DA commitment %s has no input on the DA server: %w", l1r.pendingComm, err))
+	} else if err != nil {
+		return nil, NewTemporaryError(fmt.Errorf("failed to fetch input of alt-DA commitment %s: %w", l1r.pendingComm, err))
+	}
+	l1r.log.Debug("resolved alt-DA commitment", "commitment", l1r.pendingComm, "size", len(input))
+	l1r.pendingComm = nil
+	return input, nil
+}
+
 // Reset re-initializes the L1 Retrieval stage to block of it's `next` progress.
 // Note that we open up the `l1r.datas` here because it is requires to maintain the
 // internal invariants that later propagate up the derivation pipeline.
 func (l1r *L1Retrieval) Reset(ctx context.Context, base eth.L1BlockRef, sysCfg eth.SystemConfig) error {
-	l1r.datas = l1r.dataSrc.OpenData(ctx, base.ID(), sysCfg.BatcherAddr)
+	l1r.datas = l1r.dataSrc.OpenData(ctx, base, sysCfg.BatcherAddr)
+	l1r.pendingComm = nil
 	l1r.log.Info("Reset of L1Retrieval done", "origin", base)
 	return io.EOF
 }
@@ -2,6 +2,7 @@ package derive
 
 import (
 	"context"
+	"errors"
 	"io"
 	"math/rand"
 	"testing"
@@ -14,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/log"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
@@ -35,8 +37,8 @@ type MockDataSource struct {
 	mock.Mock
 }
 
-func (m *MockDataSource) OpenData(ctx context.Context, id eth.BlockID, batcherAddr common.Address) DataIter {
-	out := m.Mock.MethodCalled("OpenData", id, batcherAddr)
+func (m *MockDataSource) OpenData(ctx context.Context, ref eth.L1BlockRef, batcherAddr common.Address) DataIter {
+	out := m.Mock.MethodCalled("OpenData", ref.ID(), batcherAddr)
 	return out[0].(DataIter)
 }
 
@@ -92,7 +94,7 @@ func TestL1RetrievalReset(t *testing.T) {
 	dataSrc.ExpectOpenData(a.ID(), &fakeDataIter{}, l1Cfg.BatcherAddr)
 	defer dataSrc.AssertExpectations(t)
 
-	l1r := NewL1Retrieval(testlog.Logger(t, log.LvlError), dataSrc, nil)
+	l1r := NewL1Retrieval(testlog.Logger(t, log.LvlError), dataSrc, nil, nil)
 
 	// We assert that it opens up the correct data on a reset
 	_ = l1r.Reset(context.Background(), a, l1Cfg)
@@ -149,7 +151,7 @@ func TestL1RetrievalNextData(t *testing.T) {
 			dataSrc := &MockDataSource{}
 			dataSrc.ExpectOpenData(test.prevBlock.ID(), &fakeDataIter{data: test.datas, errs: test.datasErrs}, test.sysCfg.BatcherAddr)
 
-			ret := NewL1Retrieval(testlog.Logger(t, log.LvlCrit), dataSrc, l1t)
+			ret := NewL1Retrieval(testlog.Logger(t, log.LvlCrit), dataSrc, nil, l1t)
 
 			// If prevErr != nil we forced an error while getting data from the previous stage
 			if test.openErr != nil {
@@ -172,3 +174,86 @@ func TestL1RetrievalNextData(t *testing.T) {
 	}
 
 }
+
+type fakeAltDAFetcher struct {
+	inputs map[altda.Keccak256Commitment][]byte
+	err    error
+}
+
+func (f *fakeAltDAFetcher) GetInput(ctx context.Context, comm altda.Keccak256Commitment) ([]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	input, ok := f.inputs[comm]
+	if !ok {
+		return nil, altda.ErrNotFound
+	}
+	return input, nil
+}
+
+// TestL1RetrievalAltDA tests that alt-DA commitments are resolved to their input,
+// and that a commitment is retried if its input cannot be fetched or the DA server does not have it.
+func TestL1RetrievalAltDA(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	a := testutils.RandomBlockRef(rng)
+	sysCfg := eth.SystemConfig{BatcherAddr: common.Address{0x55}}
+
+	input := testutils.RandomData(rng, 100)
+	comm := altda.Keccak256(input)
+	missing := altda.Keccak256(testutils.RandomData(rng, 100))
+	plain := append([]byte{DerivationVersion0}, testutils.RandomData(rng, 10)...)
+	invalid := []byte{altda.TxDataVersion1, 0x01, 0x02}
+
+	l1t := &MockL1Traversal{}
+	l1t.ExpectNextL1Block(a, nil)
+	l1t.ExpectSystemConfig(sysCfg)
+	dataSrc := &MockDataSource{}
+	dataSrc.ExpectOpenData(a.ID(), &fakeDataIter{
+		data: []eth.Data{comm.TxData(), missing.TxData(), plain, invalid, nil},
+		errs: []error{nil, nil, nil, nil, io.EOF},
+	}, sysCfg.BatcherAddr)
+
+	fetcher := &fakeAltDAFetcher{inputs: map[altda.Keccak256Commitment][]byte{}, err: errors.New("unavailable")}
+	ret := NewL1Retrieval(testlog.Logger(t, log.LvlCrit), dataSrc, fetcher, l1t)
+
+	// the input is not available yet, the commitment stays pending
+	data, err := ret.NextData(context.Background())
+	require.Nil(t, data)
+	require.ErrorIs(t, err, ErrTemporary)
+
+	// once available, the pending commitment is resolved to its input
+	fetcher.err = nil
+	fetcher.inputs[comm] = input
+	data, err = ret.NextData(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, input, data)
+
+	// commitments without input on the DA server are not skipped, but retried
+	for i := 0; i < 2; i++ {
+		data, err = ret.NextData(context.Background())
+		require.Nil(t, data)
+		require.ErrorIs(t, err, ErrTemporary)
+		require.ErrorIs(t, err, altda.ErrNotFound)
+	}
+	missingInput := testutils.RandomData(rng, 10)
+	fetcher.inputs[missing] = missingInput
+	data, err = ret.NextData(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, missingInput, data)
+
+	// plain frame data is passed through
+	data, err = ret.NextData(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, plain, data)
+
+	// invalid commitments are passed through, to be dropped by the frame parser
+	data, err = ret.NextData(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, invalid, data)
+
+	_, err = ret.NextData(context.Background())
+	require.ErrorIs(t, err, io.EOF)
+
+	l1t.AssertExpectations(t)
+	dataSrc.AssertExpectations(t)
+}
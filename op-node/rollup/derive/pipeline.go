@@ -77,20 +77,31 @@ type DerivationPipeline struct {
 	traversal *L1Traversal
 	eng       EngineQueueStage
 
+	// Stages that are inspected for the pipeline status
+	bank            *ChannelBank
+	batchQueue      *BatchQueue
+	attributesQueue *AttributesQueue
+
 	metrics Metrics
 }
 
 // NewDerivationPipeline creates a derivation pipeline, which should be reset before use.
-func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, engine Engine, metrics Metrics, syncCfg *sync.Config) *DerivationPipeline {
+// The L1 blobs fetcher is optional, and only required if batches are read from blobs.
+func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetcher, l1Blobs L1BlobsFetcher, altDA AltDAInputFetcher, engine Engine, metrics Metrics, syncCfg *sync.Config) *DerivationPipeline {
+	if !cfg.UseAltDA {
+		altDA = nil
+	} else if altDA == nil {
+		altDA = noAltDAFetcher{}
+	}
 
 	// Pull stages
 	l1Traversal := NewL1Traversal(log, cfg, l1Fetcher)
-	dataSrc := NewDataSourceFactory(log, cfg, l1Fetcher) // auxiliary stage for L1Retrieval
-	l1Src := NewL1Retrieval(log, dataSrc, l1Traversal)
+	dataSrc := NewDataSourceFactory(log, cfg, l1Fetcher, l1Blobs) // auxiliary stage for L1Retrieval
+	l1Src := NewL1Retrieval(log, dataSrc, altDA, l1Traversal)
 	frameQueue := NewFrameQueue(log, l1Src)
 	bank := NewChannelBank(log, cfg, frameQueue, l1Fetcher, metrics)
-	chInReader := NewChannelInReader(log, bank, metrics)
-	batchQueue := NewBatchQueue(log, cfg, chInReader)
+	chInReader := NewChannelInReader(log, cfg, bank, metrics)
+	batchQueue := NewBatchQueue(log, cfg, chInReader, engine)
 	attrBuilder := NewFetchingAttributesBuilder(cfg, l1Fetcher, engine)
 	attributesQueue := NewAttributesQueue(log, cfg, attrBuilder, batchQueue)
 
@@ -111,6 +122,10 @@ func NewDerivationPipeline(log log.Logger, cfg *rollup.Config, l1Fetcher L1Fetch
 		eng:       eng,
 		metrics:   metrics,
 		traversal: l1Traversal,
+
+		bank:            bank,
+		batchQueue:      batchQueue,
+		attributesQueue: attributesQueue,
 	}
 }
 
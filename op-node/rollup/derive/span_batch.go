@@ -0,0 +1,437 @@
+package derive
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/log"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// Span batch format
+//
+// spanBatch := SpanBatchType ++ prefix ++ payload
+// prefix    := rel_timestamp ++ l1_origin_num ++ parent_check ++ l1_origin_check
+// payload   := block_count ++ origin_bits ++ block_tx_counts ++ txs
+// txs       := tx_data_lengths ++ tx_datas
+//
+// rel_timestamp:   uvarint, timestamp of the first block, relative to the L2 genesis time
+// l1_origin_num:   uvarint, L1 origin number of the last block of the span
+// parent_check:    first 20 bytes of the parent hash of the first block of the span
+// l1_origin_check: first 20 bytes of the L1 origin hash of the last block of the span
+// block_count:     uvarint, number of L2 blocks in the span, must be larger than 0
+// origin_bits:     bitlist of block_count bits, padded to full bytes, big-endian.
+//                  Bit i is set if the L1 origin of block i is different from the L1 origin of the block before it.
+// block_tx_counts: one uvarint per block, the number of transactions in the block
+// tx_data_lengths: one uvarint per transaction, the length of the opaque transaction
+// tx_datas:        the concatenated opaque transactions, encoded as in singular batches
+//
+// Timestamps of the blocks in the span are implied by the block time,
+// and L1 origin numbers are implied by l1_origin_num and origin_bits.
+
+// MaxSpanBatchElementCount is the maximum number of blocks or transactions in a span batch.
+// It is bounded by the channel size limit, since every element takes at least one byte to encode.
+const MaxSpanBatchElementCount = MaxRLPBytesPerChannel
+
+var (
+	ErrTooBigSpanBatchSize = errors.New("span batch size limit reached")
+	ErrEmptySpanBatch      = errors.New("span-batch must not be empty")
+)
+
+// RawSpanBatch is the wire-representation of a span batch.
+// It is converted into a SpanBatch, given the rollup configuration, to derive the individual L2 block inputs.
+type RawSpanBatch struct {
+	RelTimestamp  uint64   `json:"rel_timestamp"`
+	L1OriginNum   uint64   `json:"l1_origin_num"`
+	ParentCheck   [20]byte `json:"parent_check"`
+	L1OriginCheck [20]byte `json:"l1_origin_check"`
+
+	BlockCount    uint64          `json:"block_count"`
+	OriginBits    *big.Int        `json:"origin_bits"`
+	BlockTxCounts []uint64        `json:"block_tx_counts"`
+	Txs           []hexutil.Bytes `json:"txs"`
+}
+
+func (b *RawSpanBatch) encode(w *bytes.Buffer) error {
+	if b.BlockCount == 0 {
+		return ErrEmptySpanBatch
+	}
+	if uint64(len(b.BlockTxCounts)) != b.BlockCount {
+		return fmt.Errorf("span batch has %d block tx counts, but %d blocks", len(b.BlockTxCounts), b.BlockCount)
+	}
+	var buf [binary.MaxVarintLen64]byte
+	w.Write(buf[:binary.PutUvarint(buf[:], b.RelTimestamp)])
+	w.Write(buf[:binary.PutUvarint(buf[:], b.L1OriginNum)])
+	w.Write(b.ParentCheck[:])
+	w.Write(b.L1OriginCheck[:])
+	w.Write(buf[:binary.PutUvarint(buf[:], b.BlockCount)])
+
+	bits := make([]byte, (b.BlockCount+7)/8)
+	if b.OriginBits != nil {
+		if b.OriginBits.BitLen() > int(b.BlockCount) {
+			return fmt.Errorf("origin bits length %d exceeds block count %d", b.OriginBits.BitLen(), b.BlockCount)
+		}
+		b.OriginBits.FillBytes(bits)
+	}
+	w.Write(bits)
+
+	totalTxs := uint64(0)
+	for _, count := range b.BlockTxCounts {
+		w.Write(buf[:binary.PutUvarint(buf[:], count)])
+		totalTxs += count
+	}
+	if totalTxs != uint64(len(b.Txs)) {
+		return fmt.Errorf("span batch has %d txs, but block tx counts add up to %d", len(b.Txs), totalTxs)
+	}
+	for _, tx := range b.Txs {
+		w.Write(buf[:binary.PutUvarint(buf[:], uint64(len(tx)))])
+	}
+	for _, tx := range b.Txs {
+		w.Write(tx)
+	}
+	return nil
+}
+
+// writeSpanBatchTxs writes the block tx count, tx data lengths and tx datas of a single block.
+// The encoding of a span batch groups these fields of all blocks together instead, but the size is the same.
+func writeSpanBatchTxs(w *bytes.Buffer, txs []hexutil.Bytes) {
+	var buf [binary.MaxVarintLen64]byte
+	w.Write(buf[:binary.PutUvarint(buf[:], uint64(len(txs)))])
+	for _, tx := range txs {
+		w.Write(buf[:binary.PutUvarint(buf[:], uint64(len(tx)))])
+	}
+	for _, tx := range txs {
+		w.Write(tx)
+	}
+}
+
+// spanBatchRLPLength returns the size of the RLP encoded BatchData of a span batch, given the prefix
+// values, the block count and the combined size of the block tx counts, tx data lengths and tx datas.
+func spanBatchRLPLength(relTimestamp uint64, l1OriginNum uint64, blockCount uint64, txsLength int) int {
+	var buf [binary.MaxVarintLen64]byte
+	size := 1 + binary.PutUvarint(buf[:], relTimestamp) + binary.PutUvarint(buf[:], l1OriginNum) + 20 + 20 +
+		binary.PutUvarint(buf[:], blockCount) + int((blockCount+7)/8) + txsLength
+	// RLP string header
+	if size < 56 {
+		return 1 + size
+	}
+	header := 1
+	for l := size; l > 0; l >>= 8 {
+		header++
+	}
+	return header + size
+}
+
+// readCount reads a uvarint element count, and checks that it does not exceed the remaining input,
+// to avoid allocations driven by malicious inputs.
+func readCount(r *bytes.Reader, name string) (uint64, error) {
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read %s: %w", name, err)
+	}
+	if count > MaxSpanBatchElementCount || count > uint64(r.Len()) {
+		return 0, fmt.Errorf("%s %d: %w", name, count, ErrTooBigSpanBatchSize)
+	}
+	return count, nil
+}
+
+func (b *RawSpanBatch) decode(r *bytes.Reader) error {
+	if r.Len() > MaxRLPBytesPerChannel {
+		return ErrTooBigSpanBatchSize
+	}
+	var err error
+	if b.RelTimestamp, err = binary.ReadUvarint(r); err != nil {
+		return fmt.Errorf("failed to read rel timestamp: %w", err)
+	}
+	if b.L1OriginNum, err = binary.ReadUvarint(r); err != nil {
+		return fmt.Errorf("failed to read l1 origin num: %w", err)
+	}
+	if _, err := io.ReadFull(r, b.ParentCheck[:]); err != nil {
+		return fmt.Errorf("failed to read parent check: %w", err)
+	}
+	if _, err := io.ReadFull(r, b.L1OriginCheck[:]); err != nil {
+		return fmt.Errorf("failed to read l1 origin check: %w", err)
+	}
+	if b.BlockCount, err = readCount(r, "block count"); err != nil {
+		return err
+	}
+	if b.BlockCount == 0 {
+		return ErrEmptySpanBatch
+	}
+
+	bits := make([]byte, (b.BlockCount+7)/8)
+	if _, err := io.ReadFull(r, bits); err != nil {
+		return fmt.Errorf("failed to read origin bits: %w", err)
+	}
+	b.OriginBits = new(big.Int).SetBytes(bits)
+	if b.OriginBits.BitLen() > int(b.BlockCount) {
+		return errors.New("invalid origin bits: bits set beyond block count")
+	}
+
+	b.BlockTxCounts = make([]uint64, 0, b.BlockCount)
+	totalTxs := uint64(0)
+	for i := uint64(0); i < b.BlockCount; i++ {
+		count, err := readCount(r, "block tx count")
+		if err != nil {
+			return err
+		}
+		totalTxs += count
+		if totalTxs > MaxSpanBatchElementCount || totalTxs > uint64(r.Len()) {
+			return fmt.Errorf("total tx count %d: %w", totalTxs, ErrTooBigSpanBatchSize)
+		}
+		b.BlockTxCounts = append(b.BlockTxCounts, count)
+	}
+
+	txLengths := make([]uint64, 0, totalTxs)
+	for i := uint64(0); i < totalTxs; i++ {
+		length, err := readCount(r, "tx data length")
+		if err != nil {
+			return err
+		}
+		txLengths = append(txLengths, length)
+	}
+	b.Txs = make([]hexutil.Bytes, 0, totalTxs)
+	for _, length := range txLengths {
+		tx := make([]byte, length)
+		if _, err := io.ReadFull(r, tx); err != nil {
+			return fmt.Errorf("failed to read tx data: %w", err)
+		}
+		b.Txs = append(b.Txs, tx)
+	}
+	if r.Len() != 0 {
+		return fmt.Errorf("span batch has %d trailing bytes", r.Len())
+	}
+	return nil
+}
+
+// Derive converts the RawSpanBatch into a SpanBatch, which carries the inputs of each individual L2 block.
+func (b *RawSpanBatch) Derive(blockTime, genesisTimestamp uint64) (*SpanBatch, error) {
+	if b.BlockCount == 0 {
+		return nil, ErrEmptySpanBatch
+	}
+	if uint64(len(b.BlockTxCounts)) != b.BlockCount {
+		return nil, fmt.Errorf("span batch has %d block tx counts, but %d blocks", len(b.BlockTxCounts), b.BlockCount)
+	}
+	originBits := b.OriginBits
+	if originBits == nil {
+		originBits = new(big.Int)
+	}
+
+	// walk back from the last block to compute the L1 origin number of every block
+	epochs := make([]uint64, b.BlockCount)
+	epoch := b.L1OriginNum
+	for i := int(b.BlockCount) - 1; i >= 0; i-- {
+		epochs[i] = epoch
+		if i > 0 && originBits.Bit(i) == 1 {
+			if epoch == 0 {
+				return nil, errors.New("invalid origin bits: L1 origin number underflow")
+			}
+			epoch--
+		}
+	}
+
+	out := &SpanBatch{
+		ParentCheck:        b.ParentCheck,
+		L1OriginCheck:      b.L1OriginCheck,
+		FirstOriginChanged: originBits.Bit(0) == 1,
+		Batches:            make([]*SpanBatchElement, 0, b.BlockCount),
+	}
+	txIdx := uint64(0)
+	for i := uint64(0); i < b.BlockCount; i++ {
+		count := b.BlockTxCounts[i]
+		if txIdx+count > uint64(len(b.Txs)) {
+			return nil, fmt.Errorf("span batch has %d txs, but block tx counts need more", len(b.Txs))
+		}
+		out.Batches = append(out.Batches, &SpanBatchElement{
+			EpochNum:     rollup.Epoch(epochs[i]),
+			Timestamp:    genesisTimestamp + b.RelTimestamp + blockTime*i,
+			Transactions: b.Txs[txIdx : txIdx+count],
+		})
+		txIdx += count
+	}
+	if txIdx != uint64(len(b.Txs)) {
+		return nil, fmt.Errorf("span batch has %d txs, but block tx counts add up to %d", len(b.Txs), txIdx)
+	}
+	return out, nil
+}
+
+// SpanBatchElement is the input to build a single L2 block of a span batch.
+// It is similar to BatchV1, but without the parent hash and the L1 origin hash,
+// which are implied by the span batch checks.
+type SpanBatchElement struct {
+	EpochNum     rollup.Epoch
+	Timestamp    uint64
+	Transactions []hexutil.Bytes
+}
+
+// SpanBatch is the derived form of a RawSpanBatch, with the inputs of each individual L2 block.
+type SpanBatch struct {
+	// First 20 bytes of the parent hash of the first block
+	ParentCheck [20]byte
+	// First 20 bytes of the L1 origin hash of the last block
+	L1OriginCheck [20]byte
+	// FirstOriginChanged is true if the first block of the span starts a new epoch
+	FirstOriginChanged bool
+	// Inputs of the L2 blocks, in order
+	Batches []*SpanBatchElement
+}
+
+var _ Batch = (*SpanBatch)(nil)
+
+// GetBatchType returns SpanBatchType.
+func (b *SpanBatch) GetBatchType() int {
+	return SpanBatchType
+}
+
+// GetTimestamp returns the timestamp of the first block in the span.
+func (b *SpanBatch) GetTimestamp() uint64 {
+	return b.Batches[0].Timestamp
+}
+
+// LogContext creates a new log context that contains information of the batch.
+func (b *SpanBatch) LogContext(log log.Logger) log.Logger {
+	if len(b.Batches) == 0 {
+		return log.New("block_count", 0)
+	}
+	return log.New(
+		"batch_type", "SpanBatch",
+		"batch_timestamp", b.Batches[0].Timestamp,
+		"parent_check", hexutil.Bytes(b.ParentCheck[:]),
+		"origin_check", hexutil.Bytes(b.L1OriginCheck[:]),
+		"start_epoch_number", b.GetStartEpochNum(),
+		"end_epoch_number", b.GetBlockEpochNum(len(b.Batches)-1),
+		"block_count", len(b.Batches),
+	)
+}
+
+// GetStartEpochNum returns the L1 origin number of the first block in the span.
+func (b *SpanBatch) GetStartEpochNum() rollup.Epoch {
+	return b.Batches[0].EpochNum
+}
+
+// GetBlockCount returns the number of blocks in the span.
+func (b *SpanBatch) GetBlockCount() int {
+	return len(b.Batches)
+}
+
+// GetBlockTimestamp returns the timestamp of the block at the given index in the span.
+func (b *SpanBatch) GetBlockTimestamp(i int) uint64 {
+	return b.Batches[i].Timestamp
+}
+
+// GetBlockEpochNum returns the L1 origin number of the block at the given index in the span.
+func (b *SpanBatch) GetBlockEpochNum(i int) uint64 {
+	return uint64(b.Batches[i].EpochNum)
+}
+
+// GetBlockTransactions returns the encoded transactions of the block at the given index in the span.
+func (b *SpanBatch) GetBlockTransactions(i int) []hexutil.Bytes {
+	return b.Batches[i].Transactions
+}
+
+// CheckParentHash checks if the given hash matches the parent check of the span.
+func (b *SpanBatch) CheckParentHash(hash common.Hash) bool {
+	return bytes.Equal(b.ParentCheck[:], hash[:20])
+}
+
+// CheckOriginHash checks if the given hash matches the L1 origin check of the span.
+func (b *SpanBatch) CheckOriginHash(hash common.Hash) bool {
+	return bytes.Equal(b.L1OriginCheck[:], hash[:20])
+}
+
+// AppendBatch appends the given singular batch to the end of the span.
+// The sequence number of the batch's L2 block is used to flag whether the first block of the span starts a new epoch.
+// The caller is responsible for only appending consecutive blocks.
+func (b *SpanBatch) AppendBatch(batch *BatchV1, seqNum uint64) {
+	if len(b.Batches) == 0 {
+		copy(b.ParentCheck[:], batch.ParentHash[:20])
+		b.FirstOriginChanged = seqNum == 0
+	}
+	copy(b.L1OriginCheck[:], batch.EpochHash[:20])
+	b.Batches = append(b.Batches, &SpanBatchElement{
+		EpochNum:     batch.EpochNum,
+		Timestamp:    batch.Timestamp,
+		Transactions: batch.Transactions,
+	})
+}
+
+// ToRawSpanBatch converts the SpanBatch into its wire-representation.
+// The genesis timestamp is needed to encode the relative timestamp of the span.
+func (b *SpanBatch) ToRawSpanBatch(genesisTimestamp uint64) (*RawSpanBatch, error) {
+	if len(b.Batches) == 0 {
+		return nil, ErrEmptySpanBatch
+	}
+	first, last := b.Batches[0], b.Batches[len(b.Batches)-1]
+	if first.Timestamp < genesisTimestamp {
+		return nil, fmt.Errorf("span batch starts at %d, before genesis time %d", first.Timestamp, genesisTimestamp)
+	}
+	raw := &RawSpanBatch{
+		RelTimestamp:  first.Timestamp - genesisTimestamp,
+		L1OriginNum:   uint64(last.EpochNum),
+		ParentCheck:   b.ParentCheck,
+		L1OriginCheck: b.L1OriginCheck,
+		BlockCount:    uint64(len(b.Batches)),
+		OriginBits:    new(big.Int),
+		BlockTxCounts: make([]uint64, 0, len(b.Batches)),
+	}
+	for i, batch := range b.Batches {
+		if i == 0 {
+			if b.FirstOriginChanged {
+				raw.OriginBits.SetBit(raw.OriginBits, 0, 1)
+			}
+		} else {
+			prev := b.Batches[i-1]
+			switch batch.EpochNum {
+			case prev.EpochNum:
+			case prev.EpochNum + 1:
+				raw.OriginBits.SetBit(raw.OriginBits, i, 1)
+			default:
+				return nil, fmt.Errorf("block %d of span batch has L1 origin %d, which does not follow %d", i, batch.EpochNum, prev.EpochNum)
+			}
+		}
+		raw.BlockTxCounts = append(raw.BlockTxCounts, uint64(len(batch.Transactions)))
+		raw.Txs = append(raw.Txs, batch.Transactions...)
+	}
+	return raw, nil
+}
+
+// GetSingularBatches converts the blocks of the span that build past the given L2 safe head into singular batches.
+// The L1 origin hashes are looked up in the given L1 origins, which must cover the full range of the span.
+// The parent hash of the returned batches is left empty: the parent check of the span covers the first block,
+// and each following block builds on the block derived before it.
+func (b *SpanBatch) GetSingularBatches(l1Origins []eth.L1BlockRef, l2SafeHead eth.L2BlockRef) ([]*BatchV1, error) {
+	var batches []*BatchV1
+	originIdx := 0
+	for _, elem := range b.Batches {
+		if elem.Timestamp <= l2SafeHead.Time {
+			continue
+		}
+		batch := &BatchV1{
+			EpochNum:     elem.EpochNum,
+			Timestamp:    elem.Timestamp,
+			Transactions: elem.Transactions,
+		}
+		found := false
+		for i := originIdx; i < len(l1Origins); i++ {
+			if l1Origins[i].Number == uint64(elem.EpochNum) {
+				originIdx = i
+				batch.EpochHash = l1Origins[i].Hash
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("unable to find L1 origin for the epoch number: %d", elem.EpochNum)
+		}
+		batches = append(batches, batch)
+	}
+	return batches, nil
+}
@@ -0,0 +1,177 @@
+package derive
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/testutils"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// randomSingularBatches creates consecutive batches that build on each other,
+// starting at the given timestamp and L1 origin, advancing the origin randomly.
+func randomSingularBatches(rng *rand.Rand, count int, timestamp uint64, origins []eth.L1BlockRef) ([]*BatchV1, []uint64) {
+	var batches []*BatchV1
+	var seqNums []uint64
+	originIdx := 0
+	seqNum := uint64(0)
+	parentHash := testutils.RandomHash(rng)
+	for i := 0; i < count; i++ {
+		if i > 0 && originIdx+1 < len(origins) && testutils.RandomBool(rng) {
+			originIdx++
+			seqNum = 0
+		}
+		var txs []hexutil.Bytes
+		for j := 0; j < rng.Intn(4); j++ {
+			txs = append(txs, append([]byte{0x02}, testutils.RandomData(rng, 1+rng.Intn(100))...))
+		}
+		batches = append(batches, &BatchV1{
+			ParentHash:   parentHash,
+			EpochNum:     rollup.Epoch(origins[originIdx].Number),
+			EpochHash:    origins[originIdx].Hash,
+			Timestamp:    timestamp + uint64(i)*2,
+			Transactions: txs,
+		})
+		seqNums = append(seqNums, seqNum)
+		seqNum++
+		parentHash = testutils.RandomHash(rng)
+	}
+	return batches, seqNums
+}
+
+func TestSpanBatchRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(0x5ba7c4))
+	origins := L1Chain([]uint64{10, 20, 30, 40, 50})
+	batches, seqNums := randomSingularBatches(rng, 30, 12, origins)
+
+	var spanBatch SpanBatch
+	for i, batch := range batches {
+		spanBatch.AppendBatch(batch, seqNums[i])
+	}
+	require.Equal(t, len(batches), spanBatch.GetBlockCount())
+	require.True(t, spanBatch.CheckParentHash(batches[0].ParentHash))
+	require.True(t, spanBatch.CheckOriginHash(batches[len(batches)-1].EpochHash))
+
+	raw, err := spanBatch.ToRawSpanBatch(10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), raw.RelTimestamp)
+
+	// encode and decode through the typed batch encoding
+	data := &BatchData{BatchType: SpanBatchType, RawSpanBatch: *raw}
+	enc, err := data.MarshalBinary()
+	require.NoError(t, err)
+	require.Equal(t, byte(SpanBatchType), enc[0])
+	var dec BatchData
+	require.NoError(t, dec.UnmarshalBinary(enc))
+	require.Equal(t, SpanBatchType, dec.BatchType)
+
+	derived, err := dec.RawSpanBatch.Derive(2, 10)
+	require.NoError(t, err)
+	require.Equal(t, spanBatch.FirstOriginChanged, derived.FirstOriginChanged)
+	require.Equal(t, spanBatch.ParentCheck, derived.ParentCheck)
+	require.Equal(t, spanBatch.L1OriginCheck, derived.L1OriginCheck)
+	require.Equal(t, len(spanBatch.Batches), len(derived.Batches))
+	for i := range spanBatch.Batches {
+		require.Equal(t, spanBatch.Batches[i].EpochNum, derived.Batches[i].EpochNum, "epoch of block %d", i)
+		require.Equal(t, spanBatch.Batches[i].Timestamp, derived.Batches[i].Timestamp, "timestamp of block %d", i)
+		require.Equal(t, len(spanBatch.Batches[i].Transactions), len(derived.Batches[i].Transactions), "txs of block %d", i)
+		for j := range spanBatch.Batches[i].Transactions {
+			require.Equal(t, spanBatch.Batches[i].Transactions[j], derived.Batches[i].Transactions[j])
+		}
+	}
+}
+
+func TestSpanBatchRLPStream(t *testing.T) {
+	rng := rand.New(rand.NewSource(0x5ba7c5))
+	origins := L1Chain([]uint64{10, 20, 30})
+	batches, seqNums := randomSingularBatches(rng, 5, 12, origins)
+	var spanBatch SpanBatch
+	for i, batch := range batches {
+		spanBatch.AppendBatch(batch, seqNums[i])
+	}
+	raw, err := spanBatch.ToRawSpanBatch(10)
+	require.NoError(t, err)
+
+	// span batches and singular batches can be mixed in the same RLP stream
+	var buf bytes.Buffer
+	require.NoError(t, rlp.Encode(&buf, &BatchData{BatchType: SpanBatchType, RawSpanBatch: *raw}))
+	require.NoError(t, rlp.Encode(&buf, &BatchData{BatchV1: *batches[0]}))
+
+	stream := rlp.NewStream(&buf, MaxRLPBytesPerChannel)
+	var first, second BatchData
+	require.NoError(t, stream.Decode(&first))
+	require.NoError(t, stream.Decode(&second))
+	require.Equal(t, SpanBatchType, first.BatchType)
+	require.Equal(t, raw.BlockCount, first.BlockCount)
+	require.Equal(t, BatchV1Type, second.BatchType)
+	require.Equal(t, *batches[0], second.BatchV1)
+}
+
+func TestSpanBatchDecodeInvalid(t *testing.T) {
+	t.Run("empty", func(t *testing.T) {
+		raw := RawSpanBatch{BlockCount: 0}
+		var buf bytes.Buffer
+		require.ErrorIs(t, raw.encode(&buf), ErrEmptySpanBatch)
+
+		var dec BatchData
+		// rel timestamp, origin num, checks, block count of 0
+		input := append([]byte{SpanBatchType, 0, 0}, make([]byte, 40)...)
+		input = append(input, 0)
+		require.ErrorIs(t, dec.UnmarshalBinary(input), ErrEmptySpanBatch)
+	})
+	t.Run("too many blocks", func(t *testing.T) {
+		var dec BatchData
+		input := append([]byte{SpanBatchType, 0, 0}, make([]byte, 40)...)
+		input = append(input, 0xff, 0xff, 0x03) // block count larger than remaining input
+		require.ErrorIs(t, dec.UnmarshalBinary(input), ErrTooBigSpanBatchSize)
+	})
+	t.Run("trailing data", func(t *testing.T) {
+		raw := RawSpanBatch{BlockCount: 1, OriginBits: new(big.Int), BlockTxCounts: []uint64{0}}
+		var buf bytes.Buffer
+		buf.WriteByte(SpanBatchType)
+		require.NoError(t, raw.encode(&buf))
+		buf.WriteByte(0)
+		var dec BatchData
+		require.ErrorContains(t, dec.UnmarshalBinary(buf.Bytes()), "trailing bytes")
+	})
+	t.Run("origin underflow", func(t *testing.T) {
+		raw := RawSpanBatch{BlockCount: 2, OriginBits: big.NewInt(2), BlockTxCounts: []uint64{0, 0}}
+		_, err := raw.Derive(2, 0)
+		require.ErrorContains(t, err, "underflow")
+	})
+}
+
+func TestSpanBatchGetSingularBatches(t *testing.T) {
+	rng := rand.New(rand.NewSource(0x5ba7c6))
+	origins := L1Chain([]uint64{10, 20, 30, 40})
+	batches, seqNums := randomSingularBatches(rng, 10, 12, origins)
+	var spanBatch SpanBatch
+	for i, batch := range batches {
+		spanBatch.AppendBatch(batch, seqNums[i])
+	}
+
+	// the first 3 blocks are already safe
+	safeHead := eth.L2BlockRef{Time: batches[2].Timestamp}
+	singular, err := spanBatch.GetSingularBatches(origins, safeHead)
+	require.NoError(t, err)
+	require.Len(t, singular, 7)
+	for i, batch := range singular {
+		expected := batches[i+3]
+		require.Equal(t, expected.Timestamp, batch.Timestamp)
+		require.Equal(t, expected.EpochNum, batch.EpochNum)
+		require.Equal(t, expected.EpochHash, batch.EpochHash)
+		require.Equal(t, expected.Transactions, batch.Transactions)
+	}
+
+	_, err = spanBatch.GetSingularBatches(origins[:1], eth.L2BlockRef{})
+	if batches[len(batches)-1].EpochNum != 0 {
+		require.ErrorContains(t, err, "unable to find L1 origin")
+	}
+}
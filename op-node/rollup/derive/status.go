@@ -0,0 +1,113 @@
+package derive
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// DerivationStatus is a snapshot of the internal state of the derivation pipeline,
+// to inspect why derivation may be stalled.
+type DerivationStatus struct {
+	// Resetting is true if the pipeline is in the process of resetting its stages.
+	Resetting bool `json:"resetting"`
+	// Stages are the stages of the pipeline, in the order they are reset in.
+	Stages []StageStatus `json:"stages"`
+	// Channels are the channels buffered in the channel bank, in FIFO order.
+	Channels []ChannelStatus `json:"channels"`
+	// Epochs are the L1 blocks tracked by the batch queue, the first being the current epoch.
+	Epochs []eth.L1BlockRef `json:"epochs"`
+	// Batches are the batches buffered in the batch queue, in order of inclusion.
+	Batches []BatchStatus `json:"batches"`
+	// DroppedBatches are the batches most recently dropped by the batch queue, oldest first.
+	DroppedBatches []BatchStatus `json:"dropped_batches"`
+	// SpanBatchRemaining is the number of blocks of the last accepted span batch that are not yet processed.
+	SpanBatchRemaining int `json:"span_batch_remaining"`
+	// PendingBatch is the batch that the attributes queue is turning into payload attributes, if any.
+	PendingBatch *BatchStatus `json:"pending_batch,omitempty"`
+	// PendingSafeBlock is the block that the engine queue is about to derive on top of the safe head, if any.
+	PendingSafeBlock *PendingSafeBlockStatus `json:"pending_safe_block,omitempty"`
+	SafeL2           eth.L2BlockRef          `json:"safe_l2"`
+	UnsafeL2         eth.L2BlockRef          `json:"unsafe_l2"`
+}
+
+// StageStatus is the status of a single stage of the derivation pipeline.
+type StageStatus struct {
+	Name   string         `json:"name"`
+	Origin eth.L1BlockRef `json:"origin"`
+}
+
+// ChannelStatus is the status of a channel buffered in the channel bank.
+type ChannelStatus struct {
+	ID ChannelID `json:"id"`
+	// OpenBlock is the L1 block that included the first frame of the channel.
+	OpenBlock eth.L1BlockRef `json:"open_block"`
+	// HighestL1InclusionBlock is the latest L1 block that included a frame of the channel.
+	HighestL1InclusionBlock eth.L1BlockRef `json:"highest_l1_inclusion_block"`
+	// Timeout is the L1 block number after which the channel times out.
+	Timeout uint64 `json:"timeout"`
+	Frames  int    `json:"frames"`
+	// HighestFrameNumber is the highest frame number seen.
+	HighestFrameNumber uint16 `json:"highest_frame_number"`
+	// Closed is true if the last frame was seen, with EndFrameNumber as its frame number.
+	Closed         bool   `json:"closed"`
+	EndFrameNumber uint16 `json:"end_frame_number"`
+	// Ready is true if all frames were seen, and the channel can be read.
+	Ready bool   `json:"ready"`
+	Size  uint64 `json:"size"`
+}
+
+// BatchStatus is the status of a batch in the batch queue.
+type BatchStatus struct {
+	Type             int            `json:"type"`
+	Timestamp        uint64         `json:"timestamp"`
+	L1InclusionBlock eth.L1BlockRef `json:"l1_inclusion_block"`
+	// Validity is the outcome of the last validity check of the batch, if it was checked.
+	Validity string `json:"validity,omitempty"`
+	// Reason is the rule of the last validity check of the batch
+	// that explains why the batch is pending or dropped.
+	Reason string `json:"reason,omitempty"`
+}
+
+// PendingSafeBlockStatus describes the payload attributes that are processed on top of the safe head.
+type PendingSafeBlockStatus struct {
+	Parent    eth.L2BlockRef `json:"parent"`
+	Timestamp uint64         `json:"timestamp"`
+	TxCount   int            `json:"tx_count"`
+}
+
+// stageName returns a readable name of a pipeline stage.
+func stageName(stage any) string {
+	return strings.TrimPrefix(fmt.Sprintf("%T", stage), "*derive.")
+}
+
+// Status returns a snapshot of the internal state of the pipeline.
+// It must not be called concurrently with any other pipeline method.
+func (dp *DerivationPipeline) Status() *DerivationStatus {
+	status := &DerivationStatus{
+		Resetting: dp.resetting < len(dp.stages),
+		SafeL2:    dp.eng.SafeL2Head(),
+		UnsafeL2:  dp.eng.UnsafeL2Head(),
+	}
+	for _, stage := range dp.stages {
+		if s, ok := stage.(interface{ Origin() eth.L1BlockRef }); ok {
+			status.Stages = append(status.Stages, StageStatus{Name: stageName(stage), Origin: s.Origin()})
+		}
+	}
+	if dp.bank != nil {
+		status.Channels = dp.bank.Status()
+	}
+	if dp.batchQueue != nil {
+		dp.batchQueue.fillStatus(status)
+	}
+	if dp.attributesQueue != nil {
+		status.PendingBatch = dp.attributesQueue.PendingBatch()
+	}
+	if eq, ok := dp.eng.(interface {
+		PendingSafeBlock() *PendingSafeBlockStatus
+	}); ok {
+		status.PendingSafeBlock = eq.PendingSafeBlock()
+	}
+	return status
+}
@@ -516,6 +516,21 @@ func (s *Driver) BlockRefWithStatus(ctx context.Context, num uint64) (eth.L2Bloc
 	}
 }
 
+// DerivationStatus blocks the driver event loop and captures a snapshot of the internal state
+// of the derivation pipeline.
+// If the event loop is too busy and the context expires, a context error is returned.
+func (s *Driver) DerivationStatus(ctx context.Context) (*derive.DerivationStatus, error) {
+	wait := make(chan struct{})
+	select {
+	case s.stateReq <- wait:
+		resp := s.derivation.Status()
+		<-wait
+		return resp, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
 // deferJSONString helps avoid a JSON-encoding performance hit if the snapshot logger does not run
 type deferJSONString struct {
 	x any
@@ -0,0 +1,123 @@
+package rollup
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrForksOutOfOrder = errors.New("network upgrades are not scheduled in order")
+	ErrUnknownFork     = errors.New("unknown network upgrade")
+)
+
+// ForkName identifies a timestamp-based network upgrade of the rollup.
+type ForkName string
+
+const (
+	Regolith ForkName = "regolith"
+	Canyon   ForkName = "canyon"
+	Delta    ForkName = "delta"
+	Fjord    ForkName = "fjord"
+)
+
+// Title returns the capitalized fork name, as used in human-readable output.
+func (n ForkName) Title() string {
+	if n == "" {
+		return ""
+	}
+	return strings.ToUpper(string(n[:1])) + string(n[1:])
+}
+
+// Fork defines a timestamp-based network upgrade, and where its activation time is configured.
+type Fork struct {
+	Name ForkName
+	// time returns the configured activation time of the fork, which can be read and overridden.
+	time func(c *Config) **uint64
+}
+
+// Forks is the schedule of the post-Bedrock network upgrades, in activation order.
+// A new network upgrade only has to be added here, and to the Config, to be part of the
+// config validation, the config description and the op-node override flags.
+var Forks = []Fork{
+	{Name: Regolith, time: func(c *Config) **uint64 { return &c.RegolithTime }},
+	{Name: Canyon, time: func(c *Config) **uint64 { return &c.CanyonTime }},
+	{Name: Delta, time: func(c *Config) **uint64 { return &c.DeltaTime }},
+	{Name: Fjord, time: func(c *Config) **uint64 { return &c.FjordTime }},
+}
+
+// Time returns the activation time of the fork in the given config, or nil if it is not scheduled.
+func (f Fork) Time(c *Config) *uint64 {
+	return *f.time(c)
+}
+
+// SetTime sets the activation time of the fork in the given config. A nil time unschedules the fork.
+func (f Fork) SetTime(c *Config, t *uint64) {
+	*f.time(c) = t
+}
+
+// forkByName returns the fork with the given name, and whether it is part of the Forks schedule.
+func forkByName(name ForkName) (Fork, bool) {
+	for _, fork := range Forks {
+		if fork.Name == name {
+			return fork, true
+		}
+	}
+	return Fork{}, false
+}
+
+// ForkTime returns the activation time of the given fork, or nil if the fork is not scheduled
+// or unknown.
+func (c *Config) ForkTime(name ForkName) *uint64 {
+	fork, ok := forkByName(name)
+	if !ok {
+		return nil
+	}
+	return fork.Time(c)
+}
+
+// SetForkTime sets the activation time of the given fork. A nil time unschedules the fork.
+// It returns ErrUnknownFork if the fork is not part of the Forks schedule.
+func (c *Config) SetForkTime(name ForkName, t *uint64) error {
+	fork, ok := forkByName(name)
+	if !ok {
+		return fmt.Errorf("%w: %q", ErrUnknownFork, name)
+	}
+	fork.SetTime(c, t)
+	return nil
+}
+
+// IsForkActive returns true if the given fork is active at or past the given L2 timestamp.
+func (c *Config) IsForkActive(name ForkName, timestamp uint64) bool {
+	t := c.ForkTime(name)
+	return t != nil && timestamp >= *t
+}
+
+// IsForkActivationBlock returns true if the L2 block at the given timestamp is the first block
+// of the given fork, i.e. the fork is active at the block but not at its parent.
+// The genesis block is never an activation block.
+func (c *Config) IsForkActivationBlock(name ForkName, l2BlockTime uint64) bool {
+	return c.IsForkActive(name, l2BlockTime) &&
+		l2BlockTime >= c.BlockTime &&
+		!c.IsForkActive(name, l2BlockTime-c.BlockTime)
+}
+
+// CheckForkOrder verifies that the scheduled forks activate in the order of the Forks schedule.
+// Forks that are not scheduled are skipped.
+func (c *Config) CheckForkOrder() error {
+	var prev *Fork
+	for i := range Forks {
+		fork := &Forks[i]
+		t := fork.Time(c)
+		if t == nil {
+			continue
+		}
+		if prev != nil {
+			if prevTime := prev.Time(c); *prevTime > *t {
+				return fmt.Errorf("%w: %s (%d) activates after %s (%d)", ErrForksOutOfOrder, prev.Name.Title(), *prevTime, fork.Name.Title(), *t)
+			}
+		}
+		prev = fork
+	}
+	return nil
+}
@@ -0,0 +1,142 @@
+package rollup
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func u64(v uint64) *uint64 {
+	return &v
+}
+
+// TestForkSchedule tests that the fork schedule refers to the matching config fields.
+func TestForkSchedule(t *testing.T) {
+	config := randConfig()
+	for i, fork := range Forks {
+		require.NoError(t, config.SetForkTime(fork.Name, u64(uint64(i+10))))
+	}
+	require.Equal(t, u64(10), config.RegolithTime)
+	require.Equal(t, u64(11), config.CanyonTime)
+	require.Equal(t, u64(12), config.DeltaTime)
+	require.Equal(t, u64(13), config.FjordTime)
+	for i, fork := range Forks {
+		require.Equal(t, u64(uint64(i+10)), config.ForkTime(fork.Name))
+		require.Equal(t, u64(uint64(i+10)), fork.Time(config))
+	}
+	require.NoError(t, config.Check())
+
+	require.NoError(t, config.SetForkTime(Delta, nil))
+	require.Nil(t, config.DeltaTime)
+
+	require.Equal(t, "Regolith", Regolith.Title())
+	require.Nil(t, config.ForkTime("unknown"))
+	require.ErrorIs(t, config.SetForkTime("unknown", u64(0)), ErrUnknownFork)
+}
+
+// TestForkActivation tests the generic activation conditions of the forks.
+func TestForkActivation(t *testing.T) {
+	for _, fork := range Forks {
+		t.Run(string(fork.Name), func(t *testing.T) {
+			config := randConfig()
+			fork.SetTime(config, nil)
+			require.False(t, config.IsForkActive(fork.Name, 0), "false if nil time, even if checking 0")
+
+			fork.SetTime(config, u64(0))
+			require.True(t, config.IsForkActive(fork.Name, 0), "true at zero")
+
+			fork.SetTime(config, u64(123))
+			require.False(t, config.IsForkActive(fork.Name, 122))
+			require.True(t, config.IsForkActive(fork.Name, 123))
+			require.True(t, config.IsForkActive(fork.Name, 124))
+		})
+	}
+}
+
+// TestForkActivationBlock tests that only the first block at or past the fork time is the activation block.
+func TestForkActivationBlock(t *testing.T) {
+	for _, fork := range Forks {
+		t.Run(string(fork.Name), func(t *testing.T) {
+			config := randConfig()
+			config.BlockTime = 2
+			fork.SetTime(config, nil)
+			require.False(t, config.IsForkActivationBlock(fork.Name, 0), "false if nil time")
+			require.False(t, config.IsForkActivationBlock(fork.Name, 100), "false if nil time")
+
+			fork.SetTime(config, u64(0))
+			require.False(t, config.IsForkActivationBlock(fork.Name, 0), "genesis is not an activation block")
+			require.False(t, config.IsForkActivationBlock(fork.Name, 2))
+
+			fork.SetTime(config, u64(100))
+			require.False(t, config.IsForkActivationBlock(fork.Name, 98))
+			require.True(t, config.IsForkActivationBlock(fork.Name, 100))
+			require.False(t, config.IsForkActivationBlock(fork.Name, 102))
+
+			// fork time not aligned with the block time
+			fork.SetTime(config, u64(101))
+			require.False(t, config.IsForkActivationBlock(fork.Name, 100))
+			require.True(t, config.IsForkActivationBlock(fork.Name, 102))
+			require.False(t, config.IsForkActivationBlock(fork.Name, 104))
+
+			require.False(t, config.IsForkActivationBlock("unknown", 102))
+		})
+	}
+}
+
+func TestCheckForkOrder(t *testing.T) {
+	tests := []struct {
+		name  string
+		times map[ForkName]*uint64
+		err   bool
+	}{
+		{name: "none"},
+		{
+			name:  "all at genesis",
+			times: map[ForkName]*uint64{Regolith: u64(0), Canyon: u64(0), Delta: u64(0), Fjord: u64(0)},
+		},
+		{
+			name:  "in order",
+			times: map[ForkName]*uint64{Regolith: u64(0), Canyon: u64(10), Delta: u64(10), Fjord: u64(20)},
+		},
+		{
+			name:  "skipped fork",
+			times: map[ForkName]*uint64{Regolith: u64(0), Delta: u64(10)},
+		},
+		{
+			name:  "out of order",
+			times: map[ForkName]*uint64{Regolith: u64(0), Canyon: u64(20), Delta: u64(10)},
+			err:   true,
+		},
+		{
+			name:  "out of order across skipped fork",
+			times: map[ForkName]*uint64{Regolith: u64(30), Fjord: u64(20)},
+			err:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config := randConfig()
+			for name, v := range test.times {
+				require.NoError(t, config.SetForkTime(name, v))
+			}
+			err := config.Check()
+			if test.err {
+				require.ErrorIs(t, err, ErrForksOutOfOrder)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestForkDescription(t *testing.T) {
+	config := randConfig()
+	require.NoError(t, config.SetForkTime(Delta, u64(0)))
+	out := config.Description(nil)
+	for _, fork := range Forks {
+		require.Contains(t, out, fmt.Sprintf("  - %s: ", fork.Name.Title()))
+	}
+	require.Contains(t, out, "Delta: @ genesis")
+	require.Contains(t, out, "Fjord: (not configured)")
+}
@@ -69,6 +69,8 @@ type Config struct {
 	// Required to identify the L2 network and create p2p signatures unique for this chain.
 	L2ChainID *big.Int `json:"l2_chain_id"`
 
+	// The network upgrade activation times below are listed, in activation order, in the Forks schedule.
+
 	// RegolithTime sets the activation time of the Regolith network-upgrade:
 	// a pre-mainnet Bedrock change that addresses findings of the Sherlock contest related to deposit attributes.
 	// "Regolith" is the loose deposited rock that sits on top of Bedrock.
@@ -79,6 +81,27 @@ type Config struct {
 	// Active if CanyonTime != nil && L2 block timestamp >= *CanyonTime, inactive otherwise.
 	CanyonTime *uint64 `json:"canyon_time,omitempty"`
 
+	// DeltaTime sets the activation time of the Delta network upgrade,
+	// which introduces span batches: a single batch that covers a contiguous range of L2 blocks.
+	// Active if DeltaTime != nil && L2 block timestamp >= *DeltaTime, inactive otherwise.
+	DeltaTime *uint64 `json:"delta_time,omitempty"`
+
+	// FjordTime sets the activation time of the Fjord network upgrade,
+	// which allows channels to be compressed with brotli, signaled by a channel version byte.
+	// Active if FjordTime != nil && L2 block timestamp >= *FjordTime, inactive otherwise.
+	// Channel decoding checks the activation against the timestamp of the L1 origin the channel is read from.
+	FjordTime *uint64 `json:"fjord_time,omitempty"`
+
+	// BlobsEnabledL1Timestamp sets the L1 timestamp from which on batches are also read from
+	// the EIP-4844 blobs of batcher transactions, next to regular calldata.
+	// Active if BlobsEnabledL1Timestamp != nil && L1 block timestamp >= *BlobsEnabledL1Timestamp, inactive otherwise.
+	BlobsEnabledL1Timestamp *uint64 `json:"blobs_data,omitempty"`
+
+	// UseAltDA enables the alt-DA mode: batcher transactions may carry a commitment to frame
+	// data that is stored on an external DA server, instead of the frame data itself.
+	// The frame data is retrieved from the DA server during derivation.
+	UseAltDA bool `json:"use_alt_da,omitempty"`
+
 	// Note: below addresses are part of the block-derivation process,
 	// and required to be the same network-wide to stay in consensus.
 
@@ -251,21 +274,39 @@ func (cfg *Config) Check() error {
 	if cfg.L2ChainID.Sign() < 1 {
 		return ErrL2ChainIDNotPositive
 	}
+	if err := cfg.CheckForkOrder(); err != nil {
+		return err
+	}
 	return nil
 }
 
 func (c *Config) L1Signer() types.Signer {
-	return types.NewLondonSigner(c.L1ChainID)
+	return types.NewCancunSigner(c.L1ChainID)
 }
 
 // IsRegolith returns true if the Regolith hardfork is active at or past the given timestamp.
 func (c *Config) IsRegolith(timestamp uint64) bool {
-	return c.RegolithTime != nil && timestamp >= *c.RegolithTime
+	return c.IsForkActive(Regolith, timestamp)
 }
 
 // IsCanyon returns true if the Canyon hardfork is active at or past the given timestamp.
 func (c *Config) IsCanyon(timestamp uint64) bool {
-	return c.CanyonTime != nil && timestamp >= *c.CanyonTime
+	return c.IsForkActive(Canyon, timestamp)
+}
+
+// IsDelta returns true if the Delta hardfork is active at or past the given timestamp.
+func (c *Config) IsDelta(timestamp uint64) bool {
+	return c.IsForkActive(Delta, timestamp)
+}
+
+// IsFjord returns true if the Fjord hardfork is active at or past the given timestamp.
+func (c *Config) IsFjord(timestamp uint64) bool {
+	return c.IsForkActive(Fjord, timestamp)
+}
+
+// IsBlobsEnabled returns true if batches are read from blobs at or past the given L1 timestamp.
+func (c *Config) IsBlobsEnabled(l1Timestamp uint64) bool {
+	return c.BlobsEnabledL1Timestamp != nil && l1Timestamp >= *c.BlobsEnabledL1Timestamp
 }
 
 // Description outputs a banner describing the important parts of rollup configuration in a human-readable form.
@@ -294,8 +335,11 @@ func (c *Config) Description(l2Chains map[string]string) string {
 	banner += fmt.Sprintf("  L1 block: %s %d\n", c.Genesis.L1.Hash, c.Genesis.L1.Number)
 	// Report the upgrade configuration
 	banner += "Post-Bedrock Network Upgrades (timestamp based):\n"
-	banner += fmt.Sprintf("  - Regolith: %s\n", fmtForkTimeOrUnset(c.RegolithTime))
-	banner += fmt.Sprintf("  - Canyon: %s\n", fmtForkTimeOrUnset(c.CanyonTime))
+	for _, fork := range Forks {
+		banner += fmt.Sprintf("  - %s: %s\n", fork.Name.Title(), fmtForkTimeOrUnset(fork.Time(c)))
+	}
+	banner += fmt.Sprintf("Batch data in blobs (L1 timestamp): %s\n", fmtForkTimeOrUnset(c.BlobsEnabledL1Timestamp))
+	banner += fmt.Sprintf("Alt-DA: %t\n", c.UseAltDA)
 	// Report the protocol version
 	banner += fmt.Sprintf("Node supports up to OP-Stack Protocol Version: %s\n", OPStackSupport)
 	return banner
@@ -317,11 +361,15 @@ func (c *Config) LogDescription(log log.Logger, l2Chains map[string]string) {
 	if networkL1 == "" {
 		networkL1 = "unknown L1"
 	}
-	log.Info("Rollup Config", "l2_chain_id", c.L2ChainID, "l2_network", networkL2, "l1_chain_id", c.L1ChainID,
+	ctx := []any{"l2_chain_id", c.L2ChainID, "l2_network", networkL2, "l1_chain_id", c.L1ChainID,
 		"l1_network", networkL1, "l2_start_time", c.Genesis.L2Time, "l2_block_hash", c.Genesis.L2.Hash.String(),
 		"l2_block_number", c.Genesis.L2.Number, "l1_block_hash", c.Genesis.L1.Hash.String(),
-		"l1_block_number", c.Genesis.L1.Number, "regolith_time", fmtForkTimeOrUnset(c.RegolithTime),
-		"canyon_time", fmtForkTimeOrUnset(c.CanyonTime))
+		"l1_block_number", c.Genesis.L1.Number}
+	for _, fork := range Forks {
+		ctx = append(ctx, string(fork.Name)+"_time", fmtForkTimeOrUnset(fork.Time(c)))
+	}
+	ctx = append(ctx, "blobs_data", fmtForkTimeOrUnset(c.BlobsEnabledL1Timestamp), "alt_da", c.UseAltDA)
+	log.Info("Rollup Config", ctx...)
 }
 
 func fmtForkTimeOrUnset(v *uint64) string {
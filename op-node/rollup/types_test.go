@@ -197,6 +197,52 @@ func TestRegolithActivation(t *testing.T) {
 	require.True(t, config.IsRegolith(124))
 }
 
+// TestDeltaActivation tests the activation condition of the Delta upgrade.
+func TestDeltaActivation(t *testing.T) {
+	config := randConfig()
+	config.DeltaTime = nil
+	require.False(t, config.IsDelta(0), "false if nil time, even if checking 0")
+	require.False(t, config.IsDelta(123456), "false if nil time")
+	config.DeltaTime = new(uint64)
+	require.True(t, config.IsDelta(0), "true at zero")
+	require.True(t, config.IsDelta(123456), "true for any")
+	x := uint64(123)
+	config.DeltaTime = &x
+	require.False(t, config.IsDelta(0))
+	require.False(t, config.IsDelta(122))
+	require.True(t, config.IsDelta(123))
+	require.True(t, config.IsDelta(124))
+}
+
+func TestBlobsEnabled(t *testing.T) {
+	config := randConfig()
+	config.BlobsEnabledL1Timestamp = nil
+	require.False(t, config.IsBlobsEnabled(0), "false if nil time, even if checking 0")
+	require.False(t, config.IsBlobsEnabled(123456), "false if nil time")
+	x := uint64(123)
+	config.BlobsEnabledL1Timestamp = &x
+	require.False(t, config.IsBlobsEnabled(122))
+	require.True(t, config.IsBlobsEnabled(123))
+	require.True(t, config.IsBlobsEnabled(124))
+}
+
+// TestFjordActivation tests the activation condition of the Fjord upgrade.
+func TestFjordActivation(t *testing.T) {
+	config := randConfig()
+	config.FjordTime = nil
+	require.False(t, config.IsFjord(0), "false if nil time, even if checking 0")
+	require.False(t, config.IsFjord(123456), "false if nil time")
+	config.FjordTime = new(uint64)
+	require.True(t, config.IsFjord(0), "true at zero")
+	require.True(t, config.IsFjord(123456), "true for any")
+	x := uint64(123)
+	config.FjordTime = &x
+	require.False(t, config.IsFjord(0))
+	require.False(t, config.IsFjord(122))
+	require.True(t, config.IsFjord(123))
+	require.True(t, config.IsFjord(124))
+}
+
 type mockL2Client struct {
 	chainID *big.Int
 	Hash    common.Hash
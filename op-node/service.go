@@ -8,6 +8,7 @@ import (
 	"os"
 	"strings"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 	oppprof "github.com/ethereum-optimism/optimism/op-service/pprof"
@@ -75,6 +76,8 @@ func NewConfig(ctx *cli.Context, log log.Logger) (*node.Config, error) {
 		L1:     l1Endpoint,
 		L2:     l2Endpoint,
 		L2Sync: l2SyncEndpoint,
+		Beacon: NewBeaconEndpointConfig(ctx),
+		AltDA:  altda.ReadCLIConfig(ctx),
 		Rollup: *rollupConfig,
 		Driver: *driverConfig,
 		RPC: node.RPCConfig{
@@ -127,6 +130,16 @@ func NewL1EndpointConfig(ctx *cli.Context) *node.L1EndpointConfig {
 	}
 }
 
+func NewBeaconEndpointConfig(ctx *cli.Context) node.L1BeaconEndpointSetup {
+	addr := ctx.String(flags.BeaconAddr.Name)
+	if addr == "" {
+		return nil
+	}
+	return &node.L1BeaconEndpointConfig{
+		BeaconAddr: addr,
+	}
+}
+
 func NewL2EndpointConfig(ctx *cli.Context, log log.Logger) (*node.L2EndpointConfig, error) {
 	l2Addr := ctx.String(flags.L2EngineAddr.Name)
 	fileName := ctx.String(flags.L2EngineJWTSecret.Name)
@@ -202,11 +215,7 @@ Conflicting configuration is deprecated, and will stop the op-node from starting
 		if err != nil {
 			return nil, err
 		}
-		if ctx.IsSet(flags.CanyonOverrideFlag.Name) {
-			canyon := ctx.Uint64(flags.CanyonOverrideFlag.Name)
-			config.CanyonTime = &canyon
-		}
-
+		applyOverrides(ctx, config)
 		return config, nil
 	}
 
@@ -220,13 +229,20 @@ Conflicting configuration is deprecated, and will stop the op-node from starting
 	if err := json.NewDecoder(file).Decode(&rollupConfig); err != nil {
 		return nil, fmt.Errorf("failed to decode rollup config: %w", err)
 	}
-	if ctx.IsSet(flags.CanyonOverrideFlag.Name) {
-		canyon := ctx.Uint64(flags.CanyonOverrideFlag.Name)
-		rollupConfig.CanyonTime = &canyon
-	}
+	applyOverrides(ctx, &rollupConfig)
 	return &rollupConfig, nil
 }
 
+// applyOverrides applies the fork activation times that are overridden with flags.
+func applyOverrides(ctx *cli.Context, rollupConfig *rollup.Config) {
+	for _, fork := range rollup.Forks {
+		if name := flags.OverrideFlagName(fork.Name); ctx.IsSet(name) {
+			t := ctx.Uint64(name)
+			fork.SetTime(rollupConfig, &t)
+		}
+	}
+}
+
 func NewSnapshotLogger(ctx *cli.Context) (log.Logger, error) {
 	snapshotFile := ctx.String(flags.SnapshotLog.Name)
 	handler := log.DiscardHandler()
@@ -0,0 +1,181 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+const (
+	versionMethod        = "eth/v1/node/version"
+	genesisMethod        = "eth/v1/beacon/genesis"
+	specMethod           = "eth/v1/config/spec"
+	sidecarsMethodPrefix = "eth/v1/beacon/blob_sidecars/"
+)
+
+// TimeToSlotFn returns the beacon chain slot of the given timestamp.
+type TimeToSlotFn func(timestamp uint64) (uint64, error)
+
+// L1BeaconClient is a client for the L1 beacon-node API, which is used to fetch the
+// blobs of batcher transactions.
+type L1BeaconClient struct {
+	cl   *http.Client
+	addr string
+
+	initLock     sync.Mutex
+	timeToSlotFn TimeToSlotFn
+}
+
+// NewL1BeaconClient returns a client for the beacon-node API at the given address.
+func NewL1BeaconClient(cl *http.Client, addr string) *L1BeaconClient {
+	return &L1BeaconClient{cl: cl, addr: strings.TrimSuffix(addr, "/")}
+}
+
+func (cl *L1BeaconClient) apiReq(ctx context.Context, dest any, method string, query url.Values) error {
+	reqURL, err := url.Parse(cl.addr + "/" + method)
+	if err != nil {
+		return fmt.Errorf("failed to parse beacon API URL: %w", err)
+	}
+	reqURL.RawQuery = query.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, reqURL.String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to construct request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	resp, err := cl.cl.Do(req)
+	if err != nil {
+		return fmt.Errorf("http Get failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return ethereum.NotFound
+	} else if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed request with status %d: %s", resp.StatusCode, string(body))
+	}
+	if err := json.NewDecoder(resp.Body).Decode(dest); err != nil {
+		return fmt.Errorf("failed to decode beacon API response: %w", err)
+	}
+	return nil
+}
+
+// GetVersion fetches the version of the beacon node. It can be used to check the connection to the node.
+func (cl *L1BeaconClient) GetVersion(ctx context.Context) (string, error) {
+	var resp struct {
+		Data struct {
+			Version string `json:"version"`
+		} `json:"data"`
+	}
+	if err := cl.apiReq(ctx, &resp, versionMethod, nil); err != nil {
+		return "", err
+	}
+	return resp.Data.Version, nil
+}
+
+// GetTimeToSlotFn returns a function that converts a timestamp to a slot number.
+// The genesis time and slot duration are fetched once, and cached afterwards.
+func (cl *L1BeaconClient) GetTimeToSlotFn(ctx context.Context) (TimeToSlotFn, error) {
+	cl.initLock.Lock()
+	defer cl.initLock.Unlock()
+	if cl.timeToSlotFn != nil {
+		return cl.timeToSlotFn, nil
+	}
+
+	var genesisResp eth.APIGenesisResponse
+	if err := cl.apiReq(ctx, &genesisResp, genesisMethod, nil); err != nil {
+		return nil, fmt.Errorf("failed to fetch beacon genesis: %w", err)
+	}
+
+	var configResp eth.APIConfigResponse
+	if err := cl.apiReq(ctx, &configResp, specMethod, nil); err != nil {
+		return nil, fmt.Errorf("failed to fetch beacon config spec: %w", err)
+	}
+
+	genesisTime := uint64(genesisResp.Data.GenesisTime)
+	secondsPerSlot := uint64(configResp.Data.SecondsPerSlot)
+	if secondsPerSlot == 0 {
+		return nil, fmt.Errorf("got bad value for seconds per slot: %v", configResp.Data.SecondsPerSlot)
+	}
+	cl.timeToSlotFn = func(timestamp uint64) (uint64, error) {
+		if timestamp < genesisTime {
+			return 0, fmt.Errorf("provided timestamp (%v) precedes genesis time (%v)", timestamp, genesisTime)
+		}
+		return (timestamp - genesisTime) / secondsPerSlot, nil
+	}
+	return cl.timeToSlotFn, nil
+}
+
+// GetBlobSidecars fetches blob sidecars that were confirmed in the specified L1 block with the
+// given indexed hashes. Order of the returned sidecars is guaranteed to be that of the hashes.
+// Blob data is not checked for validity.
+func (cl *L1BeaconClient) GetBlobSidecars(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.BlobSidecar, error) {
+	if len(hashes) == 0 {
+		return []*eth.BlobSidecar{}, nil
+	}
+	slotFn, err := cl.GetTimeToSlotFn(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get time to slot function: %w", err)
+	}
+	slot, err := slotFn(ref.Time)
+	if err != nil {
+		return nil, fmt.Errorf("error in converting ref.Time to slot: %w", err)
+	}
+
+	query := url.Values{}
+	for _, h := range hashes {
+		query.Add("indices", strconv.FormatUint(h.Index, 10))
+	}
+	var resp eth.APIGetBlobSidecarsResponse
+	if err := cl.apiReq(ctx, &resp, sidecarsMethodPrefix+strconv.FormatUint(slot, 10), query); err != nil {
+		return nil, fmt.Errorf("failed to fetch blob sidecars for slot %v block %v: %w", slot, ref, err)
+	}
+
+	// the beacon node may return the sidecars in any order, or more sidecars than requested
+	sidecarsByIndex := make(map[uint64]*eth.BlobSidecar, len(resp.Data))
+	for _, sidecar := range resp.Data {
+		sidecarsByIndex[uint64(sidecar.Index)] = sidecar
+	}
+	out := make([]*eth.BlobSidecar, len(hashes))
+	for i, h := range hashes {
+		sidecar, ok := sidecarsByIndex[h.Index]
+		if !ok {
+			return nil, fmt.Errorf("missing blob sidecar %d in slot %d: %w", h.Index, slot, ethereum.NotFound)
+		}
+		out[i] = sidecar
+	}
+	return out, nil
+}
+
+// GetBlobs fetches blobs that were confirmed in the specified L1 block with the given indexed
+// hashes. The order of the returned blobs will match the order of `hashes`. Confirms each
+// blob's validity by checking its proof against the commitment, and confirming the commitment
+// hashes to the expected value. Returns error if any blob is found invalid.
+func (cl *L1BeaconClient) GetBlobs(ctx context.Context, ref eth.L1BlockRef, hashes []eth.IndexedBlobHash) ([]*eth.Blob, error) {
+	sidecars, err := cl.GetBlobSidecars(ctx, ref, hashes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blob sidecars for L1BlockRef %s: %w", ref, err)
+	}
+	out := make([]*eth.Blob, len(hashes))
+	for i, sidecar := range sidecars {
+		commitment := kzg4844.Commitment(sidecar.KZGCommitment)
+		if hash := eth.KZGToVersionedHash(commitment); hash != hashes[i].Hash {
+			return nil, fmt.Errorf("expected hash %s for blob at index %d but got %s", hashes[i].Hash, hashes[i].Index, hash)
+		}
+		if err := eth.VerifyBlobProof(&sidecar.Blob, commitment, kzg4844.Proof(sidecar.KZGProof)); err != nil {
+			return nil, fmt.Errorf("blob at index %d failed verification: %w", hashes[i].Index, err)
+		}
+		out[i] = &sidecar.Blob
+	}
+	return out, nil
+}
@@ -0,0 +1,112 @@
+package sources
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+func makeTestBlobSidecar(t *testing.T, index uint64, data string) *eth.BlobSidecar {
+	var blob eth.Blob
+	require.NoError(t, blob.FromData(eth.Data(data)))
+	commitment, err := kzg4844.BlobToCommitment(*blob.KZGBlob())
+	require.NoError(t, err)
+	proof, err := kzg4844.ComputeBlobProof(*blob.KZGBlob(), commitment)
+	require.NoError(t, err)
+	return &eth.BlobSidecar{
+		Blob:          blob,
+		Index:         eth.Uint64String(index),
+		KZGCommitment: eth.Bytes48(commitment),
+		KZGProof:      eth.Bytes48(proof),
+	}
+}
+
+func newTestBeaconServer(t *testing.T, slot string, sidecars []*eth.BlobSidecar) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/eth/v1/beacon/genesis", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(&eth.APIGenesisResponse{Data: eth.ReducedGenesisData{GenesisTime: 10}}))
+	})
+	mux.HandleFunc("/eth/v1/config/spec", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewEncoder(w).Encode(&eth.APIConfigResponse{Data: eth.ReducedConfigData{SecondsPerSlot: 2}}))
+	})
+	mux.HandleFunc("/eth/v1/beacon/blob_sidecars/"+slot, func(w http.ResponseWriter, r *http.Request) {
+		// serve the sidecars in reverse order, the client must reorder them
+		resp := eth.APIGetBlobSidecarsResponse{}
+		for i := len(sidecars) - 1; i >= 0; i-- {
+			resp.Data = append(resp.Data, sidecars[i])
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(&resp))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestL1BeaconClient(t *testing.T) {
+	sidecars := []*eth.BlobSidecar{
+		makeTestBlobSidecar(t, 0, "first blob"),
+		makeTestBlobSidecar(t, 1, "second blob"),
+	}
+	hashes := make([]eth.IndexedBlobHash, len(sidecars))
+	for i, sc := range sidecars {
+		hashes[i] = eth.IndexedBlobHash{
+			Index: uint64(sc.Index),
+			Hash:  eth.KZGToVersionedHash(kzg4844.Commitment(sc.KZGCommitment)),
+		}
+	}
+	// timestamp 30 is slot 10, with genesis time 10 and 2 seconds per slot
+	ref := eth.L1BlockRef{Number: 100, Time: 30}
+	srv := newTestBeaconServer(t, "10", sidecars)
+	ctx := context.Background()
+
+	t.Run("time to slot", func(t *testing.T) {
+		cl := NewL1BeaconClient(srv.Client(), srv.URL)
+		fn, err := cl.GetTimeToSlotFn(ctx)
+		require.NoError(t, err)
+		slot, err := fn(30)
+		require.NoError(t, err)
+		require.Equal(t, uint64(10), slot)
+		_, err = fn(9)
+		require.ErrorContains(t, err, "precedes genesis time")
+	})
+
+	t.Run("get blobs", func(t *testing.T) {
+		cl := NewL1BeaconClient(srv.Client(), srv.URL)
+		blobs, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{hashes[1], hashes[0]})
+		require.NoError(t, err)
+		require.Len(t, blobs, 2)
+		data, err := blobs[0].ToData()
+		require.NoError(t, err)
+		require.Equal(t, eth.Data("second blob"), data)
+		data, err = blobs[1].ToData()
+		require.NoError(t, err)
+		require.Equal(t, eth.Data("first blob"), data)
+	})
+
+	t.Run("hash mismatch", func(t *testing.T) {
+		cl := NewL1BeaconClient(srv.Client(), srv.URL)
+		badHash := eth.IndexedBlobHash{Index: 0, Hash: hashes[1].Hash}
+		_, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{badHash})
+		require.ErrorContains(t, err, "expected hash")
+	})
+
+	t.Run("missing sidecar", func(t *testing.T) {
+		cl := NewL1BeaconClient(srv.Client(), srv.URL)
+		_, err := cl.GetBlobs(ctx, ref, []eth.IndexedBlobHash{{Index: 2, Hash: hashes[0].Hash}})
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+
+	t.Run("unknown slot", func(t *testing.T) {
+		cl := NewL1BeaconClient(srv.Client(), srv.URL)
+		_, err := cl.GetBlobs(ctx, eth.L1BlockRef{Time: 50}, hashes)
+		require.ErrorIs(t, err, ethereum.NotFound)
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/ethereum-optimism/optimism/op-node/client"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
 	"github.com/ethereum-optimism/optimism/op-service/eth"
 )
 
@@ -31,6 +32,12 @@ func (r *RollupClient) SyncStatus(ctx context.Context) (*eth.SyncStatus, error)
 	return output, err
 }
 
+func (r *RollupClient) DerivationStatus(ctx context.Context) (*derive.DerivationStatus, error) {
+	var output *derive.DerivationStatus
+	err := r.rpc.CallContext(ctx, &output, "optimism_derivationStatus")
+	return output, err
+}
+
 func (r *RollupClient) RollupConfig(ctx context.Context) (*rollup.Config, error) {
 	var output *rollup.Config
 	err := r.rpc.CallContext(ctx, &output, "optimism_rollupConfig")
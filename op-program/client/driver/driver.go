@@ -35,8 +35,10 @@ type Driver struct {
 	targetBlockNum uint64
 }
 
-func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher, l2Source L2Source, targetBlockNum uint64) *Driver {
-	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, l2Source, metrics.NoopMetrics, &sync.Config{})
+// NewDriver creates a driver that derives L2 blocks up to targetBlockNum.
+// The alt-DA input fetcher is only used if the rollup uses alt-DA.
+func NewDriver(logger log.Logger, cfg *rollup.Config, l1Source derive.L1Fetcher, altDA derive.AltDAInputFetcher, l2Source L2Source, targetBlockNum uint64) *Driver {
+	pipeline := derive.NewDerivationPipeline(logger, cfg, l1Source, nil, altDA, l2Source, metrics.NoopMetrics, &sync.Config{})
 	pipeline.Reset()
 	return &Driver{
 		logger:         logger,
@@ -0,0 +1,34 @@
+package l1
+
+import (
+	"context"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
+	"github.com/ethereum-optimism/optimism/op-node/rollup/derive"
+	preimage "github.com/ethereum-optimism/optimism/op-preimage"
+)
+
+// AltDAInputFetcher implements derive.AltDAInputFetcher using the pure preimage.Oracle.
+// Keccak256 commitments are the keccak256 hash of their input, so the input is retrieved as
+// a keccak256 pre-image. The host must be able to fetch the input from its DA server:
+// like on the node, inputs that are not available are never skipped.
+type AltDAInputFetcher struct {
+	oracle preimage.Oracle
+	hint   preimage.Hinter
+}
+
+var _ derive.AltDAInputFetcher = (*AltDAInputFetcher)(nil)
+
+func NewAltDAInputFetcher(raw preimage.Oracle, hint preimage.Hinter) *AltDAInputFetcher {
+	return &AltDAInputFetcher{
+		oracle: raw,
+		hint:   hint,
+	}
+}
+
+func (p *AltDAInputFetcher) GetInput(_ context.Context, comm altda.Keccak256Commitment) ([]byte, error) {
+	p.hint.Hint(AltDAInputHint(comm))
+	return p.oracle.Get(preimage.Keccak256Key(common.Hash(comm))), nil
+}
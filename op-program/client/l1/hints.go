@@ -10,6 +10,7 @@ const (
 	HintL1BlockHeader  = "l1-block-header"
 	HintL1Transactions = "l1-transactions"
 	HintL1Receipts     = "l1-receipts"
+	HintAltDAInput     = "altda-input"
 )
 
 type BlockHeaderHint common.Hash
@@ -35,3 +36,12 @@ var _ preimage.Hint = ReceiptsHint{}
 func (l ReceiptsHint) Hint() string {
 	return HintL1Receipts + " " + (common.Hash)(l).String()
 }
+
+// AltDAInputHint requests the input of an alt-DA commitment, identified by its keccak256 hash.
+type AltDAInputHint common.Hash
+
+var _ preimage.Hint = AltDAInputHint{}
+
+func (l AltDAInputHint) Hint() string {
+	return HintAltDAInput + " " + (common.Hash)(l).String()
+}
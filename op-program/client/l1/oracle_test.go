@@ -1,6 +1,7 @@
 package l1
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/rand"
@@ -13,6 +14,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/mpt"
@@ -103,3 +105,26 @@ func TestPreimageOracleBlockByHash(t *testing.T) {
 		})
 	}
 }
+
+func TestAltDAInputFetcher(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	input := testutils.RandomData(rng, 100)
+	comm := altda.Keccak256(input)
+
+	var hints mock.Mock
+	fetcher := NewAltDAInputFetcher(
+		preimage.OracleFn(func(key preimage.Key) []byte {
+			require.Equal(t, preimage.Keccak256Key(common.Hash(comm)), key)
+			return input
+		}),
+		preimage.HinterFn(func(v preimage.Hint) {
+			hints.MethodCalled("hint", v.Hint())
+		}),
+	)
+
+	hints.On("hint", AltDAInputHint(comm).Hint()).Once().Return()
+	got, err := fetcher.GetInput(context.Background(), comm)
+	require.NoError(t, err)
+	require.Equal(t, input, got)
+	hints.AssertExpectations(t)
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/log"
@@ -97,12 +98,32 @@ func TestDataDir(t *testing.T) {
 	require.Equal(t, expected, cfg.DataDir)
 }
 
+func TestDataFormat(t *testing.T) {
+	t.Run("DefaultDirectory", func(t *testing.T) {
+		cfg := configForArgs(t, addRequiredArgs())
+		require.Equal(t, types.DataFormatDirectory, cfg.DataFormat)
+	})
+	for _, format := range types.SupportedDataFormats {
+		format := format
+		t.Run(format.String(), func(t *testing.T) {
+			cfg := configForArgs(t, addRequiredArgs("--data.format", format.String()))
+			require.Equal(t, format, cfg.DataFormat)
+		})
+	}
+}
+
 func TestL2(t *testing.T) {
 	expected := "https://example.com:8545"
 	cfg := configForArgs(t, addRequiredArgs("--l2", expected))
 	require.Equal(t, expected, cfg.L2URL)
 }
 
+func TestAltDAServer(t *testing.T) {
+	expected := "http://localhost:3100"
+	cfg := configForArgs(t, addRequiredArgs("--altda.da-server", expected))
+	require.Equal(t, expected, cfg.AltDAServerURL)
+}
+
 func TestL2Genesis(t *testing.T) {
 	t.Run("RequiredWithCustomNetwork", func(t *testing.T) {
 		rollupCfgFile := writeValidRollupConfig(t)
@@ -12,6 +12,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/log"
@@ -30,6 +31,8 @@ var (
 	ErrInvalidL2ClaimBlock = errors.New("invalid l2 claim block number")
 	ErrDataDirRequired     = errors.New("datadir must be specified when in non-fetching mode")
 	ErrNoExecInServerMode  = errors.New("exec command must not be set when in server mode")
+	ErrInvalidDataFormat   = errors.New("invalid data format")
+	ErrMissingAltDAServer  = errors.New("alt-DA server must be specified when fetching data for a rollup that uses alt-DA")
 )
 
 type Config struct {
@@ -37,6 +40,8 @@ type Config struct {
 	// DataDir is the directory to read/write pre-image data from/to.
 	//If not set, an in-memory key-value store is used and fetching data must be enabled
 	DataDir string
+	// DataFormat is the format the pre-image data is stored in within DataDir
+	DataFormat types.DataFormat
 
 	// L1Head is the block has of the L1 chain head block
 	L1Head     common.Hash
@@ -50,6 +55,9 @@ type Config struct {
 	// L2OutputRoot is the agreed L2 output root to start derivation from
 	L2OutputRoot common.Hash
 	L2URL        string
+	// AltDAServerURL is the address of the DA server to fetch alt-DA inputs from.
+	// Only required if the rollup uses alt-DA and fetching is enabled.
+	AltDAServerURL string
 	// L2Claim is the claimed L2 output root to verify
 	L2Claim common.Hash
 	// L2ClaimBlockNumber is the block number the claimed L2 output root is from
@@ -100,9 +108,15 @@ func (c *Config) Check() error {
 	if !c.FetchingEnabled() && c.DataDir == "" {
 		return ErrDataDirRequired
 	}
+	if c.FetchingEnabled() && c.Rollup.UseAltDA && c.AltDAServerURL == "" {
+		return ErrMissingAltDAServer
+	}
 	if c.ServerMode && c.ExecCmd != "" {
 		return ErrNoExecInServerMode
 	}
+	if !c.DataFormat.Valid() {
+		return ErrInvalidDataFormat
+	}
 	return nil
 }
 
@@ -132,6 +146,7 @@ func NewConfig(
 		L2ClaimBlockNumber:  l2ClaimBlockNum,
 		L1RPCKind:           sources.RPCKindBasic,
 		IsCustomChainConfig: isCustomConfig,
+		DataFormat:          types.DataFormatDirectory,
 	}
 }
 
@@ -184,7 +199,9 @@ func NewConfigFromCLI(log log.Logger, ctx *cli.Context) (*Config, error) {
 	return &Config{
 		Rollup:              rollupCfg,
 		DataDir:             ctx.String(flags.DataDir.Name),
+		DataFormat:          types.DataFormat(ctx.String(flags.DataFormat.Name)),
 		L2URL:               ctx.String(flags.L2NodeAddr.Name),
+		AltDAServerURL:      ctx.String(flags.AltDAServer.Name),
 		L2ChainConfig:       l2ChainConfig,
 		L2Head:              l2Head,
 		L2OutputRoot:        l2OutputRoot,
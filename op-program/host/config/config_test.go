@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/rollup"
 	"github.com/ethereum-optimism/optimism/op-program/chainconfig"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/stretchr/testify/require"
@@ -143,6 +144,21 @@ func TestFetchingEnabled(t *testing.T) {
 	})
 }
 
+func TestRequireAltDAServerWhenFetching(t *testing.T) {
+	rollupCfg := *validRollupConfig
+	rollupCfg.UseAltDA = true
+	cfg := validConfig()
+	cfg.Rollup = &rollupCfg
+	require.NoError(t, cfg.Check(), "alt-DA server not required in non-fetching mode")
+
+	cfg.L1URL = "https://example.com:1234"
+	cfg.L2URL = "https://example.com:5678"
+	require.ErrorIs(t, cfg.Check(), ErrMissingAltDAServer)
+
+	cfg.AltDAServerURL = "http://localhost:3100"
+	require.NoError(t, cfg.Check())
+}
+
 func TestRequireDataDirInNonFetchingMode(t *testing.T) {
 	cfg := validConfig()
 	cfg.DataDir = ""
@@ -160,6 +176,22 @@ func TestRejectExecAndServerMode(t *testing.T) {
 	require.ErrorIs(t, err, ErrNoExecInServerMode)
 }
 
+func TestDataFormat(t *testing.T) {
+	for _, format := range types.SupportedDataFormats {
+		format := format
+		t.Run(format.String(), func(t *testing.T) {
+			cfg := validConfig()
+			cfg.DataFormat = format
+			require.NoError(t, cfg.Check())
+		})
+	}
+	t.Run("Invalid", func(t *testing.T) {
+		cfg := validConfig()
+		cfg.DataFormat = "foo"
+		require.ErrorIs(t, cfg.Check(), ErrInvalidDataFormat)
+	})
+}
+
 func TestIsCustomChainConfig(t *testing.T) {
 	t.Run("nonCustom", func(t *testing.T) {
 		cfg := validConfig()
@@ -8,7 +8,10 @@ import (
 	"io/fs"
 	"os"
 	"os/exec"
+	"os/signal"
+	"syscall"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/chaincfg"
 	"github.com/ethereum-optimism/optimism/op-node/client"
 	"github.com/ethereum-optimism/optimism/op-node/sources"
@@ -19,6 +22,7 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/host/flags"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
 	"github.com/ethereum-optimism/optimism/op-program/host/prefetcher"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	oppio "github.com/ethereum-optimism/optimism/op-program/io"
 	opservice "github.com/ethereum-optimism/optimism/op-service"
 	"github.com/ethereum/go-ethereum/common"
@@ -39,6 +43,9 @@ func Main(logger log.Logger, cfg *config.Config) error {
 
 	ctx := context.Background()
 	if cfg.ServerMode {
+		// Stop cleanly when interrupted, so the pre-images fetched so far are persisted
+		ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+		defer stop()
 		preimageChan := cl.CreatePreimageChannel()
 		hinterChan := cl.CreateHinterChannel()
 		return PreimageServer(ctx, logger, cfg, preimageChan, hinterChan)
@@ -127,6 +134,7 @@ func FaultProofProgram(ctx context.Context, logger log.Logger, cfg *config.Confi
 func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config, preimageChannel oppio.FileChannel, hintChannel oppio.FileChannel) error {
 	var serverDone chan error
 	var hinterDone chan error
+	var kv kvstore.KV
 	defer func() {
 		preimageChannel.Close()
 		hintChannel.Close()
@@ -138,18 +146,17 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 			// Wait for hinter to complete
 			<-hinterDone
 		}
+		// Close the storage only once nothing else can write to it
+		if closer, ok := kv.(io.Closer); ok {
+			if err := closer.Close(); err != nil {
+				logger.Error("Failed to close pre-image storage", "err", err)
+			}
+		}
 	}()
 	logger.Info("Starting preimage server")
-	var kv kvstore.KV
-	if cfg.DataDir == "" {
-		logger.Info("Using in-memory storage")
-		kv = kvstore.NewMemKV()
-	} else {
-		logger.Info("Creating disk storage", "datadir", cfg.DataDir)
-		if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
-			return fmt.Errorf("creating datadir: %w", err)
-		}
-		kv = kvstore.NewDiskKV(cfg.DataDir)
+	kv, err := createKV(logger, cfg)
+	if err != nil {
+		return err
 	}
 
 	var (
@@ -183,6 +190,28 @@ func PreimageServer(ctx context.Context, logger log.Logger, cfg *config.Config,
 		return err
 	case err := <-hinterDone:
 		return err
+	case <-ctx.Done():
+		logger.Info("Stopping preimage server")
+		return nil
+	}
+}
+
+func createKV(logger log.Logger, cfg *config.Config) (kvstore.KV, error) {
+	if cfg.DataDir == "" {
+		logger.Info("Using in-memory storage")
+		return kvstore.NewMemKV(), nil
+	}
+	logger.Info("Creating disk storage", "datadir", cfg.DataDir, "format", cfg.DataFormat)
+	if err := os.MkdirAll(cfg.DataDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating datadir: %w", err)
+	}
+	switch cfg.DataFormat {
+	case types.DataFormatDirectory:
+		return kvstore.NewDiskKV(cfg.DataDir), nil
+	case types.DataFormatPebble, types.DataFormatLevelDB:
+		return kvstore.NewDBKV(cfg.DataDir, cfg.DataFormat)
+	default:
+		return nil, fmt.Errorf("invalid data format: %v", cfg.DataFormat)
 	}
 }
 
@@ -210,7 +239,12 @@ func makePrefetcher(ctx context.Context, logger log.Logger, kv kvstore.KV, cfg *
 		return nil, fmt.Errorf("failed to create L2 client: %w", err)
 	}
 	l2DebugCl := &L2Source{L2Client: l2Cl, DebugClient: sources.NewDebugClient(l2RPC.CallContext)}
-	return prefetcher.NewPrefetcher(logger, l1Cl, l2DebugCl, kv), nil
+	var altDA prefetcher.AltDASource
+	if cfg.AltDAServerURL != "" {
+		logger.Info("Using alt-DA server", "url", cfg.AltDAServerURL)
+		altDA = altda.NewDAClient(cfg.AltDAServerURL)
+	}
+	return prefetcher.NewPrefetcher(logger, l1Cl, l2DebugCl, altDA, kv), nil
 }
 
 func routeHints(logger log.Logger, hHostRW io.ReadWriter, hinter preimage.HintHandler) chan error {
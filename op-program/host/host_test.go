@@ -14,8 +14,10 @@ import (
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/host/config"
 	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
 	"github.com/ethereum-optimism/optimism/op-program/io"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 )
@@ -55,6 +57,45 @@ func TestServerMode(t *testing.T) {
 	require.ErrorIs(t, waitFor(result), kvstore.ErrNotFound)
 }
 
+func TestServerModeDatabase(t *testing.T) {
+	dir := t.TempDir()
+	value := []byte("hello world")
+	key := preimage.Keccak256Key(crypto.Keccak256Hash(value))
+	kv, err := kvstore.NewDBKV(dir, types.DataFormatPebble)
+	require.NoError(t, err)
+	require.NoError(t, kv.Put(key.PreimageKey(), value))
+	require.NoError(t, kv.Close())
+
+	cfg := config.NewConfig(chaincfg.Goerli, chainconfig.OPGoerliChainConfig, common.Hash{0x11}, common.Hash{0x22}, common.Hash{0x33}, common.Hash{0x44}, 1000)
+	cfg.DataDir = dir
+	cfg.DataFormat = types.DataFormatPebble
+	cfg.ServerMode = true
+
+	preimageServer, preimageClient, err := io.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	defer preimageClient.Close()
+	hintServer, hintClient, err := io.CreateBidirectionalChannel()
+	require.NoError(t, err)
+	defer hintClient.Close()
+	logger := testlog.Logger(t, log.LvlTrace)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	result := make(chan error)
+	go func() {
+		result <- PreimageServer(ctx, logger, cfg, preimageServer, hintServer)
+	}()
+
+	pClient := preimage.NewOracleClient(preimageClient)
+	require.Equal(t, value, pClient.Get(key))
+
+	// Should stop cleanly when the context is done, closing the database
+	cancel()
+	require.NoError(t, waitFor(result))
+	kv, err = kvstore.NewDBKV(dir, types.DataFormatPebble)
+	require.NoError(t, err)
+	require.NoError(t, kv.Close())
+}
+
 func waitFor(ch chan error) error {
 	timeout := time.After(30 * time.Second)
 	select {
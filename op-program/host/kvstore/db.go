@@ -0,0 +1,115 @@
+package kvstore
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/ethdb"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+)
+
+const (
+	// dbCacheSize is the cache size of the database in MiB
+	dbCacheSize = 64
+	// dbHandles is the number of open file handles the database may use
+	dbHandles = 64
+)
+
+// DBKV is a key-value store backed by an embedded pebble or LevelDB database.
+// Writes are batched, and only written to the database once the batch is large enough, or when the DBKV is flushed or closed.
+// Pre-images in the pending batch are still available to Get.
+// DBKV is safe for concurrent use with a single DBKV instance, but the database can only be opened by one instance at a time.
+type DBKV struct {
+	sync.RWMutex
+	db      ethdb.KeyValueStore
+	batch   ethdb.Batch
+	pending map[common.Hash][]byte
+}
+
+// NewDBKV opens, or creates, the database of the given format in the path.
+// The DBKV must be closed to write the last batch of pre-images to the database.
+func NewDBKV(path string, format types.DataFormat) (*DBKV, error) {
+	var db ethdb.KeyValueStore
+	var err error
+	switch format {
+	case types.DataFormatPebble:
+		db, err = rawdb.NewPebbleDBDatabase(path, dbCacheSize, dbHandles, "", false)
+	case types.DataFormatLevelDB:
+		db, err = rawdb.NewLevelDBDatabase(path, dbCacheSize, dbHandles, "", false)
+	default:
+		return nil, fmt.Errorf("unsupported database format: %v", format)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %v database %v: %w", format, path, err)
+	}
+	return &DBKV{
+		db:      db,
+		batch:   db.NewBatch(),
+		pending: make(map[common.Hash][]byte),
+	}, nil
+}
+
+func (d *DBKV) Put(k common.Hash, v []byte) error {
+	d.Lock()
+	defer d.Unlock()
+	if err := d.batch.Put(k[:], v); err != nil {
+		return fmt.Errorf("failed to add pre-image %s to batch: %w", k, err)
+	}
+	d.pending[k] = v
+	if d.batch.ValueSize() >= ethdb.IdealBatchSize {
+		return d.flush()
+	}
+	return nil
+}
+
+func (d *DBKV) Get(k common.Hash) ([]byte, error) {
+	d.RLock()
+	defer d.RUnlock()
+	if v, ok := d.pending[k]; ok {
+		return v, nil
+	}
+	v, err := d.db.Get(k[:])
+	if err != nil {
+		// The not found error differs between databases, so check if the key exists to tell them apart
+		if has, hasErr := d.db.Has(k[:]); hasErr == nil && !has {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to read pre-image %s from database: %w", k, err)
+	}
+	return v, nil
+}
+
+// Flush writes the pending batch of pre-images to the database.
+func (d *DBKV) Flush() error {
+	d.Lock()
+	defer d.Unlock()
+	return d.flush()
+}
+
+func (d *DBKV) flush() error {
+	if len(d.pending) == 0 {
+		return nil
+	}
+	if err := d.batch.Write(); err != nil {
+		return fmt.Errorf("failed to write batch of %d pre-images: %w", len(d.pending), err)
+	}
+	d.batch.Reset()
+	d.pending = make(map[common.Hash][]byte)
+	return nil
+}
+
+// Close writes the pending batch of pre-images to the database and closes it.
+func (d *DBKV) Close() error {
+	d.Lock()
+	defer d.Unlock()
+	flushErr := d.flush()
+	if err := d.db.Close(); err != nil {
+		return fmt.Errorf("failed to close database: %w", err)
+	}
+	return flushErr
+}
+
+var _ KV = (*DBKV)(nil)
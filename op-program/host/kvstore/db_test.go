@@ -0,0 +1,93 @@
+package kvstore
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+)
+
+var dbFormats = []types.DataFormat{types.DataFormatPebble, types.DataFormatLevelDB}
+
+func TestDBKV(t *testing.T) {
+	for _, format := range dbFormats {
+		format := format
+		t.Run(format.String(), func(t *testing.T) {
+			kv, err := NewDBKV(t.TempDir(), format)
+			require.NoError(t, err)
+			t.Cleanup(func() {
+				require.NoError(t, kv.Close())
+			})
+			kvTest(t, kv)
+		})
+	}
+}
+
+func TestDBKVPersistence(t *testing.T) {
+	for _, format := range dbFormats {
+		format := format
+		t.Run(format.String(), func(t *testing.T) {
+			dir := t.TempDir()
+			val := []byte{1, 2, 3, 4}
+			key := crypto.Keccak256Hash(val)
+
+			kv, err := NewDBKV(dir, format)
+			require.NoError(t, err)
+			require.NoError(t, kv.Put(key, val))
+			require.NoError(t, kv.Put(common.Hash{0xaa}, []byte{}))
+			require.NoError(t, kv.Close())
+
+			kv, err = NewDBKV(dir, format)
+			require.NoError(t, err)
+			defer kv.Close()
+			actual, err := kv.Get(key)
+			require.NoError(t, err)
+			require.Equal(t, val, actual)
+			actual, err = kv.Get(common.Hash{0xaa})
+			require.NoError(t, err)
+			require.Empty(t, actual)
+			_, err = kv.Get(common.Hash{0xbb})
+			require.ErrorIs(t, err, ErrNotFound)
+		})
+	}
+}
+
+func TestDBKVBatching(t *testing.T) {
+	kv, err := NewDBKV(t.TempDir(), types.DataFormatPebble)
+	require.NoError(t, err)
+	defer kv.Close()
+
+	val := []byte{1, 2, 3}
+	require.NoError(t, kv.Put(common.Hash{0x01}, val))
+	require.Len(t, kv.pending, 1)
+	// Pending pre-images are available before they are written to the database
+	actual, err := kv.Get(common.Hash{0x01})
+	require.NoError(t, err)
+	require.Equal(t, val, actual)
+	has, err := kv.db.Has(common.Hash{0x01}.Bytes())
+	require.NoError(t, err)
+	require.False(t, has)
+
+	require.NoError(t, kv.Flush())
+	require.Empty(t, kv.pending)
+	has, err = kv.db.Has(common.Hash{0x01}.Bytes())
+	require.NoError(t, err)
+	require.True(t, has)
+
+	// The batch is written automatically once it is large enough
+	large := make([]byte, ethdb.IdealBatchSize)
+	require.NoError(t, kv.Put(common.Hash{0x02}, large))
+	require.Empty(t, kv.pending)
+	actual, err = kv.Get(common.Hash{0x02})
+	require.NoError(t, err)
+	require.Equal(t, large, actual)
+}
+
+func TestDBKVUnsupportedFormat(t *testing.T) {
+	_, err := NewDBKV(t.TempDir(), types.DataFormatDirectory)
+	require.ErrorContains(t, err, "unsupported database format")
+}
@@ -0,0 +1,79 @@
+package kvstore
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// migrateReadDirBatch is the number of directory entries read at a time,
+// so a directory with millions of pre-images isn't listed into memory all at once.
+const migrateReadDirBatch = 1024
+
+// MigrateDirectory copies every pre-image stored by DiskKV in the directory to the destination KV store,
+// calling progress with the number of pre-images copied so far after each one. The directory is not modified.
+// Files in the directory that are not pre-images, such as temp files of incomplete writes, are skipped.
+// Returns the number of pre-images copied.
+func MigrateDirectory(dir string, dest KV, progress func(count int)) (int, error) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return 0, fmt.Errorf("failed to open pre-image directory %v: %w", dir, err)
+	}
+	defer f.Close()
+	count := 0
+	for {
+		entries, err := f.ReadDir(migrateReadDirBatch)
+		for _, entry := range entries {
+			key, ok := diskKVKey(entry)
+			if !ok {
+				continue
+			}
+			value, err := readDiskKVFile(filepath.Join(dir, entry.Name()))
+			if err != nil {
+				return count, fmt.Errorf("failed to read pre-image %s: %w", key, err)
+			}
+			if err := dest.Put(key, value); err != nil {
+				return count, fmt.Errorf("failed to write pre-image %s: %w", key, err)
+			}
+			count++
+			if progress != nil {
+				progress(count)
+			}
+		}
+		if errors.Is(err, io.EOF) {
+			return count, nil
+		} else if err != nil {
+			return count, fmt.Errorf("failed to list pre-image directory %v: %w", dir, err)
+		}
+	}
+}
+
+// diskKVKey parses the key of a pre-image file named by DiskKV.
+func diskKVKey(entry os.DirEntry) (common.Hash, bool) {
+	if !entry.Type().IsRegular() {
+		return common.Hash{}, false
+	}
+	name, ok := strings.CutSuffix(entry.Name(), ".txt")
+	if !ok || len(name) != 2+2*common.HashLength || !strings.HasPrefix(name, "0x") {
+		return common.Hash{}, false
+	}
+	key, err := hex.DecodeString(name[2:])
+	if err != nil {
+		return common.Hash{}, false
+	}
+	return common.BytesToHash(key), true
+}
+
+func readDiskKVFile(path string) ([]byte, error) {
+	dat, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return hex.DecodeString(string(dat))
+}
@@ -0,0 +1,59 @@
+package kvstore
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMigrateDirectory(t *testing.T) {
+	dir := t.TempDir()
+	source := NewDiskKV(dir)
+	values := make(map[common.Hash][]byte)
+	for i := 0; i < migrateReadDirBatch+10; i++ {
+		val := []byte{byte(i), byte(i >> 8)}
+		key := crypto.Keccak256Hash(val)
+		values[key] = val
+		require.NoError(t, source.Put(key, val))
+	}
+	require.NoError(t, source.Put(common.Hash{0xaa}, []byte{}))
+	values[common.Hash{0xaa}] = []byte{}
+
+	// Files that aren't pre-images are skipped
+	require.NoError(t, os.WriteFile(filepath.Join(dir, common.Hash{0xbb}.String()+".txt.1234"), []byte("00"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "0x1234.txt"), []byte("00"), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "0x"+common.Hash{}.String()[4:]+"zz.txt"), []byte("00"), 0o644))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, common.Hash{0xcc}.String()+".txt"), 0o755))
+
+	dest := NewMemKV()
+	var progress []int
+	count, err := MigrateDirectory(dir, dest, func(count int) {
+		progress = append(progress, count)
+	})
+	require.NoError(t, err)
+	require.Equal(t, len(values), count)
+	require.Len(t, progress, len(values))
+	require.Equal(t, len(values), progress[len(progress)-1])
+	require.Len(t, dest.m, len(values))
+	for key, val := range values {
+		actual, err := dest.Get(key)
+		require.NoError(t, err)
+		require.Equal(t, val, actual)
+	}
+}
+
+func TestMigrateDirectoryInvalidPreimage(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, common.Hash{0xaa}.String()+".txt"), []byte("not hex"), 0o644))
+	_, err := MigrateDirectory(dir, NewMemKV(), nil)
+	require.ErrorContains(t, err, "failed to read pre-image")
+}
+
+func TestMigrateDirectoryMissing(t *testing.T) {
+	_, err := MigrateDirectory(filepath.Join(t.TempDir(), "missing"), NewMemKV(), nil)
+	require.ErrorIs(t, err, os.ErrNotExist)
+}
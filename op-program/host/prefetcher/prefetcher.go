@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
 	"github.com/ethereum-optimism/optimism/op-program/client/l2"
@@ -32,19 +33,29 @@ type L2Source interface {
 	OutputByRoot(ctx context.Context, root common.Hash) (eth.Output, error)
 }
 
+// AltDASource retrieves the inputs of alt-DA commitments.
+type AltDASource interface {
+	GetInput(ctx context.Context, comm altda.Keccak256Commitment) ([]byte, error)
+}
+
 type Prefetcher struct {
 	logger    log.Logger
 	l1Fetcher L1Source
 	l2Fetcher L2Source
-	lastHint  string
-	kvStore   kvstore.KV
+	// altDA is nil if no alt-DA server is configured
+	altDA    AltDASource
+	lastHint string
+	kvStore  kvstore.KV
 }
 
-func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l2Fetcher L2Source, kvStore kvstore.KV) *Prefetcher {
+// NewPrefetcher creates a prefetcher. The alt-DA source is optional, and only required if the
+// rollup uses alt-DA.
+func NewPrefetcher(logger log.Logger, l1Fetcher L1Source, l2Fetcher L2Source, altDA AltDASource, kvStore kvstore.KV) *Prefetcher {
 	return &Prefetcher{
 		logger:    logger,
 		l1Fetcher: NewRetryingL1Source(logger, l1Fetcher),
 		l2Fetcher: NewRetryingL2Source(logger, l2Fetcher),
+		altDA:     altDA,
 		kvStore:   kvStore,
 	}
 }
@@ -103,6 +114,15 @@ func (p *Prefetcher) prefetch(ctx context.Context, hint string) error {
 			return fmt.Errorf("failed to fetch L1 block %s receipts: %w", hash, err)
 		}
 		return p.storeReceipts(receipts)
+	case l1.HintAltDAInput:
+		if p.altDA == nil {
+			return fmt.Errorf("cannot fetch alt-DA input %s: no alt-DA server configured", hash)
+		}
+		input, err := p.altDA.GetInput(ctx, altda.Keccak256Commitment(hash))
+		if err != nil {
+			return fmt.Errorf("failed to fetch alt-DA input %s: %w", hash, err)
+		}
+		return p.kvStore.Put(preimage.Keccak256Key(hash).PreimageKey(), input)
 	case l2.HintL2BlockHeader, l2.HintL2Transactions:
 		header, txs, err := p.l2Fetcher.InfoAndTxsByHash(ctx, hash)
 		if err != nil {
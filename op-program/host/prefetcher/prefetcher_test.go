@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/stretchr/testify/require"
 
+	altda "github.com/ethereum-optimism/optimism/op-alt-da"
 	"github.com/ethereum-optimism/optimism/op-node/testutils"
 	preimage "github.com/ethereum-optimism/optimism/op-preimage"
 	"github.com/ethereum-optimism/optimism/op-program/client/l1"
@@ -257,6 +258,49 @@ func TestFetchL2Code(t *testing.T) {
 	})
 }
 
+type altDASource map[altda.Keccak256Commitment][]byte
+
+func (s altDASource) GetInput(_ context.Context, comm altda.Keccak256Commitment) ([]byte, error) {
+	input, ok := s[comm]
+	if !ok {
+		return nil, altda.ErrNotFound
+	}
+	return input, nil
+}
+
+func TestFetchAltDAInput(t *testing.T) {
+	rng := rand.New(rand.NewSource(123))
+	input := testutils.RandomData(rng, 30)
+	comm := altda.Keccak256(input)
+
+	t.Run("Unknown", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		prefetcher.altDA = altDASource{comm: input}
+
+		fetcher := l1.NewAltDAInputFetcher(asOracleFn(t, prefetcher), asHinter(t, prefetcher))
+		result, err := fetcher.GetInput(context.Background(), comm)
+		require.NoError(t, err)
+		require.EqualValues(t, input, result)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+		prefetcher.altDA = altDASource{}
+
+		require.NoError(t, prefetcher.Hint(l1.AltDAInputHint(comm).Hint()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.Keccak256Key(common.Hash(comm)).PreimageKey())
+		require.ErrorIs(t, err, altda.ErrNotFound)
+	})
+
+	t.Run("NoServer", func(t *testing.T) {
+		prefetcher, _, _, _ := createPrefetcher(t)
+
+		require.NoError(t, prefetcher.Hint(l1.AltDAInputHint(comm).Hint()))
+		_, err := prefetcher.GetPreimage(context.Background(), preimage.Keccak256Key(common.Hash(comm)).PreimageKey())
+		require.ErrorContains(t, err, "no alt-DA server configured")
+	})
+}
+
 func TestBadHints(t *testing.T) {
 	prefetcher, _, _, kv := createPrefetcher(t)
 	hash := common.Hash{0xad}
@@ -314,7 +358,7 @@ func TestRetryWhenNotAvailableAfterPrefetching(t *testing.T) {
 	_, l1Source, l2Cl, kv := createPrefetcher(t)
 	putsToIgnore := 2
 	kv = &unreliableKvStore{KV: kv, putsToIgnore: putsToIgnore}
-	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlInfo), l1Source, l2Cl, kv)
+	prefetcher := NewPrefetcher(testlog.Logger(t, log.LvlInfo), l1Source, l2Cl, nil, kv)
 
 	// Expect one call for each ignored put, plus one more request for when the put succeeds
 	for i := 0; i < putsToIgnore+1; i++ {
@@ -365,7 +409,7 @@ func createPrefetcher(t *testing.T) (*Prefetcher, *testutils.MockL1Source, *l2Cl
 		MockDebugClient: new(testutils.MockDebugClient),
 	}
 
-	prefetcher := NewPrefetcher(logger, l1Source, l2Source, kv)
+	prefetcher := NewPrefetcher(logger, l1Source, l2Source, nil, kv)
 	return prefetcher, l1Source, l2Source, kv
 }
 
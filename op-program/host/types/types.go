@@ -0,0 +1,29 @@
+package types
+
+// DataFormat is the format the pre-image data is stored in within the data directory.
+type DataFormat string
+
+const (
+	// DataFormatDirectory stores each pre-image as a separate hex encoded file.
+	DataFormatDirectory DataFormat = "directory"
+	// DataFormatPebble stores the pre-images in a pebble database.
+	DataFormatPebble DataFormat = "pebble"
+	// DataFormatLevelDB stores the pre-images in a LevelDB database.
+	DataFormatLevelDB DataFormat = "leveldb"
+)
+
+var SupportedDataFormats = []DataFormat{DataFormatDirectory, DataFormatPebble, DataFormatLevelDB}
+
+func (f DataFormat) String() string {
+	return string(f)
+}
+
+// Valid returns true if the data format is one of the supported formats.
+func (f DataFormat) Valid() bool {
+	for _, supported := range SupportedDataFormats {
+		if f == supported {
+			return true
+		}
+	}
+	return false
+}
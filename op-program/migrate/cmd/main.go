@@ -0,0 +1,80 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/ethereum-optimism/optimism/op-program/host/kvstore"
+	"github.com/ethereum-optimism/optimism/op-program/host/types"
+	openum "github.com/ethereum-optimism/optimism/op-service/enum"
+	oplog "github.com/ethereum-optimism/optimism/op-service/log"
+)
+
+const progressInterval = 100_000
+
+var (
+	SourceFlag = &cli.PathFlag{
+		Name:     "source",
+		Usage:    "Directory of pre-image data in the directory format, which is not modified",
+		Required: true,
+	}
+	DestFlag = &cli.PathFlag{
+		Name:     "dest",
+		Usage:    "Directory to write the database of pre-image data to, which is created if it doesn't exist",
+		Required: true,
+	}
+	DataFormatFlag = &cli.StringFlag{
+		Name:  "data.format",
+		Usage: fmt.Sprintf("Format of the database to write. Available formats: %s", openum.EnumString([]types.DataFormat{types.DataFormatPebble, types.DataFormatLevelDB})),
+		Value: string(types.DataFormatPebble),
+	}
+)
+
+func main() {
+	oplog.SetupDefaults()
+	app := cli.NewApp()
+	app.Name = "migrate"
+	app.Usage = "Migrate op-program pre-image data to a database"
+	app.Description = "Copies the pre-images of an op-program datadir in the directory format, with a file per pre-image, " +
+		"to a datadir in a database format. Run op-program with the new datadir and the matching --data.format."
+	app.Flags = []cli.Flag{SourceFlag, DestFlag, DataFormatFlag}
+	app.Action = Migrate
+	if err := app.Run(os.Args); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "Failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func Migrate(ctx *cli.Context) error {
+	logger := oplog.NewLogger(oplog.DefaultCLIConfig())
+	format := types.DataFormat(ctx.String(DataFormatFlag.Name))
+	if format != types.DataFormatPebble && format != types.DataFormatLevelDB {
+		return fmt.Errorf("unsupported data format: %v", format)
+	}
+	source := ctx.Path(SourceFlag.Name)
+	dest := ctx.Path(DestFlag.Name)
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return fmt.Errorf("failed to create destination directory: %w", err)
+	}
+	kv, err := kvstore.NewDBKV(dest, format)
+	if err != nil {
+		return err
+	}
+	logger.Info("Migrating pre-images", "source", source, "dest", dest, "format", format)
+	count, err := kvstore.MigrateDirectory(source, kv, func(count int) {
+		if count%progressInterval == 0 {
+			logger.Info("Migrating pre-images", "count", count)
+		}
+	})
+	if closeErr := kv.Close(); closeErr != nil {
+		err = errors.Join(err, closeErr)
+	}
+	if err != nil {
+		return err
+	}
+	logger.Info("Migrated pre-images", "count", count)
+	return nil
+}
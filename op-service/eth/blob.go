@@ -0,0 +1,241 @@
+package eth
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+)
+
+const (
+	BlobSize        = 4096 * 32
+	MaxBlobDataSize = 4096*31 - 4
+	// blobEncodingVersion is the version of the encoding of arbitrary data into a blob.
+	blobEncodingVersion = 0
+	// VersionedHashVersionKZG is the version byte of a KZG commitment versioned hash, see EIP-4844.
+	VersionedHashVersionKZG = 0x01
+)
+
+var (
+	ErrBlobInvalidFieldElement        = errors.New("invalid field element")
+	ErrBlobInvalidEncodingVersion     = errors.New("invalid blob encoding version")
+	ErrBlobInvalidLength              = errors.New("invalid length for blob")
+	ErrBlobInputTooLarge              = errors.New("too much data to encode in one blob")
+	ErrBlobExtraneousData             = errors.New("non-zero data encountered where blob should be empty")
+	ErrBlobExtraneousDataFieldElement = errors.New("non-zero data encountered where field element should be empty")
+)
+
+// Blob is an EIP-4844 data blob of 4096 field elements of 32 bytes each.
+type Blob [BlobSize]byte
+
+func (b *Blob) KZGBlob() *kzg4844.Blob {
+	return (*kzg4844.Blob)(b)
+}
+
+func (b *Blob) UnmarshalJSON(text []byte) error {
+	return hexutil.UnmarshalFixedJSON(reflect.TypeOf(b), text, b[:])
+}
+
+func (b *Blob) UnmarshalText(text []byte) error {
+	return hexutil.UnmarshalFixedText("Blob", text, b[:])
+}
+
+func (b *Blob) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(b[:]).MarshalText()
+}
+
+func (b *Blob) String() string {
+	return hexutil.Encode(b[:])
+}
+
+// TerminalString implements log.TerminalStringer, formatting a string for console
+// output during logging.
+func (b *Blob) TerminalString() string {
+	return fmt.Sprintf("%x..%x", b[:3], b[BlobSize-3:])
+}
+
+func (b *Blob) ComputeKZGCommitment() (kzg4844.Commitment, error) {
+	return kzg4844.BlobToCommitment(*b.KZGBlob())
+}
+
+// KZGToVersionedHash computes the "blob hash" (a.k.a. versioned-hash) of a blob-commitment, as used in a blob-tx.
+// We implement it here because it is unfortunately not (currently) exposed by geth.
+func KZGToVersionedHash(commitment kzg4844.Commitment) (out common.Hash) {
+	// EIP-4844 spec:
+	//	def kzg_to_versioned_hash(commitment: KZGCommitment) -> VersionedHash:
+	//		return VERSIONED_HASH_VERSION_KZG + sha256(commitment)[1:]
+	h := sha256.New()
+	h.Write(commitment[:])
+	_ = h.Sum(out[:0])
+	out[0] = VersionedHashVersionKZG
+	return out
+}
+
+// VerifyBlobProof verifies that the given blob and proof corresponds to the given commitment,
+// returning error if the verification fails.
+func VerifyBlobProof(blob *Blob, commitment kzg4844.Commitment, proof kzg4844.Proof) error {
+	return kzg4844.VerifyBlobProof(*blob.KZGBlob(), commitment, proof)
+}
+
+// FromData encodes the given input data into this blob.
+//
+// Every field element keeps its most significant byte at zero, so it always represents a valid
+// BLS12-381 scalar, leaving 31 usable bytes per field element. The first field element holds the
+// encoding version (1 byte) and the length of the data (3 bytes, big-endian), directly followed
+// by the first bytes of the data. The remainder of the blob is zeroed.
+func (b *Blob) FromData(data Data) error {
+	if len(data) > MaxBlobDataSize {
+		return fmt.Errorf("%w: len=%v", ErrBlobInputTooLarge, len(data))
+	}
+	b.Clear()
+
+	b[1] = blobEncodingVersion
+	b[2] = byte(len(data) >> 16)
+	b[3] = byte(len(data) >> 8)
+	b[4] = byte(len(data))
+
+	// the first field element has room for 27 data bytes after the header
+	n := copy(b[5:32], data)
+	data = data[n:]
+	for i := 1; len(data) > 0; i++ {
+		n := copy(b[i*32+1:(i+1)*32], data)
+		data = data[n:]
+	}
+	return nil
+}
+
+// ToData decodes the blob into raw byte data. See FromData for the encoding scheme.
+// It returns an error if the blob is not a valid encoding of data.
+func (b *Blob) ToData() (Data, error) {
+	for i := 0; i < BlobSize; i += 32 {
+		if b[i] != 0 {
+			return nil, fmt.Errorf("%w: field element %d has non-zero high-order byte", ErrBlobInvalidFieldElement, i/32)
+		}
+	}
+	if b[1] != blobEncodingVersion {
+		return nil, fmt.Errorf("%w: expected version %d, got %d", ErrBlobInvalidEncodingVersion, blobEncodingVersion, b[1])
+	}
+	length := uint32(b[2])<<16 | uint32(b[3])<<8 | uint32(b[4])
+	if length > MaxBlobDataSize {
+		return nil, fmt.Errorf("%w: got %d", ErrBlobInvalidLength, length)
+	}
+
+	out := make(Data, 0, length)
+	remaining := int(length)
+	// index of the field element that is currently being read
+	fe := 0
+	chunk := b[5:32]
+	for {
+		if remaining <= len(chunk) {
+			// the rest of this field element must be empty
+			for _, x := range chunk[remaining:] {
+				if x != 0 {
+					return nil, fmt.Errorf("%w: field element %d", ErrBlobExtraneousDataFieldElement, fe)
+				}
+			}
+			out = append(out, chunk[:remaining]...)
+			break
+		}
+		out = append(out, chunk...)
+		remaining -= len(chunk)
+		fe++
+		chunk = b[fe*32+1 : (fe+1)*32]
+	}
+
+	// all field elements after the data must be empty
+	for i := (fe + 1) * 32; i < BlobSize; i++ {
+		if b[i] != 0 {
+			return nil, fmt.Errorf("%w: at byte %d", ErrBlobExtraneousData, i)
+		}
+	}
+	return out, nil
+}
+
+// Clear resets the blob to all zeroes.
+func (b *Blob) Clear() {
+	for i := 0; i < BlobSize; i++ {
+		b[i] = 0
+	}
+}
+
+type Bytes48 [48]byte
+
+func (b *Bytes48) UnmarshalJSON(text []byte) error {
+	return hexutil.UnmarshalFixedJSON(reflect.TypeOf(b), text, b[:])
+}
+
+func (b *Bytes48) UnmarshalText(text []byte) error {
+	return hexutil.UnmarshalFixedText("Bytes48", text, b[:])
+}
+
+func (b Bytes48) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(b[:]).MarshalText()
+}
+
+func (b Bytes48) String() string {
+	return hexutil.Encode(b[:])
+}
+
+// TerminalString implements log.TerminalStringer, formatting a string for console
+// output during logging.
+func (b Bytes48) TerminalString() string {
+	return fmt.Sprintf("%x..%x", b[:3], b[45:])
+}
+
+// Uint64String is a decimal string representation of an uint64, as used by the beacon API.
+type Uint64String uint64
+
+func (v Uint64String) MarshalText() ([]byte, error) {
+	return []byte(strconv.FormatUint(uint64(v), 10)), nil
+}
+
+func (v *Uint64String) UnmarshalText(b []byte) error {
+	n, err := strconv.ParseUint(string(b), 0, 64)
+	if err != nil {
+		return err
+	}
+	*v = Uint64String(n)
+	return nil
+}
+
+// IndexedBlobHash represents a blob hash that commits to a single blob confirmed in a block.
+// The index helps us avoid unnecessary blob to blob hash conversions to find the right content in a sidecar.
+type IndexedBlobHash struct {
+	Index uint64      // absolute index in the block, a.k.a. position in sidecar blobs array
+	Hash  common.Hash // hash of the blob, used for consistency checks
+}
+
+// BlobSidecar is a blob, together with its KZG commitment and proof, as served by the beacon API.
+type BlobSidecar struct {
+	BlockRoot     Bytes32      `json:"block_root"`
+	Slot          Uint64String `json:"slot"`
+	Blob          Blob         `json:"blob"`
+	Index         Uint64String `json:"index"`
+	KZGCommitment Bytes48      `json:"kzg_commitment"`
+	KZGProof      Bytes48      `json:"kzg_proof"`
+}
+
+type APIGetBlobSidecarsResponse struct {
+	Data []*BlobSidecar `json:"data"`
+}
+
+type APIGenesisResponse struct {
+	Data ReducedGenesisData `json:"data"`
+}
+
+type ReducedGenesisData struct {
+	GenesisTime Uint64String `json:"genesis_time"`
+}
+
+type APIConfigResponse struct {
+	Data ReducedConfigData `json:"data"`
+}
+
+type ReducedConfigData struct {
+	SecondsPerSlot Uint64String `json:"SECONDS_PER_SLOT"`
+}
@@ -0,0 +1,75 @@
+package eth
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobEncodeDecode(t *testing.T) {
+	rng := rand.New(rand.NewSource(1234))
+	for _, size := range []int{0, 1, 26, 27, 28, 31, 58, 59, 1000, 30_000, MaxBlobDataSize - 1, MaxBlobDataSize} {
+		data := make(Data, size)
+		rng.Read(data)
+
+		var b Blob
+		require.NoError(t, b.FromData(data))
+		for i := 0; i < BlobSize; i += 32 {
+			require.Zero(t, b[i], "field element %d must have a zero high-order byte", i/32)
+		}
+		decoded, err := b.ToData()
+		require.NoError(t, err, "size %d", size)
+		require.Equal(t, data, decoded, "size %d", size)
+	}
+}
+
+func TestBlobTooLarge(t *testing.T) {
+	var b Blob
+	require.ErrorIs(t, b.FromData(make(Data, MaxBlobDataSize+1)), ErrBlobInputTooLarge)
+}
+
+func TestBlobDecodeInvalid(t *testing.T) {
+	var b Blob
+	require.NoError(t, b.FromData(Data("hello world")))
+
+	t.Run("invalid field element", func(t *testing.T) {
+		invalid := b
+		invalid[32*7] = 1
+		_, err := invalid.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidFieldElement)
+	})
+	t.Run("invalid version", func(t *testing.T) {
+		invalid := b
+		invalid[1] = 1
+		_, err := invalid.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidEncodingVersion)
+	})
+	t.Run("invalid length", func(t *testing.T) {
+		invalid := b
+		invalid[2] = 0xff
+		_, err := invalid.ToData()
+		require.ErrorIs(t, err, ErrBlobInvalidLength)
+	})
+	t.Run("extraneous data in field element", func(t *testing.T) {
+		invalid := b
+		invalid[31] = 1
+		_, err := invalid.ToData()
+		require.ErrorIs(t, err, ErrBlobExtraneousDataFieldElement)
+	})
+	t.Run("extraneous data", func(t *testing.T) {
+		invalid := b
+		invalid[BlobSize-1] = 1
+		_, err := invalid.ToData()
+		require.ErrorIs(t, err, ErrBlobExtraneousData)
+	})
+}
+
+func TestBlobKZG(t *testing.T) {
+	var b Blob
+	require.NoError(t, b.FromData(Data("some batch data")))
+	commitment, err := b.ComputeKZGCommitment()
+	require.NoError(t, err)
+	hash := KZGToVersionedHash(commitment)
+	require.Equal(t, byte(VersionedHashVersionKZG), hash[0])
+}
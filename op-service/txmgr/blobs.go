@@ -0,0 +1,106 @@
+package txmgr
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto/kzg4844"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rlp"
+
+	"github.com/ethereum-optimism/optimism/op-service/eth"
+)
+
+// BlobTxSidecar contains the blobs of a blob transaction, together with their KZG commitments and proofs.
+// It is not part of the signed transaction, but is required to publish the transaction to the network.
+type BlobTxSidecar struct {
+	Blobs       []kzg4844.Blob
+	Commitments []kzg4844.Commitment
+	Proofs      []kzg4844.Proof
+}
+
+// MakeSidecar computes the KZG commitments and proofs of the given blobs.
+func MakeSidecar(blobs []*eth.Blob) (*BlobTxSidecar, error) {
+	sidecar := &BlobTxSidecar{}
+	for i, blob := range blobs {
+		rawBlob := *blob.KZGBlob()
+		sidecar.Blobs = append(sidecar.Blobs, rawBlob)
+		commitment, err := kzg4844.BlobToCommitment(rawBlob)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compute KZG commitment of blob %d in tx candidate: %w", i, err)
+		}
+		sidecar.Commitments = append(sidecar.Commitments, commitment)
+		proof, err := kzg4844.ComputeBlobProof(rawBlob, commitment)
+		if err != nil {
+			return nil, fmt.Errorf("cannot compute KZG proof for fast commitment verification of blob %d in tx candidate: %w", i, err)
+		}
+		sidecar.Proofs = append(sidecar.Proofs, proof)
+	}
+	return sidecar, nil
+}
+
+// BlobHashes computes the versioned hashes of the blob commitments, as included in the blob transaction.
+func (sc *BlobTxSidecar) BlobHashes() []common.Hash {
+	hashes := make([]common.Hash, len(sc.Commitments))
+	for i, commitment := range sc.Commitments {
+		hashes[i] = eth.KZGToVersionedHash(commitment)
+	}
+	return hashes
+}
+
+// blobTxWithSidecar is the EIP-4844 network representation of a blob transaction:
+// the transaction payload body, followed by the blobs, commitments and proofs.
+type blobTxWithSidecar struct {
+	Tx          rlp.RawValue
+	Blobs       []kzg4844.Blob
+	Commitments []kzg4844.Commitment
+	Proofs      []kzg4844.Proof
+}
+
+// EncodeBlobTxNetwork encodes a signed blob transaction together with its sidecar, in the
+// network representation that is accepted by eth_sendRawTransaction:
+//
+//	0x03 || rlp([tx_payload_body, blobs, commitments, proofs])
+func EncodeBlobTxNetwork(tx *types.Transaction, sidecar *BlobTxSidecar) ([]byte, error) {
+	if tx.Type() != types.BlobTxType {
+		return nil, fmt.Errorf("expected blob tx, got tx type %d", tx.Type())
+	}
+	if len(sidecar.Blobs) != len(tx.BlobHashes()) {
+		return nil, fmt.Errorf("tx has %d blob hashes, but sidecar has %d blobs", len(tx.BlobHashes()), len(sidecar.Blobs))
+	}
+	txData, err := tx.MarshalBinary()
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode blob tx: %w", err)
+	}
+	var buf bytes.Buffer
+	buf.WriteByte(types.BlobTxType)
+	err = rlp.Encode(&buf, &blobTxWithSidecar{
+		Tx:          txData[1:], // strip the tx type byte
+		Blobs:       sidecar.Blobs,
+		Commitments: sidecar.Commitments,
+		Proofs:      sidecar.Proofs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode blob tx with sidecar: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// blobTxClient extends the eth client with the ability to send blob transactions.
+type blobTxClient struct {
+	*ethclient.Client
+}
+
+var _ BlobTxBackend = (*blobTxClient)(nil)
+
+func (c *blobTxClient) SendBlobTransaction(ctx context.Context, tx *types.Transaction, sidecar *BlobTxSidecar) error {
+	data, err := EncodeBlobTxNetwork(tx, sidecar)
+	if err != nil {
+		return err
+	}
+	return c.Client.Client().CallContext(ctx, nil, "eth_sendRawTransaction", hexutil.Encode(data))
+}
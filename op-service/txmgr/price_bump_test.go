@@ -17,13 +17,14 @@ type priceBumpTest struct {
 	newBasefee  int64
 	expectedTip int64
 	expectedFC  int64
+	isBlobTx    bool
 }
 
 func (tc *priceBumpTest) run(t *testing.T) {
 	prevFC := calcGasFeeCap(big.NewInt(tc.prevBasefee), big.NewInt(tc.prevGasTip))
 	lgr := testlog.Logger(t, log.LvlCrit)
 
-	tip, fc := updateFees(big.NewInt(tc.prevGasTip), prevFC, big.NewInt(tc.newGasTip), big.NewInt(tc.newBasefee), lgr)
+	tip, fc := updateFees(big.NewInt(tc.prevGasTip), prevFC, big.NewInt(tc.newGasTip), big.NewInt(tc.newBasefee), tc.isBlobTx, lgr)
 
 	require.Equal(t, tc.expectedTip, tip.Int64(), "tip must be as expected")
 	require.Equal(t, tc.expectedFC, fc.Int64(), "fee cap must be as expected")
@@ -89,3 +90,38 @@ func TestUpdateFees(t *testing.T) {
 		t.Run(fmt.Sprint(i), test.run)
 	}
 }
+
+func TestUpdateFeesBlobTx(t *testing.T) {
+	require.Equal(t, int64(100), blobPriceBump, "test must be updated if blobPriceBump is adjusted")
+	tests := []priceBumpTest{
+		{
+			prevGasTip: 100, prevBasefee: 1000,
+			newGasTip: 90, newBasefee: 900,
+			expectedTip: 200, expectedFC: 4200,
+			isBlobTx: true,
+		},
+		{
+			prevGasTip: 100, prevBasefee: 1000,
+			newGasTip: 150, newBasefee: 1000,
+			expectedTip: 200, expectedFC: 4200,
+			isBlobTx: true,
+		},
+		{
+			prevGasTip: 100, prevBasefee: 1000,
+			newGasTip: 101, newBasefee: 3000,
+			expectedTip: 200, expectedFC: 6200,
+			isBlobTx: true,
+		},
+		{
+			prevGasTip: 100, prevBasefee: 1000,
+			newGasTip: 250, newBasefee: 2000,
+			expectedTip: 250, expectedFC: 4250,
+			isBlobTx: true,
+		},
+	}
+	for i, test := range tests {
+		i := i
+		test := test
+		t.Run(fmt.Sprint(i), test.run)
+	}
+}
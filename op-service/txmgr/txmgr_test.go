@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/ethereum-optimism/optimism/op-node/testlog"
+	"github.com/ethereum-optimism/optimism/op-service/eth"
 	"github.com/ethereum-optimism/optimism/op-service/txmgr/metrics"
 
 	"github.com/ethereum/go-ethereum"
@@ -286,7 +287,7 @@ func TestTxMgrConfirmAtMinGasPrice(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -314,7 +315,7 @@ func TestTxMgrNeverConfirmCancel(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -343,7 +344,7 @@ func TestTxMgrConfirmsAtHigherGasPrice(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -374,7 +375,7 @@ func TestTxMgrBlocksOnFailingRpcCalls(t *testing.T) {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Equal(t, err, context.DeadlineExceeded)
 	require.Nil(t, receipt)
 }
@@ -387,7 +388,7 @@ func TestTxMgr_CraftTx(t *testing.T) {
 
 	// Craft the transaction.
 	gasTipCap, gasFeeCap := h.gasPricer.feesForEpoch(h.gasPricer.epoch + 1)
-	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	tx, err := h.mgr.craftTx(context.Background(), candidate, nil)
 	require.Nil(t, err)
 	require.NotNil(t, tx)
 
@@ -416,7 +417,7 @@ func TestTxMgr_EstimateGas(t *testing.T) {
 	gasEstimate := h.gasPricer.baseBaseFee.Uint64()
 
 	// Craft the transaction.
-	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	tx, err := h.mgr.craftTx(context.Background(), candidate, nil)
 	require.Nil(t, err)
 	require.NotNil(t, tx)
 
@@ -433,18 +434,18 @@ func TestTxMgr_EstimateGasFails(t *testing.T) {
 	candidate.GasLimit = 0
 
 	// Craft a successful transaction.
-	tx, err := h.mgr.craftTx(context.Background(), candidate)
+	tx, err := h.mgr.craftTx(context.Background(), candidate, nil)
 	require.Nil(t, err)
 	lastNonce := tx.Nonce()
 
 	// Mock gas estimation failure.
 	h.gasPricer.err = fmt.Errorf("execution error")
-	_, err = h.mgr.craftTx(context.Background(), candidate)
+	_, err = h.mgr.craftTx(context.Background(), candidate, nil)
 	require.ErrorContains(t, err, "failed to estimate gas")
 
 	// Ensure successful craft uses the correct nonce
 	h.gasPricer.err = nil
-	tx, err = h.mgr.craftTx(context.Background(), candidate)
+	tx, err = h.mgr.craftTx(context.Background(), candidate, nil)
 	require.Nil(t, err)
 	require.Equal(t, lastNonce+1, tx.Nonce())
 }
@@ -477,7 +478,7 @@ func TestTxMgrOnlyOnePublicationSucceeds(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 
 	require.NotNil(t, receipt)
@@ -512,7 +513,7 @@ func TestTxMgrConfirmsMinGasPriceAfterBumping(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -557,7 +558,7 @@ func TestTxMgrDoesntAbortNonceTooLowAfterMiningTx(t *testing.T) {
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
-	receipt, err := h.mgr.sendTx(ctx, tx)
+	receipt, err := h.mgr.sendTx(ctx, tx, nil)
 	require.Nil(t, err)
 	require.NotNil(t, receipt)
 	require.Equal(t, h.gasPricer.expGasFeeCap().Uint64(), receipt.GasUsed)
@@ -657,6 +658,7 @@ type failingBackend struct {
 	returnSuccessBlockNumber bool
 	returnSuccessReceipt     bool
 	baseFee, gasTip          *big.Int
+	excessDataGas            *big.Int
 }
 
 // BlockNumber for the failingBackend returns errRpcFailure on the first
@@ -688,7 +690,8 @@ func (b *failingBackend) TransactionReceipt(
 
 func (b *failingBackend) HeaderByNumber(_ context.Context, _ *big.Int) (*types.Header, error) {
 	return &types.Header{
-		BaseFee: b.baseFee,
+		BaseFee:       b.baseFee,
+		ExcessDataGas: b.excessDataGas,
 	}, nil
 }
 
@@ -960,3 +963,169 @@ func TestNonceReset(t *testing.T) {
 	// internal nonce tracking should be reset every 3rd tx
 	require.Equal(t, []uint64{0, 0, 1, 2, 0, 1, 2, 0}, nonces)
 }
+
+// blobBackend extends the mockBackend with blob transaction support.
+type blobBackend struct {
+	*mockBackend
+	excessDataGas *big.Int
+	sidecars      map[common.Hash]*BlobTxSidecar
+}
+
+func (b *blobBackend) HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error) {
+	return &types.Header{
+		BaseFee:       b.g.basefee(),
+		ExcessDataGas: b.excessDataGas,
+	}, nil
+}
+
+func (b *blobBackend) SendBlobTransaction(ctx context.Context, tx *types.Transaction, sidecar *BlobTxSidecar) error {
+	b.mu.Lock()
+	b.sidecars[tx.Hash()] = sidecar
+	b.mu.Unlock()
+	return b.SendTransaction(ctx, tx)
+}
+
+func newBlobTestHarness(t *testing.T) (*testHarness, *blobBackend) {
+	h := newTestHarness(t)
+	backend := &blobBackend{
+		mockBackend:   h.backend,
+		excessDataGas: big.NewInt(0),
+		sidecars:      make(map[common.Hash]*BlobTxSidecar),
+	}
+	h.mgr.backend = backend
+	return h, backend
+}
+
+func testBlobs(t *testing.T, data ...string) []*eth.Blob {
+	var blobs []*eth.Blob
+	for _, d := range data {
+		var b eth.Blob
+		require.NoError(t, b.FromData(eth.Data(d)))
+		blobs = append(blobs, &b)
+	}
+	return blobs
+}
+
+// TestTxMgr_CraftBlobTx ensures that the tx manager creates blob transactions
+// when the candidate carries blobs.
+func TestTxMgr_CraftBlobTx(t *testing.T) {
+	t.Parallel()
+	h, _ := newBlobTestHarness(t)
+	candidate := h.createTxCandidate()
+	candidate.TxData = nil
+	candidate.Blobs = testBlobs(t, "first", "second")
+
+	sidecar, err := MakeSidecar(candidate.Blobs)
+	require.NoError(t, err)
+	require.Len(t, sidecar.Commitments, 2)
+	require.Len(t, sidecar.Proofs, 2)
+
+	tx, err := h.mgr.craftTx(context.Background(), candidate, sidecar)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), tx.Type())
+	require.Equal(t, sidecar.BlobHashes(), tx.BlobHashes())
+	for i, hash := range tx.BlobHashes() {
+		require.Equal(t, eth.KZGToVersionedHash(sidecar.Commitments[i]), hash)
+	}
+	// the blob base fee is at its minimum, so the blob fee cap is the minimum blob tx fee
+	require.Equal(t, big.NewInt(minBlobTxFee), tx.BlobGasFeeCap())
+
+	enc, err := EncodeBlobTxNetwork(tx, sidecar)
+	require.NoError(t, err)
+	require.Equal(t, byte(types.BlobTxType), enc[0])
+	require.Greater(t, len(enc), 2*eth.BlobSize)
+}
+
+// TestTxMgr_CraftBlobTxPreCancun ensures that blob transactions cannot be
+// crafted if L1 does not provide a blob base fee.
+func TestTxMgr_CraftBlobTxPreCancun(t *testing.T) {
+	t.Parallel()
+	h, backend := newBlobTestHarness(t)
+	backend.excessDataGas = nil
+	candidate := h.createTxCandidate()
+	candidate.Blobs = testBlobs(t, "data")
+	sidecar, err := MakeSidecar(candidate.Blobs)
+	require.NoError(t, err)
+
+	_, err = h.mgr.craftTx(context.Background(), candidate, sidecar)
+	require.ErrorContains(t, err, "blob base fee")
+}
+
+// TestTxMgr_SendBlobTxUnsupportedBackend ensures that blob transactions are
+// rejected if the backend cannot publish them.
+func TestTxMgr_SendBlobTxUnsupportedBackend(t *testing.T) {
+	t.Parallel()
+	h := newTestHarness(t)
+	candidate := h.createTxCandidate()
+	candidate.Blobs = testBlobs(t, "data")
+
+	_, err := h.mgr.Send(context.Background(), candidate)
+	require.ErrorIs(t, err, ErrBlobTxNotSupported)
+}
+
+// TestTxMgr_SendBlobTx ensures that blob transactions are published together
+// with their sidecar.
+func TestTxMgr_SendBlobTx(t *testing.T) {
+	t.Parallel()
+	h, backend := newBlobTestHarness(t)
+	candidate := h.createTxCandidate()
+	candidate.Blobs = testBlobs(t, "data")
+
+	sendTx := func(ctx context.Context, tx *types.Transaction) error {
+		txHash := tx.Hash()
+		h.backend.mine(&txHash, tx.GasFeeCap())
+		return nil
+	}
+	h.backend.setTxSender(sendTx)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	receipt, err := h.mgr.Send(ctx, candidate)
+	require.NoError(t, err)
+	require.NotNil(t, receipt)
+
+	backend.mu.RLock()
+	defer backend.mu.RUnlock()
+	sidecar, ok := backend.sidecars[receipt.TxHash]
+	require.True(t, ok, "blob tx must be sent with sidecar")
+	require.Len(t, sidecar.Blobs, 1)
+}
+
+// TestIncreaseGasPriceBlobTx ensures that the tip, fee cap and blob fee cap
+// of blob transactions are all bumped by at least 100%.
+func TestIncreaseGasPriceBlobTx(t *testing.T) {
+	t.Parallel()
+	borkedBackend := failingBackend{
+		gasTip:        big.NewInt(10),
+		baseFee:       big.NewInt(100),
+		excessDataGas: big.NewInt(0),
+	}
+	mgr := &SimpleTxManager{
+		cfg: Config{
+			Signer: func(ctx context.Context, from common.Address, tx *types.Transaction) (*types.Transaction, error) {
+				return tx, nil
+			},
+		},
+		name:    "TEST",
+		backend: &borkedBackend,
+		l:       testlog.Logger(t, log.LvlCrit),
+		metr:    &metrics.NoopTxMetrics{},
+	}
+
+	inbox := common.HexToAddress("0x42000000000000000000000000000000000000ff")
+	message := &types.BlobTx{
+		To:         &inbox,
+		BlobHashes: []common.Hash{{0x01}},
+	}
+	blobFeeCap := big.NewInt(2 * minBlobTxFee)
+	require.NoError(t, finishBlobTx(message, big.NewInt(1), big.NewInt(10), big.NewInt(210), blobFeeCap, nil))
+	tx := types.NewTx(message)
+
+	newTx, err := mgr.increaseGasPrice(context.Background(), tx)
+	require.NoError(t, err)
+	require.Equal(t, uint8(types.BlobTxType), newTx.Type())
+	require.Equal(t, tx.BlobHashes(), newTx.BlobHashes())
+	require.Equal(t, new(big.Int).Mul(blobFeeCap, big.NewInt(2)), newTx.BlobGasFeeCap())
+	require.Equal(t, new(big.Int).Mul(tx.GasTipCap(), big.NewInt(2)), newTx.GasTipCap())
+	require.Equal(t, new(big.Int).Mul(tx.GasFeeCap(), big.NewInt(2)), newTx.GasFeeCap())
+}
@@ -3,17 +3,34 @@ pragma solidity 0.8.15;
 
 import { IPreimageOracle } from "./interfaces/IPreimageOracle.sol";
 import { PreimageKeyLib } from "./PreimageKeyLib.sol";
+import { LibKeccak } from "./libraries/LibKeccak.sol";
 import "./libraries/CannonErrors.sol";
 
 /// @title PreimageOracle
 /// @notice A contract for storing permissioned pre-images.
 contract PreimageOracle is IPreimageOracle {
+    /// @notice A keccak256 preimage that is loaded across multiple transactions.
+    struct LargePreimageProposal {
+        /// @notice The sponge state after absorbing the first `bytesProcessed` bytes of the preimage.
+        LibKeccak.StateMatrix stateMatrix;
+        /// @notice The size of the preimage, excluding the length prefix.
+        uint64 claimedSize;
+        /// @notice The number of bytes of the preimage absorbed so far.
+        uint64 bytesProcessed;
+        /// @notice The offset of the part to load, including the length prefix.
+        uint64 partOffset;
+        /// @notice The part at `partOffset`, filled in as the bytes it covers are absorbed.
+        bytes32 part;
+    }
+
     /// @notice Mapping of pre-image keys to pre-image lengths.
     mapping(bytes32 => uint256) public preimageLengths;
     /// @notice Mapping of pre-image keys to pre-image parts.
     mapping(bytes32 => mapping(uint256 => bytes32)) public preimageParts;
     /// @notice Mapping of pre-image keys to pre-image part offsets.
     mapping(bytes32 => mapping(uint256 => bool)) public preimagePartOk;
+    /// @notice Mapping of proposer addresses and proposal ids to large preimage proposals.
+    mapping(address => mapping(uint256 => LargePreimageProposal)) internal largePreimageProposals;
 
     /// @inheritdoc IPreimageOracle
     function readPreimage(bytes32 _key, uint256 _offset) external view returns (bytes32 dat_, uint256 datLen_) {
@@ -116,4 +133,111 @@ contract PreimageOracle is IPreimageOracle {
         preimageParts[key][_partOffset] = part;
         preimageLengths[key] = size;
     }
+
+    /// @notice Returns the number of bytes of a large preimage proposal that have been absorbed so far.
+    /// @param _proposer The account that loads the preimage.
+    /// @param _uuid The id of the proposal.
+    /// @return bytesProcessed_ The number of bytes absorbed so far.
+    function largePreimageBytesProcessed(
+        address _proposer,
+        uint256 _uuid
+    )
+        external
+        view
+        returns (uint256 bytesProcessed_)
+    {
+        bytesProcessed_ = largePreimageProposals[_proposer][_uuid].bytesProcessed;
+    }
+
+    /// @inheritdoc IPreimageOracle
+    function initLargeKeccak256Preimage(uint256 _uuid, uint64 _partOffset, uint64 _claimedSize) external {
+        // Revert if the given part offset is not within bounds.
+        if (uint256(_partOffset) > uint256(_claimedSize) + 8) {
+            revert PartOffsetOOB();
+        }
+
+        LargePreimageProposal storage proposal = largePreimageProposals[msg.sender][_uuid];
+        delete proposal.stateMatrix;
+        proposal.claimedSize = _claimedSize;
+        proposal.bytesProcessed = 0;
+        proposal.partOffset = _partOffset;
+        // The length prefix is known up front, so the bytes of the part that cover it can be filled in already.
+        proposal.part = fillPart(bytes32(0), _partOffset, abi.encodePacked(_claimedSize), 0);
+    }
+
+    /// @inheritdoc IPreimageOracle
+    function addLargeKeccak256PreimageData(
+        uint256 _uuid,
+        uint256 _offset,
+        bytes calldata _input,
+        bool _finalize
+    )
+        external
+    {
+        LargePreimageProposal storage proposal = largePreimageProposals[msg.sender][_uuid];
+        uint256 processed = proposal.bytesProcessed;
+        if (_offset != processed) {
+            revert InvalidInputOffset();
+        }
+        uint256 end = processed + _input.length;
+        if (end > proposal.claimedSize) {
+            revert InvalidInputSize();
+        }
+        if (_finalize ? end != proposal.claimedSize : _input.length % LibKeccak.BLOCK_SIZE_BYTES != 0) {
+            revert InvalidInputSize();
+        }
+
+        // Absorb all complete blocks of the input and fill in the bytes of the part that they cover.
+        bytes memory input = _input;
+        LibKeccak.StateMatrix memory stateMatrix = proposal.stateMatrix;
+        uint256 blocks = input.length / LibKeccak.BLOCK_SIZE_BYTES;
+        for (uint256 i = 0; i < blocks; i++) {
+            LibKeccak.absorb(stateMatrix, input, i * LibKeccak.BLOCK_SIZE_BYTES);
+        }
+        // Offsets of the part include the 8 byte length prefix.
+        bytes32 part = fillPart(proposal.part, proposal.partOffset, input, processed + 8);
+
+        if (!_finalize) {
+            proposal.stateMatrix = stateMatrix;
+            proposal.bytesProcessed = uint64(end);
+            proposal.part = part;
+            return;
+        }
+
+        // Pad and absorb the rest of the input, then load the part under the key of the completed hash.
+        LibKeccak.absorbFinal(stateMatrix, input, blocks * LibKeccak.BLOCK_SIZE_BYTES);
+        // Mask out the prefix byte, replace with type 2 byte
+        bytes32 key =
+            bytes32((uint256(LibKeccak.squeeze(stateMatrix)) & ~(uint256(0xFF) << 248)) | (uint256(2) << 248));
+        preimagePartOk[key][proposal.partOffset] = true;
+        preimageParts[key][proposal.partOffset] = part;
+        preimageLengths[key] = end;
+
+        delete largePreimageProposals[msg.sender][_uuid];
+    }
+
+    /// @notice Fills in the bytes of a preimage part that are covered by `_data`.
+    /// @param _part The part filled in so far.
+    /// @param _partOffset The offset of the part, including the length prefix.
+    /// @param _data Bytes of the length prefixed preimage.
+    /// @param _dataOffset The offset of `_data` in the length prefixed preimage.
+    /// @return part_ The part with the bytes covered by `_data` filled in.
+    function fillPart(
+        bytes32 _part,
+        uint256 _partOffset,
+        bytes memory _data,
+        uint256 _dataOffset
+    )
+        internal
+        pure
+        returns (bytes32 part_)
+    {
+        part_ = _part;
+        for (uint256 i = 0; i < 32; i++) {
+            uint256 pos = _partOffset + i;
+            if (pos >= _dataOffset && pos < _dataOffset + _data.length) {
+                part_ |= bytes32(_data[pos - _dataOffset]) >> (8 * i);
+            }
+        }
+    }
 }
@@ -44,4 +44,27 @@ interface IPreimageOracle {
     /// @param _partOffset The offset of the preimage to read.
     /// @param _preimage The preimage data.
     function loadKeccak256PreimagePart(uint256 _partOffset, bytes calldata _preimage) external;
+
+    /// @notice Starts loading a keccak256 preimage that is too large to load with `loadKeccak256PreimagePart` in a
+    ///         single transaction. Any earlier proposal of the caller with the same id is discarded.
+    /// @param _uuid An id chosen by the caller to identify the proposal.
+    /// @param _partOffset The offset of the preimage part to load, including the 8 byte length prefix.
+    /// @param _claimedSize The size of the preimage, excluding the length prefix.
+    function initLargeKeccak256Preimage(uint256 _uuid, uint64 _partOffset, uint64 _claimedSize) external;
+
+    /// @notice Absorbs the next bytes of a large preimage proposal of the caller. Once the last bytes have been
+    ///         absorbed, the part of the preimage at the part offset of the proposal is loaded into the oracle.
+    /// @param _uuid The id of the proposal.
+    /// @param _offset The offset in the preimage of the first byte of `_input`, which must be the number of bytes
+    ///        absorbed so far. A chunk that is submitted twice therefore reverts rather than being absorbed twice.
+    /// @param _input The next bytes of the preimage. Unless `_finalize` is set, the length must be a multiple of the
+    ///        keccak256 block size of 136 bytes.
+    /// @param _finalize Whether `_input` contains the last bytes of the preimage.
+    function addLargeKeccak256PreimageData(
+        uint256 _uuid,
+        uint256 _offset,
+        bytes calldata _input,
+        bool _finalize
+    )
+        external;
 }
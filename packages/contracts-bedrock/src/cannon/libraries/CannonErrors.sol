@@ -3,3 +3,10 @@ pragma solidity 0.8.15;
 
 /// @notice Thrown when a passed part offset is out of bounds.
 error PartOffsetOOB();
+
+/// @notice Thrown when data is added to a large preimage proposal at an offset other than the number of bytes
+///         absorbed so far.
+error InvalidInputOffset();
+
+/// @notice Thrown when the data added to a large preimage proposal doesn't fit its size.
+error InvalidInputSize();
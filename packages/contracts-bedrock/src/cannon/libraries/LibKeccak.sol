@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: MIT
+pragma solidity 0.8.15;
+
+/// @title LibKeccak
+/// @notice An incremental implementation of the keccak256 hash function. The sponge state can be stored between
+///         calls, which allows a preimage that is too large for a single transaction to be hashed across several.
+library LibKeccak {
+    /// @notice The number of bytes absorbed into the sponge state by each permutation.
+    uint256 internal constant BLOCK_SIZE_BYTES = 136;
+
+    /// @notice The keccak-f[1600] sponge state. Lane (x, y) is stored at index x + 5 * y.
+    struct StateMatrix {
+        uint64[25] state;
+    }
+
+    /// @notice Absorbs the block of `BLOCK_SIZE_BYTES` bytes of `_input` starting at `_offset` into the state.
+    /// @param _stateMatrix The state to absorb the block into.
+    /// @param _input The bytes containing the block.
+    /// @param _offset The offset of the block in `_input`.
+    function absorb(StateMatrix memory _stateMatrix, bytes memory _input, uint256 _offset) internal pure {
+        for (uint256 i = 0; i < BLOCK_SIZE_BYTES / 8; i++) {
+            _stateMatrix.state[i] ^= readLane(_input, _offset + i * 8);
+        }
+        permute(_stateMatrix);
+    }
+
+    /// @notice Pads the last bytes of the input, starting at `_offset`, and absorbs them into the state.
+    /// @param _stateMatrix The state to absorb the padded bytes into.
+    /// @param _input The bytes containing the end of the input. Fewer than `BLOCK_SIZE_BYTES` bytes must remain
+    ///        after `_offset`.
+    /// @param _offset The offset of the last bytes in `_input`.
+    function absorbFinal(StateMatrix memory _stateMatrix, bytes memory _input, uint256 _offset) internal pure {
+        uint256 remaining = _input.length - _offset;
+        require(remaining < BLOCK_SIZE_BYTES, "LibKeccak: final input must be shorter than a block");
+
+        bytes memory padded = new bytes(BLOCK_SIZE_BYTES);
+        for (uint256 i = 0; i < remaining; i++) {
+            padded[i] = _input[_offset + i];
+        }
+        padded[remaining] = bytes1(0x01);
+        padded[BLOCK_SIZE_BYTES - 1] |= bytes1(0x80);
+        absorb(_stateMatrix, padded, 0);
+    }
+
+    /// @notice Squeezes the hash out of a state that the padded input has been absorbed into.
+    /// @param _stateMatrix The state to squeeze.
+    /// @return hash_ The keccak256 hash of the absorbed input.
+    function squeeze(StateMatrix memory _stateMatrix) internal pure returns (bytes32 hash_) {
+        for (uint256 i = 0; i < 4; i++) {
+            hash_ |= bytes32(uint256(reverseBytes(_stateMatrix.state[i])) << (192 - 64 * i));
+        }
+    }
+
+    /// @notice Applies the keccak-f[1600] permutation to the state.
+    /// @param _stateMatrix The state to permute.
+    function permute(StateMatrix memory _stateMatrix) internal pure {
+        uint64[24] memory roundConstants = [
+            uint64(0x0000000000000001),
+            0x0000000000008082,
+            0x800000000000808A,
+            0x8000000080008000,
+            0x000000000000808B,
+            0x0000000080000001,
+            0x8000000080008081,
+            0x8000000000008009,
+            0x000000000000008A,
+            0x0000000000000088,
+            0x0000000080008009,
+            0x000000008000000A,
+            0x000000008000808B,
+            0x800000000000008B,
+            0x8000000000008089,
+            0x8000000000008003,
+            0x8000000000008002,
+            0x8000000000000080,
+            0x000000000000800A,
+            0x800000008000000A,
+            0x8000000080008081,
+            0x8000000000008080,
+            0x0000000080000001,
+            0x8000000080008008
+        ];
+        uint8[25] memory rotations = [
+            uint8(0), 1, 62, 28, 27, 36, 44, 6, 55, 20, 3, 10, 43, 25, 39, 41, 45, 15, 21, 8, 18, 2, 61, 56, 14
+        ];
+
+        uint64[25] memory a = _stateMatrix.state;
+        uint64[5] memory c;
+        uint64[25] memory b;
+        for (uint256 round = 0; round < 24; round++) {
+            // Theta
+            for (uint256 x = 0; x < 5; x++) {
+                c[x] = a[x] ^ a[x + 5] ^ a[x + 10] ^ a[x + 15] ^ a[x + 20];
+            }
+            for (uint256 x = 0; x < 5; x++) {
+                uint64 d = c[(x + 4) % 5] ^ rotateLeft(c[(x + 1) % 5], 1);
+                for (uint256 y = 0; y < 25; y += 5) {
+                    a[x + y] ^= d;
+                }
+            }
+            // Rho and pi
+            for (uint256 x = 0; x < 5; x++) {
+                for (uint256 y = 0; y < 5; y++) {
+                    b[y + 5 * ((2 * x + 3 * y) % 5)] = rotateLeft(a[x + 5 * y], rotations[x + 5 * y]);
+                }
+            }
+            // Chi
+            for (uint256 x = 0; x < 5; x++) {
+                for (uint256 y = 0; y < 25; y += 5) {
+                    a[x + y] = b[x + y] ^ (~b[(x + 1) % 5 + y] & b[(x + 2) % 5 + y]);
+                }
+            }
+            // Iota
+            a[0] ^= roundConstants[round];
+        }
+    }
+
+    /// @notice Reads the little-endian lane of 8 bytes of `_input` starting at `_offset`.
+    function readLane(bytes memory _input, uint256 _offset) private pure returns (uint64 lane_) {
+        for (uint256 i = 0; i < 8; i++) {
+            lane_ |= uint64(uint8(_input[_offset + i])) << (8 * i);
+        }
+    }
+
+    /// @notice Reverses the byte order of a lane.
+    function reverseBytes(uint64 _lane) private pure returns (uint64 reversed_) {
+        for (uint256 i = 0; i < 8; i++) {
+            reversed_ = (reversed_ << 8) | ((_lane >> (8 * i)) & 0xFF);
+        }
+    }
+
+    /// @notice Rotates a lane left by `_n` bits.
+    function rotateLeft(uint64 _lane, uint256 _n) private pure returns (uint64) {
+        return (_lane << _n) | (_lane >> (64 - _n));
+    }
+}
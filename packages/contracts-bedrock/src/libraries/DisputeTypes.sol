@@ -87,6 +87,10 @@ library GameTypes {
 
     /// @dev The game will use a `IDisputeGame` implementation that utilizes attestation proofs.
     GameType internal constant ATTESTATION = GameType.wrap(2);
+
+    /// @dev The game will use a `IDisputeGame` implementation that utilizes fault proofs, bisecting
+    ///      the output roots before the cannon execution trace.
+    GameType internal constant OUTPUT_CANNON = GameType.wrap(3);
 }
 
 /// @title VMStatuses
@@ -5,6 +5,7 @@ import { Test } from "forge-std/Test.sol";
 
 import { PreimageOracle } from "src/cannon/PreimageOracle.sol";
 import { PreimageKeyLib } from "src/cannon/PreimageKeyLib.sol";
+import { LibKeccak } from "src/cannon/libraries/LibKeccak.sol";
 import "src/cannon/libraries/CannonErrors.sol";
 
 contract PreimageOracle_Test is Test {
@@ -129,4 +130,151 @@ contract PreimageOracle_Test is Test {
         vm.expectRevert("pre-image must exist");
         oracle.readPreimage(key, offset);
     }
+
+    /// @notice Tests that a pre-image loaded across multiple calls sets the same part as loading it in a single call.
+    function testFuzz_addLargeKeccak256PreimageData_matchesSingleCall_succeeds(
+        uint256 _size,
+        uint256 _partOffset
+    )
+        public
+    {
+        uint256 size = bound(_size, 0, 1000);
+        uint256 partOffset = bound(_partOffset, 0, size + 8);
+        bytes memory preimage = new bytes(size);
+        for (uint256 i = 0; i < size; i++) {
+            preimage[i] = bytes1(keccak256(abi.encode(i)));
+        }
+        loadLargePreimage(1, preimage, partOffset, 2 * LibKeccak.BLOCK_SIZE_BYTES);
+
+        PreimageOracle expected = new PreimageOracle();
+        expected.loadKeccak256PreimagePart(partOffset, preimage);
+        bytes32 key = PreimageKeyLib.keccak256PreimageKey(preimage);
+        assertTrue(oracle.preimagePartOk(key, partOffset));
+        assertEq(oracle.preimageParts(key, partOffset), expected.preimageParts(key, partOffset));
+        assertEq(oracle.preimageLengths(key), size);
+
+        // The completed proposal is removed
+        assertEq(oracle.largePreimageBytesProcessed(address(this), 1), 0);
+    }
+
+    /// @notice Tests that the progress of a large pre-image proposal is tracked.
+    function test_addLargeKeccak256PreimageData_tracksProgress_succeeds() public {
+        oracle.initLargeKeccak256Preimage(1, 0, 1000);
+        oracle.addLargeKeccak256PreimageData(1, 0, new bytes(LibKeccak.BLOCK_SIZE_BYTES), false);
+        assertEq(oracle.largePreimageBytesProcessed(address(this), 1), LibKeccak.BLOCK_SIZE_BYTES);
+
+        // Proposals are separate for each proposer
+        assertEq(oracle.largePreimageBytesProcessed(address(0xbeef), 1), 0);
+
+        // Initializing the proposal again restarts it
+        oracle.initLargeKeccak256Preimage(1, 0, 1000);
+        assertEq(oracle.largePreimageBytesProcessed(address(this), 1), 0);
+    }
+
+    /// @notice Tests that a large pre-image proposal cannot be started with an out-of-bounds offset.
+    function test_initLargeKeccak256Preimage_outOfBoundsOffset_reverts() public {
+        vm.expectRevert(PartOffsetOOB.selector);
+        oracle.initLargeKeccak256Preimage(1, 1009, 1000);
+    }
+
+    /// @notice Tests that data must be added to a large pre-image proposal in order.
+    function test_addLargeKeccak256PreimageData_wrongOffset_reverts() public {
+        oracle.initLargeKeccak256Preimage(1, 0, 1000);
+        oracle.addLargeKeccak256PreimageData(1, 0, new bytes(LibKeccak.BLOCK_SIZE_BYTES), false);
+
+        // Submitting the same chunk again must not absorb it twice
+        vm.expectRevert(InvalidInputOffset.selector);
+        oracle.addLargeKeccak256PreimageData(1, 0, new bytes(LibKeccak.BLOCK_SIZE_BYTES), false);
+    }
+
+    /// @notice Tests that data added to a large pre-image proposal must be whole blocks until the last chunk.
+    function test_addLargeKeccak256PreimageData_partialBlock_reverts() public {
+        oracle.initLargeKeccak256Preimage(1, 0, 1000);
+
+        vm.expectRevert(InvalidInputSize.selector);
+        oracle.addLargeKeccak256PreimageData(1, 0, new bytes(100), false);
+    }
+
+    /// @notice Tests that the last chunk of a large pre-image proposal must complete the claimed size.
+    function test_addLargeKeccak256PreimageData_wrongFinalSize_reverts() public {
+        oracle.initLargeKeccak256Preimage(1, 0, 1000);
+
+        vm.expectRevert(InvalidInputSize.selector);
+        oracle.addLargeKeccak256PreimageData(1, 0, new bytes(LibKeccak.BLOCK_SIZE_BYTES), true);
+
+        vm.expectRevert(InvalidInputSize.selector);
+        oracle.addLargeKeccak256PreimageData(1, 0, new bytes(1001), true);
+    }
+
+    /// @notice Loads a pre-image into the oracle across multiple calls of `_chunkSize` bytes.
+    function loadLargePreimage(
+        uint256 _uuid,
+        bytes memory _preimage,
+        uint256 _partOffset,
+        uint256 _chunkSize
+    )
+        internal
+    {
+        oracle.initLargeKeccak256Preimage(_uuid, uint64(_partOffset), uint64(_preimage.length));
+        uint256 offset = 0;
+        while (true) {
+            uint256 end = offset + _chunkSize;
+            bool finalize = end >= _preimage.length;
+            if (finalize) {
+                end = _preimage.length;
+            }
+            bytes memory chunk = new bytes(end - offset);
+            for (uint256 i = 0; i < chunk.length; i++) {
+                chunk[i] = _preimage[offset + i];
+            }
+            oracle.addLargeKeccak256PreimageData(_uuid, offset, chunk, finalize);
+            if (finalize) {
+                break;
+            }
+            offset = end;
+        }
+    }
+}
+
+contract LibKeccak_Test is Test {
+    /// @notice Tests that hashing the input one block at a time matches keccak256.
+    function testFuzz_squeeze_matchesKeccak256_succeeds(bytes memory _input) public {
+        assertEq(hash(_input), keccak256(_input));
+    }
+
+    /// @notice Tests that inputs around the padding boundaries hash to keccak256. The padding of an input that is
+    ///         one byte short of a block fits in a single byte, and an input of a whole block is padded with a full
+    ///         extra block.
+    function test_squeeze_paddingBoundaries_succeeds() public {
+        uint256 rate = LibKeccak.BLOCK_SIZE_BYTES;
+        uint256[9] memory lengths =
+            [uint256(0), 1, rate - 1, rate, rate + 1, 2 * rate - 1, 2 * rate, 2 * rate + 1, 3 * rate];
+        for (uint256 i = 0; i < lengths.length; i++) {
+            bytes memory input = new bytes(lengths[i]);
+            for (uint256 j = 0; j < input.length; j++) {
+                input[j] = bytes1(uint8(j * 7 + lengths[i]));
+            }
+            assertEq(hash(input), keccak256(input));
+        }
+    }
+
+    /// @notice Tests that an input one byte short of a block, which starts with a 0x01 byte and ends with a 0x80
+    ///         byte like the padding does, hashes to keccak256.
+    function test_squeeze_paddingByteValues_succeeds() public {
+        bytes memory input = new bytes(LibKeccak.BLOCK_SIZE_BYTES - 1);
+        input[0] = 0x01;
+        input[input.length - 1] = 0x80;
+        assertEq(hash(input), keccak256(input));
+    }
+
+    /// @notice Hashes `_input` by absorbing its complete blocks, then the padded rest, and squeezing the state.
+    function hash(bytes memory _input) internal pure returns (bytes32) {
+        LibKeccak.StateMatrix memory stateMatrix;
+        uint256 blocks = _input.length / LibKeccak.BLOCK_SIZE_BYTES;
+        for (uint256 i = 0; i < blocks; i++) {
+            LibKeccak.absorb(stateMatrix, _input, i * LibKeccak.BLOCK_SIZE_BYTES);
+        }
+        LibKeccak.absorbFinal(stateMatrix, _input, blocks * LibKeccak.BLOCK_SIZE_BYTES);
+        return LibKeccak.squeeze(stateMatrix);
+    }
 }